@@ -41,6 +41,8 @@ type ClientService interface {
 
 	GetWorkloadendpoint(params *GetWorkloadendpointParams, opts ...ClientOption) (*GetWorkloadendpointOK, error)
 
+	PostCoordinatorDatapathFallback(params *PostCoordinatorDatapathFallbackParams, opts ...ClientOption) (*PostCoordinatorDatapathFallbackOK, error)
+
 	PostIpamIP(params *PostIpamIPParams, opts ...ClientOption) (*PostIpamIPOK, error)
 
 	PostIpamIps(params *PostIpamIpsParams, opts ...ClientOption) (*PostIpamIpsOK, error)
@@ -212,6 +214,48 @@ func (a *Client) GetWorkloadendpoint(params *GetWorkloadendpointParams, opts ...
 	panic(msg)
 }
 
+/*
+	PostCoordinatorDatapathFallback reports coordinator datapath fallback
+
+	Send a request to daemonset to record that coordinator falls back to
+
+the route datapath for a Pod
+*/
+func (a *Client) PostCoordinatorDatapathFallback(params *PostCoordinatorDatapathFallbackParams, opts ...ClientOption) (*PostCoordinatorDatapathFallbackOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostCoordinatorDatapathFallbackParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostCoordinatorDatapathFallback",
+		Method:             "POST",
+		PathPattern:        "/coordinator/datapath/fallback",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostCoordinatorDatapathFallbackReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostCoordinatorDatapathFallbackOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostCoordinatorDatapathFallback: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PostIpamIP gets ip from spiderpool daemon
 
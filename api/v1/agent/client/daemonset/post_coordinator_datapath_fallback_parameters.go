@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// NewPostCoordinatorDatapathFallbackParams creates a new PostCoordinatorDatapathFallbackParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewPostCoordinatorDatapathFallbackParams() *PostCoordinatorDatapathFallbackParams {
+	return &PostCoordinatorDatapathFallbackParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewPostCoordinatorDatapathFallbackParamsWithTimeout creates a new PostCoordinatorDatapathFallbackParams object
+// with the ability to set a timeout on a request.
+func NewPostCoordinatorDatapathFallbackParamsWithTimeout(timeout time.Duration) *PostCoordinatorDatapathFallbackParams {
+	return &PostCoordinatorDatapathFallbackParams{
+		timeout: timeout,
+	}
+}
+
+// NewPostCoordinatorDatapathFallbackParamsWithContext creates a new PostCoordinatorDatapathFallbackParams object
+// with the ability to set a context for a request.
+func NewPostCoordinatorDatapathFallbackParamsWithContext(ctx context.Context) *PostCoordinatorDatapathFallbackParams {
+	return &PostCoordinatorDatapathFallbackParams{
+		Context: ctx,
+	}
+}
+
+// NewPostCoordinatorDatapathFallbackParamsWithHTTPClient creates a new PostCoordinatorDatapathFallbackParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewPostCoordinatorDatapathFallbackParamsWithHTTPClient(client *http.Client) *PostCoordinatorDatapathFallbackParams {
+	return &PostCoordinatorDatapathFallbackParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+PostCoordinatorDatapathFallbackParams contains all the parameters to send to the API endpoint
+
+	for the post coordinator datapath fallback operation.
+
+	Typically these are written to a http.Request.
+*/
+type PostCoordinatorDatapathFallbackParams struct {
+
+	// CoordinatorDatapathFallback.
+	CoordinatorDatapathFallback *models.CoordinatorDatapathFallbackArgs
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the post coordinator datapath fallback params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostCoordinatorDatapathFallbackParams) WithDefaults() *PostCoordinatorDatapathFallbackParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the post coordinator datapath fallback params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostCoordinatorDatapathFallbackParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) WithTimeout(timeout time.Duration) *PostCoordinatorDatapathFallbackParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) WithContext(ctx context.Context) *PostCoordinatorDatapathFallbackParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) WithHTTPClient(client *http.Client) *PostCoordinatorDatapathFallbackParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithCoordinatorDatapathFallback adds the coordinatorDatapathFallback to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) WithCoordinatorDatapathFallback(coordinatorDatapathFallback *models.CoordinatorDatapathFallbackArgs) *PostCoordinatorDatapathFallbackParams {
+	o.SetCoordinatorDatapathFallback(coordinatorDatapathFallback)
+	return o
+}
+
+// SetCoordinatorDatapathFallback adds the coordinatorDatapathFallback to the post coordinator datapath fallback params
+func (o *PostCoordinatorDatapathFallbackParams) SetCoordinatorDatapathFallback(coordinatorDatapathFallback *models.CoordinatorDatapathFallbackArgs) {
+	o.CoordinatorDatapathFallback = coordinatorDatapathFallback
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *PostCoordinatorDatapathFallbackParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.CoordinatorDatapathFallback != nil {
+		if err := r.SetBodyParam(o.CoordinatorDatapathFallback); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostCoordinatorDatapathFallbackReader is a Reader for the PostCoordinatorDatapathFallback structure.
+type PostCoordinatorDatapathFallbackReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *PostCoordinatorDatapathFallbackReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewPostCoordinatorDatapathFallbackOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewPostCoordinatorDatapathFallbackFailure()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewPostCoordinatorDatapathFallbackOK creates a PostCoordinatorDatapathFallbackOK with default headers values
+func NewPostCoordinatorDatapathFallbackOK() *PostCoordinatorDatapathFallbackOK {
+	return &PostCoordinatorDatapathFallbackOK{}
+}
+
+/*
+PostCoordinatorDatapathFallbackOK describes a response with status code 200, with default header values.
+
+Success
+*/
+type PostCoordinatorDatapathFallbackOK struct {
+}
+
+// IsSuccess returns true when this post coordinator datapath fallback o k response has a 2xx status code
+func (o *PostCoordinatorDatapathFallbackOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this post coordinator datapath fallback o k response has a 3xx status code
+func (o *PostCoordinatorDatapathFallbackOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post coordinator datapath fallback o k response has a 4xx status code
+func (o *PostCoordinatorDatapathFallbackOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post coordinator datapath fallback o k response has a 5xx status code
+func (o *PostCoordinatorDatapathFallbackOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post coordinator datapath fallback o k response a status code equal to that given
+func (o *PostCoordinatorDatapathFallbackOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the post coordinator datapath fallback o k response
+func (o *PostCoordinatorDatapathFallbackOK) Code() int {
+	return 200
+}
+
+func (o *PostCoordinatorDatapathFallbackOK) Error() string {
+	return fmt.Sprintf("[POST /coordinator/datapath/fallback][%d] postCoordinatorDatapathFallbackOK ", 200)
+}
+
+func (o *PostCoordinatorDatapathFallbackOK) String() string {
+	return fmt.Sprintf("[POST /coordinator/datapath/fallback][%d] postCoordinatorDatapathFallbackOK ", 200)
+}
+
+func (o *PostCoordinatorDatapathFallbackOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewPostCoordinatorDatapathFallbackFailure creates a PostCoordinatorDatapathFallbackFailure with default headers values
+func NewPostCoordinatorDatapathFallbackFailure() *PostCoordinatorDatapathFallbackFailure {
+	return &PostCoordinatorDatapathFallbackFailure{}
+}
+
+/*
+PostCoordinatorDatapathFallbackFailure describes a response with status code 500, with default header values.
+
+Failed to record the fallback
+*/
+type PostCoordinatorDatapathFallbackFailure struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post coordinator datapath fallback failure response has a 2xx status code
+func (o *PostCoordinatorDatapathFallbackFailure) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post coordinator datapath fallback failure response has a 3xx status code
+func (o *PostCoordinatorDatapathFallbackFailure) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post coordinator datapath fallback failure response has a 4xx status code
+func (o *PostCoordinatorDatapathFallbackFailure) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post coordinator datapath fallback failure response has a 5xx status code
+func (o *PostCoordinatorDatapathFallbackFailure) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this post coordinator datapath fallback failure response a status code equal to that given
+func (o *PostCoordinatorDatapathFallbackFailure) IsCode(code int) bool {
+	return code == 500
+}
+
+// Code gets the status code for the post coordinator datapath fallback failure response
+func (o *PostCoordinatorDatapathFallbackFailure) Code() int {
+	return 500
+}
+
+func (o *PostCoordinatorDatapathFallbackFailure) Error() string {
+	return fmt.Sprintf("[POST /coordinator/datapath/fallback][%d] postCoordinatorDatapathFallbackFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostCoordinatorDatapathFallbackFailure) String() string {
+	return fmt.Sprintf("[POST /coordinator/datapath/fallback][%d] postCoordinatorDatapathFallbackFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostCoordinatorDatapathFallbackFailure) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostCoordinatorDatapathFallbackFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -23,6 +23,15 @@ import (
 // swagger:model CoordinatorConfig
 type CoordinatorConfig struct {
 
+	// the datapath to forward the hijacked traffic to the host
+	Datapath string `json:"datapath,omitempty"`
+
+	// fall back to the route datapath if the ebpf datapath fails to set up
+	DatapathFallback bool `json:"datapathFallback,omitempty"`
+
+	// delegated routes
+	DelegatedRoutes []*CoordinatorRoute `json:"delegatedRoutes"`
+
 	// hijack c ID r
 	HijackCIDR []string `json:"hijackCIDR"`
 
@@ -33,6 +42,9 @@ type CoordinatorConfig struct {
 	// Required: true
 	Mode *string `json:"mode"`
 
+	// the pod CIDRs allocated to the node of the Pod
+	NodePodCIDR []string `json:"nodePodCIDR"`
+
 	// overlay pod c ID r
 	// Required: true
 	OverlayPodCIDR []string `json:"overlayPodCIDR"`
@@ -71,6 +83,10 @@ type CoordinatorConfig struct {
 func (m *CoordinatorConfig) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDelegatedRoutes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMode(formats); err != nil {
 		res = append(res, err)
 	}
@@ -97,6 +113,32 @@ func (m *CoordinatorConfig) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *CoordinatorConfig) validateDelegatedRoutes(formats strfmt.Registry) error {
+	if swag.IsZero(m.DelegatedRoutes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.DelegatedRoutes); i++ {
+		if swag.IsZero(m.DelegatedRoutes[i]) { // not required
+			continue
+		}
+
+		if m.DelegatedRoutes[i] != nil {
+			if err := m.DelegatedRoutes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("delegatedRoutes" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("delegatedRoutes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *CoordinatorConfig) validateMode(formats strfmt.Registry) error {
 
 	if err := validate.Required("mode", "body", m.Mode); err != nil {
@@ -163,6 +205,10 @@ func (m *CoordinatorConfig) validateTunePodRoutes(formats strfmt.Registry) error
 func (m *CoordinatorConfig) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateDelegatedRoutes(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidatePolicyRoutes(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -173,6 +219,26 @@ func (m *CoordinatorConfig) ContextValidate(ctx context.Context, formats strfmt.
 	return nil
 }
 
+func (m *CoordinatorConfig) contextValidateDelegatedRoutes(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.DelegatedRoutes); i++ {
+
+		if m.DelegatedRoutes[i] != nil {
+			if err := m.DelegatedRoutes[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("delegatedRoutes" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("delegatedRoutes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *CoordinatorConfig) contextValidatePolicyRoutes(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.PolicyRoutes); i++ {
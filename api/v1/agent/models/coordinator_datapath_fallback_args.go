@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// CoordinatorDatapathFallbackArgs Coordinator datapath fallback information
+//
+// swagger:model CoordinatorDatapathFallbackArgs
+type CoordinatorDatapathFallbackArgs struct {
+
+	// the datapath which fails to set up
+	// Required: true
+	Datapath *string `json:"datapath"`
+
+	// interface
+	Interface string `json:"interface,omitempty"`
+
+	// pod name
+	// Required: true
+	PodName *string `json:"podName"`
+
+	// pod namespace
+	// Required: true
+	PodNamespace *string `json:"podNamespace"`
+
+	// reason
+	Reason string `json:"reason,omitempty"`
+}
+
+// Validate validates this coordinator datapath fallback args
+func (m *CoordinatorDatapathFallbackArgs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDatapath(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodNamespace(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *CoordinatorDatapathFallbackArgs) validateDatapath(formats strfmt.Registry) error {
+
+	if err := validate.Required("datapath", "body", m.Datapath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CoordinatorDatapathFallbackArgs) validatePodName(formats strfmt.Registry) error {
+
+	if err := validate.Required("podName", "body", m.PodName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CoordinatorDatapathFallbackArgs) validatePodNamespace(formats strfmt.Registry) error {
+
+	if err := validate.Required("podNamespace", "body", m.PodNamespace); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this coordinator datapath fallback args based on context it is used
+func (m *CoordinatorDatapathFallbackArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CoordinatorDatapathFallbackArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CoordinatorDatapathFallbackArgs) UnmarshalBinary(b []byte) error {
+	var res CoordinatorDatapathFallbackArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
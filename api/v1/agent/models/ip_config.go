@@ -47,6 +47,9 @@ type IPConfig struct {
 	// Required: true
 	Nic *string `json:"nic"`
 
+	// the prefix delegated to the interface in prefix delegation mode
+	Prefix string `json:"prefix,omitempty"`
+
 	// version
 	// Required: true
 	// Enum: [4 6]
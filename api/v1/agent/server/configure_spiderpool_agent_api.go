@@ -83,6 +83,11 @@ func configureAPI(api *restapi.SpiderpoolAgentAPIAPI) http.Handler {
 			return middleware.NotImplemented("operation daemonset.GetWorkloadendpoint has not yet been implemented")
 		})
 	}
+	if api.DaemonsetPostCoordinatorDatapathFallbackHandler == nil {
+		api.DaemonsetPostCoordinatorDatapathFallbackHandler = daemonset.PostCoordinatorDatapathFallbackHandlerFunc(func(params daemonset.PostCoordinatorDatapathFallbackParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostCoordinatorDatapathFallback has not yet been implemented")
+		})
+	}
 	if api.DaemonsetPostIpamIPHandler == nil {
 		api.DaemonsetPostIpamIPHandler = daemonset.PostIpamIPHandlerFunc(func(params daemonset.PostIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.PostIpamIP has not yet been implemented")
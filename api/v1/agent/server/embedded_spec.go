@@ -69,6 +69,37 @@ func init() {
         }
       }
     },
+    "/coordinator/datapath/fallback": {
+      "post": {
+        "description": "Send a request to daemonset to record that coordinator falls back to\nthe route datapath for a Pod\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Report coordinator datapath fallback",
+        "parameters": [
+          {
+            "name": "coordinator-datapath-fallback",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CoordinatorDatapathFallbackArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Failed to record the fallback",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/healthy": {
       "get": {
         "description": "Check spiderpool daemonset health to make sure whether it's ready\nfor CNI plugin usage\n",
@@ -325,6 +356,20 @@ func init() {
         "tunePodRoutes"
       ],
       "properties": {
+        "datapath": {
+          "description": "the datapath to forward the hijacked traffic to the host",
+          "type": "string"
+        },
+        "datapathFallback": {
+          "description": "fall back to the route datapath if the ebpf datapath fails to set up",
+          "type": "boolean"
+        },
+        "delegatedRoutes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CoordinatorRoute"
+          }
+        },
         "hijackCIDR": {
           "type": "array",
           "items": {
@@ -337,6 +382,13 @@ func init() {
         "mode": {
           "type": "string"
         },
+        "nodePodCIDR": {
+          "description": "the pod CIDRs allocated to the node of the Pod",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "overlayPodCIDR": {
           "type": "array",
           "items": {
@@ -378,6 +430,33 @@ func init() {
         }
       }
     },
+    "CoordinatorDatapathFallbackArgs": {
+      "description": "Coordinator datapath fallback information",
+      "type": "object",
+      "required": [
+        "podName",
+        "podNamespace",
+        "datapath"
+      ],
+      "properties": {
+        "datapath": {
+          "description": "the datapath which fails to set up",
+          "type": "string"
+        },
+        "interface": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    },
     "CoordinatorRoute": {
       "description": "Coordinator static route",
       "type": "object",
@@ -518,6 +597,10 @@ func init() {
         "nic": {
           "type": "string"
         },
+        "prefix": {
+          "description": "the prefix delegated to the interface in prefix delegation mode",
+          "type": "string"
+        },
         "version": {
           "type": "integer",
           "enum": [
@@ -776,6 +859,37 @@ func init() {
         }
       }
     },
+    "/coordinator/datapath/fallback": {
+      "post": {
+        "description": "Send a request to daemonset to record that coordinator falls back to\nthe route datapath for a Pod\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Report coordinator datapath fallback",
+        "parameters": [
+          {
+            "name": "coordinator-datapath-fallback",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CoordinatorDatapathFallbackArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Failed to record the fallback",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/healthy": {
       "get": {
         "description": "Check spiderpool daemonset health to make sure whether it's ready\nfor CNI plugin usage\n",
@@ -1032,6 +1146,20 @@ func init() {
         "tunePodRoutes"
       ],
       "properties": {
+        "datapath": {
+          "description": "the datapath to forward the hijacked traffic to the host",
+          "type": "string"
+        },
+        "datapathFallback": {
+          "description": "fall back to the route datapath if the ebpf datapath fails to set up",
+          "type": "boolean"
+        },
+        "delegatedRoutes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CoordinatorRoute"
+          }
+        },
         "hijackCIDR": {
           "type": "array",
           "items": {
@@ -1044,6 +1172,13 @@ func init() {
         "mode": {
           "type": "string"
         },
+        "nodePodCIDR": {
+          "description": "the pod CIDRs allocated to the node of the Pod",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "overlayPodCIDR": {
           "type": "array",
           "items": {
@@ -1085,6 +1220,33 @@ func init() {
         }
       }
     },
+    "CoordinatorDatapathFallbackArgs": {
+      "description": "Coordinator datapath fallback information",
+      "type": "object",
+      "required": [
+        "podName",
+        "podNamespace",
+        "datapath"
+      ],
+      "properties": {
+        "datapath": {
+          "description": "the datapath which fails to set up",
+          "type": "string"
+        },
+        "interface": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    },
     "CoordinatorRoute": {
       "description": "Coordinator static route",
       "type": "object",
@@ -1225,6 +1387,10 @@ func init() {
         "nic": {
           "type": "string"
         },
+        "prefix": {
+          "description": "the prefix delegated to the interface in prefix delegation mode",
+          "type": "string"
+        },
         "version": {
           "type": "integer",
           "enum": [
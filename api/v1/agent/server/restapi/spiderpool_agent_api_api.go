@@ -73,6 +73,9 @@ func NewSpiderpoolAgentAPIAPI(spec *loads.Document) *SpiderpoolAgentAPIAPI {
 		DaemonsetGetWorkloadendpointHandler: daemonset.GetWorkloadendpointHandlerFunc(func(params daemonset.GetWorkloadendpointParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.GetWorkloadendpoint has not yet been implemented")
 		}),
+		DaemonsetPostCoordinatorDatapathFallbackHandler: daemonset.PostCoordinatorDatapathFallbackHandlerFunc(func(params daemonset.PostCoordinatorDatapathFallbackParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostCoordinatorDatapathFallback has not yet been implemented")
+		}),
 		DaemonsetPostIpamIPHandler: daemonset.PostIpamIPHandlerFunc(func(params daemonset.PostIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.PostIpamIP has not yet been implemented")
 		}),
@@ -131,6 +134,8 @@ type SpiderpoolAgentAPIAPI struct {
 	RuntimeGetRuntimeStartupHandler runtimeops.GetRuntimeStartupHandler
 	// DaemonsetGetWorkloadendpointHandler sets the operation handler for the get workloadendpoint operation
 	DaemonsetGetWorkloadendpointHandler daemonset.GetWorkloadendpointHandler
+	// DaemonsetPostCoordinatorDatapathFallbackHandler sets the operation handler for the post coordinator datapath fallback operation
+	DaemonsetPostCoordinatorDatapathFallbackHandler daemonset.PostCoordinatorDatapathFallbackHandler
 	// DaemonsetPostIpamIPHandler sets the operation handler for the post ipam IP operation
 	DaemonsetPostIpamIPHandler daemonset.PostIpamIPHandler
 	// DaemonsetPostIpamIpsHandler sets the operation handler for the post ipam ips operation
@@ -236,6 +241,9 @@ func (o *SpiderpoolAgentAPIAPI) Validate() error {
 	if o.DaemonsetGetWorkloadendpointHandler == nil {
 		unregistered = append(unregistered, "daemonset.GetWorkloadendpointHandler")
 	}
+	if o.DaemonsetPostCoordinatorDatapathFallbackHandler == nil {
+		unregistered = append(unregistered, "daemonset.PostCoordinatorDatapathFallbackHandler")
+	}
 	if o.DaemonsetPostIpamIPHandler == nil {
 		unregistered = append(unregistered, "daemonset.PostIpamIPHandler")
 	}
@@ -365,6 +373,10 @@ func (o *SpiderpoolAgentAPIAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/coordinator/datapath/fallback"] = daemonset.NewPostCoordinatorDatapathFallback(o.context, o.DaemonsetPostCoordinatorDatapathFallbackHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/ipam/ip"] = daemonset.NewPostIpamIP(o.context, o.DaemonsetPostIpamIPHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
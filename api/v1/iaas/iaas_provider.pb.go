@@ -0,0 +1,910 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// The IaaSProvider service is called by the grpc driver of the Spiderpool
+// IaaS network provider integration. The messages are encoded with the
+// default protobuf codec. If iaasNetworkProvider.grpc.codec is "json",
+// Spiderpool encodes them with the proto3 JSON mapping and the "json"
+// content subtype instead, and the providers must register a JSON codec,
+// e.g. with protojson. The integer fields are int32 so that they are
+// encoded as JSON numbers.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.1
+// 	protoc        v4.25.1
+// source: api/v1/iaas/iaas_provider.proto
+
+package iaas
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AllocateIPRequest struct {
+	state                    protoimpl.MessageState  `protogen:"open.v1"`
+	PodName                  string                  `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	PodNamespace             string                  `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace,proto3" json:"pod_namespace,omitempty"`
+	PodUid                   string                  `protobuf:"bytes,3,opt,name=pod_uid,json=podUID,proto3" json:"pod_uid,omitempty"`
+	NodeName                 string                  `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	IaasIpsAllocationRequest []*IaaSIPAllocationItem `protobuf:"bytes,5,rep,name=iaas_ips_allocation_request,json=iaasIPsAllocationRequest,proto3" json:"iaas_ips_allocation_request,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *AllocateIPRequest) Reset() {
+	*x = AllocateIPRequest{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateIPRequest) ProtoMessage() {}
+
+func (x *AllocateIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateIPRequest.ProtoReflect.Descriptor instead.
+func (*AllocateIPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AllocateIPRequest) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *AllocateIPRequest) GetPodNamespace() string {
+	if x != nil {
+		return x.PodNamespace
+	}
+	return ""
+}
+
+func (x *AllocateIPRequest) GetPodUid() string {
+	if x != nil {
+		return x.PodUid
+	}
+	return ""
+}
+
+func (x *AllocateIPRequest) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *AllocateIPRequest) GetIaasIpsAllocationRequest() []*IaaSIPAllocationItem {
+	if x != nil {
+		return x.IaasIpsAllocationRequest
+	}
+	return nil
+}
+
+type IaaSIPAllocationItem struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	IpAddress    string                 `protobuf:"bytes,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Subnet       string                 `protobuf:"bytes,2,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	ParentNicMac string                 `protobuf:"bytes,3,opt,name=parent_nic_mac,json=parentNicMac,proto3" json:"parent_nic_mac,omitempty"`
+	// 4 or 6, IPv4 is assumed if it's empty.
+	IpVersion int32 `protobuf:"varint,4,opt,name=ip_version,json=ipVersion,proto3" json:"ip_version,omitempty"`
+	// The prefix delegated to the interface in prefix delegation mode.
+	Prefix        string `protobuf:"bytes,5,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IaaSIPAllocationItem) Reset() {
+	*x = IaaSIPAllocationItem{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IaaSIPAllocationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IaaSIPAllocationItem) ProtoMessage() {}
+
+func (x *IaaSIPAllocationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IaaSIPAllocationItem.ProtoReflect.Descriptor instead.
+func (*IaaSIPAllocationItem) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IaaSIPAllocationItem) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationItem) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationItem) GetParentNicMac() string {
+	if x != nil {
+		return x.ParentNicMac
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationItem) GetIpVersion() int32 {
+	if x != nil {
+		return x.IpVersion
+	}
+	return 0
+}
+
+func (x *IaaSIPAllocationItem) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type AllocateIPResponse struct {
+	state                     protoimpl.MessageState    `protogen:"open.v1"`
+	PodName                   string                    `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	PodNamespace              string                    `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace,proto3" json:"pod_namespace,omitempty"`
+	NodeName                  string                    `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	IaasIpsAllocationResponse []*IaaSIPAllocationResult `protobuf:"bytes,4,rep,name=iaas_ips_allocation_response,json=iaasIPsAllocationResponse,proto3" json:"iaas_ips_allocation_response,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *AllocateIPResponse) Reset() {
+	*x = AllocateIPResponse{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateIPResponse) ProtoMessage() {}
+
+func (x *AllocateIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateIPResponse.ProtoReflect.Descriptor instead.
+func (*AllocateIPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AllocateIPResponse) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *AllocateIPResponse) GetPodNamespace() string {
+	if x != nil {
+		return x.PodNamespace
+	}
+	return ""
+}
+
+func (x *AllocateIPResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *AllocateIPResponse) GetIaasIpsAllocationResponse() []*IaaSIPAllocationResult {
+	if x != nil {
+		return x.IaasIpsAllocationResponse
+	}
+	return nil
+}
+
+type IaaSIPAllocationResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ParentNicMac string                 `protobuf:"bytes,1,opt,name=parent_nic_mac,json=parentNicMac,proto3" json:"parent_nic_mac,omitempty"`
+	Subnet       string                 `protobuf:"bytes,2,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	IpAddress    string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	MacAddress   string                 `protobuf:"bytes,4,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	VlanId       int32                  `protobuf:"varint,5,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`
+	// The prefix delegated to the interface, which must contain the IP address.
+	DelegatedPrefix string `protobuf:"bytes,6,opt,name=delegated_prefix,json=delegatedPrefix,proto3" json:"delegated_prefix,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *IaaSIPAllocationResult) Reset() {
+	*x = IaaSIPAllocationResult{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IaaSIPAllocationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IaaSIPAllocationResult) ProtoMessage() {}
+
+func (x *IaaSIPAllocationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IaaSIPAllocationResult.ProtoReflect.Descriptor instead.
+func (*IaaSIPAllocationResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IaaSIPAllocationResult) GetParentNicMac() string {
+	if x != nil {
+		return x.ParentNicMac
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationResult) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationResult) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationResult) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+func (x *IaaSIPAllocationResult) GetVlanId() int32 {
+	if x != nil {
+		return x.VlanId
+	}
+	return 0
+}
+
+func (x *IaaSIPAllocationResult) GetDelegatedPrefix() string {
+	if x != nil {
+		return x.DelegatedPrefix
+	}
+	return ""
+}
+
+type ReleaseIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PodName       string                 `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	PodNamespace  string                 `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace,proto3" json:"pod_namespace,omitempty"`
+	PodUid        string                 `protobuf:"bytes,3,opt,name=pod_uid,json=podUID,proto3" json:"pod_uid,omitempty"`
+	NodeName      string                 `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	ParentNicMac  string                 `protobuf:"bytes,5,opt,name=parent_nic_mac,json=parentNicMac,proto3" json:"parent_nic_mac,omitempty"`
+	Subnet        string                 `protobuf:"bytes,6,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,7,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	IpVersion     int32                  `protobuf:"varint,8,opt,name=ip_version,json=ipVersion,proto3" json:"ip_version,omitempty"`
+	Prefix        string                 `protobuf:"bytes,9,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseIPRequest) Reset() {
+	*x = ReleaseIPRequest{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseIPRequest) ProtoMessage() {}
+
+func (x *ReleaseIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseIPRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseIPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReleaseIPRequest) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetPodNamespace() string {
+	if x != nil {
+		return x.PodNamespace
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetPodUid() string {
+	if x != nil {
+		return x.PodUid
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetParentNicMac() string {
+	if x != nil {
+		return x.ParentNicMac
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetIpVersion() int32 {
+	if x != nil {
+		return x.IpVersion
+	}
+	return 0
+}
+
+func (x *ReleaseIPRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type ReleaseIPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseIPResponse) Reset() {
+	*x = ReleaseIPResponse{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseIPResponse) ProtoMessage() {}
+
+func (x *ReleaseIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseIPResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseIPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{5}
+}
+
+type ListAssignmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The assignments of all nodes are listed if it's empty.
+	NodeName      string `protobuf:"bytes,1,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Continue      string `protobuf:"bytes,3,opt,name=continue,proto3" json:"continue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsRequest) Reset() {
+	*x = ListAssignmentsRequest{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAssignmentsRequest) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *ListAssignmentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAssignmentsRequest) GetContinue() string {
+	if x != nil {
+		return x.Continue
+	}
+	return ""
+}
+
+type ListAssignmentsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	IaasIpAssignments []*IaaSIPAssignment    `protobuf:"bytes,1,rep,name=iaas_ip_assignments,json=iaasIPAssignments,proto3" json:"iaas_ip_assignments,omitempty"`
+	// The token to get the next page, it's empty for the last page.
+	Continue      string `protobuf:"bytes,2,opt,name=continue,proto3" json:"continue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsResponse) Reset() {
+	*x = ListAssignmentsResponse{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAssignmentsResponse) GetIaasIpAssignments() []*IaaSIPAssignment {
+	if x != nil {
+		return x.IaasIpAssignments
+	}
+	return nil
+}
+
+func (x *ListAssignmentsResponse) GetContinue() string {
+	if x != nil {
+		return x.Continue
+	}
+	return ""
+}
+
+type IaaSIPAssignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeName      string                 `protobuf:"bytes,1,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	ParentNicMac  string                 `protobuf:"bytes,2,opt,name=parent_nic_mac,json=parentNicMac,proto3" json:"parent_nic_mac,omitempty"`
+	Subnet        string                 `protobuf:"bytes,3,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	IpVersion     int32                  `protobuf:"varint,5,opt,name=ip_version,json=ipVersion,proto3" json:"ip_version,omitempty"`
+	Prefix        string                 `protobuf:"bytes,6,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	PodName       string                 `protobuf:"bytes,7,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	PodNamespace  string                 `protobuf:"bytes,8,opt,name=pod_namespace,json=podNamespace,proto3" json:"pod_namespace,omitempty"`
+	PodUid        string                 `protobuf:"bytes,9,opt,name=pod_uid,json=podUID,proto3" json:"pod_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IaaSIPAssignment) Reset() {
+	*x = IaaSIPAssignment{}
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IaaSIPAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IaaSIPAssignment) ProtoMessage() {}
+
+func (x *IaaSIPAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_iaas_iaas_provider_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IaaSIPAssignment.ProtoReflect.Descriptor instead.
+func (*IaaSIPAssignment) Descriptor() ([]byte, []int) {
+	return file_api_v1_iaas_iaas_provider_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IaaSIPAssignment) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetParentNicMac() string {
+	if x != nil {
+		return x.ParentNicMac
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetIpVersion() int32 {
+	if x != nil {
+		return x.IpVersion
+	}
+	return 0
+}
+
+func (x *IaaSIPAssignment) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetPodNamespace() string {
+	if x != nil {
+		return x.PodNamespace
+	}
+	return ""
+}
+
+func (x *IaaSIPAssignment) GetPodUid() string {
+	if x != nil {
+		return x.PodUid
+	}
+	return ""
+}
+
+var File_api_v1_iaas_iaas_provider_proto protoreflect.FileDescriptor
+
+var file_api_v1_iaas_iaas_provider_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x61, 0x61, 0x73, 0x2f, 0x69, 0x61,
+	0x61, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x12, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61,
+	0x61, 0x73, 0x2e, 0x76, 0x31, 0x22, 0xf2, 0x01, 0x0a, 0x11, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x65, 0x49, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70,
+	0x6f, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
+	0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x64, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70,
+	0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x6f, 0x64, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f,
+	0x64, 0x55, 0x49, 0x44, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x67, 0x0a, 0x1b, 0x69, 0x61, 0x61, 0x73, 0x5f, 0x69, 0x70, 0x73, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x61, 0x61, 0x53,
+	0x49, 0x50, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x18, 0x69, 0x61, 0x61, 0x73, 0x49, 0x50, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x14, 0x49,
+	0x61, 0x61, 0x53, 0x49, 0x50, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x74, 0x65, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x69, 0x63, 0x5f, 0x6d, 0x61, 0x63, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x4e, 0x69, 0x63, 0x4d, 0x61, 0x63,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0xde, 0x01, 0x0a, 0x12, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x65, 0x49, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x70, 0x6f, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x64,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x6b, 0x0a, 0x1c, 0x69,
+	0x61, 0x61, 0x73, 0x5f, 0x69, 0x70, 0x73, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69,
+	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x61, 0x61, 0x53, 0x49, 0x50, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x19, 0x69,
+	0x61, 0x61, 0x73, 0x49, 0x50, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x16, 0x49, 0x61, 0x61,
+	0x53, 0x49, 0x50, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x69,
+	0x63, 0x5f, 0x6d, 0x61, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x4e, 0x69, 0x63, 0x4d, 0x61, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x76, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65,
+	0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x9c, 0x02, 0x0a, 0x10, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x49, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f,
+	0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f,
+	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x64, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x6f,
+	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f,
+	0x64, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x64,
+	0x55, 0x49, 0x44, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x69, 0x63, 0x5f, 0x6d,
+	0x61, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x4e, 0x69, 0x63, 0x4d, 0x61, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x69, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x22, 0x13, 0x0a, 0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49,
+	0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x67, 0x0a, 0x16, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
+	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
+	0x75, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54,
+	0x0a, 0x13, 0x69, 0x61, 0x61, 0x73, 0x5f, 0x69, 0x70, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x70,
+	0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x61, 0x61, 0x53, 0x49, 0x50, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x11, 0x69, 0x61, 0x61, 0x73, 0x49, 0x50, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65,
+	0x22, 0x9c, 0x02, 0x0a, 0x10, 0x49, 0x61, 0x61, 0x53, 0x49, 0x50, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x69, 0x63,
+	0x5f, 0x6d, 0x61, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x4e, 0x69, 0x63, 0x4d, 0x61, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e,
+	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f, 0x64, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x5f, 0x75, 0x69,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x64, 0x55, 0x49, 0x44, 0x32,
+	0xb2, 0x02, 0x0a, 0x0c, 0x49, 0x61, 0x61, 0x53, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x12, 0x5c, 0x0a, 0x0b, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x49, 0x50, 0x73, 0x12,
+	0x25, 0x2e, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x49, 0x50, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x65, 0x49, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58,
+	0x0a, 0x09, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x50, 0x12, 0x24, 0x2e, 0x73, 0x70,
+	0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69,
+	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x50,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x2e, 0x73, 0x70,
+	0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72,
+	0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x69, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x2d, 0x69, 0x6f, 0x2f,
+	0x73, 0x70, 0x69, 0x64, 0x65, 0x72, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x69, 0x61, 0x61, 0x73, 0x3b, 0x69, 0x61, 0x61, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v1_iaas_iaas_provider_proto_rawDescOnce sync.Once
+	file_api_v1_iaas_iaas_provider_proto_rawDescData = file_api_v1_iaas_iaas_provider_proto_rawDesc
+)
+
+func file_api_v1_iaas_iaas_provider_proto_rawDescGZIP() []byte {
+	file_api_v1_iaas_iaas_provider_proto_rawDescOnce.Do(func() {
+		file_api_v1_iaas_iaas_provider_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v1_iaas_iaas_provider_proto_rawDescData)
+	})
+	return file_api_v1_iaas_iaas_provider_proto_rawDescData
+}
+
+var file_api_v1_iaas_iaas_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_v1_iaas_iaas_provider_proto_goTypes = []any{
+	(*AllocateIPRequest)(nil),       // 0: spiderpool.iaas.v1.AllocateIPRequest
+	(*IaaSIPAllocationItem)(nil),    // 1: spiderpool.iaas.v1.IaaSIPAllocationItem
+	(*AllocateIPResponse)(nil),      // 2: spiderpool.iaas.v1.AllocateIPResponse
+	(*IaaSIPAllocationResult)(nil),  // 3: spiderpool.iaas.v1.IaaSIPAllocationResult
+	(*ReleaseIPRequest)(nil),        // 4: spiderpool.iaas.v1.ReleaseIPRequest
+	(*ReleaseIPResponse)(nil),       // 5: spiderpool.iaas.v1.ReleaseIPResponse
+	(*ListAssignmentsRequest)(nil),  // 6: spiderpool.iaas.v1.ListAssignmentsRequest
+	(*ListAssignmentsResponse)(nil), // 7: spiderpool.iaas.v1.ListAssignmentsResponse
+	(*IaaSIPAssignment)(nil),        // 8: spiderpool.iaas.v1.IaaSIPAssignment
+}
+var file_api_v1_iaas_iaas_provider_proto_depIdxs = []int32{
+	1, // 0: spiderpool.iaas.v1.AllocateIPRequest.iaas_ips_allocation_request:type_name -> spiderpool.iaas.v1.IaaSIPAllocationItem
+	3, // 1: spiderpool.iaas.v1.AllocateIPResponse.iaas_ips_allocation_response:type_name -> spiderpool.iaas.v1.IaaSIPAllocationResult
+	8, // 2: spiderpool.iaas.v1.ListAssignmentsResponse.iaas_ip_assignments:type_name -> spiderpool.iaas.v1.IaaSIPAssignment
+	0, // 3: spiderpool.iaas.v1.IaaSProvider.AllocateIPs:input_type -> spiderpool.iaas.v1.AllocateIPRequest
+	4, // 4: spiderpool.iaas.v1.IaaSProvider.ReleaseIP:input_type -> spiderpool.iaas.v1.ReleaseIPRequest
+	6, // 5: spiderpool.iaas.v1.IaaSProvider.ListAssignments:input_type -> spiderpool.iaas.v1.ListAssignmentsRequest
+	2, // 6: spiderpool.iaas.v1.IaaSProvider.AllocateIPs:output_type -> spiderpool.iaas.v1.AllocateIPResponse
+	5, // 7: spiderpool.iaas.v1.IaaSProvider.ReleaseIP:output_type -> spiderpool.iaas.v1.ReleaseIPResponse
+	7, // 8: spiderpool.iaas.v1.IaaSProvider.ListAssignments:output_type -> spiderpool.iaas.v1.ListAssignmentsResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_iaas_iaas_provider_proto_init() }
+func file_api_v1_iaas_iaas_provider_proto_init() {
+	if File_api_v1_iaas_iaas_provider_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_v1_iaas_iaas_provider_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_iaas_iaas_provider_proto_goTypes,
+		DependencyIndexes: file_api_v1_iaas_iaas_provider_proto_depIdxs,
+		MessageInfos:      file_api_v1_iaas_iaas_provider_proto_msgTypes,
+	}.Build()
+	File_api_v1_iaas_iaas_provider_proto = out.File
+	file_api_v1_iaas_iaas_provider_proto_rawDesc = nil
+	file_api_v1_iaas_iaas_provider_proto_goTypes = nil
+	file_api_v1_iaas_iaas_provider_proto_depIdxs = nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// The IaaSProvider service is called by the grpc driver of the Spiderpool
+// IaaS network provider integration. The messages are encoded with the
+// default protobuf codec. If iaasNetworkProvider.grpc.codec is "json",
+// Spiderpool encodes them with the proto3 JSON mapping and the "json"
+// content subtype instead, and the providers must register a JSON codec,
+// e.g. with protojson. The integer fields are int32 so that they are
+// encoded as JSON numbers.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: api/v1/iaas/iaas_provider.proto
+
+package iaas
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	IaaSProvider_AllocateIPs_FullMethodName     = "/spiderpool.iaas.v1.IaaSProvider/AllocateIPs"
+	IaaSProvider_ReleaseIP_FullMethodName       = "/spiderpool.iaas.v1.IaaSProvider/ReleaseIP"
+	IaaSProvider_ListAssignments_FullMethodName = "/spiderpool.iaas.v1.IaaSProvider/ListAssignments"
+)
+
+// IaaSProviderClient is the client API for IaaSProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IaaSProviderClient interface {
+	// AllocateIPs binds the IPs to the parent NIC of the node.
+	AllocateIPs(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*AllocateIPResponse, error)
+	// ReleaseIP unbinds an IP from the parent NIC of the node.
+	ReleaseIP(ctx context.Context, in *ReleaseIPRequest, opts ...grpc.CallOption) (*ReleaseIPResponse, error)
+	// ListAssignments lists a page of the IPs bound on the cloud platform.
+	ListAssignments(ctx context.Context, in *ListAssignmentsRequest, opts ...grpc.CallOption) (*ListAssignmentsResponse, error)
+}
+
+type iaaSProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIaaSProviderClient(cc grpc.ClientConnInterface) IaaSProviderClient {
+	return &iaaSProviderClient{cc}
+}
+
+func (c *iaaSProviderClient) AllocateIPs(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*AllocateIPResponse, error) {
+	out := new(AllocateIPResponse)
+	err := c.cc.Invoke(ctx, IaaSProvider_AllocateIPs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iaaSProviderClient) ReleaseIP(ctx context.Context, in *ReleaseIPRequest, opts ...grpc.CallOption) (*ReleaseIPResponse, error) {
+	out := new(ReleaseIPResponse)
+	err := c.cc.Invoke(ctx, IaaSProvider_ReleaseIP_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iaaSProviderClient) ListAssignments(ctx context.Context, in *ListAssignmentsRequest, opts ...grpc.CallOption) (*ListAssignmentsResponse, error) {
+	out := new(ListAssignmentsResponse)
+	err := c.cc.Invoke(ctx, IaaSProvider_ListAssignments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IaaSProviderServer is the server API for IaaSProvider service.
+// All implementations must embed UnimplementedIaaSProviderServer
+// for forward compatibility
+type IaaSProviderServer interface {
+	// AllocateIPs binds the IPs to the parent NIC of the node.
+	AllocateIPs(context.Context, *AllocateIPRequest) (*AllocateIPResponse, error)
+	// ReleaseIP unbinds an IP from the parent NIC of the node.
+	ReleaseIP(context.Context, *ReleaseIPRequest) (*ReleaseIPResponse, error)
+	// ListAssignments lists a page of the IPs bound on the cloud platform.
+	ListAssignments(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error)
+	mustEmbedUnimplementedIaaSProviderServer()
+}
+
+// UnimplementedIaaSProviderServer must be embedded to have forward compatible implementations.
+type UnimplementedIaaSProviderServer struct {
+}
+
+func (UnimplementedIaaSProviderServer) AllocateIPs(context.Context, *AllocateIPRequest) (*AllocateIPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateIPs not implemented")
+}
+func (UnimplementedIaaSProviderServer) ReleaseIP(context.Context, *ReleaseIPRequest) (*ReleaseIPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseIP not implemented")
+}
+func (UnimplementedIaaSProviderServer) ListAssignments(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssignments not implemented")
+}
+func (UnimplementedIaaSProviderServer) mustEmbedUnimplementedIaaSProviderServer() {}
+
+// UnsafeIaaSProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IaaSProviderServer will
+// result in compilation errors.
+type UnsafeIaaSProviderServer interface {
+	mustEmbedUnimplementedIaaSProviderServer()
+}
+
+func RegisterIaaSProviderServer(s grpc.ServiceRegistrar, srv IaaSProviderServer) {
+	s.RegisterService(&IaaSProvider_ServiceDesc, srv)
+}
+
+func _IaaSProvider_AllocateIPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IaaSProviderServer).AllocateIPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IaaSProvider_AllocateIPs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IaaSProviderServer).AllocateIPs(ctx, req.(*AllocateIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IaaSProvider_ReleaseIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IaaSProviderServer).ReleaseIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IaaSProvider_ReleaseIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IaaSProviderServer).ReleaseIP(ctx, req.(*ReleaseIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IaaSProvider_ListAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IaaSProviderServer).ListAssignments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IaaSProvider_ListAssignments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IaaSProviderServer).ListAssignments(ctx, req.(*ListAssignmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IaaSProvider_ServiceDesc is the grpc.ServiceDesc for IaaSProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IaaSProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spiderpool.iaas.v1.IaaSProvider",
+	HandlerType: (*IaaSProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AllocateIPs",
+			Handler:    _IaaSProvider_AllocateIPs_Handler,
+		},
+		{
+			MethodName: "ReleaseIP",
+			Handler:    _IaaSProvider_ReleaseIP_Handler,
+		},
+		{
+			MethodName: "ListAssignments",
+			Handler:    _IaaSProvider_ListAssignments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/iaas/iaas_provider.proto",
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"k8s.io/utils/exec"
+
+	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+)
+
+// setupBandwidth shapes and marks the traffic of the pod's interface. It's done
+// in the netns of the pod instead of by the bandwidth plugin, which requires
+// the host veth of the interface, but the macvlan and ipvlan ones have none.
+func setupBandwidth(netnsPath, ifName string, bw *BandwidthConfig) error {
+	netns, err := ns.GetNS(netnsPath)
+	if err != nil {
+		return fmt.Errorf("failed to GetNS %q: %w", netnsPath, err)
+	}
+	defer func() { _ = netns.Close() }()
+
+	var backend netfilterBackend
+	if bw.DSCP != nil || bw.Priority != "" {
+		ipFamily, err := networking.GetIPFamilyByIface(netns, ifName)
+		if err != nil {
+			return fmt.Errorf("failed to get the IP family of %s: %w", ifName, err)
+		}
+		backend = newNetfilterBackend(exec.New(), ipFamily)
+	}
+
+	return netns.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(ifName)
+		if err != nil {
+			return fmt.Errorf("failed to get link %s: %w", ifName, err)
+		}
+
+		if bw.EgressRate > 0 {
+			if err := networking.SetEgressRateLimit(link, bw.EgressRate, bw.EgressBurst); err != nil {
+				return err
+			}
+		}
+
+		if bw.IngressRate > 0 {
+			if err := networking.SetIngressRateLimit(link, bw.IngressRate, bw.IngressBurst); err != nil {
+				return err
+			}
+		}
+
+		if backend != nil {
+			if err := backend.EnsureQoSRules(ifName, bw.DSCP, bw.Priority); err != nil {
+				return fmt.Errorf("failed to mark the DSCP and priority by %s: %w", backend.Name(), err)
+			}
+		}
+
+		return nil
+	})
+}
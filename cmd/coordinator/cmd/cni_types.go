@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/containernetworking/cni/pkg/types"
@@ -43,6 +44,22 @@ const (
 	ModeDisable  Mode = "disable"
 )
 
+type Datapath string
+
+const (
+	// DatapathRoute forwards the hijacked traffic to the host by routes
+	DatapathRoute Datapath = "route"
+	// DatapathEBPF forwards the hijacked traffic to the host by a TC program
+	DatapathEBPF Datapath = "ebpf"
+)
+
+// SupportedDatapaths indicate the datapath that coordinator support.
+var SupportedDatapaths = []string{string(DatapathRoute), string(DatapathEBPF)}
+
+// PriorityRegex matches the skb priority of the bandwidth, which is the tc
+// class ID "major:minor" in hex, like "1:10".
+var PriorityRegex = regexp.MustCompile(`^[0-9a-fA-F]{1,4}:[0-9a-fA-F]{1,4}$`)
+
 type Config struct {
 	types.NetConf
 	VethLinkAddress    string      `json:"vethLinkAddress,omitempty"`
@@ -57,11 +74,34 @@ type Config struct {
 	TunePodRoutes      *bool       `json:"tunePodRoutes,omitempty"`
 	PodDefaultRouteNIC string      `json:"podDefaultRouteNic,omitempty"`
 	Mode               Mode        `json:"mode,omitempty"`
+	Datapath           Datapath    `json:"datapath,omitempty"`
+	DatapathFallback   *bool       `json:"datapathFallback,omitempty"`
 	HostRuleTable      *int64      `json:"hostRuleTable,omitempty"`
 	HostRPFilter       *int32      `json:"hostRPFilter,omitempty" `
 	PodRPFilter        *int32      `json:"podRPFilter,omitempty" `
 	TxQueueLen         *int64      `json:"txQueueLen,omitempty"`
 	LogOptions         *LogOptions `json:"logOptions,omitempty"`
+
+	Bandwidth     *BandwidthConfig `json:"bandwidth,omitempty"`
+	RuntimeConfig RuntimeConfig    `json:"runtimeConfig,omitempty"`
+}
+
+// BandwidthConfig shapes the traffic of the pod's interface, the rates are in
+// bits per second and the bursts are in bits, like the bandwidth plugin.
+type BandwidthConfig struct {
+	IngressRate  uint64 `json:"ingressRate,omitempty"`
+	IngressBurst uint64 `json:"ingressBurst,omitempty"`
+	EgressRate   uint64 `json:"egressRate,omitempty"`
+	EgressBurst  uint64 `json:"egressBurst,omitempty"`
+	DSCP         *int32 `json:"dscp,omitempty"`
+	Priority     string `json:"priority,omitempty"`
+}
+
+// RuntimeConfig is passed by Multus for the capabilities of the plugin.
+type RuntimeConfig struct {
+	// Bandwidth is the "bandwidth" of the pod in the Multus network annotation,
+	// only the rates and bursts are passed.
+	Bandwidth *BandwidthConfig `json:"bandwidth,omitempty"`
 }
 
 type Route struct {
@@ -167,6 +207,20 @@ func ParseConfig(stdin []byte, coordinatorConfig *models.CoordinatorConfig) (*Co
 		conf.Mode = Mode(*coordinatorConfig.Mode)
 	}
 
+	if conf.Datapath == "" {
+		conf.Datapath = Datapath(coordinatorConfig.Datapath)
+	}
+	if conf.Datapath == "" {
+		conf.Datapath = DatapathRoute
+	}
+	if !slices.Contains(SupportedDatapaths, string(conf.Datapath)) {
+		return nil, fmt.Errorf("unsupported datapath %s, it must be one of %v", conf.Datapath, SupportedDatapaths)
+	}
+
+	if conf.DatapathFallback == nil {
+		conf.DatapathFallback = &coordinatorConfig.DatapathFallback
+	}
+
 	if conf.PodDefaultRouteNIC == "" && coordinatorConfig.PodDefaultRouteNIC != "" {
 		conf.PodDefaultRouteNIC = coordinatorConfig.PodDefaultRouteNIC
 	}
@@ -183,9 +237,45 @@ func ParseConfig(stdin []byte, coordinatorConfig *models.CoordinatorConfig) (*Co
 	if conf.VethMTU == nil {
 		conf.VethMTU = ptr.To(defaultUnderlayVethMTU)
 	}
+
+	// the bandwidth of the pod overrides the rate and burst of the network in
+	// each direction whose rate is set by the pod
+	if conf.Bandwidth != nil && conf.RuntimeConfig.Bandwidth != nil {
+		podBandwidth := conf.RuntimeConfig.Bandwidth
+		if podBandwidth.IngressRate > 0 {
+			conf.Bandwidth.IngressRate = podBandwidth.IngressRate
+			conf.Bandwidth.IngressBurst = podBandwidth.IngressBurst
+		}
+		if podBandwidth.EgressRate > 0 {
+			conf.Bandwidth.EgressRate = podBandwidth.EgressRate
+			conf.Bandwidth.EgressBurst = podBandwidth.EgressBurst
+		}
+	}
+	if conf.Bandwidth != nil {
+		if err = validateBandwidth(conf.Bandwidth); err != nil {
+			return nil, err
+		}
+	}
 	return &conf, nil
 }
 
+func validateBandwidth(bw *BandwidthConfig) error {
+	if (bw.IngressRate > 0) != (bw.IngressBurst > 0) {
+		return fmt.Errorf("ingressRate and ingressBurst of bandwidth must be set together")
+	}
+	if (bw.EgressRate > 0) != (bw.EgressBurst > 0) {
+		return fmt.Errorf("egressRate and egressBurst of bandwidth must be set together")
+	}
+	if bw.DSCP != nil && (*bw.DSCP < 0 || *bw.DSCP > 63) {
+		return fmt.Errorf("dscp of bandwidth must be in range [0, 63]")
+	}
+	if bw.Priority != "" && !PriorityRegex.MatchString(bw.Priority) {
+		return fmt.Errorf("priority %q of bandwidth must be in the form major:minor in hex", bw.Priority)
+	}
+
+	return nil
+}
+
 func validateHwPrefix(prefix string) error {
 	if prefix == "" {
 		return nil
@@ -207,6 +297,12 @@ func ValidateRoutes(conf *Config, coordinatorConfig *models.CoordinatorConfig) e
 		conf.ServiceCIDR = coordinatorConfig.ServiceCIDR
 	}
 
+	// the pod CIDRs of the node are more precise than the ones of the cluster,
+	// which are only used if the node ones aren't tracked.
+	if len(conf.OverlayPodCIDR) == 0 {
+		conf.OverlayPodCIDR = coordinatorConfig.NodePodCIDR
+	}
+
 	if len(conf.OverlayPodCIDR) == 0 {
 		conf.OverlayPodCIDR = coordinatorConfig.OverlayPodCIDR
 	}
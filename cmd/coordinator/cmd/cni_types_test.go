@@ -0,0 +1,66 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+var _ = Describe("ParseConfig", Label("cni_types"), func() {
+	coordinatorConfig := &models.CoordinatorConfig{
+		Mode:           ptr.To(string(ModeAuto)),
+		OverlayPodCIDR: []string{},
+		ServiceCIDR:    []string{},
+		TunePodRoutes:  ptr.To(true),
+	}
+
+	// the network shapes the traffic of both directions, whose rates are
+	// overridden by the pod in the runtimeConfig
+	parseBandwidth := func(runtimeConfig string) *BandwidthConfig {
+		stdin := `{
+			"cniVersion": "1.0.0",
+			"name": "macvlan",
+			"type": "coordinator",
+			"bandwidth": {"ingressRate": 100, "ingressBurst": 10, "egressRate": 200, "egressBurst": 20, "dscp": 46, "priority": "1:10"},
+			"runtimeConfig": ` + runtimeConfig + `
+		}`
+
+		conf, err := ParseConfig([]byte(stdin), coordinatorConfig)
+		Expect(err).NotTo(HaveOccurred())
+		return conf.Bandwidth
+	}
+
+	It("uses the bandwidth of the network without the bandwidth of the pod", func() {
+		Expect(*parseBandwidth(`{}`)).To(Equal(BandwidthConfig{
+			IngressRate: 100, IngressBurst: 10, EgressRate: 200, EgressBurst: 20, DSCP: ptr.To(int32(46)), Priority: "1:10",
+		}))
+	})
+
+	It("overrides both directions by the bandwidth of the pod", func() {
+		Expect(*parseBandwidth(`{"bandwidth": {"ingressRate": 50, "ingressBurst": 5, "egressRate": 60, "egressBurst": 6}}`)).To(Equal(BandwidthConfig{
+			IngressRate: 50, IngressBurst: 5, EgressRate: 60, EgressBurst: 6, DSCP: ptr.To(int32(46)), Priority: "1:10",
+		}))
+	})
+
+	It("overrides only the direction whose rate is set by the pod", func() {
+		Expect(*parseBandwidth(`{"bandwidth": {"ingressRate": 50, "ingressBurst": 5}}`)).To(Equal(BandwidthConfig{
+			IngressRate: 50, IngressBurst: 5, EgressRate: 200, EgressBurst: 20, DSCP: ptr.To(int32(46)), Priority: "1:10",
+		}))
+
+		Expect(*parseBandwidth(`{"bandwidth": {"egressRate": 60, "egressBurst": 6}}`)).To(Equal(BandwidthConfig{
+			IngressRate: 100, IngressBurst: 10, EgressRate: 60, EgressBurst: 6, DSCP: ptr.To(int32(46)), Priority: "1:10",
+		}))
+	})
+
+	It("rejects the invalid priority", func() {
+		stdin := `{"cniVersion": "1.0.0", "name": "macvlan", "type": "coordinator", "bandwidth": {"priority": "10"}}`
+
+		_, err := ParseConfig([]byte(stdin), coordinatorConfig)
+		Expect(err).To(MatchError(ContainSubstring("priority")))
+	})
+})
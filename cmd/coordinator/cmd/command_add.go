@@ -13,6 +13,7 @@ import (
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/vishvananda/netlink"
 	"go.uber.org/zap"
+	"k8s.io/utils/ptr"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
@@ -53,6 +54,13 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		return err
 	}
 
+	// the bandwidth is set up even if coordinator is disabled
+	if conf.Bandwidth != nil {
+		if err = setupBandwidth(args.Netns, args.IfName, conf.Bandwidth); err != nil {
+			return fmt.Errorf("failed to set up the bandwidth of %s: %w", args.IfName, err)
+		}
+	}
+
 	if conf.Mode == ModeDisable {
 		return types.PrintResult(conf.PrevResult, conf.CNIVersion)
 	}
@@ -86,9 +94,11 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 	c := &coordinator{
 		HijackCIDR:       conf.OverlayPodCIDR,
 		Routes:           conf.PolicyRoutes,
+		DelegatedRoutes:  convertCoordinatorPolicyRoutes(coordinatorConfig.DelegatedRoutes),
 		hostRuleTable:    int(*conf.HostRuleTable),
 		currentInterface: args.IfName,
 		tuneMode:         conf.Mode,
+		datapath:         conf.Datapath,
 		vethLinkAddress:  conf.VethLinkAddress,
 		vethMTU:          *conf.VethMTU,
 	}
@@ -266,7 +276,36 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 
 	logger.Debug("Show the addrs of pod's eth0", zap.Any("v4PodOverlayNicAddr", c.v4PodOverlayNicAddr), zap.Any("v6PodOverlayNicAddr", c.v6PodOverlayNicAddr))
 
-	if err = c.setupHostRoutes(logger); err != nil {
+	// the ebpf datapath redirects the traffic between the pod and the host by
+	// the TC programs, instead of the hijack routes in the pod and the routes
+	// of the pod IPs in the host rule table
+	redirected := false
+	if c.datapath == DatapathEBPF {
+		if err = c.setupRedirect(logger); err == nil {
+			redirected = true
+		} else if conf.DatapathFallback == nil || !*conf.DatapathFallback {
+			logger.Error("failed to setupRedirect", zap.Error(err))
+			return fmt.Errorf("failed to set up the ebpf datapath: %w", err)
+		} else {
+			logger.Warn("failed to setupRedirect, fall back to the routes", zap.Error(err))
+			c.cleanupRedirect(logger)
+			// the fallback is recorded as an Event of the pod by spiderpool-agent
+			_, reportErr := client.Daemonset.PostCoordinatorDatapathFallback(daemonset.NewPostCoordinatorDatapathFallbackParams().WithCoordinatorDatapathFallback(
+				&models.CoordinatorDatapathFallbackArgs{
+					PodName:      ptr.To(string(k8sArgs.K8S_POD_NAME)),
+					PodNamespace: ptr.To(string(k8sArgs.K8S_POD_NAMESPACE)),
+					Interface:    args.IfName,
+					Datapath:     ptr.To(string(c.datapath)),
+					Reason:       err.Error(),
+				},
+			))
+			if reportErr != nil {
+				logger.Warn("failed to report the datapath fallback", zap.Error(reportErr))
+			}
+		}
+	}
+
+	if err = c.setupHostRoutes(logger, redirected); err != nil {
 		logger.Error(err.Error())
 		return err
 	}
@@ -287,9 +326,11 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		}
 	}
 
-	if err = c.setupHijackRoutes(logger, c.currentRuleTable); err != nil {
-		logger.Error("failed to setupHijackRoutes", zap.Error(err))
-		return err
+	if !redirected {
+		if err = c.setupHijackRoutes(logger, c.currentRuleTable); err != nil {
+			logger.Error("failed to setupHijackRoutes", zap.Error(err))
+			return err
+		}
 	}
 
 	if c.tuneMode == ModeUnderlay && c.firstInvoke {
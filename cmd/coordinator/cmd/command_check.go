@@ -0,0 +1,116 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"k8s.io/utils/exec"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	plugincmd "github.com/spidernet-io/spiderpool/cmd/spiderpool/cmd"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+	"github.com/spidernet-io/spiderpool/pkg/openapi"
+)
+
+// CmdCheck reports whether the mark rules for veth0 are present in the pod.
+func CmdCheck(args *skel.CmdArgs) (err error) {
+	k8sArgs := plugincmd.K8sArgs{}
+	if err = types.LoadArgs(args.Args, &k8sArgs); nil != err {
+		return fmt.Errorf("failed to load CNI ENV args: %w", err)
+	}
+
+	client, err := openapi.NewAgentOpenAPIUnixClient(constant.DefaultIPAMUnixSocketPath)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Daemonset.GetCoordinatorConfig(daemonset.NewGetCoordinatorConfigParams().WithGetCoordinatorConfig(
+		&models.GetCoordinatorArgs{
+			PodName:      string(k8sArgs.K8S_POD_NAME),
+			PodNamespace: string(k8sArgs.K8S_POD_NAMESPACE),
+		},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to GetCoordinatorConfig: %w", err)
+	}
+	coordinatorConfig := resp.Payload
+
+	conf, err := ParseConfig(args.StdinData, coordinatorConfig)
+	if err != nil {
+		return err
+	}
+
+	if conf.Mode == ModeDisable {
+		return nil
+	}
+
+	logger, err := logutils.SetupFileLogging(conf.LogOptions.LogLevel,
+		conf.LogOptions.LogFilePath, conf.LogOptions.LogFileMaxSize,
+		conf.LogOptions.LogFileMaxAge, conf.LogOptions.LogFileMaxCount)
+	if err != nil {
+		return fmt.Errorf("failed to init logger: %w ", err)
+	}
+
+	logger = logger.Named(BinNamePlugin).With(
+		zap.String("Action", "CHECK"),
+		zap.String("ContainerID", args.ContainerID),
+		zap.String("Netns", args.Netns),
+		zap.String("IfName", args.IfName),
+	)
+
+	netns, err := ns.GetNS(args.Netns)
+	if err != nil {
+		logger.Error("failed to GetNS", zap.Error(err))
+		return fmt.Errorf("failed to GetNS %s: %w", args.Netns, err)
+	}
+	defer func() { _ = netns.Close() }()
+
+	// the mark rules are added for veth0 in the underlay mode only
+	vethExist := true
+	err = netns.Do(func(_ ns.NetNS) error {
+		_, err := netlink.LinkByName(defaultUnderlayVethName)
+		var linkNotFoundErr netlink.LinkNotFoundError
+		if errors.As(err, &linkNotFoundErr) {
+			vethExist = false
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		logger.Error("failed to get veth0 of pod", zap.Error(err))
+		return fmt.Errorf("failed to get %s of pod: %w", defaultUnderlayVethName, err)
+	}
+
+	if !vethExist {
+		logger.Debug("veth0 is not found in pod, nothing to check")
+		return nil
+	}
+
+	ipFamily, err := networking.GetIPFamilyByIface(netns, args.IfName)
+	if err != nil {
+		logger.Error("failed to GetIPFamilyByIface", zap.Error(err))
+		return err
+	}
+
+	backend := newNetfilterBackend(exec.New(), ipFamily)
+	if err = netns.Do(func(_ ns.NetNS) error {
+		return backend.CheckMarkRules()
+	}); err != nil {
+		logger.Error("the mark rules are missing", zap.String("Backend", backend.Name()), zap.Error(err))
+		return err
+	}
+
+	logger.Debug("the mark rules are present", zap.String("Backend", backend.Name()))
+	return nil
+}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net"
 	"os"
 
 	"github.com/containernetworking/cni/pkg/skel"
@@ -13,6 +14,7 @@ import (
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/vishvananda/netlink"
 	"go.uber.org/zap"
+	"k8s.io/utils/exec"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
@@ -86,6 +88,8 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 	}
 	defer func() { _ = c.netns.Close() }()
 
+	// the endpoints of the ebpf datapath to the host veth are deleted after it
+	hostVethIndex := -1
 	hostVeth := getHostVethName(args.ContainerID)
 	vethLink, err := netlink.LinkByName(hostVeth)
 	if err != nil {
@@ -97,11 +101,24 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 			return fmt.Errorf("failed to get host veth device %s: %w", hostVeth, err)
 		}
 	} else {
+		hostVethIndex = vethLink.Attrs().Index
 		if err = netlink.LinkDel(vethLink); err != nil {
 			logger.Sugar().Warn("failed to del hostVeth", zap.Error(err))
 			return fmt.Errorf("failed to del hostVeth %s: %w", hostVeth, err)
 		}
 		logger.Sugar().Debug("success to del hostVeth", zap.String("HostVeth", hostVeth))
+
+		// the mark rules are added for veth0 in the underlay mode only
+		for _, backend := range newNetfilterBackends(exec.New(), netlink.FAMILY_ALL) {
+			if err = c.netns.Do(func(_ ns.NetNS) error {
+				return backend.DeleteMarkRules()
+			}); err != nil {
+				// ignore err
+				logger.Sugar().Warn("failed to delete the mark rules, ignore error", zap.String("Backend", backend.Name()), zap.Error(err))
+			} else {
+				logger.Sugar().Debug("success to delete the mark rules", zap.String("Backend", backend.Name()))
+			}
+		}
 	}
 
 	err = c.netns.Do(func(netNS ns.NetNS) error {
@@ -116,8 +133,10 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 		logger.Sugar().Warn("failed to GetAddersByName, ignore error", zap.Error(err))
 	}
 
+	prefixes := make([]*net.IPNet, 0, len(c.currentAddress))
 	for idx := range c.currentAddress {
 		ipNet := networking.ConvertMaxMaskIPNet(c.currentAddress[idx].IP)
+		prefixes = append(prefixes, ipNet)
 		err = networking.DelToRuleTable(ipNet, c.hostRuleTable)
 		if err != nil && !os.IsNotExist(err) {
 			logger.Sugar().Error("failed to DelToRuleTable", zap.Int("HostRuleTable", c.hostRuleTable), zap.String("Dst", ipNet.String()), zap.Error(err))
@@ -125,6 +144,11 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 		}
 	}
 
+	// the leaked endpoints are cleaned by the orphan sweeper of spiderpool-agent
+	if err = deleteHostEndpoints(prefixes, hostVethIndex); err != nil {
+		logger.Sugar().Warn("failed to delete the endpoints from the host maps, ignore error", zap.Error(err))
+	}
+
 	logger.Info("cmdDel end")
 	return nil
 }
@@ -0,0 +1,348 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	"k8s.io/utils/exec"
+)
+
+const (
+	nftCmd      = "nft"
+	iptablesCmd = "iptables"
+
+	// nftTable is the table of coordinator in the pod, which holds all of its
+	// chains, so that they're updated and removed atomically.
+	nftTable = "spiderpool-coordinator"
+
+	// nftQoSTable is the table of the DSCP and priority rules, which is
+	// independent of the mark rules, since they're set up for each interface.
+	nftQoSTable = "spiderpool-qos"
+)
+
+// nftFamilies is the families of the tables, the route chain of the inet
+// family isn't supported by the old kernels.
+var nftFamilies = []string{"ip", "ip6"}
+
+// netfilterBackend programs the rules in the pod to mark the connections coming
+// from veth0, so that their reply packets are forwarded via veth0. It must be
+// called in the netns of the pod.
+type netfilterBackend interface {
+	// Name returns the name of the backend.
+	Name() string
+	// EnsureMarkRules ensures the rules exist.
+	EnsureMarkRules() error
+	// CheckMarkRules returns an error if any rule is missing.
+	CheckMarkRules() error
+	// DeleteMarkRules deletes the rules, it's not an error if they're missing.
+	DeleteMarkRules() error
+	// EnsureQoSRules ensures the traffic sent by the interface is marked
+	// with the DSCP if it's not nil, and the skb priority if it's not empty.
+	EnsureQoSRules(ifName string, dscp *int32, priority string) error
+}
+
+// newNetfilterBackend uses the native nftables on the nftables-only hosts,
+// otherwise uses iptables, so that the rules live in the same framework as
+// the other rules of the host. ADD and CHECK select the backend in the same
+// way, DEL deletes the rules of all backends by newNetfilterBackends.
+func newNetfilterBackend(execer exec.Interface, ipFamily int) netfilterBackend {
+	families, protocols := netfilterFamilies(ipFamily)
+	if isNFTablesOnlyHost(execer) {
+		return &nftablesBackend{execer: execer, families: families}
+	}
+
+	return &iptablesBackend{execer: execer, protocols: protocols}
+}
+
+// newNetfilterBackends returns all backends whose command is found, so that
+// the rules are deleted even if the backend of the host has changed since
+// they're added.
+func newNetfilterBackends(execer exec.Interface, ipFamily int) []netfilterBackend {
+	families, protocols := netfilterFamilies(ipFamily)
+
+	var backends []netfilterBackend
+	if _, err := execer.LookPath(nftCmd); err == nil {
+		backends = append(backends, &nftablesBackend{execer: execer, families: families})
+	}
+	if _, err := execer.LookPath(iptablesCmd); err == nil {
+		backends = append(backends, &iptablesBackend{execer: execer, protocols: protocols})
+	}
+
+	return backends
+}
+
+// isNFTablesOnlyHost returns true if the nft command is found, and iptables
+// is either missing, broken or the nf_tables variant, whose version is like
+// "iptables v1.8.7 (nf_tables)". The nft command is also shipped on many
+// hosts running the legacy iptables, so it's not enough by itself.
+func isNFTablesOnlyHost(execer exec.Interface) bool {
+	if _, err := execer.LookPath(nftCmd); err != nil {
+		return false
+	}
+
+	if _, err := execer.LookPath(iptablesCmd); err != nil {
+		return true
+	}
+
+	out, err := execer.Command(iptablesCmd, "-V").CombinedOutput()
+	if err != nil {
+		return true
+	}
+
+	return strings.Contains(string(out), "nf_tables")
+}
+
+func netfilterFamilies(ipFamily int) ([]string, []utiliptables.Protocol) {
+	switch ipFamily {
+	case netlink.FAMILY_V4:
+		return []string{"ip"}, []utiliptables.Protocol{utiliptables.ProtocolIPv4}
+	case netlink.FAMILY_V6:
+		return []string{"ip6"}, []utiliptables.Protocol{utiliptables.ProtocolIPv6}
+	case netlink.FAMILY_ALL:
+		return nftFamilies, []utiliptables.Protocol{utiliptables.ProtocolIPv4, utiliptables.ProtocolIPv6}
+	}
+
+	return nil, nil
+}
+
+type nftablesBackend struct {
+	execer   exec.Interface
+	families []string
+}
+
+func (n *nftablesBackend) Name() string {
+	return "nftables"
+}
+
+// nftMarkRules returns the rules of the table by chain, each rule is
+// commented so that it can be found in the output of nft.
+func nftMarkRules() map[string][]string {
+	mark := fmt.Sprintf("%#x", getMarkInt(defaultMarkBit))
+	return map[string][]string{
+		"prerouting": {
+			fmt.Sprintf(`iifname %q ct state new meta mark set %s comment "spiderpool: mark new connections from %s"`, defaultUnderlayVethName, mark, defaultUnderlayVethName),
+			fmt.Sprintf(`meta mark %s ct mark set meta mark comment "spiderpool: save mark"`, mark),
+		},
+		"output": {
+			`meta mark set ct mark comment "spiderpool: restore mark"`,
+		},
+	}
+}
+
+// EnsureMarkRules replaces the tables of coordinator in a transaction.
+func (n *nftablesBackend) EnsureMarkRules() error {
+	rules := nftMarkRules()
+
+	var script strings.Builder
+	for _, family := range n.families {
+		// create the table if it's missing, so that the delete never fails
+		fmt.Fprintf(&script, "add table %s %s\n", family, nftTable)
+		fmt.Fprintf(&script, "delete table %s %s\n", family, nftTable)
+		fmt.Fprintf(&script, "table %s %s {\n", family, nftTable)
+		fmt.Fprintf(&script, "\tchain prerouting {\n")
+		fmt.Fprintf(&script, "\t\ttype filter hook prerouting priority mangle; policy accept;\n")
+		for _, rule := range rules["prerouting"] {
+			fmt.Fprintf(&script, "\t\t%s\n", rule)
+		}
+		fmt.Fprintf(&script, "\t}\n")
+		// the route chain reroutes the packets whose mark is restored
+		fmt.Fprintf(&script, "\tchain output {\n")
+		fmt.Fprintf(&script, "\t\ttype route hook output priority mangle; policy accept;\n")
+		for _, rule := range rules["output"] {
+			fmt.Fprintf(&script, "\t\t%s\n", rule)
+		}
+		fmt.Fprintf(&script, "\t}\n")
+		fmt.Fprintf(&script, "}\n")
+	}
+
+	if _, err := n.run(script.String(), "-f", "-"); err != nil {
+		return fmt.Errorf("nftables ensure table %s err: %w", nftTable, err)
+	}
+
+	return nil
+}
+
+// CheckMarkRules looks up the comments of the rules in the table, since nft
+// may format the rules differently from the way they're added.
+func (n *nftablesBackend) CheckMarkRules() error {
+	for _, family := range n.families {
+		for chain, rules := range nftMarkRules() {
+			out, err := n.run("", "list", "chain", family, nftTable, chain)
+			if err != nil {
+				return fmt.Errorf("nftables chain %s of table %s %s is missing: %w", chain, family, nftTable, err)
+			}
+
+			for _, rule := range rules {
+				comment := rule[strings.Index(rule, "comment "):]
+				if !strings.Contains(out, comment) {
+					return fmt.Errorf("nftables rule %q is missing in chain %s of table %s %s", rule, chain, family, nftTable)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteMarkRules deletes the tables of both families in a transaction, in
+// case the IP family of the pod has changed since they're added.
+func (n *nftablesBackend) DeleteMarkRules() error {
+	var script strings.Builder
+	for _, family := range nftFamilies {
+		fmt.Fprintf(&script, "add table %s %s\n", family, nftTable)
+		fmt.Fprintf(&script, "delete table %s %s\n", family, nftTable)
+	}
+
+	if _, err := n.run(script.String(), "-f", "-"); err != nil {
+		return fmt.Errorf("nftables delete table %s err: %w", nftTable, err)
+	}
+
+	return nil
+}
+
+// EnsureQoSRules replaces the chain of the interface in the QoS tables in a
+// transaction.
+func (n *nftablesBackend) EnsureQoSRules(ifName string, dscp *int32, priority string) error {
+	chain := "qos-" + ifName
+
+	var statements []string
+	if priority != "" {
+		statements = append(statements, "meta priority set "+priority)
+	}
+
+	var script strings.Builder
+	for _, family := range n.families {
+		familyStatements := statements
+		if dscp != nil {
+			familyStatements = append([]string{fmt.Sprintf("%s dscp set %d", family, *dscp)}, statements...)
+		}
+
+		fmt.Fprintf(&script, "add table %s %s\n", family, nftQoSTable)
+		fmt.Fprintf(&script, "add chain %s %s %s { type filter hook postrouting priority mangle; policy accept; }\n", family, nftQoSTable, chain)
+		fmt.Fprintf(&script, "flush chain %s %s %s\n", family, nftQoSTable, chain)
+		fmt.Fprintf(&script, "add rule %s %s %s oifname %q %s comment \"spiderpool: qos of %s\"\n", family, nftQoSTable, chain, ifName, strings.Join(familyStatements, " "), ifName)
+	}
+
+	if _, err := n.run(script.String(), "-f", "-"); err != nil {
+		return fmt.Errorf("nftables ensure chain %s of table %s err: %w", chain, nftQoSTable, err)
+	}
+
+	return nil
+}
+
+func (n *nftablesBackend) run(stdin string, args ...string) (string, error) {
+	cmd := n.execer.Command(nftCmd, args...)
+	if stdin != "" {
+		cmd.SetStdin(strings.NewReader(stdin))
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+type iptablesBackend struct {
+	execer    exec.Interface
+	protocols []utiliptables.Protocol
+}
+
+func (i *iptablesBackend) Name() string {
+	return "iptables"
+}
+
+// markRules returns the rules in the mangle table by chain.
+func (i *iptablesBackend) markRules() []struct {
+	chain utiliptables.Chain
+	args  []string
+} {
+	markStr := getMarkString(getMarkInt(defaultMarkBit))
+	return []struct {
+		chain utiliptables.Chain
+		args  []string
+	}{
+		{utiliptables.ChainPrerouting, []string{"-i", defaultUnderlayVethName, "-m", "conntrack", "--ctstate", "NEW", "-j", "MARK", "--set-xmark", markStr}},
+		{utiliptables.ChainPrerouting, []string{"-m", "mark", "--mark", markStr, "-j", "CONNMARK", "--save-mark"}},
+		{utiliptables.ChainOutput, []string{"-j", "CONNMARK", "--restore-mark"}},
+	}
+}
+
+func (i *iptablesBackend) EnsureMarkRules() error {
+	for _, protocol := range i.protocols {
+		ipt := utiliptables.New(i.execer, protocol)
+		for _, rule := range i.markRules() {
+			if _, err := ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, rule.chain, rule.args...); err != nil {
+				return fmt.Errorf("iptables ensureRule err: %v: %w", rule.args, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckMarkRules checks the rules by the -C option, EnsureRule is not used
+// because it adds the missing rules.
+func (i *iptablesBackend) CheckMarkRules() error {
+	for _, protocol := range i.protocols {
+		cmd := iptablesCmd
+		if protocol == utiliptables.ProtocolIPv6 {
+			cmd = "ip6tables"
+		}
+
+		for _, rule := range i.markRules() {
+			args := append([]string{"-w", "-t", string(utiliptables.TableMangle), "-C", string(rule.chain)}, rule.args...)
+			if out, err := i.execer.Command(cmd, args...).CombinedOutput(); err != nil {
+				return fmt.Errorf("%s rule %v is missing in chain %s: %w: %s", cmd, rule.args, rule.chain, err, strings.TrimSpace(string(out)))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *iptablesBackend) DeleteMarkRules() error {
+	for _, protocol := range i.protocols {
+		ipt := utiliptables.New(i.execer, protocol)
+		for _, rule := range i.markRules() {
+			if err := ipt.DeleteRule(utiliptables.TableMangle, rule.chain, rule.args...); err != nil {
+				return fmt.Errorf("iptables deleteRule err: %v: %w", rule.args, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EnsureQoSRules marks the DSCP by the DSCP target, and the skb priority by
+// the CLASSIFY target.
+func (i *iptablesBackend) EnsureQoSRules(ifName string, dscp *int32, priority string) error {
+	var rules [][]string
+	if dscp != nil {
+		rules = append(rules, []string{"-o", ifName, "-j", "DSCP", "--set-dscp", strconv.Itoa(int(*dscp))})
+	}
+	if priority != "" {
+		rules = append(rules, []string{"-o", ifName, "-j", "CLASSIFY", "--set-class", priority})
+	}
+
+	for _, protocol := range i.protocols {
+		ipt := utiliptables.New(i.execer, protocol)
+		for _, args := range rules {
+			if _, err := ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainPostrouting, args...); err != nil {
+				return fmt.Errorf("iptables ensureRule err: %v: %w", args, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	"k8s.io/utils/exec"
+	testexec "k8s.io/utils/exec/testing"
+	"k8s.io/utils/ptr"
+)
+
+// fakeCall is a command called by the backends.
+type fakeCall struct {
+	argv  []string
+	stdin string
+}
+
+// newFakeExec returns the exec which finds the commands in paths, and replies
+// each command by the reply. The commands are recorded in calls.
+func newFakeExec(paths []string, reply func(call fakeCall) ([]byte, error)) (*testexec.FakeExec, *[]fakeCall) {
+	calls := &[]fakeCall{}
+	fake := &testexec.FakeExec{
+		LookPathFunc: func(file string) (string, error) {
+			for _, path := range paths {
+				if path == file {
+					return "/usr/sbin/" + file, nil
+				}
+			}
+			return "", fmt.Errorf("%s not found", file)
+		},
+	}
+
+	for i := 0; i < 32; i++ {
+		fake.CommandScript = append(fake.CommandScript, func(cmd string, args ...string) exec.Cmd {
+			fakeCmd := &testexec.FakeCmd{}
+			action := func() ([]byte, []byte, error) {
+				call := fakeCall{argv: append([]string{cmd}, args...)}
+				if fakeCmd.Stdin != nil {
+					stdin, _ := io.ReadAll(fakeCmd.Stdin)
+					call.stdin = string(stdin)
+				}
+				*calls = append(*calls, call)
+
+				out, err := reply(call)
+				return out, nil, err
+			}
+			fakeCmd.RunScript = []testexec.FakeAction{action}
+			fakeCmd.CombinedOutputScript = []testexec.FakeAction{action}
+			return testexec.InitFakeCmd(fakeCmd, cmd, args...)
+		})
+	}
+
+	return fake, calls
+}
+
+// replyVersion replies the version of iptables, and succeeds for the others.
+func replyVersion(version string) func(call fakeCall) ([]byte, error) {
+	return func(call fakeCall) ([]byte, error) {
+		if call.argv[0] == iptablesCmd && len(call.argv) == 2 && (call.argv[1] == "-V" || call.argv[1] == "--version") {
+			return []byte(version), nil
+		}
+		return nil, nil
+	}
+}
+
+// countArg returns the number of calls with the arg.
+func countArg(calls []fakeCall, arg string) int {
+	count := 0
+	for _, call := range calls {
+		for _, a := range call.argv {
+			if a == arg {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+var _ = Describe("Netfilter", Label("netfilter"), func() {
+	Context("newNetfilterBackend", func() {
+		It("uses iptables on the hosts running the legacy iptables even if nft is found", func() {
+			fake, _ := newFakeExec([]string{nftCmd, iptablesCmd}, replyVersion("iptables v1.8.7 (legacy)"))
+			Expect(newNetfilterBackend(fake, netlink.FAMILY_V4).Name()).To(Equal("iptables"))
+		})
+
+		It("uses nftables on the hosts running the nf_tables variant of iptables", func() {
+			fake, _ := newFakeExec([]string{nftCmd, iptablesCmd}, replyVersion("iptables v1.8.7 (nf_tables)"))
+			Expect(newNetfilterBackend(fake, netlink.FAMILY_V4).Name()).To(Equal("nftables"))
+		})
+
+		It("uses nftables on the hosts without iptables", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, replyVersion(""))
+			Expect(newNetfilterBackend(fake, netlink.FAMILY_V4).Name()).To(Equal("nftables"))
+			Expect(*calls).To(BeEmpty())
+		})
+
+		It("uses iptables on the hosts without nft", func() {
+			fake, calls := newFakeExec([]string{iptablesCmd}, replyVersion("iptables v1.8.7 (nf_tables)"))
+			Expect(newNetfilterBackend(fake, netlink.FAMILY_V4).Name()).To(Equal("iptables"))
+			Expect(*calls).To(BeEmpty())
+		})
+
+		It("returns all backends whose command is found for DEL", func() {
+			fake, _ := newFakeExec([]string{nftCmd, iptablesCmd}, replyVersion("iptables v1.8.7 (legacy)"))
+			backends := newNetfilterBackends(fake, netlink.FAMILY_ALL)
+			Expect(backends).To(HaveLen(2))
+			Expect(backends[0].Name()).To(Equal("nftables"))
+			Expect(backends[1].Name()).To(Equal("iptables"))
+
+			fake, _ = newFakeExec(nil, replyVersion(""))
+			Expect(newNetfilterBackends(fake, netlink.FAMILY_ALL)).To(BeEmpty())
+		})
+	})
+
+	Context("nftablesBackend", func() {
+		It("replaces the tables of all families in a single transaction", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, replyVersion(""))
+			backend := &nftablesBackend{execer: fake, families: nftFamilies}
+			Expect(backend.EnsureMarkRules()).NotTo(HaveOccurred())
+
+			Expect(*calls).To(HaveLen(1))
+			Expect((*calls)[0].argv).To(Equal([]string{nftCmd, "-f", "-"}))
+
+			script := (*calls)[0].stdin
+			for _, family := range nftFamilies {
+				replace := fmt.Sprintf("add table %s %s\ndelete table %s %s\ntable %s %s {\n", family, nftTable, family, nftTable, family, nftTable)
+				Expect(script).To(ContainSubstring(replace))
+			}
+			Expect(script).To(ContainSubstring("type filter hook prerouting priority mangle; policy accept;"))
+			Expect(script).To(ContainSubstring("type route hook output priority mangle; policy accept;"))
+			Expect(script).To(ContainSubstring(`iifname "veth0" ct state new meta mark set 0x1 comment "spiderpool: mark new connections from veth0"`))
+			Expect(script).To(ContainSubstring(`meta mark 0x1 ct mark set meta mark comment "spiderpool: save mark"`))
+			Expect(script).To(ContainSubstring(`meta mark set ct mark comment "spiderpool: restore mark"`))
+		})
+
+		It("generates the table of the IP family only", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, replyVersion(""))
+			backend := &nftablesBackend{execer: fake, families: []string{"ip6"}}
+			Expect(backend.EnsureMarkRules()).NotTo(HaveOccurred())
+
+			Expect(*calls).To(HaveLen(1))
+			Expect((*calls)[0].stdin).To(ContainSubstring("table ip6 " + nftTable))
+			Expect((*calls)[0].stdin).NotTo(ContainSubstring("table ip " + nftTable))
+		})
+
+		It("returns the error of nft with its stderr", func() {
+			fake, _ := newFakeExec([]string{nftCmd}, func(call fakeCall) ([]byte, error) {
+				return nil, &testexec.FakeExitError{Status: 1}
+			})
+			backend := &nftablesBackend{execer: fake, families: nftFamilies}
+			Expect(backend.EnsureMarkRules()).To(HaveOccurred())
+		})
+
+		It("checks the present rules by their comments", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, func(call fakeCall) ([]byte, error) {
+				var out strings.Builder
+				for _, rule := range nftMarkRules()[call.argv[len(call.argv)-1]] {
+					fmt.Fprintf(&out, "\t\t%s # handle 2\n", rule)
+				}
+				return []byte(out.String()), nil
+			})
+			backend := &nftablesBackend{execer: fake, families: []string{"ip"}}
+			Expect(backend.CheckMarkRules()).NotTo(HaveOccurred())
+			Expect(*calls).To(HaveLen(2))
+		})
+
+		It("fails to check if a rule is missing", func() {
+			fake, _ := newFakeExec([]string{nftCmd}, func(call fakeCall) ([]byte, error) {
+				return []byte(`meta mark set ct mark comment "spiderpool: restore mark"`), nil
+			})
+			backend := &nftablesBackend{execer: fake, families: []string{"ip"}}
+			Expect(backend.CheckMarkRules()).To(MatchError(ContainSubstring("is missing")))
+		})
+
+		It("fails to check if the table is missing", func() {
+			fake, _ := newFakeExec([]string{nftCmd}, func(call fakeCall) ([]byte, error) {
+				return nil, &testexec.FakeExitError{Status: 1}
+			})
+			backend := &nftablesBackend{execer: fake, families: []string{"ip"}}
+			Expect(backend.CheckMarkRules()).To(MatchError(ContainSubstring("is missing")))
+		})
+
+		It("deletes the tables of both families idempotently", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, replyVersion(""))
+			backend := &nftablesBackend{execer: fake, families: []string{"ip"}}
+			Expect(backend.DeleteMarkRules()).NotTo(HaveOccurred())
+			Expect(backend.DeleteMarkRules()).NotTo(HaveOccurred())
+
+			Expect(*calls).To(HaveLen(2))
+			for _, call := range *calls {
+				Expect(call.argv).To(Equal([]string{nftCmd, "-f", "-"}))
+				for _, family := range nftFamilies {
+					// the table is added before it's deleted, so the delete never fails
+					Expect(call.stdin).To(ContainSubstring(fmt.Sprintf("add table %s %s\ndelete table %s %s\n", family, nftTable, family, nftTable)))
+				}
+			}
+		})
+	})
+
+	Context("QoS rules", func() {
+		It("marks the DSCP and priority in the chain of the interface by nftables", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, replyVersion(""))
+			backend := &nftablesBackend{execer: fake, families: nftFamilies}
+			Expect(backend.EnsureQoSRules("net1", ptr.To(int32(46)), "1:10")).NotTo(HaveOccurred())
+
+			Expect(*calls).To(HaveLen(1))
+			script := (*calls)[0].stdin
+			Expect(script).To(ContainSubstring("flush chain ip spiderpool-qos qos-net1\n"))
+			Expect(script).To(ContainSubstring(`add rule ip spiderpool-qos qos-net1 oifname "net1" ip dscp set 46 meta priority set 1:10 comment "spiderpool: qos of net1"`))
+			Expect(script).To(ContainSubstring(`add rule ip6 spiderpool-qos qos-net1 oifname "net1" ip6 dscp set 46 meta priority set 1:10 comment "spiderpool: qos of net1"`))
+		})
+
+		It("marks only the priority by nftables", func() {
+			fake, calls := newFakeExec([]string{nftCmd}, replyVersion(""))
+			backend := &nftablesBackend{execer: fake, families: []string{"ip"}}
+			Expect(backend.EnsureQoSRules("net1", nil, "1:10")).NotTo(HaveOccurred())
+
+			Expect((*calls)[0].stdin).To(ContainSubstring(`oifname "net1" meta priority set 1:10 comment`))
+			Expect((*calls)[0].stdin).NotTo(ContainSubstring("dscp"))
+		})
+
+		It("marks the DSCP and priority by the DSCP and CLASSIFY targets of iptables", func() {
+			fake, calls := newFakeExec([]string{iptablesCmd}, func(call fakeCall) ([]byte, error) {
+				for _, arg := range call.argv {
+					if arg == "-C" {
+						return nil, &testexec.FakeExitError{Status: 1}
+					}
+				}
+				return []byte("iptables v1.8.7 (legacy)"), nil
+			})
+			backend := &iptablesBackend{execer: fake, protocols: []utiliptables.Protocol{utiliptables.ProtocolIPv4}}
+			Expect(backend.EnsureQoSRules("net1", ptr.To(int32(46)), "1:10")).NotTo(HaveOccurred())
+
+			var appended [][]string
+			for _, call := range *calls {
+				if slices.Contains(call.argv, "-A") {
+					appended = append(appended, call.argv[slices.Index(call.argv, "-A"):])
+				}
+			}
+			Expect(appended).To(Equal([][]string{
+				{"-A", "POSTROUTING", "-t", "mangle", "-o", "net1", "-j", "DSCP", "--set-dscp", "46"},
+				{"-A", "POSTROUTING", "-t", "mangle", "-o", "net1", "-j", "CLASSIFY", "--set-class", "1:10"},
+			}))
+		})
+	})
+
+	Context("iptablesBackend", func() {
+		It("checks the present rules by -C", func() {
+			fake, calls := newFakeExec([]string{iptablesCmd}, replyVersion(""))
+			backend := newNetfilterBackend(fake, netlink.FAMILY_V4)
+			Expect(backend.CheckMarkRules()).NotTo(HaveOccurred())
+
+			Expect(*calls).To(HaveLen(3))
+			for _, call := range *calls {
+				Expect(call.argv[:5]).To(Equal([]string{iptablesCmd, "-w", "-t", "mangle", "-C"}))
+			}
+		})
+
+		It("fails to check if a rule is missing", func() {
+			fake, _ := newFakeExec([]string{iptablesCmd}, func(call fakeCall) ([]byte, error) {
+				if call.argv[0] == iptablesCmd && call.argv[1] == "-V" {
+					return []byte("iptables v1.8.7 (legacy)"), nil
+				}
+				return []byte("iptables: Bad rule (does a matching rule exist in that chain?)."), &testexec.FakeExitError{Status: 1}
+			})
+			backend := newNetfilterBackend(fake, netlink.FAMILY_V4)
+			Expect(backend.CheckMarkRules()).To(MatchError(ContainSubstring("is missing")))
+		})
+
+		It("deletes the rules idempotently", func() {
+			// the rules are present until they're deleted
+			deleted := false
+			fake, calls := newFakeExec([]string{iptablesCmd}, func(call fakeCall) ([]byte, error) {
+				for _, arg := range call.argv {
+					switch arg {
+					case "-C":
+						if deleted {
+							return nil, &testexec.FakeExitError{Status: 1}
+						}
+						return nil, nil
+					case "-D":
+						return nil, nil
+					}
+				}
+				return []byte("iptables v1.8.7 (legacy)"), nil
+			})
+			backend := &iptablesBackend{execer: fake, protocols: []utiliptables.Protocol{utiliptables.ProtocolIPv4}}
+			Expect(backend.DeleteMarkRules()).NotTo(HaveOccurred())
+			Expect(countArg(*calls, "-D")).To(Equal(3))
+
+			deleted = true
+			*calls = nil
+			Expect(backend.DeleteMarkRules()).NotTo(HaveOccurred())
+			Expect(countArg(*calls, "-C")).To(Equal(3))
+			Expect(countArg(*calls, "-D")).To(BeZero())
+		})
+	})
+})
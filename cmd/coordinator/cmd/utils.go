@@ -4,9 +4,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
+	"slices"
 
 	"github.com/cilium/cilium/pkg/mac"
 	"github.com/containernetworking/plugins/pkg/ip"
@@ -15,21 +17,23 @@ import (
 	"github.com/vishvananda/netlink"
 	"go.uber.org/zap"
 	"golang.org/x/sys/unix"
-	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
 	"k8s.io/utils/exec"
 
+	"github.com/spidernet-io/spiderpool/pkg/networking/ebpf"
 	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+	"github.com/spidernet-io/spiderpool/pkg/networking/sysctl"
 )
 
 type coordinator struct {
 	firstInvoke                                                  bool
 	ipFamily, currentRuleTable, hostRuleTable                    int
 	tuneMode                                                     Mode
+	datapath                                                     Datapath
 	hostVethName, podVethName, vethLinkAddress, currentInterface string
 	vethMTU                                                      int64
 	v4HijackRouteGw, v6HijackRouteGw                             net.IP
 	HijackCIDR                                                   []string
-	Routes                                                       []Route
+	Routes, DelegatedRoutes                                      []Route
 	netns, hostNs                                                ns.NetNS
 	hostVethHwAddress, podVethHwAddress                          net.HardwareAddr
 	currentAddress                                               []netlink.Addr
@@ -339,6 +343,245 @@ func (c *coordinator) setupHijackRoutes(logger *zap.Logger, ruleTable int) error
 	return err
 }
 
+// setupHijackRedirect attaches a TC program to the egress of the pod's nic,
+// which redirects the traffic to the hijack subnets to veth0/eth0 instead of
+// the hijack routes. The veth pair is still required, since the traffic can't
+// be redirected to the host netns and the macvlan or ipvlan NIC of the pod
+// can't reach the host.
+func (c *coordinator) setupHijackRedirect(logger *zap.Logger) error {
+	cidrs := make([]*net.IPNet, 0, len(c.HijackCIDR))
+	for _, hijack := range c.HijackCIDR {
+		_, ipNet, err := net.ParseCIDR(hijack)
+		if err != nil {
+			logger.Error("Invalid Hijack Cidr", zap.String("Cidr", hijack), zap.Error(err))
+			return err
+		}
+
+		if !routeMatchesIPFamily(ipNet, c.ipFamily) {
+			continue
+		}
+		cidrs = append(cidrs, ipNet)
+	}
+
+	return c.netns.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(c.currentInterface)
+		if err != nil {
+			return err
+		}
+
+		podVethLink, err := netlink.LinkByName(c.podVethName)
+		if err != nil {
+			return err
+		}
+
+		// the hw addresses are rewritten, so that the host veth accepts the
+		// redirected packets
+		err = ebpf.AttachRedirect(link, &ebpf.Redirect{
+			CIDRs:         cidrs,
+			TargetIfindex: podVethLink.Attrs().Index,
+			DstHwAddr:     c.hostVethHwAddress,
+			SrcHwAddr:     c.podVethHwAddress,
+		})
+		if err != nil {
+			return err
+		}
+
+		logger.Debug("Attach the redirect program for hijack cidrs successfully", zap.String("Interface", c.currentInterface), zap.Strings("HijackCIDR", c.HijackCIDR))
+		return nil
+	})
+}
+
+// setupRedirect sets up the ebpf datapath of the pod and the host.
+func (c *coordinator) setupRedirect(logger *zap.Logger) error {
+	if err := c.setupHijackRedirect(logger); err != nil {
+		return err
+	}
+
+	return c.setupHostRedirect(logger)
+}
+
+// setupHostRedirect adds the pod IPs and the prefixes delegated to the pod to
+// the host maps of the ebpf datapath, instead of a route for each of them in
+// the host rule table. The subnets of the pod IPs are routed to
+// ebpf.HostLinkName, whose program redirects the traffic to the host veth of
+// the pod, or forwards it to the host NIC of the subnet if it matches no pod.
+// equivalent to: "ip route add <subnet> dev spider-host table <hostRuleTable>"
+func (c *coordinator) setupHostRedirect(logger *zap.Logger) error {
+	maps, err := ebpf.OpenHostRedirect(ebpf.DefaultPinPath)
+	if err != nil {
+		return fmt.Errorf("failed to open the host maps: %w", err)
+	}
+	defer maps.Close()
+
+	// the program is attached before the subnets are routed to it, otherwise
+	// the traffic to the subnets is dropped
+	if _, err = maps.Attach(); err != nil {
+		return fmt.Errorf("failed to attach the host program: %w", err)
+	}
+
+	hostVethLink, err := netlink.LinkByName(c.hostVethName)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range c.currentAddress {
+		subnet := &net.IPNet{IP: addr.IP.Mask(addr.Mask), Mask: addr.Mask}
+		if !isHostMaskIPNet(subnet) {
+			uplinkIndex, err := getUplinkIndex(subnet)
+			if err != nil {
+				return err
+			}
+			if err = maps.AddSubnet(subnet, uplinkIndex); err != nil {
+				return err
+			}
+			if err = networking.AddRouteWithProtocol(logger, c.hostRuleTable, c.ipFamily, netlink.SCOPE_LINK, ebpf.HostLinkName, nil, subnet, nil, nil, networking.RouteProtocolCoordinator); err != nil {
+				return fmt.Errorf("failed to AddRouteTable for the subnet of the pod: %w", err)
+			}
+		}
+
+		// the hw addresses are rewritten, so that veth0/eth0 accepts the
+		// redirected packets
+		ipNet := networking.ConvertMaxMaskIPNet(addr.IP)
+		if err = maps.AddEndpoint(ipNet, hostVethLink.Attrs().Index, c.podVethHwAddress, c.hostVethHwAddress); err != nil {
+			return err
+		}
+		logger.Info("add endpoint for pod to the host maps", zap.String("Dst", ipNet.String()), zap.String("Subnet", subnet.String()))
+	}
+
+	for _, dst := range c.delegatedPrefixes() {
+		if err = maps.AddEndpoint(dst, hostVethLink.Attrs().Index, c.podVethHwAddress, c.hostVethHwAddress); err != nil {
+			return err
+		}
+		logger.Info("add endpoint for delegated prefix of pod to the host maps", zap.String("Dst", dst.String()))
+	}
+
+	// the replies of the pod come from the host veth, while the route to the
+	// pod is via ebpf.HostLinkName
+	if c.ipFamily != netlink.FAMILY_V6 {
+		if err = sysctl.SetSysctl(fmt.Sprintf("net.ipv4.conf.%s.rp_filter", c.hostVethName), "2"); err != nil {
+			return fmt.Errorf("failed to set rp_filter of %s: %w", c.hostVethName, err)
+		}
+	}
+
+	return nil
+}
+
+// cleanupRedirect removes what setupHijackRedirect and setupHostRedirect set
+// up, once the ebpf datapath falls back to the routes.
+func (c *coordinator) cleanupRedirect(logger *zap.Logger) {
+	err := c.netns.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(c.currentInterface)
+		if err != nil {
+			return err
+		}
+		return ebpf.DetachRedirect(link)
+	})
+	if err != nil {
+		logger.Warn("failed to detach the redirect program, ignore error", zap.Error(err))
+	}
+
+	prefixes := c.delegatedPrefixes()
+	for _, addr := range c.currentAddress {
+		prefixes = append(prefixes, networking.ConvertMaxMaskIPNet(addr.IP))
+	}
+	if err = deleteHostEndpoints(prefixes, -1); err != nil {
+		logger.Warn("failed to delete the endpoints from the host maps, ignore error", zap.Error(err))
+	}
+}
+
+// delegatedPrefixes returns the prefixes delegated to the pod IPs on the
+// current interface.
+func (c *coordinator) delegatedPrefixes() []*net.IPNet {
+	var prefixes []*net.IPNet
+	for _, route := range c.DelegatedRoutes {
+		gw := net.ParseIP(route.Gw)
+		if !slices.ContainsFunc(c.currentAddress, func(addr netlink.Addr) bool { return addr.IP.Equal(gw) }) {
+			continue
+		}
+		// the delegated routes have been validated by ParseConfig
+		if _, dst, err := net.ParseCIDR(route.Dst); err == nil {
+			prefixes = append(prefixes, dst)
+		}
+	}
+	return prefixes
+}
+
+// deleteHostEndpoints deletes the prefixes and the endpoints to the NIC of
+// ifindex from the host maps of the ebpf datapath, ifindex is ignored if it's
+// negative. It's a no-op if the host maps haven't been pinned.
+func deleteHostEndpoints(prefixes []*net.IPNet, ifindex int) error {
+	maps, err := ebpf.LoadHostRedirect(ebpf.DefaultPinPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer maps.Close()
+
+	if ifindex >= 0 {
+		endpoints, err := maps.Endpoints()
+		if err != nil {
+			return err
+		}
+		for _, endpoint := range endpoints {
+			if endpoint.Ifindex == ifindex {
+				prefixes = append(prefixes, endpoint.Prefix)
+			}
+		}
+	}
+
+	var errs []error
+	for _, prefix := range prefixes {
+		errs = append(errs, maps.Delete(prefix))
+	}
+	return errors.Join(errs...)
+}
+
+// getUplinkIndex returns the index of the NIC which the main table of the host
+// routes the subnet to, the most specific route covering the subnet is taken.
+func getUplinkIndex(subnet *net.IPNet) (int, error) {
+	family := netlink.FAMILY_V4
+	if subnet.IP.To4() == nil {
+		family = netlink.FAMILY_V6
+	}
+
+	routes, err := netlink.RouteListFiltered(family, &netlink.Route{Table: unix.RT_TABLE_MAIN}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list routes of the main table: %w", err)
+	}
+
+	subnetOnes, _ := subnet.Mask.Size()
+	index, indexOnes := 0, -1
+	for _, route := range routes {
+		if route.LinkIndex == 0 {
+			continue
+		}
+
+		ones := 0
+		if route.Dst != nil {
+			if !route.Dst.Contains(subnet.IP) {
+				continue
+			}
+			ones, _ = route.Dst.Mask.Size()
+		}
+		if ones > subnetOnes || ones <= indexOnes {
+			continue
+		}
+		index, indexOnes = route.LinkIndex, ones
+	}
+
+	if indexOnes < 0 {
+		return 0, fmt.Errorf("no route to the subnet %s in the main table of the host", subnet)
+	}
+	return index, nil
+}
+
+func isHostMaskIPNet(ipNet *net.IPNet) bool {
+	ones, bits := ipNet.Mask.Size()
+	return ones == bits
+}
+
 func (c *coordinator) setupPolicyRoutes(logger *zap.Logger) error {
 	if len(c.Routes) == 0 {
 		return nil
@@ -412,8 +655,10 @@ func routeMatchesIPFamily(dst *net.IPNet, ipFamily int) bool {
 }
 
 // setupHostRoutes create routes for all host IPs, make sure that traffic to
-// pod's host is forward to veth pair device.
-func (c *coordinator) setupHostRoutes(logger *zap.Logger) error {
+// pod's host is forward to veth pair device. The routes of the pod IPs and the
+// delegated prefixes in the host rule table are skipped if hostRedirected,
+// since they're endpoints of the host maps instead.
+func (c *coordinator) setupHostRoutes(logger *zap.Logger, hostRedirected bool) error {
 	var err error
 	err = c.netns.Do(func(_ ns.NetNS) error {
 		// traffic sent to the pod its node is forwarded via veth0/eth0
@@ -489,15 +734,42 @@ func (c *coordinator) setupHostRoutes(logger *zap.Logger) error {
 			}
 		}
 
+		if hostRedirected {
+			continue
+		}
+
 		// set routes for host
 		// equivalent: ip add  <chainedIPs> dev <hostVethName> table  on host
-		if err = networking.AddRoute(logger, c.hostRuleTable, c.ipFamily, netlink.SCOPE_LINK, c.hostVethName, nil, ipNet, nil, nil); err != nil {
+		if err = networking.AddRouteWithProtocol(logger, c.hostRuleTable, c.ipFamily, netlink.SCOPE_LINK, c.hostVethName, nil, ipNet, nil, nil, networking.RouteProtocolCoordinator); err != nil {
 			logger.Error("failed to AddRouteTable for preInterfaceIPAddress", zap.Error(err))
 			return fmt.Errorf("failed to AddRouteTable for preInterfaceIPAddress: %w", err)
 		}
 		logger.Info("add route for to pod in host", zap.String("Dst", ipNet.String()))
 	}
 
+	if hostRedirected {
+		return nil
+	}
+
+	// set routes of the prefixes delegated to the pod for host
+	// equivalent: ip route add <prefix> via <podIP> dev <hostVethName> table <hostRuleTable> on host
+	for _, route := range c.DelegatedRoutes {
+		gw := net.ParseIP(route.Gw)
+		if !slices.ContainsFunc(c.currentAddress, func(addr netlink.Addr) bool { return addr.IP.Equal(gw) }) {
+			continue
+		}
+
+		_, dst, err := net.ParseCIDR(route.Dst)
+		if err != nil {
+			return fmt.Errorf("invalid delegated route dst %q: %w", route.Dst, err)
+		}
+		if err = networking.AddRouteWithProtocol(logger, c.hostRuleTable, c.ipFamily, netlink.SCOPE_UNIVERSE, c.hostVethName, nil, dst, gw, gw, networking.RouteProtocolCoordinator); err != nil {
+			logger.Error("failed to AddRouteTable for delegated prefix", zap.Error(err))
+			return fmt.Errorf("failed to AddRouteTable for delegated prefix: %w", err)
+		}
+		logger.Info("add route for delegated prefix of pod in host", zap.String("Dst", dst.String()), zap.String("Gw", gw.String()))
+	}
+
 	return nil
 }
 
@@ -570,6 +842,17 @@ func (c *coordinator) tunePodRoutes(logger *zap.Logger, configDefaultRouteNIC st
 				}
 			}
 
+			// the traffic from the delegated prefixes is forwarded via the
+			// current interface as well as the pod IPs, rather than the default
+			// route of the pod, e.g. the calico or cilium one in overlay mode
+			for _, prefix := range c.delegatedPrefixes() {
+				err = networking.AddFromRuleTable(prefix, c.currentRuleTable)
+				if err != nil && !os.IsExist(err) {
+					logger.Error("failed to AddFromRuleTable for delegated prefix", zap.Error(err))
+					return err
+				}
+			}
+
 			if c.tuneMode == ModeOverlay && c.firstInvoke {
 				// mv calico or cilium default route to table 100 to fix to the problem of
 				// inconsistent routes, the pod forwards the response packet from net1 (macvlan)
@@ -613,28 +896,24 @@ func (c *coordinator) tunePodRoutes(logger *zap.Logger, configDefaultRouteNIC st
 // makeReplyPacketViaVeth make sure that tcp replay packet is forward by veth0
 // NOTE: underlay mode only.
 func (c *coordinator) makeReplyPacketViaVeth(logger *zap.Logger) error {
-	var iptablesInterface []utiliptables.Interface
 	var ipFamily []int
-	execer := exec.New()
 	markInt := getMarkInt(defaultMarkBit)
 	switch c.ipFamily {
 	case netlink.FAMILY_V4:
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv4))
 		ipFamily = append(ipFamily, netlink.FAMILY_V4)
 	case netlink.FAMILY_V6:
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv6))
 		ipFamily = append(ipFamily, netlink.FAMILY_V6)
 	case netlink.FAMILY_ALL:
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv4))
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv6))
 		ipFamily = append(ipFamily, netlink.FAMILY_V4)
 		ipFamily = append(ipFamily, netlink.FAMILY_V6)
 	}
 
+	backend := newNetfilterBackend(exec.New(), c.ipFamily)
 	return c.netns.Do(func(_ ns.NetNS) error {
-		if err := c.ensureIPtablesRule(iptablesInterface); err != nil {
+		if err := backend.EnsureMarkRules(); err != nil {
 			return err
 		}
+		logger.Debug("Ensure the mark rules successfully", zap.String("Backend", backend.Name()))
 
 		for _, family := range ipFamily {
 			if err := networking.AddRuleTableWithMark(markInt, defaultPodRuleTable, family); err != nil && !os.IsExist(err) {
@@ -676,44 +955,6 @@ func getMarkString(mark int) string {
 	return fmt.Sprintf("%#08x", mark)
 }
 
-func (c *coordinator) ensureIPtablesRule(iptablesInterfaces []utiliptables.Interface) error {
-	markStr := getMarkString(getMarkInt(0))
-	for _, ipt := range iptablesInterfaces {
-		if ipt == nil {
-			continue
-		}
-		_, err := ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainPrerouting, []string{
-			"-i", defaultUnderlayVethName,
-			"-m", "conntrack",
-			"--ctstate", "NEW",
-			"-j", "MARK",
-			"--set-xmark", markStr,
-		}...)
-		if err != nil {
-			return fmt.Errorf("iptables ensureRule err: failed to set-xmark: %w", err)
-		}
-
-		_, err = ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainPrerouting, []string{
-			"-m", "mark",
-			"--mark", markStr,
-			"-j", "CONNMARK",
-			"--save-mark",
-		}...)
-		if err != nil {
-			return fmt.Errorf("iptables ensureRule err: failed to save-mark: %w", err)
-		}
-
-		_, err = ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainOutput, []string{
-			"-j", "CONNMARK",
-			"--restore-mark",
-		}...)
-		if err != nil {
-			return fmt.Errorf("iptables ensureRule err: failed to restore-mark: %w", err)
-		}
-	}
-	return nil
-}
-
 func GetAllHostIPRouteForPod(c *coordinator, ipFamily int, allPodIP []netlink.Addr) (finalNodeIPList []net.IP, e error) {
 	finalNodeIPList = []net.IP{}
 
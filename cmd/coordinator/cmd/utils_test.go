@@ -0,0 +1,241 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"net"
+	"os"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+	"k8s.io/utils/ptr"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+var _ = Describe("setupHijackRoutes — real netns", Label("hijack_routes"), func() {
+	const stdin = `{"cniVersion": "1.0.0", "name": "macvlan", "type": "coordinator"}`
+
+	var podNetns ns.NetNS
+
+	BeforeEach(func() {
+		if os.Geteuid() != 0 {
+			Skip("the real netns harness requires root")
+		}
+
+		var err error
+		podNetns, err = testutils.NewNS()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			_ = podNetns.Close()
+			_ = testutils.UnmountNS(podNetns)
+		})
+
+		// veth0 is the veth of the pod, whose peer is left in the pod netns
+		// as the host isn't involved.
+		err = podNetns.Do(func(_ ns.NetNS) error {
+			if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: defaultUnderlayVethName}, PeerName: "vethhost"}); err != nil {
+				return err
+			}
+
+			for _, name := range []string{"vethhost", defaultUnderlayVethName} {
+				link, err := netlink.LinkByName(name)
+				if err != nil {
+					return err
+				}
+				if err := netlink.LinkSetUp(link); err != nil {
+					return err
+				}
+			}
+
+			link, err := netlink.LinkByName(defaultUnderlayVethName)
+			if err != nil {
+				return err
+			}
+			for _, gw := range []string{"10.6.0.1/32", "fd00:6::1/128"} {
+				_, dst, err := net.ParseCIDR(gw)
+				if err != nil {
+					return err
+				}
+				if err := netlink.RouteAdd(&netlink.Route{LinkIndex: link.Attrs().Index, Dst: dst, Scope: netlink.SCOPE_LINK}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	// hijackRoutes sets up the hijack routes of the configuration, and
+	// returns the destinations of the routes installed in the pod netns.
+	hijackRoutes := func(coordinatorConfig *models.CoordinatorConfig) []string {
+		conf, err := ParseConfig([]byte(stdin), coordinatorConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		c := &coordinator{
+			HijackCIDR:      conf.OverlayPodCIDR,
+			firstInvoke:     true,
+			ipFamily:        netlink.FAMILY_ALL,
+			podVethName:     defaultUnderlayVethName,
+			netns:           podNetns,
+			v4HijackRouteGw: net.ParseIP("10.6.0.1"),
+			v6HijackRouteGw: net.ParseIP("fd00:6::1"),
+		}
+		c.HijackCIDR = append(c.HijackCIDR, conf.ServiceCIDR...)
+		Expect(c.setupHijackRoutes(zap.NewNop(), unix.RT_TABLE_MAIN)).To(Succeed())
+
+		dsts := []string{}
+		err = podNetns.Do(func(_ ns.NetNS) error {
+			link, err := netlink.LinkByName(defaultUnderlayVethName)
+			if err != nil {
+				return err
+			}
+
+			routes, err := netlink.RouteList(link, netlink.FAMILY_ALL)
+			if err != nil {
+				return err
+			}
+			for _, route := range routes {
+				if route.Gw != nil {
+					dsts = append(dsts, route.Dst.String())
+				}
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return dsts
+	}
+
+	It("routes the pod CIDRs of the node instead of the ones of the cluster", func() {
+		Expect(hijackRoutes(&models.CoordinatorConfig{
+			Mode:           ptr.To(string(ModeAuto)),
+			TunePodRoutes:  ptr.To(true),
+			OverlayPodCIDR: []string{"10.244.0.0/16", "fd00:244::/48"},
+			NodePodCIDR:    []string{"10.244.1.0/24", "fd00:244:1::/64"},
+			ServiceCIDR:    []string{"10.233.0.0/18"},
+		})).To(ConsistOf("10.244.1.0/24", "fd00:244:1::/64", "10.233.0.0/18"))
+	})
+
+	It("routes the pod CIDRs of the cluster if the ones of the node aren't tracked", func() {
+		Expect(hijackRoutes(&models.CoordinatorConfig{
+			Mode:           ptr.To(string(ModeAuto)),
+			TunePodRoutes:  ptr.To(true),
+			OverlayPodCIDR: []string{"10.244.0.0/16", "fd00:244::/48"},
+			ServiceCIDR:    []string{},
+		})).To(ConsistOf("10.244.0.0/16", "fd00:244::/48"))
+	})
+})
+
+var _ = Describe("setupHostRoutes — real netns", Label("host_routes"), func() {
+	const hostRuleTable = 500
+
+	var hostNetns, podNetns ns.NetNS
+
+	BeforeEach(func() {
+		if os.Geteuid() != 0 {
+			Skip("the real netns harness requires root")
+		}
+
+		var err error
+		for _, netns := range []*ns.NetNS{&hostNetns, &podNetns} {
+			*netns, err = testutils.NewNS()
+			Expect(err).NotTo(HaveOccurred())
+		}
+		DeferCleanup(func() {
+			for _, netns := range []ns.NetNS{hostNetns, podNetns} {
+				_ = netns.Close()
+				_ = testutils.UnmountNS(netns)
+			}
+		})
+
+		// calihost is the host veth of eth0, which is set up by the overlay CNI
+		err = hostNetns.Do(func(_ ns.NetNS) error {
+			if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "calihost"}, PeerName: defaultOverlayVethName}); err != nil {
+				return err
+			}
+
+			link, err := netlink.LinkByName(defaultOverlayVethName)
+			if err != nil {
+				return err
+			}
+			if err := netlink.LinkSetNsFd(link, int(podNetns.Fd())); err != nil {
+				return err
+			}
+
+			link, err = netlink.LinkByName("calihost")
+			if err != nil {
+				return err
+			}
+			return netlink.LinkSetUp(link)
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = podNetns.Do(func(_ ns.NetNS) error {
+			link, err := netlink.LinkByName(defaultOverlayVethName)
+			if err != nil {
+				return err
+			}
+			return netlink.LinkSetUp(link)
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("routes the delegated prefixes of the current interface in overlay mode", func() {
+		currentAddress := []netlink.Addr{}
+		for _, addr := range []string{"10.6.0.2/24", "fd00:6::2/64"} {
+			a, err := netlink.ParseAddr(addr)
+			Expect(err).NotTo(HaveOccurred())
+			currentAddress = append(currentAddress, *a)
+		}
+
+		c := &coordinator{
+			tuneMode:         ModeOverlay,
+			ipFamily:         netlink.FAMILY_ALL,
+			hostRuleTable:    hostRuleTable,
+			currentRuleTable: 100,
+			currentInterface: "net1",
+			podVethName:      defaultOverlayVethName,
+			hostVethName:     "calihost",
+			netns:            podNetns,
+			currentAddress:   currentAddress,
+			DelegatedRoutes: []Route{
+				{Dst: "10.7.0.0/30", Gw: "10.6.0.2"},
+				{Dst: "fd00:7::/64", Gw: "fd00:6::2"},
+				// delegated to the IP of another interface
+				{Dst: "10.8.0.0/30", Gw: "10.6.0.3"},
+			},
+		}
+
+		routes := map[string]string{}
+		err := hostNetns.Do(func(_ ns.NetNS) error {
+			if err := c.setupHostRoutes(zap.NewNop(), false); err != nil {
+				return err
+			}
+
+			filter := &netlink.Route{Table: hostRuleTable}
+			list, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, filter, netlink.RT_FILTER_TABLE)
+			if err != nil {
+				return err
+			}
+			for _, route := range list {
+				routes[route.Dst.String()] = route.Gw.String()
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(routes).To(Equal(map[string]string{
+			"10.6.0.2/32":   "<nil>",
+			"fd00:6::2/128": "<nil>",
+			"10.7.0.0/30":   "10.6.0.2",
+			"fd00:7::/64":   "fd00:6::2",
+		}))
+	})
+})
@@ -20,9 +20,5 @@ func init() {
 }
 
 func main() {
-	skel.PluginMain(cmd.CmdAdd, cmdCheck, cmd.CmdDel, cniSpecVersion.All, "Coordinator")
-}
-
-func cmdCheck(args *skel.CmdArgs) error {
-	return nil
+	skel.PluginMain(cmd.CmdAdd, cmd.CmdCheck, cmd.CmdDel, cniSpecVersion.All, "Coordinator")
 }
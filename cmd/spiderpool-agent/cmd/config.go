@@ -20,6 +20,7 @@ import (
 	"github.com/spidernet-io/spiderpool/api/v1/agent/client"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/server"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
 	"github.com/spidernet-io/spiderpool/pkg/ipam"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
@@ -70,8 +71,15 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_WAIT_SUBNET_POOL_MAX_RETRIES", "25", false, nil, nil, &agentContext.Cfg.WaitSubnetPoolMaxRetries},
 
 	{"MULTUS_CLUSTER_NETWORK", "", false, &agentContext.Cfg.MultusClusterNetwork, nil, nil},
+	{"SPIDERPOOL_CNI_CONFIG_DIR", "", false, &agentContext.Cfg.DefaultCniConfDir, nil, nil},
+	{"SPIDERPOOL_COORDINATOR_ORPHAN_SWEEP_INTERVAL_IN_SECOND", "0", false, nil, nil, &agentContext.Cfg.CoordinatorOrphanSweepInterval},
+	{"SPIDERPOOL_COORDINATOR_DEAFULT_NAME", "default", false, &agentContext.Cfg.DefaultCoordinatorName, nil, nil},
 }
 
+// ipv6StableSecretEnv is the secret hashed into the 'stable' IPv6 addresses,
+// which is read apart from the Config to keep it out of the logs.
+const ipv6StableSecretEnv = "SPIDERPOOL_IPV6_STABLE_SECRET"
+
 type Config struct {
 	CommitVersion string
 	CommitTime    string
@@ -98,7 +106,10 @@ type Config struct {
 	WaitSubnetPoolTime       int
 	WaitSubnetPoolMaxRetries int
 
-	MultusClusterNetwork string
+	MultusClusterNetwork           string
+	DefaultCniConfDir              string
+	CoordinatorOrphanSweepInterval int
+	DefaultCoordinatorName         string
 
 	// configmap
 	spiderpooltypes.SpiderpoolConfigmapConfig
@@ -125,6 +136,7 @@ type AgentContext struct {
 	SubnetManager         subnetmanager.SubnetManager
 	KubevirtManager       kubevirtmanager.KubevirtManager
 	NetworkResourcePlugin *networkresourceplugin.Manager
+	IaaSCircuitBreaker    *iaasclient.CircuitBreaker
 
 	// k8s client
 	ClientSet *kubernetes.Clientset
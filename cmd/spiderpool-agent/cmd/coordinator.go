@@ -4,16 +4,27 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	otelapi "go.opentelemetry.io/otel/metric"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/server/restapi/daemonset"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/coordinatormanager"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 )
 
 var unixGetCoordinatorConfig = &_unixGetCoordinatorConfig{}
@@ -62,6 +73,16 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 	if coord.Spec.VethLinkAddress != nil {
 		vethLinkAddress = *coord.Spec.VethLinkAddress
 	}
+	var datapath string
+	if coord.Spec.Datapath != nil {
+		datapath = *coord.Spec.Datapath
+	}
+
+	var datapathFallback bool
+	if coord.Spec.DatapathFallback != nil {
+		datapathFallback = *coord.Spec.DatapathFallback
+	}
+
 	var vethMTU int64
 	if coord.Spec.VethMTU != nil {
 		vethMTU = int64(*coord.Spec.VethMTU)
@@ -74,7 +95,10 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 
 	config := &models.CoordinatorConfig{
 		Mode:               coord.Spec.Mode,
+		Datapath:           datapath,
+		DatapathFallback:   datapathFallback,
 		OverlayPodCIDR:     coord.Status.OverlayPodCIDR,
+		NodePodCIDR:        coordinatormanager.NodePodCIDRs(&coord, pod.Spec.NodeName),
 		ServiceCIDR:        coord.Status.ServiceCIDR,
 		HijackCIDR:         coord.Spec.HijackCIDR,
 		PolicyRoutes:       convertCoordinatorPolicyRoutes(coord.Spec.PolicyRoutes),
@@ -88,6 +112,15 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 		TxQueueLen:         int64(*coord.Spec.TxQueueLen),
 	}
 
+	// the prefixes delegated to the Pod are routed to its IP addresses
+	endpoint, err := agentContext.EndpointManager.GetEndpointByName(ctx, pod.Namespace, pod.Name, constant.IgnoreCache)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(fmt.Sprintf("failed to get SpiderEndpoint %s/%s: %v", pod.Namespace, pod.Name, err)))
+	}
+	if err == nil {
+		config.DelegatedRoutes = convertDelegatedRoutes(endpoint.Status.Current.IPs)
+	}
+
 	if config.OverlayPodCIDR == nil {
 		config.OverlayPodCIDR = []string{}
 	}
@@ -98,6 +131,33 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 	return daemonset.NewGetCoordinatorConfigOK().WithPayload(config)
 }
 
+var unixPostCoordinatorDatapathFallback = &_unixPostCoordinatorDatapathFallback{}
+
+type _unixPostCoordinatorDatapathFallback struct{}
+
+// Handle handles Post requests for /coordinator/datapath/fallback.
+func (g *_unixPostCoordinatorDatapathFallback) Handle(params daemonset.PostCoordinatorDatapathFallbackParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+	args := params.CoordinatorDatapathFallback
+
+	metric.CoordinatorDatapathFallbackCounts.Add(ctx, 1, otelapi.WithAttributes(attribute.String("datapath", *args.Datapath)))
+
+	pod, err := agentContext.PodManager.GetPodByName(ctx, *args.PodNamespace, *args.PodName, constant.UseCache)
+	if err != nil {
+		return daemonset.NewPostCoordinatorDatapathFallbackFailure().WithPayload(models.Error(fmt.Sprintf("failed to get pod %s/%s: %v", *args.PodNamespace, *args.PodName, err)))
+	}
+
+	event.EventRecorder.Eventf(
+		pod,
+		corev1.EventTypeWarning,
+		coordinatormanager.EventReasonCoordinatorDatapathFallback,
+		"Coordinator fell back to the route datapath for the interface %s since the %s datapath failed: %s",
+		args.Interface, *args.Datapath, args.Reason,
+	)
+
+	return daemonset.NewPostCoordinatorDatapathFallbackOK()
+}
+
 func convertCoordinatorPolicyRoutes(routes []spiderpoolv2beta1.Route) []*models.CoordinatorRoute {
 	if len(routes) == 0 {
 		return nil
@@ -112,3 +172,91 @@ func convertCoordinatorPolicyRoutes(routes []spiderpoolv2beta1.Route) []*models.
 	}
 	return result
 }
+
+func convertDelegatedRoutes(details []spiderpoolv2beta1.IPAllocationDetail) []*models.CoordinatorRoute {
+	var result []*models.CoordinatorRoute
+	for _, d := range details {
+		if d.IPv4Prefix != nil && d.IPv4 != nil {
+			result = append(result, &models.CoordinatorRoute{
+				Dst: *d.IPv4Prefix,
+				Gw:  strings.Split(*d.IPv4, "/")[0],
+			})
+		}
+		if d.IPv6Prefix != nil && d.IPv6 != nil {
+			result = append(result, &models.CoordinatorRoute{
+				Dst: *d.IPv6Prefix,
+				Gw:  strings.Split(*d.IPv6, "/")[0],
+			})
+		}
+	}
+
+	return result
+}
+
+// watchDefaultCNI records an Event of the Node once the default CNI in the CNI
+// config directory of the Node has changed.
+func watchDefaultCNI(ctx context.Context, clientSet kubernetes.Interface) {
+	err := coordinatormanager.WatchDefaultCNI(ctx, logger, agentContext.Cfg.DefaultCniConfDir, func(previous, current string) {
+		node, err := clientSet.CoreV1().Nodes().Get(ctx, agentContext.Cfg.NodeName, metav1.GetOptions{})
+		if err != nil {
+			logger.Sugar().Warnf("failed to get Node %s: %v", agentContext.Cfg.NodeName, err)
+			return
+		}
+
+		event.EventRecorder.Eventf(
+			node,
+			corev1.EventTypeNormal,
+			coordinatormanager.EventReasonDefaultCNIChanged,
+			"The default CNI of the Node has changed from %q to %q",
+			previous, current,
+		)
+	})
+	if err != nil {
+		logger.Sugar().Warnf("unable to watch the default CNI changes: %v", err)
+	}
+}
+
+// startCoordinatorOrphanSweeper cleans the host veths, routes and rules leaked
+// by coordinator periodically, each cleanup is recorded by the metrics and an
+// Event of the Node.
+func startCoordinatorOrphanSweeper(ctx context.Context, clientSet kubernetes.Interface, c client.Reader) {
+	sweeper := &coordinatormanager.OrphanSweeper{
+		NodeName: agentContext.Cfg.NodeName,
+		Client:   c,
+		Interval: time.Duration(agentContext.Cfg.CoordinatorOrphanSweepInterval) * time.Second,
+		OnCleaned: func(orphan coordinatormanager.Orphan, err error) {
+			attr := otelapi.WithAttributes(attribute.String("type", orphan.Type))
+			if err != nil {
+				metric.CoordinatorOrphanCleanFailureCounts.Add(ctx, 1, attr)
+			} else {
+				metric.CoordinatorOrphanCleanedCounts.Add(ctx, 1, attr)
+			}
+
+			node, getErr := clientSet.CoreV1().Nodes().Get(ctx, agentContext.Cfg.NodeName, metav1.GetOptions{})
+			if getErr != nil {
+				logger.Sugar().Warnf("failed to get Node %s: %v", agentContext.Cfg.NodeName, getErr)
+				return
+			}
+
+			if err != nil {
+				event.EventRecorder.Eventf(
+					node,
+					corev1.EventTypeWarning,
+					coordinatormanager.EventReasonCoordinatorOrphanCleanFailed,
+					"Failed to clean the %s leaked by coordinator: %v",
+					orphan, err,
+				)
+				return
+			}
+
+			event.EventRecorder.Eventf(
+				node,
+				corev1.EventTypeNormal,
+				coordinatormanager.EventReasonCoordinatorOrphanCleaned,
+				"Cleaned the %s leaked by coordinator",
+				orphan,
+			)
+		},
+	}
+	sweeper.Start(ctx, logger)
+}
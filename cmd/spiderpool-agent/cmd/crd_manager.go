@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -79,5 +80,19 @@ func newCRDManager() (ctrl.Manager, error) {
 		return nil, err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(agentContext.InnerCtx, &spiderpoolv2beta1.SpiderIPClaim{}, constant.SpecIPPoolField, func(raw client.Object) []string {
+		ipClaim := raw.(*spiderpoolv2beta1.SpiderIPClaim)
+		return []string{ipClaim.Spec.IPPool}
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(agentContext.InnerCtx, &corev1.Pod{}, constant.SpecNodeNameField, func(raw client.Object) []string {
+		pod := raw.(*corev1.Pod)
+		return []string{pod.Spec.NodeName}
+	}); err != nil {
+		return nil, err
+	}
+
 	return mgr, nil
 }
@@ -27,9 +27,13 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	iaasClientPkg "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	"github.com/spidernet-io/spiderpool/pkg/ipam"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
@@ -83,7 +87,7 @@ func DaemonMain() {
 	logger.Sugar().Infof("Spiderpool-agent config: %+v", agentContext.Cfg)
 
 	// Validate IaaS provider configuration and create client
-	if agentContext.Cfg.IaaSProviderConfig.ServerURL != "" {
+	if iaasClientPkg.IsEnabled(&agentContext.Cfg.IaaSProviderConfig) {
 		if err := iaasClientPkg.ValidateConfig(&agentContext.Cfg.IaaSProviderConfig); err != nil {
 			logger.Sugar().Fatalf("IaaS provider configuration validation failed: %v", err)
 		}
@@ -91,8 +95,8 @@ func DaemonMain() {
 
 	// Create IaaS client if configured
 	var iaasClient iaasClientPkg.Client
-	if agentContext.Cfg.IaaSProviderConfig.ServerURL != "" {
-		c, err := iaasClientPkg.NewClient(&agentContext.Cfg.IaaSProviderConfig, logger)
+	if iaasClientPkg.IsEnabled(&agentContext.Cfg.IaaSProviderConfig) {
+		c, err := iaasClientPkg.NewDriver(&agentContext.Cfg.IaaSProviderConfig, logger)
 		if err != nil {
 			logger.Sugar().Fatalf("Failed to create IaaS client: %v", err)
 		} else {
@@ -100,6 +104,15 @@ func DaemonMain() {
 			logger.Info("IaaS provider configured and client created successfully")
 		}
 
+		// fail the CNI calls fast once the IaaS provider is unavailable
+		if iaasClientPkg.IsCircuitBreakerEnabled(&agentContext.Cfg.IaaSProviderConfig) {
+			breaker, err := iaasClientPkg.NewCircuitBreaker(iaasClient, &agentContext.Cfg.IaaSProviderConfig, logger.Named("iaas-circuit-breaker"))
+			if err != nil {
+				logger.Sugar().Fatalf("Failed to create IaaS circuit breaker: %v", err)
+			}
+			iaasClient = breaker
+			agentContext.IaaSCircuitBreaker = breaker
+		}
 	}
 
 	// setup sysctls
@@ -179,6 +192,9 @@ func DaemonMain() {
 	}
 	agentContext.ClientSet = clientSet
 
+	// record the Pod events of the IaaS provider failures
+	event.InitEventRecorder(clientSet, mgr.GetScheme(), constant.SpiderpoolAgent)
+
 	networkResourcePluginConfig, err := networkresourceplugin.ApplyDefaultsAndValidate(&agentContext.Cfg.SpiderpoolConfigmapConfig)
 	if err != nil {
 		logger.Sugar().Fatalf("Failed to validate network resource plugin config: %v", err)
@@ -189,7 +205,7 @@ func DaemonMain() {
 		}
 		agentContext.NetworkResourcePlugin = networkresourceplugin.NewManagerWithNodeGetter(
 			*networkResourcePluginConfig,
-			agentContext.Cfg.IaaSProviderConfig.ServerURL != "",
+			iaasClientPkg.IsEnabled(&agentContext.Cfg.IaaSProviderConfig),
 			nodeGetter,
 			nil,
 			logger,
@@ -197,6 +213,11 @@ func DaemonMain() {
 		agentContext.NetworkResourcePlugin.Start(agentContext.InnerCtx)
 	}
 
+	if len(agentContext.Cfg.DefaultCniConfDir) != 0 {
+		logger.Info("Begin to watch the default CNI changes")
+		watchDefaultCNI(agentContext.InnerCtx, clientSet)
+	}
+
 	logger.Info("Begin to initialize spiderpool-agent metrics HTTP server")
 	initAgentMetricsServer(agentContext.InnerCtx)
 
@@ -219,10 +240,27 @@ func DaemonMain() {
 		OperationGapDuration:                 time.Duration(agentContext.Cfg.WaitSubnetPoolTime) * time.Second,
 		AgentNamespace:                       agentContext.Cfg.AgentPodNamespace,
 		APIReader:                            mgr.GetClient(),
+		PoolSelection:                        agentContext.Cfg.PoolSelectionConfig.Policy,
+		PoolSpreadTopologyKey:                agentContext.Cfg.PoolSelectionConfig.SpreadTopologyKey,
 	}
 	if len(agentContext.Cfg.MultusClusterNetwork) != 0 {
 		ipamConfig.MultusClusterNetwork = ptr.To(agentContext.Cfg.MultusClusterNetwork)
 	}
+	if iaasClient != nil {
+		// retry the IaaS releases failed in CNI DEL by the IaaS reconciler of spiderpool-controller
+		queue, err := iaasreconciler.NewPendingReleaseQueue(mgr.GetClient(), mgr.GetAPIReader(), agentContext.Cfg.AgentPodNamespace)
+		if err != nil {
+			logger.Sugar().Fatalf("Failed to create IaaS pending release queue: %v", err)
+		}
+		ipamConfig.IaaSReleaseQueue = queue
+
+		// invalidate the parentNicMac cache of IaaS client once the SpiderMultusConfigs change
+		informer, err := mgr.GetCache().GetInformer(agentContext.InnerCtx, &spiderpoolv2beta1.SpiderMultusConfig{})
+		if err != nil {
+			logger.Sugar().Fatalf("Failed to get SpiderMultusConfig informer: %v", err)
+		}
+		ipamConfig.MultusConfigInformer = informer
+	}
 	ipam, err := ipam.NewIPAM(
 		ipamConfig,
 		agentContext.IPPoolManager,
@@ -257,6 +295,11 @@ func DaemonMain() {
 		logger.Fatal("failed to wait for syncing controller-runtime cache")
 	}
 
+	if agentContext.Cfg.CoordinatorOrphanSweepInterval > 0 {
+		logger.Info("Begin to sweep the coordinator orphans")
+		startCoordinatorOrphanSweeper(agentContext.InnerCtx, clientSet, mgr.GetClient())
+	}
+
 	logger.Info("Begin to initialize spiderpool-agent OpenAPI HTTP server")
 	srv, err := newAgentOpenAPIHttpServer()
 	if nil != err {
@@ -453,12 +496,20 @@ func initAgentServiceManagers(ctx context.Context) {
 	agentContext.ReservedIPManager = rIPManager
 
 	logger.Debug("Begin to initialize IPPool manager")
+	var multusClusterNetwork *string
+	if len(agentContext.Cfg.MultusClusterNetwork) != 0 {
+		multusClusterNetwork = ptr.To(agentContext.Cfg.MultusClusterNetwork)
+	}
 	ipPoolManager, err := ippoolmanager.NewIPPoolManager(
 		ippoolmanager.IPPoolManagerConfig{
 			MaxAllocatedIPs:           &agentContext.Cfg.IPPoolMaxAllocatedIPs,
 			EnableKubevirtStaticIP:    agentContext.Cfg.EnableKubevirtStaticIP,
 			EnableIPConflictDetection: agentContext.Cfg.EnableIPConflictDetection,
 			EnableGatewayDetection:    agentContext.Cfg.EnableGatewayDetection,
+			DefaultCoordinatorName:    agentContext.Cfg.DefaultCoordinatorName,
+			AgentNamespace:            agentContext.Cfg.AgentPodNamespace,
+			MultusClusterNetwork:      multusClusterNetwork,
+			IPv6StableSecret:          []byte(os.Getenv(ipv6StableSecretEnv)),
 		},
 		agentContext.CRDManager.GetClient(),
 		agentContext.CRDManager.GetAPIReader(),
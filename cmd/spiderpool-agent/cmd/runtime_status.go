@@ -43,6 +43,13 @@ func (g *_httpGetAgentReadiness) Handle(params runtime.GetRuntimeReadinessParams
 		return runtime.NewGetRuntimeReadinessInternalServerError()
 	}
 
+	if g.IaaSCircuitBreaker != nil {
+		if err := g.IaaSCircuitBreaker.Ready(); err != nil {
+			logger.Sugar().Errorf("spiderpool-agent is not ready, error: %v", err)
+			return runtime.NewGetRuntimeReadinessInternalServerError()
+		}
+	}
+
 	return runtime.NewGetRuntimeReadinessOK()
 }
 
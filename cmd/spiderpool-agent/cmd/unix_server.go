@@ -34,6 +34,7 @@ func newAgentOpenAPIUnixServer() (*agentOpenAPIServer.Server, error) {
 	api.DaemonsetPostIpamIpsHandler = unixPostAgentIpamIps
 	api.DaemonsetDeleteIpamIpsHandler = unixDeleteAgentIpamIps
 	api.DaemonsetGetCoordinatorConfigHandler = unixGetCoordinatorConfig
+	api.DaemonsetPostCoordinatorDatapathFallbackHandler = unixPostCoordinatorDatapathFallback
 	api.DaemonsetGetWorkloadendpointHandler = unixGetWorkloadendpoint
 
 	// new agent OpenAPI server with api
@@ -125,6 +125,16 @@ func (c *CoreClient) clean(validate, mutating string) error {
 		jobResult = multierror.Append(jobResult, err)
 	}
 
+	// Clean up SpiderIPClaim resources of spiderpool
+	if err := c.cleanSpiderpoolResources(ctx, &spiderpoolv2beta1.SpiderIPClaimList{}, constant.KindSpiderIPClaim); err != nil {
+		jobResult = multierror.Append(jobResult, err)
+	}
+
+	// Clean up SpiderTenantIPPool resources of spiderpool
+	if err := c.cleanSpiderpoolResources(ctx, &spiderpoolv2beta1.SpiderTenantIPPoolList{}, constant.KindSpiderTenantIPPool); err != nil {
+		jobResult = multierror.Append(jobResult, err)
+	}
+
 	// Clean up SpiderMultusConfig resources of spiderpool
 	if err := c.cleanSpiderpoolResources(ctx, &spiderpoolv2beta1.SpiderMultusConfigList{}, constant.KindSpiderMultusConfig); err != nil {
 		jobResult = multierror.Append(jobResult, err)
@@ -203,6 +213,8 @@ func (c *CoreClient) cleanSpiderpoolResources(ctx context.Context, list client.O
 			cleanFinalizers = true
 		case constant.KindSpiderCoordinator:
 			cleanFinalizers = true
+		case constant.KindSpiderTenantIPPool:
+			cleanFinalizers = true
 		default:
 			cleanFinalizers = false
 		}
@@ -23,6 +23,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
 	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
@@ -50,6 +51,9 @@ type envConf struct {
 	associateIntKey  *int
 }
 
+// externalIPAMTokenEnv is the env of the token to authenticate to the external IPAM.
+const externalIPAMTokenEnv = "SPIDERPOOL_EXTERNAL_IPAM_TOKEN"
+
 // EnvInfo collects the env and relevant agentContext properties.
 var envInfo = []envConf{
 	{"GIT_COMMIT_VERSION", "", false, &controllerContext.Cfg.CommitVersion, nil, nil},
@@ -92,6 +96,8 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_SUBNET_INFORMER_WORKERS", "5", true, nil, nil, &controllerContext.Cfg.SubnetInformerWorkers},
 	{"SPIDERPOOL_SUBNET_INFORMER_MAX_WORKQUEUE_LENGTH", "10000", false, nil, nil, &controllerContext.Cfg.SubnetInformerMaxWorkqueueLength},
 	{"SPIDERPOOL_SUBNET_APPLICATION_CONTROLLER_WORKERS", "5", true, nil, nil, &controllerContext.Cfg.SubnetAppControllerWorkers},
+	{"SPIDERPOOL_SUBNET_AUTO_POOL_SHRINK_DELAY", "0", false, nil, nil, &controllerContext.Cfg.AutoPoolShrinkDelay},
+	{"SPIDERPOOL_SUBNET_AUTO_POOL_SHRINK_MIN_IP_NUMBER", "0", false, nil, nil, &controllerContext.Cfg.AutoPoolShrinkMinIPNumber},
 
 	{"SPIDERPOOL_COORDINATOR_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableCoordinator, nil},
 	{"SPIDERPOOL_COORDINATOR_DEAFULT_NAME", "default", false, &controllerContext.Cfg.DefaultCoordinatorName, nil, nil},
@@ -151,6 +157,8 @@ type Config struct {
 	SubnetInformerWorkers            int
 	SubnetInformerMaxWorkqueueLength int
 	SubnetAppControllerWorkers       int
+	AutoPoolShrinkDelay              int
+	AutoPoolShrinkMinIPNumber        int
 
 	IPPoolInformerResyncPeriod       int
 	IPPoolInformerWorkers            int
@@ -194,6 +202,7 @@ type ControllerContext struct {
 	KubevirtManager   kubevirtmanager.KubevirtManager
 	Leader            election.SpiderLeaseElector
 	IaaSClient        iaasclient.Client
+	IaaSReleaseQueue  iaasreconciler.PendingReleaseQueue
 
 	// handler
 	HTTPServer        *server.Server
@@ -81,6 +81,13 @@ func newCRDManager() (ctrl.Manager, error) {
 		return nil, err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(controllerContext.InnerCtx, &spiderpoolv2beta1.SpiderIPClaim{}, constant.SpecIPPoolField, func(raw client.Object) []string {
+		ipClaim := raw.(*spiderpoolv2beta1.SpiderIPClaim)
+		return []string{ipClaim.Spec.IPPool}
+	}); err != nil {
+		return nil, err
+	}
+
 	// register a http handler for webhook health check
 	mgr.GetWebhookServer().Register(constant.WebhookMutateRoute, &_webhookHealthCheck{})
 
@@ -27,9 +27,13 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/coordinatormanager"
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/externalipam"
 	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
 	iaasClientPkg "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
+	"github.com/spidernet-io/spiderpool/pkg/ipclaimmanager"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	crdclientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
 	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
@@ -42,6 +46,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/tenantippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
@@ -84,15 +89,15 @@ func DaemonMain() {
 	logger.Sugar().Infof("Spiderpool-controller config: %+v", controllerContext.Cfg)
 
 	// Validate IaaS provider configuration
-	if controllerContext.Cfg.IaaSProviderConfig.ServerURL != "" {
+	if iaasClientPkg.IsEnabled(&controllerContext.Cfg.IaaSProviderConfig) {
 		if err := iaasClientPkg.ValidateConfig(&controllerContext.Cfg.IaaSProviderConfig); err != nil {
 			logger.Sugar().Fatalf("IaaS provider configuration validation failed: %v", err)
 		}
 	}
 
 	// Create IaaS client if configured
-	if controllerContext.Cfg.IaaSProviderConfig.ServerURL != "" {
-		c, err := iaasClientPkg.NewClient(&controllerContext.Cfg.IaaSProviderConfig, logger)
+	if iaasClientPkg.IsEnabled(&controllerContext.Cfg.IaaSProviderConfig) {
+		c, err := iaasClientPkg.NewDriver(&controllerContext.Cfg.IaaSProviderConfig, logger)
 		if err != nil {
 			logger.Sugar().Fatalf("Failed to create IaaS client: %v", err)
 		} else {
@@ -101,6 +106,11 @@ func DaemonMain() {
 		}
 	}
 
+	// Validate external IPAM configuration
+	if err := externalipam.ValidateConfig(&controllerContext.Cfg.ExternalIPAMConfig); err != nil {
+		logger.Sugar().Fatalf("External IPAM configuration validation failed: %v", err)
+	}
+
 	// Set up gops.
 	if controllerContext.Cfg.GopsListenPort != "" {
 		address := "127.0.0.1:" + controllerContext.Cfg.GopsListenPort
@@ -208,6 +218,19 @@ func DaemonMain() {
 		}
 	}()
 
+	if controllerContext.IaaSClient != nil {
+		logger.Info("Begin to initialize IaaS Reconciler")
+		initIaaSReconciler(controllerContext.InnerCtx)
+	}
+
+	if controllerContext.Cfg.ExternalIPAMConfig.Enabled {
+		logger.Info("Begin to initialize external IPAM Syncer")
+		initExternalIPAMSyncer(controllerContext.InnerCtx)
+	}
+
+	logger.Info("Begin to initialize IPClaim GC")
+	initIPClaimGC(controllerContext.InnerCtx)
+
 	logger.Info("Begin to initialize IP GC Manager")
 	initGCManager(controllerContext.InnerCtx)
 
@@ -297,7 +320,7 @@ func initControllerServiceManagers(ctx context.Context) {
 	}
 	if networkResourcePluginConfig.Enabled {
 		podENIConfig = podmanager.PodENIResourceInjectConfig{
-			ProviderEnabled:       controllerContext.Cfg.IaaSProviderConfig.ServerURL != "",
+			ProviderEnabled:       iaasClientPkg.IsEnabled(&controllerContext.Cfg.IaaSProviderConfig),
 			PluginEnabled:         len(networkResourcePluginConfig.ResourceAdvertisement.SubENI.Rules) > 0,
 			MasterNICEnabled:      len(networkResourcePluginConfig.ResourceAdvertisement.MasterNIC.Rules) > 0,
 			InjectPodENIResources: controllerContext.Cfg.PodResourceInjectConfig.Enabled,
@@ -364,6 +387,13 @@ func initControllerServiceManagers(ctx context.Context) {
 		logger.Fatal(err.Error())
 	}
 
+	logger.Debug("Begin to set up IPClaim webhook")
+	if err := (&ipclaimmanager.IPClaimWebhook{
+		APIReader: controllerContext.CRDManager.GetAPIReader(),
+	}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
+		logger.Fatal(err.Error())
+	}
+
 	logger.Debug("Begin to initialize IPPool manager")
 	ipPoolManager, err := ippoolmanager.NewIPPoolManager(
 		ippoolmanager.IPPoolManagerConfig{
@@ -421,6 +451,13 @@ func initControllerServiceManagers(ctx context.Context) {
 			logger.Fatal(err.Error())
 		}
 
+		logger.Debug("Begin to set up TenantIPPool webhook")
+		if err := (&tenantippoolmanager.TenantIPPoolWebhook{
+			APIReader: controllerContext.CRDManager.GetAPIReader(),
+		}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
+			logger.Fatal(err.Error())
+		}
+
 		logger.Sugar().Debugf("Begin to initialize cluster Subnet AutoPool default redimdamt IP number to %d", controllerContext.Cfg.ClusterSubnetAutoPoolDefaultRedundantIPNumber)
 		*applicationinformers.ClusterSubnetAutoPoolDefaultRedundantIPNumber = controllerContext.Cfg.ClusterSubnetAutoPoolDefaultRedundantIPNumber
 	} else {
@@ -437,6 +474,90 @@ func initControllerServiceManagers(ctx context.Context) {
 	}
 }
 
+func initIaaSReconciler(ctx context.Context) {
+	queue, err := iaasreconciler.NewPendingReleaseQueue(
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.CRDManager.GetAPIReader(),
+		controllerContext.Cfg.ControllerPodNamespace,
+	)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+	controllerContext.IaaSReleaseQueue = queue
+
+	interval := constant.DefaultIaaSReconcileInterval
+	if controllerContext.Cfg.IaaSProviderConfig.ReconcileInterval != "" {
+		interval, err = time.ParseDuration(controllerContext.Cfg.IaaSProviderConfig.ReconcileInterval)
+		if nil != err {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	reconciler, err := iaasreconciler.NewReconciler(
+		iaasreconciler.ReconcilerConfig{Interval: interval},
+		controllerContext.CRDManager.GetAPIReader(),
+		controllerContext.IaaSClient,
+		queue,
+		controllerContext.Leader,
+	)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+
+	go reconciler.Start(ctx)
+}
+
+func initIPClaimGC(ctx context.Context) {
+	gc, err := ipclaimmanager.NewIPClaimGC(
+		ipclaimmanager.IPClaimGCConfig{Interval: constant.DefaultIPClaimGCInterval},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+
+	go gc.Start(ctx)
+}
+
+func initExternalIPAMSyncer(ctx context.Context) {
+	// The token is not a field of the controller configuration, which is printed in the log.
+	extClient, err := externalipam.NewClient(&controllerContext.Cfg.ExternalIPAMConfig, os.Getenv(externalIPAMTokenEnv), logger.Named("External-IPAM-Client"))
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+
+	interval, err := externalipam.SyncInterval(&controllerContext.Cfg.ExternalIPAMConfig)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+
+	syncer, err := externalipam.NewSyncer(
+		externalipam.SyncerConfig{
+			Interval:            interval,
+			ImportPrefixes:      controllerContext.Cfg.ExternalIPAMConfig.ImportPrefixes,
+			MaxImportPrefixSize: controllerContext.Cfg.ExternalIPAMConfig.MaxImportPrefixSize,
+		},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.CRDManager.GetAPIReader(),
+		extClient,
+		controllerContext.Leader,
+	)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+
+	informer, err := controllerContext.CRDManager.GetCache().GetInformer(ctx, &spiderpoolv2beta1.SpiderIPPool{})
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+	if _, err := informer.AddEventHandler(syncer.EventHandler()); nil != err {
+		logger.Fatal(err.Error())
+	}
+
+	go syncer.Start(ctx)
+}
+
 func initGCManager(ctx context.Context) {
 	// EnableStatefulSet was determined by Configmap.
 	gcIPConfig.EnableStatefulSet = controllerContext.Cfg.EnableStatefulSet
@@ -456,6 +577,7 @@ func initGCManager(ctx context.Context) {
 		controllerContext.NodeManager,
 		controllerContext.Leader,
 		controllerContext.IaaSClient,
+		controllerContext.IaaSReleaseQueue,
 	)
 	if nil != err {
 		logger.Fatal(err.Error())
@@ -579,6 +701,25 @@ func setupInformers(k8sClient *kubernetes.Clientset) {
 			logger.Fatal(err.Error())
 		}
 
+		logger.Info("Begin to set up TenantIPPool informer")
+		tenantIPPoolController, err := tenantippoolmanager.NewTenantIPPoolController(
+			tenantippoolmanager.TenantIPPoolControllerConfig{
+				Workers:             controllerContext.Cfg.SubnetInformerWorkers,
+				MaxWorkqueueLength:  controllerContext.Cfg.SubnetInformerMaxWorkqueueLength,
+				WorkQueueMaxRetries: controllerContext.Cfg.WorkQueueMaxRetries,
+				LeaderRetryElectGap: time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
+				ResyncPeriod:        time.Duration(controllerContext.Cfg.SubnetInformerResyncPeriod) * time.Second,
+			},
+			controllerContext.CRDManager.GetClient(),
+			controllerContext.Leader,
+		)
+		if nil != err {
+			logger.Fatal(err.Error())
+		}
+		if err := tenantIPPoolController.SetupInformer(controllerContext.InnerCtx, crdClient); err != nil {
+			logger.Fatal(err.Error())
+		}
+
 		if controllerContext.Cfg.EnableAutoPoolForApplication {
 			logger.Info("Begin to set up auto-created IPPool controller")
 			subnetAppController, err := applicationcontroller.NewSubnetAppController(
@@ -593,6 +734,8 @@ func setupInformers(k8sClient *kubernetes.Clientset) {
 					WorkQueueMaxRetries:           controllerContext.Cfg.WorkQueueMaxRetries,
 					WorkQueueRequeueDelayDuration: time.Duration(controllerContext.Cfg.WorkQueueRequeueDelayDuration) * time.Second,
 					LeaderRetryElectGap:           time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
+					AutoPoolShrinkDelay:           time.Duration(controllerContext.Cfg.AutoPoolShrinkDelay) * time.Second,
+					AutoPoolShrinkMinIPNumber:     controllerContext.Cfg.AutoPoolShrinkMinIPNumber,
 				})
 			if nil != err {
 				logger.Fatal(err.Error())
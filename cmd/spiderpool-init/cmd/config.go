@@ -55,6 +55,9 @@ const (
 	ENVDefaultReadinessFile              = "SPIDERPOOL_INIT_READINESS_FILE"
 	ENVDefaultCoordinatorVethLinkAddress = "SPIDERPOOL_INIT_DEFAULT_COORDINATOR_VETH_LINK_ADDRESS"
 	ENVDefaultCoordinatorVethMTU         = "SPIDERPOOL_INIT_DEFAULT_COORDINATOR_VETH_MTU"
+	ENVDefaultCoordinatorDatapath        = "SPIDERPOOL_INIT_DEFAULT_COORDINATOR_DATAPATH"
+
+	ENVDefaultCoordinatorDatapathFallback = "SPIDERPOOL_INIT_DEFAULT_COORDINATOR_DATAPATH_FALLBACK"
 )
 
 var (
@@ -70,6 +73,8 @@ type InitDefaultConfig struct {
 
 	CoordinatorName               string
 	CoordinatorMode               string
+	CoordinatorDatapath           string
+	CoordinatorDatapathFallback   bool
 	CoordinatorPodCIDRType        string
 	CoordinatorPodDefaultRouteNic string
 	CoordinatorPodMACPrefix       string
@@ -128,6 +133,17 @@ func parseENVAsDefault() InitDefaultConfig {
 		if config.CoordinatorMode == "" {
 			config.CoordinatorMode = string(coordinatorcmd.ModeAuto)
 		}
+		config.CoordinatorDatapath = strings.ReplaceAll(os.Getenv(ENVDefaultCoordinatorDatapath), "\"", "")
+		if config.CoordinatorDatapath == "" {
+			config.CoordinatorDatapath = string(coordinatorcmd.DatapathRoute)
+		}
+		if edf := strings.ReplaceAll(os.Getenv(ENVDefaultCoordinatorDatapathFallback), "\"", ""); edf != "" {
+			df, err := strconv.ParseBool(edf)
+			if err != nil {
+				logger.Sugar().Fatalf("ENV %s %s: %v", ENVDefaultCoordinatorDatapathFallback, edf, err)
+			}
+			config.CoordinatorDatapathFallback = df
+		}
 		config.CoordinatorPodCIDRType = strings.ReplaceAll(os.Getenv(ENVDefaultCoordinatorPodCIDRType), "\"", "")
 
 		etpr := strings.ReplaceAll(os.Getenv(ENVDefaultCoordinatorTunePodRoutes), "\"", "")
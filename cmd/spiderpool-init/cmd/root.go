@@ -42,6 +42,8 @@ func Execute() {
 			},
 			Spec: spiderpoolv2beta1.CoordinatorSpec{
 				Mode:               &config.CoordinatorMode,
+				Datapath:           &config.CoordinatorDatapath,
+				DatapathFallback:   &config.CoordinatorDatapathFallback,
 				PodCIDRType:        &config.CoordinatorPodCIDRType,
 				TunePodRoutes:      &config.CoordinatorTunePodRoutes,
 				PodDefaultRouteNIC: &config.CoordinatorPodDefaultRouteNic,
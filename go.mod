@@ -8,6 +8,7 @@ require (
 	github.com/cilium/cilium v1.14.1
 	github.com/containernetworking/cni v1.1.2
 	github.com/containernetworking/plugins v1.5.1
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/go-openapi/errors v0.22.0
 	github.com/go-openapi/loads v0.21.2
 	github.com/go-openapi/runtime v0.26.2
@@ -73,12 +74,14 @@ require github.com/google/go-cmp v0.6.0 // indirect
 require k8s.io/component-base v0.29.4 // indirect
 
 require (
+	github.com/cilium/ebpf v0.11.0
 	github.com/go-logr/stdr v1.2.2
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/mdlayher/arp v0.0.0-20220221190821-c37aaafac7f9
 	github.com/safchain/ethtool v0.6.1
 	go.uber.org/automaxprocs v1.5.3
-	google.golang.org/grpc v1.62.0
+	google.golang.org/grpc v1.63.2
+	google.golang.org/protobuf v1.36.1
 	k8s.io/kubectl v0.26.3
 	k8s.io/kubelet v0.29.4
 )
@@ -100,7 +103,6 @@ require (
 	github.com/evanphx/json-patch/v5 v5.6.0 // indirect
 	github.com/fatih/color v1.13.0 // indirect
 	github.com/felixge/httpsnoop v1.0.3 // indirect
-	github.com/fsnotify/fsnotify v1.7.0 // indirect
 	github.com/go-ole/go-ole v1.2.6 // indirect
 	github.com/go-openapi/analysis v0.21.4 // indirect
 	github.com/go-openapi/inflect v0.19.0 // indirect
@@ -182,8 +184,7 @@ require (
 	golang.org/x/time v0.3.0 // indirect
 	golang.org/x/tools/go/packages/packagestest v0.1.1-deprecated // indirect
 	gomodules.xyz/jsonpatch/v2 v2.4.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80 // indirect
-	google.golang.org/protobuf v1.36.1 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240227224415-6ceb2ff114de // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	k8s.io/gengo/v2 v2.0.0-20240228010128-51d4e06bde70 // indirect
@@ -32,6 +32,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
@@ -66,6 +67,10 @@ type SubnetAppController struct {
 	cronJobLister   batchlisters.CronJobLister
 	cronJobInformer cache.SharedIndexInformer
 
+	// autoPoolShrinkTimestamps records the time when an auto-created IPPool
+	// was first found to have more IPs than its application desires.
+	autoPoolShrinkTimestamps sync.Map
+
 	SubnetAppControllerConfig
 }
 
@@ -77,6 +82,24 @@ type SubnetAppControllerConfig struct {
 	WorkQueueMaxRetries           int
 	WorkQueueRequeueDelayDuration time.Duration
 	LeaderRetryElectGap           time.Duration
+
+	// AutoPoolShrinkDelay is how long an auto-created IPPool must stay
+	// oversized before its redundant IPs are returned to the SpiderSubnet.
+	AutoPoolShrinkDelay time.Duration
+	// AutoPoolShrinkMinIPNumber is the IP number an auto-created IPPool
+	// would never be shrunk below.
+	AutoPoolShrinkMinIPNumber int
+}
+
+// autoPoolShrinkPendingError means the auto-created IPPool needs to be
+// shrunk, but the shrink delay has not expired yet.
+type autoPoolShrinkPendingError struct {
+	poolName string
+	after    time.Duration
+}
+
+func (e *autoPoolShrinkPendingError) Error() string {
+	return fmt.Sprintf("auto-created IPPool %s will be shrunk after %v", e.poolName, e.after)
 }
 
 func NewSubnetAppController(client client.Client, apiReader client.Reader, subnetMgr subnetmanager.SubnetManager, subnetAppControllerConfig SubnetAppControllerConfig) (*SubnetAppController, error) {
@@ -534,6 +557,15 @@ func (sac *SubnetAppController) processNextWorkItem() bool {
 
 		err := sac.syncHandler(key, log)
 		if nil != err {
+			// requeue the items whose auto-created IPPools are waiting for shrink
+			var shrinkErr *autoPoolShrinkPendingError
+			if errors.As(err, &shrinkErr) {
+				sac.workQueue.Forget(obj)
+				sac.workQueue.AddAfter(obj, shrinkErr.after)
+				log.Sugar().Debugf("%v, requeue it", err)
+				return nil
+			}
+
 			// discard wrong input items
 			if errors.Is(err, constant.ErrWrongInput) {
 				sac.workQueue.Forget(obj)
@@ -719,9 +751,22 @@ func (sac *SubnetAppController) applyAutoIPPool(ctx context.Context, podSubnetCo
 ) error {
 	log := logutils.FromContext(ctx)
 
-	// retrieve application pools
-	fn := func(subnetName string, ipVersion types.IPVersion, ifName string) error {
-		var tmpPool *spiderpoolv2beta1.SpiderIPPool
+	// record the nearest pending shrink of the application auto-created IPPools
+	var shrinkErr *autoPoolShrinkPendingError
+	var shrinkLock sync.Mutex
+
+	var desiredIPNumber int
+	var annoPoolIPNumberVal string
+	if podSubnetConfig.FlexibleIPNum != nil {
+		desiredIPNumber = appReplicas + *podSubnetConfig.FlexibleIPNum
+		annoPoolIPNumberVal = fmt.Sprintf("+%d", *podSubnetConfig.FlexibleIPNum)
+	} else {
+		desiredIPNumber = podSubnetConfig.AssignIPNum
+		annoPoolIPNumberVal = strconv.Itoa(podSubnetConfig.AssignIPNum)
+	}
+
+	// retrieve application pool in the given SpiderSubnet
+	findPool := func(subnetName string, ipVersion types.IPVersion, ifName string) (*spiderpoolv2beta1.SpiderIPPool, error) {
 		tmpPoolList := &spiderpoolv2beta1.SpiderIPPoolList{}
 		matchLabels := client.MatchingLabels{
 			constant.LabelIPPoolOwnerSpiderSubnet:         subnetName,
@@ -735,53 +780,106 @@ func (sac *SubnetAppController) applyAutoIPPool(ctx context.Context, podSubnetCo
 
 		err := sac.apiReader.List(ctx, tmpPoolList, matchLabels)
 		if nil != err {
-			return fmt.Errorf("failed to get auto-created IPPoolList with matchLabels %v, error: %w", matchLabels, err)
+			return nil, fmt.Errorf("failed to get auto-created IPPoolList with matchLabels %v, error: %w", matchLabels, err)
+		}
+
+		for i := range tmpPoolList.Items {
+			// We need to ignore the previous same NamespacedName application corresponding auto-created IPPool.
+			// Because this auto-created IPPool will be deleted by the system with 'ippool-reclaim'
+			labels := tmpPoolList.Items[i].GetLabels()
+			if labels[constant.LabelIPPoolReclaimIPPool] == constant.True && labels[constant.LabelIPPoolOwnerApplicationUID] != string(podController.UID) {
+				log.Sugar().Debugf("found the previous same app auto-created IPPool %s", tmpPoolList.Items[i].Name)
+				continue
+			}
+
+			// If 'ippool-reclaim' is true, we'll go to scale the auto-created IPPool because of the same application UID.
+			// If 'ippool-reclaim' is false, we'll reuse this auto-crated IPPool and refresh its application UID label.
+			tmpPool := tmpPoolList.Items[i].DeepCopy()
+			log.Sugar().Debugf("found reuse app auto-created IPPool %s", tmpPool.Name)
+			return tmpPool, nil
 		}
 
-		if len(tmpPoolList.Items) == 0 {
-			tmpPool = nil
-		} else {
-			for i := range tmpPoolList.Items {
-				// We need to ignore the previous same NamespacedName application corresponding auto-created IPPool.
-				// Because this auto-created IPPool will be deleted by the system with 'ippool-reclaim'
-				labels := tmpPoolList.Items[i].GetLabels()
-				if labels[constant.LabelIPPoolReclaimIPPool] == constant.True && labels[constant.LabelIPPoolOwnerApplicationUID] != string(podController.UID) {
-					log.Sugar().Debugf("found the previous same app auto-created IPPool %s", tmpPoolList.Items[i].Name)
-					continue
+		return nil, nil
+	}
+
+	// reconcile application pools with the ordered SpiderSubnets, the latter SpiderSubnets serve as the fallbacks
+	// and they only take the IPs that the former exhausted SpiderSubnets couldn't supply.
+	fn := func(subnetNames []string, ipVersion types.IPVersion, ifName string) error {
+		var retryErr error
+		remainingIPNumber := desiredIPNumber
+
+		for index, subnetName := range subnetNames {
+			tmpPool, err := findPool(subnetName, ipVersion, ifName)
+			if nil != err {
+				return err
+			}
+			// no need to create an auto-created IPPool in the fallback SpiderSubnet
+			if tmpPool == nil && remainingIPNumber == 0 {
+				continue
+			}
+
+			var currentIPNumber int
+			poolIPNumber := remainingIPNumber
+			if tmpPool != nil {
+				poolIPs, err := spiderpoolip.ParseIPRanges(ipVersion, tmpPool.Spec.IPs)
+				if nil != err {
+					return fmt.Errorf("%w: failed to parse IPPool %s Spec IPs %s: %w", constant.ErrWrongInput, tmpPool.Name, tmpPool.Spec.IPs, err)
 				}
+				currentIPNumber = len(poolIPs)
 
-				// If 'ippool-reclaim' is true, we'll go to scale the auto-created IPPool because of the same application UID.
-				// If 'ippool-reclaim' is false, we'll reuse this auto-crated IPPool and refresh its application UID label.
-				tmpPool = tmpPoolList.Items[i].DeepCopy()
-				log.Sugar().Debugf("found reuse app auto-created IPPool %s", tmpPool.Name)
-				break
+				var shrinkAfter time.Duration
+				poolIPNumber, shrinkAfter, err = sac.autoPoolShrinkIPNumber(ctx, tmpPool, ipVersion, remainingIPNumber)
+				if nil != err {
+					return err
+				}
+				if shrinkAfter > 0 {
+					shrinkLock.Lock()
+					if shrinkErr == nil || shrinkAfter < shrinkErr.after {
+						shrinkErr = &autoPoolShrinkPendingError{poolName: tmpPool.Name, after: shrinkAfter}
+					}
+					shrinkLock.Unlock()
+				}
 			}
-		}
 
-		var desiredIPNumber int
-		var annoPoolIPNumberVal string
-		if podSubnetConfig.FlexibleIPNum != nil {
-			desiredIPNumber = appReplicas + *podSubnetConfig.FlexibleIPNum
-			annoPoolIPNumberVal = fmt.Sprintf("+%d", *podSubnetConfig.FlexibleIPNum)
-		} else {
-			desiredIPNumber = podSubnetConfig.AssignIPNum
-			annoPoolIPNumberVal = strconv.Itoa(podSubnetConfig.AssignIPNum)
-		}
+			log.Sugar().Infof("try to reconcile auto-created IPv%d IPPool for Interface %s by SpiderSubnet %s with application controller %v",
+				ipVersion, ifName, subnetName, podController.AppNamespacedName)
+			pool, err := sac.subnetMgr.ReconcileAutoIPPool(ctx, tmpPool, subnetName, podController, types.AutoPoolProperty{
+				DesiredIPNumber:     poolIPNumber,
+				IPVersion:           ipVersion,
+				IsReclaimIPPool:     podSubnetConfig.ReclaimIPPool,
+				IfName:              ifName,
+				AnnoPoolIPNumberVal: annoPoolIPNumberVal,
+				IsFallback:          index != 0,
+			})
+			if nil != err {
+				if !errors.Is(err, constant.ErrFreeIPsNotEnough) || index == len(subnetNames)-1 {
+					return err
+				}
 
-		log.Sugar().Infof("try to reconcile auto-created IPv%d IPPool for Interface %s by SpiderSubnet %s with application controller %v",
-			ipVersion, ifName, subnetName, podController.AppNamespacedName)
-		_, err = sac.subnetMgr.ReconcileAutoIPPool(ctx, tmpPool, subnetName, podController, types.AutoPoolProperty{
-			DesiredIPNumber:     desiredIPNumber,
-			IPVersion:           ipVersion,
-			IsReclaimIPPool:     podSubnetConfig.ReclaimIPPool,
-			IfName:              ifName,
-			AnnoPoolIPNumberVal: annoPoolIPNumberVal,
-		})
-		if nil != err {
-			return err
+				// The SpiderSubnet is exhausted or the IPPool couldn't be shrunk, the IPPool keeps its current IPs
+				// and the next SpiderSubnet takes the rest.
+				log.Sugar().Warnf("failed to reconcile auto-created IPPool in SpiderSubnet %s, fall back to the next SpiderSubnet: %v", subnetName, err)
+				if poolIPNumber < currentIPNumber {
+					retryErr = err
+				}
+				remainingIPNumber = max(0, remainingIPNumber-currentIPNumber)
+				continue
+			}
+
+			// Some IPs are still occupied by the terminating Pods, retry to return them to the SpiderSubnet later.
+			poolIPs, err := spiderpoolip.ParseIPRanges(ipVersion, pool.Spec.IPs)
+			if nil != err {
+				return fmt.Errorf("%w: failed to parse IPPool %s Spec IPs %s: %w", constant.ErrWrongInput, pool.Name, pool.Spec.IPs, err)
+			}
+			if len(poolIPs) > poolIPNumber {
+				retryErr = fmt.Errorf("auto-created IPPool %s still has %d IPs more than desired: %w", pool.Name, len(poolIPs)-poolIPNumber, constant.ErrFreeIPsNotEnough)
+			} else {
+				sac.autoPoolShrinkTimestamps.Delete(pool.Name)
+			}
+			remainingIPNumber = max(0, remainingIPNumber-len(poolIPs))
 		}
 
-		return nil
+		return retryErr
 	}
 
 	processNext := func(item types.AnnoSubnetItem) error {
@@ -791,14 +889,14 @@ func (sac *SubnetAppController) applyAutoIPPool(ctx context.Context, podSubnetCo
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				errV4 = fn(item.IPv4[0], constant.IPv4, item.Interface)
+				errV4 = fn(item.IPv4, constant.IPv4, item.Interface)
 			}()
 		}
 		if sac.EnableIPv6 && len(item.IPv6) != 0 {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				errV6 = fn(item.IPv6[0], constant.IPv6, item.Interface)
+				errV6 = fn(item.IPv6, constant.IPv6, item.Interface)
 			}()
 		}
 
@@ -829,9 +927,52 @@ func (sac *SubnetAppController) applyAutoIPPool(ctx context.Context, podSubnetCo
 			constant.ErrWrongInput, podController.Kind, podController.Namespace, podController.Name, podSubnetConfig)
 	}
 
+	if shrinkErr != nil {
+		return shrinkErr
+	}
+
 	return nil
 }
 
+// autoPoolShrinkIPNumber applies the shrink policy to the auto-created IPPool, it returns the IP number that the
+// IPPool should be reconciled with. The IPPool would be shrunk only if it stays oversized longer than the shrink delay,
+// and it never goes below the shrink minimum IP number. If the shrink delay has not expired, the current IP number
+// and the remaining delay are returned.
+func (sac *SubnetAppController) autoPoolShrinkIPNumber(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool,
+	ipVersion types.IPVersion, desiredIPNumber int,
+) (int, time.Duration, error) {
+	log := logutils.FromContext(ctx)
+
+	poolIPs, err := spiderpoolip.ParseIPRanges(ipVersion, pool.Spec.IPs)
+	if nil != err {
+		return 0, 0, fmt.Errorf("%w: failed to parse IPPool %s Spec IPs %s: %w", constant.ErrWrongInput, pool.Name, pool.Spec.IPs, err)
+	}
+
+	if desiredIPNumber >= len(poolIPs) {
+		sac.autoPoolShrinkTimestamps.Delete(pool.Name)
+		return desiredIPNumber, 0, nil
+	}
+
+	shrinkIPNumber := max(desiredIPNumber, min(sac.AutoPoolShrinkMinIPNumber, len(poolIPs)))
+	if shrinkIPNumber == len(poolIPs) {
+		log.Sugar().Debugf("auto-created IPPool %s already reaches the shrink minimum IP number %d", pool.Name, sac.AutoPoolShrinkMinIPNumber)
+		sac.autoPoolShrinkTimestamps.Delete(pool.Name)
+		return shrinkIPNumber, 0, nil
+	}
+
+	if sac.AutoPoolShrinkDelay > 0 {
+		now := time.Now()
+		val, _ := sac.autoPoolShrinkTimestamps.LoadOrStore(pool.Name, now)
+		if elapsed := now.Sub(val.(time.Time)); elapsed < sac.AutoPoolShrinkDelay {
+			log.Sugar().Debugf("auto-created IPPool %s is oversized, wait %v to shrink it", pool.Name, sac.AutoPoolShrinkDelay-elapsed)
+			return len(poolIPs), sac.AutoPoolShrinkDelay - elapsed, nil
+		}
+	}
+
+	log.Sugar().Infof("try to shrink auto-created IPPool %s from %d IPs to %d IPs", pool.Name, len(poolIPs), shrinkIPNumber)
+	return shrinkIPNumber, 0, nil
+}
+
 // hasSubnetConfigChanged checks whether application subnet configuration changed and the application replicas changed or not.
 // The second parameter newSubnetConfig must not be nil.
 func hasSubnetConfigChanged(ctx context.Context, oldSubnetConfig, newSubnetConfig *types.PodSubnetAnnoConfig,
@@ -945,16 +1086,28 @@ func (sac *SubnetAppController) controllerDeleteHandler() applicationinformers.A
 
 func (sac *SubnetAppController) deleteAutoPools(ctx context.Context, appUID k8types.UID) error {
 	log := logutils.FromContext(ctx)
-	err := sac.client.DeleteAllOf(ctx, &spiderpoolv2beta1.SpiderIPPool{}, client.MatchingLabels{
+
+	poolList := &spiderpoolv2beta1.SpiderIPPoolList{}
+	err := sac.client.List(ctx, poolList, client.MatchingLabels{constant.LabelIPPoolOwnerApplicationUID: string(appUID)})
+	if nil != err {
+		return fmt.Errorf("failed to list auto-created IPPools of application UID %s: %w", appUID, err)
+	}
+
+	err = sac.client.DeleteAllOf(ctx, &spiderpoolv2beta1.SpiderIPPool{}, client.MatchingLabels{
 		constant.LabelIPPoolOwnerApplicationUID: string(appUID),
 		constant.LabelIPPoolReclaimIPPool:       constant.True,
 	})
 	if nil != err {
-		if apierrors.IsNotFound(err) {
-			log.Info("delete application corresponding auto-created IPPools successfully")
-			return nil
+		if !apierrors.IsNotFound(err) {
+			return err
 		}
-		return err
+		log.Info("delete application corresponding auto-created IPPools successfully")
+	}
+
+	// The application is gone, so no shrink of its auto-created IPPools is pending anymore.
+	for i := range poolList.Items {
+		sac.autoPoolShrinkTimestamps.Delete(poolList.Items[i].Name)
 	}
+
 	return nil
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 )
 
 var _ = Describe("AppController", Label("app_controller_test"), func() {
@@ -891,5 +892,122 @@ var _ = Describe("AppController", Label("app_controller_test"), func() {
 			err := control.deleteAutoPools(ctx, deployment1.UID)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("test deleteAutoPools with list error", func() {
+			patch := gomonkey.ApplyMethodReturn(control.client, "List", constant.ErrUnknown)
+			defer patch.Reset()
+
+			err := control.deleteAutoPools(ctx, deployment1.UID)
+			Expect(err).To(MatchError(constant.ErrUnknown))
+		})
+
+		It("forget the pending shrinks of the deleted auto-created IPPools", func() {
+			pool := &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "auto4-test-deployment-eth0-123",
+					Labels: map[string]string{
+						constant.LabelIPPoolOwnerApplicationUID: string(deployment1.UID),
+						constant.LabelIPPoolReclaimIPPool:       constant.True,
+					},
+				},
+			}
+			Expect(control.client.Create(ctx, pool)).To(Succeed())
+			control.autoPoolShrinkTimestamps.Store(pool.Name, time.Now())
+			control.autoPoolShrinkTimestamps.Store("auto4-other-deployment-eth0-456", time.Now())
+
+			err := control.deleteAutoPools(ctx, deployment1.UID)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok := control.autoPoolShrinkTimestamps.Load(pool.Name)
+			Expect(ok).To(BeFalse())
+			_, ok = control.autoPoolShrinkTimestamps.Load("auto4-other-deployment-eth0-456")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("test autoPoolShrinkIPNumber", func() {
+		var ctx context.Context
+		var control *subnetApplicationController
+		var pool *spiderpoolv2beta1.SpiderIPPool
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			c, err := newController()
+			Expect(err).NotTo(HaveOccurred())
+			control = c
+
+			pool = &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "auto4-test-deployment-eth0-123",
+				},
+				Spec: spiderpoolv2beta1.IPPoolSpec{
+					IPVersion: ptr.To(constant.IPv4),
+					Subnet:    "172.16.0.0/16",
+					IPs:       []string{"172.16.41.1-172.16.41.10"},
+				},
+			}
+		})
+
+		It("scale up the auto-created IPPool", func() {
+			ipNum, after, err := control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 12)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeZero())
+			Expect(ipNum).To(Equal(12))
+		})
+
+		It("shrink the auto-created IPPool immediately without delay", func() {
+			ipNum, after, err := control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeZero())
+			Expect(ipNum).To(Equal(2))
+		})
+
+		It("never shrink the auto-created IPPool below the minimum IP number", func() {
+			control.AutoPoolShrinkMinIPNumber = 5
+			ipNum, after, err := control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeZero())
+			Expect(ipNum).To(Equal(5))
+
+			control.AutoPoolShrinkMinIPNumber = 20
+			ipNum, after, err = control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeZero())
+			Expect(ipNum).To(Equal(10))
+		})
+
+		It("delay to shrink the auto-created IPPool", func() {
+			control.AutoPoolShrinkDelay = time.Minute
+			ipNum, after, err := control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeNumerically(">", 0))
+			Expect(after).To(BeNumerically("<=", time.Minute))
+			Expect(ipNum).To(Equal(10))
+
+			control.autoPoolShrinkTimestamps.Store(pool.Name, time.Now().Add(-2*time.Minute))
+			ipNum, after, err = control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeZero())
+			Expect(ipNum).To(Equal(2))
+		})
+
+		It("cancel the pending shrink once the application scales up again", func() {
+			control.AutoPoolShrinkDelay = time.Minute
+			_, after, err := control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeNumerically(">", 0))
+
+			_, after, err = control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(BeZero())
+			_, ok := control.autoPoolShrinkTimestamps.Load(pool.Name)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("failed to parse the auto-created IPPool IPs", func() {
+			pool.Spec.IPs = []string{"invalid"}
+			_, _, err := control.autoPoolShrinkIPNumber(ctx, pool, constant.IPv4, 2)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
 	})
 })
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"net"
 	"sort"
 	"strconv"
@@ -65,6 +66,21 @@ func AutoPoolName(controllerName string, ipVersion types.IPVersion, ifName strin
 	return fmt.Sprintf("auto%d-%s-%s-%s", ipVersion, strings.ToLower(controllerName), strings.ToLower(ifName), strings.ToLower(lastOne))
 }
 
+// FallbackAutoPoolName generates the auto-created IPPool name in the fallback SpiderSubnet, which is the one that is not the first
+// SpiderSubnet specified for the interface. We append a short hash of the SpiderSubnet name to distinguish it from the primary one.
+func FallbackAutoPoolName(controllerName string, ipVersion types.IPVersion, ifName string, appUID apitypes.UID, subnetName string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(subnetName))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())[:randomLength+1]
+
+	name := AutoPoolName(controllerName, ipVersion, ifName, appUID)
+	if len(name)+len(suffix) > maxNameLength {
+		name = name[:maxNameLength-len(suffix)]
+	}
+
+	return name + suffix
+}
+
 // ApplicationLabelGV switches the kubernetes APIVersion from "/" link format to "_" link format for kubernetes label value usage.
 func ApplicationLabelGV(apiVersion string) string {
 	// Kubernetes API Group might be empty, ref: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#api-versions
@@ -248,10 +264,10 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 	return &subnetAnnoConfig, nil
 }
 
-// mutateAndValidateSubnetAnno will filter multiple subnets you specified and only leaves you the first one to use.
+// mutateAndValidateSubnetAnno checks the subnets you specified for each interface. Every interface could specify an ordered
+// list of SpiderSubnets for each IP version, the latter ones serve as the fallbacks once the former ones are exhausted.
 // And it also checks Interface name or subnets you specified whether are duplicate.
 func mutateAndValidateSubnetAnno(subnetConfig *types.PodSubnetAnnoConfig) error {
-	// the present version, we just only support one SpiderSubnet object to choose
 	if len(subnetConfig.MultipleSubnets) != 0 {
 		var v4SubnetsArray, v6SubnetsArray []string
 		var ifNameArray []string
@@ -259,20 +275,15 @@ func mutateAndValidateSubnetAnno(subnetConfig *types.PodSubnetAnnoConfig) error
 		for index := range subnetConfig.MultipleSubnets {
 			ifNameArray = append(ifNameArray, subnetConfig.MultipleSubnets[index].Interface)
 
-			if len(subnetConfig.MultipleSubnets[index].IPv4) != 0 {
-				subnetConfig.MultipleSubnets[index].IPv4 = []string{subnetConfig.MultipleSubnets[index].IPv4[0]}
-				if subnetConfig.MultipleSubnets[index].IPv4[0] == "" {
-					return fmt.Errorf("it's invalid to set an empty IPv4 subnet with mutilple interfaces")
-				}
-				v4SubnetsArray = append(v4SubnetsArray, subnetConfig.MultipleSubnets[index].IPv4[0])
+			if slices.Contains(subnetConfig.MultipleSubnets[index].IPv4, "") {
+				return fmt.Errorf("it's invalid to set an empty IPv4 subnet with mutilple interfaces")
 			}
-			if len(subnetConfig.MultipleSubnets[index].IPv6) != 0 {
-				subnetConfig.MultipleSubnets[index].IPv6 = []string{subnetConfig.MultipleSubnets[index].IPv6[0]}
-				if subnetConfig.MultipleSubnets[index].IPv6[0] == "" {
-					return fmt.Errorf("it's invalid to set an empty IPv6 subnet with mutilple interfaces")
-				}
-				v6SubnetsArray = append(v6SubnetsArray, subnetConfig.MultipleSubnets[index].IPv6[0])
+			v4SubnetsArray = append(v4SubnetsArray, subnetConfig.MultipleSubnets[index].IPv4...)
+
+			if slices.Contains(subnetConfig.MultipleSubnets[index].IPv6, "") {
+				return fmt.Errorf("it's invalid to set an empty IPv6 subnet with mutilple interfaces")
 			}
+			v6SubnetsArray = append(v6SubnetsArray, subnetConfig.MultipleSubnets[index].IPv6...)
 
 			// all none
 			if len(subnetConfig.MultipleSubnets[index].IPv4) == 0 && len(subnetConfig.MultipleSubnets[index].IPv6) == 0 {
@@ -280,9 +291,9 @@ func mutateAndValidateSubnetAnno(subnetConfig *types.PodSubnetAnnoConfig) error
 			}
 		}
 
-		// validate duplicate subnet
+		// validate duplicate subnet, no matter in the same interface fallback list or among multiple interfaces
 		if containsDuplicate(v4SubnetsArray) || containsDuplicate(v6SubnetsArray) {
-			return fmt.Errorf("it's invalid to use the same subnet for multiple interfaces: %v", subnetConfig)
+			return fmt.Errorf("it's invalid to use the same subnet for multiple interfaces or multiple times: %v", subnetConfig)
 		}
 
 		// validate duplicate interface
@@ -290,23 +301,23 @@ func mutateAndValidateSubnetAnno(subnetConfig *types.PodSubnetAnnoConfig) error
 			return fmt.Errorf("it's invalid to use the same Interface name for multiple interfaces: %v", subnetConfig)
 		}
 	} else if subnetConfig.SingleSubnet != nil {
-		if len(subnetConfig.SingleSubnet.IPv4) != 0 {
-			subnetConfig.SingleSubnet.IPv4 = []string{subnetConfig.SingleSubnet.IPv4[0]}
-			if subnetConfig.SingleSubnet.IPv4[0] == "" {
-				return fmt.Errorf("it's invalid to set an empty IPv4 subnet with single interface: %v", subnetConfig)
-			}
+		if slices.Contains(subnetConfig.SingleSubnet.IPv4, "") {
+			return fmt.Errorf("it's invalid to set an empty IPv4 subnet with single interface: %v", subnetConfig)
 		}
-		if len(subnetConfig.SingleSubnet.IPv6) != 0 {
-			subnetConfig.SingleSubnet.IPv6 = []string{subnetConfig.SingleSubnet.IPv6[0]}
-			if subnetConfig.SingleSubnet.IPv6[0] == "" {
-				return fmt.Errorf("it's invalid to set an empty IPv6 subnet with single interface: %v", subnetConfig)
-			}
+		if slices.Contains(subnetConfig.SingleSubnet.IPv6, "") {
+			return fmt.Errorf("it's invalid to set an empty IPv6 subnet with single interface: %v", subnetConfig)
 		}
 
 		// all none
 		if len(subnetConfig.SingleSubnet.IPv4) == 0 && len(subnetConfig.SingleSubnet.IPv6) == 0 {
 			return fmt.Errorf("it's invalid to set dual empty subnet with single interface: %v", subnetConfig)
 		}
+
+		// validate duplicate subnet in the fallback list
+		if containsDuplicate(subnetConfig.SingleSubnet.IPv4) || containsDuplicate(subnetConfig.SingleSubnet.IPv6) {
+			return fmt.Errorf("it's invalid to use the same subnet multiple times with single interface: %v", subnetConfig)
+		}
+
 		// specify 'eth0' as the default single interface if it's none.
 		if subnetConfig.SingleSubnet.Interface == "" {
 			subnetConfig.SingleSubnet.Interface = constant.ClusterDefaultInterfaceName
@@ -50,6 +50,19 @@ var _ = Describe("Utils", func() {
 			result := AutoPoolName(controllerName, ipVersion, ifName, controllerUID)
 			Expect(result).To(Equal(expectRes))
 		})
+
+		It("fallback subnet auto-created IPPool name", Label("unittest", "AutoPoolName"), func() {
+			controllerUID := apitypes.UID("a-b-c-d")
+			primary := AutoPoolName(controllerName, ipVersion, ifName, controllerUID)
+
+			result1 := FallbackAutoPoolName(controllerName, ipVersion, ifName, controllerUID, "subnet1")
+			Expect(result1).To(HavePrefix(primary + "-"))
+			Expect(result1).To(HaveLen(len(primary) + 6))
+			Expect(FallbackAutoPoolName(controllerName, ipVersion, ifName, controllerUID, "subnet1")).To(Equal(result1))
+
+			result2 := FallbackAutoPoolName(controllerName, ipVersion, ifName, controllerUID, "subnet2")
+			Expect(result2).NotTo(Equal(result1))
+		})
 	})
 
 	Context("test ApplicationLabelGV", func() {
@@ -376,6 +389,28 @@ var _ = Describe("Utils", func() {
 			Expect(mutateAndValidateSubnetAnno(&subnetConfig)).To(HaveOccurred())
 		})
 
+		It("MultipleSubnets containsDuplicate fallback subnet among interfaces", func() {
+			subnetConfig = types.PodSubnetAnnoConfig{
+				MultipleSubnets: []types.AnnoSubnetItem{
+					{Interface: "eth0", IPv4: []string{"subnet1", "subnet2"}},
+					{Interface: "eth1", IPv4: []string{"subnet3", "subnet2"}},
+				},
+			}
+			Expect(mutateAndValidateSubnetAnno(&subnetConfig)).To(HaveOccurred())
+		})
+
+		It("MultipleSubnets keeps the fallback subnets in order", func() {
+			subnetConfig = types.PodSubnetAnnoConfig{
+				MultipleSubnets: []types.AnnoSubnetItem{
+					{Interface: "eth0", IPv4: []string{"subnet1", "subnet2"}, IPv6: []string{"subnet-v6-1", "subnet-v6-2"}},
+					{Interface: "eth1", IPv4: []string{"subnet3"}},
+				},
+			}
+			Expect(mutateAndValidateSubnetAnno(&subnetConfig)).NotTo(HaveOccurred())
+			Expect(subnetConfig.MultipleSubnets[0].IPv4).To(Equal([]string{"subnet1", "subnet2"}))
+			Expect(subnetConfig.MultipleSubnets[0].IPv6).To(Equal([]string{"subnet-v6-1", "subnet-v6-2"}))
+		})
+
 		It("MultipleSubnets containsDuplicate interface", func() {
 			subnetConfig = types.PodSubnetAnnoConfig{
 				MultipleSubnets: []types.AnnoSubnetItem{
@@ -410,6 +445,24 @@ var _ = Describe("Utils", func() {
 			Expect(mutateAndValidateSubnetAnno(&subnetConfig)).To(HaveOccurred())
 		})
 
+		It("SingleSubnet empty IPv4 fallback subnet", func() {
+			subnetConfig = types.PodSubnetAnnoConfig{
+				SingleSubnet: &types.AnnoSubnetItem{
+					Interface: "eth0", IPv4: []string{"subnet1", ""},
+				},
+			}
+			Expect(mutateAndValidateSubnetAnno(&subnetConfig)).To(HaveOccurred())
+		})
+
+		It("SingleSubnet containsDuplicate fallback subnet", func() {
+			subnetConfig = types.PodSubnetAnnoConfig{
+				SingleSubnet: &types.AnnoSubnetItem{
+					Interface: "eth0", IPv6: []string{"subnet-v6-1", "subnet-v6-1"},
+				},
+			}
+			Expect(mutateAndValidateSubnetAnno(&subnetConfig)).To(HaveOccurred())
+		})
+
 		It("SingleSubnet both IPV4 and IPv6 empty subnet", func() {
 			subnetConfig = types.PodSubnetAnnoConfig{
 				SingleSubnet: &types.AnnoSubnetItem{
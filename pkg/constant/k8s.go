@@ -34,6 +34,7 @@ const (
 	KindKubevirtVM  = "VirtualMachine"
 	KindKubevirtVMI = "VirtualMachineInstance"
 	KindServiceCIDR = "ServiceCIDR"
+	KindNode        = "Node"
 )
 
 var K8sKinds = []string{
@@ -142,6 +143,8 @@ const (
 	KindSpiderIPPool         = "SpiderIPPool"
 	KindSpiderEndpoint       = "SpiderEndpoint"
 	KindSpiderReservedIP     = "SpiderReservedIP"
+	KindSpiderIPClaim        = "SpiderIPClaim"
+	KindSpiderTenantIPPool   = "SpiderTenantIPPool"
 	KindSpiderCoordinator    = "SpiderCoordinator"
 	KindSpiderMultusConfig   = "SpiderMultusConfig"
 	KindSpiderClaimParameter = "SpiderClaimParameter"
@@ -180,15 +183,25 @@ const (
 )
 
 const (
-	MacvlanCNI = "macvlan"
-	IPVlanCNI  = "ipvlan"
-	VlanCNI    = "vlan"
-	SriovCNI   = "sriov"
-	IBSriovCNI = "ib-sriov"
-	IPoIBCNI   = "ipoib"
-	OvsCNI     = "ovs"
-	CustomCNI  = "custom"
-	TuningCNI  = "tuning"
+	MacvlanCNI    = "macvlan"
+	IPVlanCNI     = "ipvlan"
+	VlanCNI       = "vlan"
+	SriovCNI      = "sriov"
+	IBSriovCNI    = "ib-sriov"
+	IPoIBCNI      = "ipoib"
+	OvsCNI        = "ovs"
+	BridgeCNI     = "bridge"
+	HostDeviceCNI = "host-device"
+	OvnK8sCNI     = "ovn-k8s"
+	CustomCNI     = "custom"
+	TuningCNI     = "tuning"
+)
+
+// the plugin type and topologies of the OVN-Kubernetes secondary networks
+const (
+	OvnK8sCNIOverlay       = "ovn-k8s-cni-overlay"
+	OvnK8sTopologyLayer2   = "layer2"
+	OvnK8sTopologyLocalnet = "localnet"
 )
 
 const (
@@ -196,12 +209,30 @@ const (
 	VlanModeAuto   = "auto"
 )
 
+const (
+	IPv6AddressModeRandom = "random"
+	IPv6AddressModeEUI64  = "eui64"
+	IPv6AddressModeStable = "stable"
+)
+
+// The policies to pick IPPools from the candidates of a NIC
+const (
+	PoolSelectionFillFirst     = "fillFirst"
+	PoolSelectionLeastUtilized = "leastUtilized"
+	PoolSelectionWeighted      = "weighted"
+	PoolSelectionSpread        = "spread"
+
+	DefaultPoolSelectionSpreadTopologyKey = corev1.LabelTopologyZone
+)
+
 const WebhookMutateRoute = "/webhook-health-check"
 
 // CRD field
 const (
 	SpecIPVersionField = "spec.ipVersion"
 	SpecDefaultField   = "spec.default"
+	SpecIPPoolField    = "spec.ippool"
+	SpecNodeNameField  = "spec.nodeName"
 )
 
 const (
@@ -233,3 +264,45 @@ const (
 	MutatingWebhookConfiguration   = "MutatingWebhookConfiguration"
 	ValidatingWebhookConfiguration = "ValidatingWebhookConfiguration"
 )
+
+// IaaS network provider
+const (
+	// IaaSPendingReleaseConfigMapName is the ConfigMap in the spiderpool-controller
+	// namespace which persists the IaaS IP releases to retry.
+	IaaSPendingReleaseConfigMapName = "spiderpool-iaas-pending-release"
+
+	EventReasonIaaSOrphanedAssignment = "IaaSOrphanedAssignment"
+	// EventReasonIaaSProviderUnavailable is recorded on the Pod if the IaaS
+	// provider is not called since the circuit breaker is open.
+	EventReasonIaaSProviderUnavailable = "IaaSProviderUnavailable"
+	EventReasonIaaSAllocateFailed      = "IaaSAllocateFailed"
+)
+
+// external IPAM
+const (
+	// LabelExternalIPAMPrefix is the label of the SpiderIPPools imported from
+	// the prefixes of external IPAM, and the SpiderReservedIPs which record
+	// the conflicts found in external IPAM. The value is the prefix ID.
+	LabelExternalIPAMPrefix = AnnotationPre + "/external-ipam-prefix"
+
+	ExternalIPAMPoolNamePrefix       = "external-ipam-"
+	ExternalIPAMReservedIPNamePrefix = "external-ipam-conflict-"
+
+	EventReasonExternalIPAMConflict   = "ExternalIPAMConflict"
+	EventReasonExternalIPAMSyncFailed = "ExternalIPAMSyncFailed"
+)
+
+// SpiderIPClaim
+const (
+	EventReasonIPClaimExpired = "IPClaimExpired"
+)
+
+// SpiderTenantIPPool
+const (
+	// LabelIPPoolOwnerTenantNamespace and LabelIPPoolOwnerTenantName are the
+	// labels of the SpiderIPPool created for a SpiderTenantIPPool.
+	LabelIPPoolOwnerTenantNamespace = AnnotationPre + "/owner-tenant-namespace"
+	LabelIPPoolOwnerTenantName      = AnnotationPre + "/owner-tenant-name"
+
+	EventReasonTenantIPPoolSyncFailed = "TenantIPPoolSyncFailed"
+)
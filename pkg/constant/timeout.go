@@ -33,4 +33,29 @@ const (
 	// no explicit httpRequestTimeout is configured. Set to IaaSProviderWorstCase
 	// plus a small margin so a single provider call has a safe default budget.
 	DefaultIaaSProviderTimeout = 50 * time.Second
+
+	// DefaultIaaSReconcileInterval is used when IaaS integration is enabled but
+	// no explicit reconcileInterval is configured.
+	DefaultIaaSReconcileInterval = 5 * time.Minute
+
+	// DefaultIaaSParentNicMacCacheTTL is the lifetime of a cached parent NIC
+	// MAC address if no explicit parentNicMacCache.ttl is configured.
+	DefaultIaaSParentNicMacCacheTTL = 24 * time.Hour
+
+	// DefaultIaaSCircuitBreakerOpenDuration is the duration before probing the
+	// IaaS provider once the circuit breaker opens.
+	DefaultIaaSCircuitBreakerOpenDuration = 10 * time.Second
+	// DefaultIaaSCircuitBreakerMaxOpenDuration is the upper bound of the open
+	// duration, which is doubled by each failed probe.
+	DefaultIaaSCircuitBreakerMaxOpenDuration = 2 * time.Minute
+
+	// DefaultExternalIPAMSyncInterval is used when external IPAM is enabled
+	// but no explicit syncInterval is configured.
+	DefaultExternalIPAMSyncInterval = 5 * time.Minute
+	// DefaultExternalIPAMRequestTimeout is the timeout of a single external
+	// IPAM API call.
+	DefaultExternalIPAMRequestTimeout = 30 * time.Second
+
+	// DefaultIPClaimGCInterval is the interval to delete the expired SpiderIPClaims.
+	DefaultIPClaimGCInterval = time.Minute
 )
@@ -0,0 +1,86 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/pkg/utils"
+)
+
+// EventReasonDefaultCNIChanged is the reason of the Event emitted when the
+// default CNI in the CNI config directory has changed.
+const EventReasonDefaultCNIChanged = "DefaultCNIChanged"
+
+// cniConfWatchDebounce merges the burst of events of the CNI config files,
+// which are usually rewritten by the CNI installers more than once.
+var cniConfWatchDebounce = 2 * time.Second
+
+// WatchDefaultCNI watches the CNI config directory with fsnotify, and calls
+// onChange with the previous and current name of the default CNI once it has
+// changed, until the ctx is done.
+func WatchDefaultCNI(ctx context.Context, logger *zap.Logger, cniConfDir string, onChange func(previous, current string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+
+	if err := watcher.Add(cniConfDir); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch the CNI config directory %s: %w", cniConfDir, err)
+	}
+
+	previous, err := utils.GetDefaultCniName(cniConfDir)
+	if err != nil {
+		logger.Sugar().Warnf("failed to get the default CNI in %s: %v", cniConfDir, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		timer := time.NewTimer(cniConfWatchDebounce)
+		timer.Stop()
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				logger.Sugar().Debugf("CNI config directory event: %v", e)
+				timer.Reset(cniConfWatchDebounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Sugar().Warnf("CNI config directory watcher error: %v", err)
+			case <-timer.C:
+				current, err := utils.GetDefaultCniName(cniConfDir)
+				if err != nil {
+					// the config file may be written partially, wait for the next event
+					logger.Sugar().Warnf("failed to get the default CNI in %s: %v", cniConfDir, err)
+					continue
+				}
+
+				if current == previous {
+					continue
+				}
+
+				logger.Sugar().Infof("The default CNI in %s has changed from %q to %q", cniConfDir, previous, current)
+				onChange(previous, current)
+				previous = current
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Default CNI watcher", Label("cni_conf_watcher"), func() {
+	writeConfList := func(dir, file, name string) {
+		data := `{"cniVersion": "0.3.1", "name": "` + name + `", "plugins": [{"type": "` + name + `"}]}`
+		Expect(os.WriteFile(filepath.Join(dir, file), []byte(data), 0o600)).To(Succeed())
+	}
+
+	var dir string
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		debounce := cniConfWatchDebounce
+		cniConfWatchDebounce = 50 * time.Millisecond
+		DeferCleanup(func() {
+			cniConfWatchDebounce = debounce
+		})
+	})
+
+	It("reports the change of the default CNI", func() {
+		writeConfList(dir, "10-calico.conflist", "calico")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		type change struct{ previous, current string }
+		changes := make(chan change, 10)
+		err := WatchDefaultCNI(ctx, zap.NewNop(), dir, func(previous, current string) {
+			changes <- change{previous, current}
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// multus and the other CNI after the default one make no change
+		writeConfList(dir, "00-multus.conflist", "multus-cni-network")
+		writeConfList(dir, "20-macvlan.conflist", "macvlan")
+		Consistently(changes, 300*time.Millisecond).ShouldNot(Receive())
+
+		writeConfList(dir, "05-cilium.conflist", "cilium")
+		Eventually(changes, 5*time.Second).Should(Receive(Equal(change{"calico", "cilium"})))
+
+		Expect(os.Remove(filepath.Join(dir, "05-cilium.conflist"))).To(Succeed())
+		Eventually(changes, 5*time.Second).Should(Receive(Equal(change{"cilium", "calico"})))
+	})
+
+	It("fails to watch a nonexistent directory", func() {
+		err := WatchDefaultCNI(context.Background(), zap.NewNop(), filepath.Join(dir, "nonexistent"), func(string, string) {})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -16,22 +16,21 @@ import (
 	"github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2alpha1"
 	"github.com/cilium/cilium/pkg/k8s/client/clientset/versioned"
 	cilium_externalversions "github.com/cilium/cilium/pkg/k8s/client/informers/externalversions"
+	ciliumV2Lister "github.com/cilium/cilium/pkg/k8s/client/listers/cilium.io/v2"
 	ciliumLister "github.com/cilium/cilium/pkg/k8s/client/listers/cilium.io/v2alpha1"
 	calicov1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	networkingv1alpha1 "k8s.io/api/networking/v1alpha1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
-	networkingInformer "k8s.io/client-go/informers/networking/v1alpha1"
 	"k8s.io/client-go/kubernetes"
 	corelister "k8s.io/client-go/listers/core/v1"
-	networkingLister "k8s.io/client-go/listers/networking/v1alpha1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -52,15 +51,19 @@ import (
 )
 
 const (
-	auto    = "auto"
-	cluster = "cluster"
-	calico  = "calico"
-	cilium  = "cilium"
-	flannel = "flannel"
-	none    = "none"
+	auto          = "auto"
+	cluster       = "cluster"
+	calico        = "calico"
+	cilium        = "cilium"
+	flannel       = "flannel"
+	antrea        = "antrea"
+	ovnKubernetes = "ovn-kubernetes"
+	kubeOVN       = "kube-ovn"
+	weave         = "weave"
+	none          = "none"
 )
 
-var SupportedPodCIDRType = []string{auto, cluster, calico, cilium, none}
+var SupportedPodCIDRType = []string{auto, cluster, calico, cilium, flannel, antrea, ovnKubernetes, kubeOVN, weave, none}
 
 const (
 	calicoIPPoolCRDName = "ippools.crd.projectcalico.org"
@@ -74,6 +77,10 @@ const (
 	Synced   = "Synced"
 )
 
+// EventReasonCoordinatorDatapathFallback is the reason of the Event recorded
+// on the Pod when coordinator falls back to the route datapath.
+const EventReasonCoordinatorDatapathFallback = "CoordinatorDatapathFallback"
+
 const messageEnqueueCoordiantor = "Enqueue Coordinator"
 
 var InformerLogger *zap.Logger
@@ -87,9 +94,11 @@ type CoordinatorController struct {
 	CoordinatorLister spiderlisters.SpiderCoordinatorLister
 	ConfigmapLister   corelister.ConfigMapLister
 	// only not to nil if the k8s serviceCIDR is enabled
-	ServiceCIDRLister networkingLister.ServiceCIDRLister
+	ServiceCIDRLister cache.GenericLister
 	// only not to nil if the cilium multu-pool is enabled
 	CiliumIPPoolLister ciliumLister.CiliumPodIPPoolLister
+	// only not to nil if the podCIDRType is cilium
+	CiliumNodeLister ciliumV2Lister.CiliumNodeLister
 
 	CoordinatorSynced cache.InformerSynced
 	ConfigmapSynced   cache.InformerSynced
@@ -97,6 +106,8 @@ type CoordinatorController struct {
 	ServiceCIDRSynced cache.InformerSynced
 	// only not to nil if the cilium multu-pool is enabled
 	CiliumIPPoolsSynced cache.InformerSynced
+	// only not to nil if the podCIDRType is cilium
+	CiliumNodesSynced cache.InformerSynced
 
 	Workqueue workqueue.RateLimitingInterface
 
@@ -161,19 +172,39 @@ func (cc *CoordinatorController) SetupInformer(
 
 			if err := cc.StartWatchPodCIDR(innerCtx, InformerLogger); err != nil {
 				InformerLogger.Error(err.Error())
+				innerCancel()
+				time.Sleep(cc.LeaderRetryElectGap)
 				continue
 			}
 
+			// restart the informer to watch the podCIDR of the new default CNI
+			err := WatchDefaultCNI(innerCtx, InformerLogger, cc.DefaultCniConfDir, func(previous, current string) {
+				cc.recordDefaultCNIChanged(innerCtx, previous, current)
+				innerCancel()
+			})
+			if err != nil {
+				InformerLogger.Sugar().Warnf("unable to watch the default CNI changes: %v", err)
+			}
+
 			InformerLogger.Info("Initialize Coordinator informer")
 			k8sInformerFactory := informers.NewSharedInformerFactory(k8sClientset, cc.ResyncPeriod)
 			spiderInformerFactory := externalversions.NewSharedInformerFactory(spiderClientset, cc.ResyncPeriod)
-			err := cc.addEventHandlers(
+			err = cc.addEventHandlers(
 				spiderInformerFactory.Spiderpool().V2beta1().SpiderCoordinators(),
 				k8sInformerFactory.Core().V1().ConfigMaps(),
-				k8sInformerFactory.Networking().V1alpha1().ServiceCIDRs(),
 			)
 			if err != nil {
 				InformerLogger.Error(err.Error())
+				innerCancel()
+				time.Sleep(cc.LeaderRetryElectGap)
+				continue
+			}
+
+			err = cc.setupServiceCIDRInformer(innerCtx, k8sClientset.Discovery(), dynamic.NewForConfigOrDie(ctrl.GetConfigOrDie()))
+			if err != nil {
+				InformerLogger.Error(err.Error())
+				innerCancel()
+				time.Sleep(cc.LeaderRetryElectGap)
 				continue
 			}
 
@@ -196,15 +227,54 @@ func (cc *CoordinatorController) StartWatchPodCIDR(ctx context.Context, logger *
 		return err
 	}
 
+	// watch the podCIDR of the type specified by the default SpiderCoordinator
+	var coord spiderpoolv2beta1.SpiderCoordinator
+	if err := cc.APIReader.Get(ctx, types.NamespacedName{Name: cc.DefaultCoordinatorName}, &coord); err == nil &&
+		coord.Spec.PodCIDRType != nil && *coord.Spec.PodCIDRType != auto {
+		cniType = *coord.Spec.PodCIDRType
+	}
+
 	switch cniType {
+	case cluster:
+		if err = cc.WatchNodePodCIDRs(ctx, logger); err != nil {
+			return err
+		}
 	case calico:
 		if err = cc.WatchCalicoIPPools(ctx, logger); err != nil {
 			return err
 		}
+		if err = cc.WatchCalicoBlockAffinities(ctx, logger); err != nil {
+			return err
+		}
 	case cilium:
 		if err = cc.WatchCiliumIPPools(ctx, logger); err != nil {
 			return err
 		}
+		if err = cc.WatchCiliumNodes(ctx, logger); err != nil {
+			return err
+		}
+		if err = cc.WatchNodePodCIDRs(ctx, logger); err != nil {
+			return err
+		}
+	case flannel:
+		if err = cc.WatchNodePodCIDRs(ctx, logger); err != nil {
+			return err
+		}
+	case antrea:
+		if err = cc.WatchAntreaIPPools(ctx, logger); err != nil {
+			return err
+		}
+		if err = cc.WatchNodePodCIDRs(ctx, logger); err != nil {
+			return err
+		}
+	case kubeOVN:
+		if err = cc.WatchKubeOVNSubnets(ctx, logger); err != nil {
+			return err
+		}
+	case weave:
+		if err = cc.WatchWeaveDaemonSet(ctx, logger); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -212,7 +282,6 @@ func (cc *CoordinatorController) StartWatchPodCIDR(ctx context.Context, logger *
 func (cc *CoordinatorController) addEventHandlers(
 	coordinatorInformer spiderinformers.SpiderCoordinatorInformer,
 	configmapInformer coreinformers.ConfigMapInformer,
-	serviceCIDRInformer networkingInformer.ServiceCIDRInformer,
 ) error {
 	cc.CoordinatorLister = coordinatorInformer.Lister()
 	cc.ConfigmapLister = configmapInformer.Lister()
@@ -237,50 +306,6 @@ func (cc *CoordinatorController) addEventHandlers(
 		return err
 	}
 
-	InformerLogger.Debug("Checking if the ServiceCIDR is available in your cluster")
-	var serviceCIDR networkingv1alpha1.ServiceCIDRList
-	err = cc.APIReader.List(context.TODO(), &serviceCIDR)
-	if err != nil {
-		InformerLogger.Warn("ServiceCIDR feature is unavailable in your cluster, Don't start the serviceCIDR informer")
-		return nil
-	}
-
-	InformerLogger.Debug("the ServiceCIDR is available in your cluster, Start the serviceCIDR informer")
-	if err = cc.addServiceCIDRHandler(serviceCIDRInformer.Informer()); err != nil {
-		return err
-	}
-	cc.ServiceCIDRLister = serviceCIDRInformer.Lister()
-	return nil
-}
-
-func (cc *CoordinatorController) addServiceCIDRHandler(serviceCIDRInformer cache.SharedIndexInformer) error {
-	_, err := serviceCIDRInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			serviceCidr := obj.(*networkingv1alpha1.ServiceCIDR)
-			logger := InformerLogger.With(
-				zap.String("ServiceCIDRName", serviceCidr.Name),
-				zap.String("Operation", "Add"),
-			)
-
-			cc.Workqueue.Add(fmt.Sprintf("ServiceCIDR/%v", serviceCidr.Name))
-			logger.Debug(messageEnqueueCoordiantor)
-		},
-		DeleteFunc: func(obj interface{}) {
-			serviceCidr := obj.(*networkingv1alpha1.ServiceCIDR)
-			logger := InformerLogger.With(
-				zap.String("ServiceCIDRName", serviceCidr.Name),
-				zap.String("Operation", "Del"),
-			)
-
-			cc.Workqueue.Add(fmt.Sprintf("ServiceCIDR/%v", serviceCidr.Name))
-			logger.Debug(messageEnqueueCoordiantor)
-		},
-	})
-	if err != nil {
-		return err
-	}
-
-	cc.ServiceCIDRSynced = serviceCIDRInformer.HasSynced
 	return nil
 }
 
@@ -327,7 +352,7 @@ func (cc *CoordinatorController) enqueueCoordinatorOnUpdate(oldObj, newObj inter
 
 func (cc *CoordinatorController) enqueueCoordinatorOnConfigMapAdd(obj interface{}) {
 	cm := obj.(*corev1.ConfigMap)
-	if cm.Name == ciliumConfig || cm.Name == kubeadmConfigMap {
+	if isPodCIDRConfigMap(cm.Name) {
 		logger := InformerLogger.With(
 			zap.String("ConfigmapName", cm.Name),
 			zap.String("Operation", "Add"),
@@ -341,7 +366,7 @@ func (cc *CoordinatorController) enqueueCoordinatorOnConfigMapAdd(obj interface{
 func (cc *CoordinatorController) enqueueCoordinatorOnConfigMapUpdated(oldObj, newObj interface{}) {
 	oldCm := oldObj.(*corev1.ConfigMap)
 	newCm := newObj.(*corev1.ConfigMap)
-	if newCm.Name == ciliumConfig || newCm.Name == kubeadmConfigMap {
+	if isPodCIDRConfigMap(newCm.Name) {
 		if reflect.DeepEqual(oldCm.Data, newCm.Data) {
 			return
 		}
@@ -358,7 +383,7 @@ func (cc *CoordinatorController) enqueueCoordinatorOnConfigMapUpdated(oldObj, ne
 
 func (cc *CoordinatorController) enqueueCoordinatorOnConfigMapDeleted(obj interface{}) {
 	cm := obj.(*corev1.ConfigMap)
-	if cm.Name == ciliumConfig || cm.Name == kubeadmConfigMap {
+	if isPodCIDRConfigMap(cm.Name) {
 		logger := InformerLogger.With(
 			zap.String("ConfigmapName", cm.Name),
 			zap.String("Operation", "DEL"),
@@ -381,6 +406,10 @@ func (cc *CoordinatorController) run(ctx context.Context, workers int) error {
 		additionalCacheSync = append(additionalCacheSync, cc.CiliumIPPoolsSynced)
 	}
 
+	if cc.CiliumNodesSynced != nil {
+		additionalCacheSync = append(additionalCacheSync, cc.CiliumNodesSynced)
+	}
+
 	if ok := cache.WaitForNamedCacheSync(
 		constant.KindSpiderCoordinator,
 		ctx.Done(),
@@ -452,7 +481,6 @@ func (cc *CoordinatorController) updatePodAndServerCIDR(ctx context.Context, log
 	coordCopy := coord.DeepCopy()
 	podCidrType := *coordCopy.Spec.PodCIDRType
 	if podCidrType == auto {
-		// TODO(@cyclinder): Do we need watch if /etc/cni/net.d has changed?
 		podCidrType, err = fetchType(cc.DefaultCniConfDir)
 		if err != nil {
 			logger.Error("unable to get CNIType in your cluster")
@@ -527,11 +555,23 @@ func (cc *CoordinatorController) updatePodAndServerCIDR(ctx context.Context, log
 			coordCopy.Status.OverlayPodCIDR = []string{}
 			return coordCopy
 		}
+	case flannel, antrea, ovnKubernetes, kubeOVN, weave:
+		if err = cc.updatePodCIDRByBackend(ctx, podCidrType, k8sPodCIDR, coordCopy); err != nil {
+			coordCopy.Status.Phase = NotReady
+			coordCopy.Status.Reason = err.Error()
+			coordCopy.Status.OverlayPodCIDR = []string{}
+			return coordCopy
+		}
 	case none:
 		coordCopy.Status.Phase = Synced
 		coordCopy.Status.OverlayPodCIDR = []string{}
 	}
 
+	if err = cc.updateNodePodCIDRs(ctx, podCidrType, coordCopy); err != nil {
+		logger.Sugar().Warnf("unable to fetch the pod CIDRs of the nodes: %v", err)
+		coordCopy.Status.NodePodCIDRs = nil
+	}
+
 	if err = cc.updateServiceCIDR(logger, coordCopy); err != nil {
 		logger.Sugar().Infof("unable to list the serviceCIDR resources: %v, update service cidr from cluster service cidr", err)
 		coordCopy.Status.ServiceCIDR = k8sServiceCIDR
@@ -585,14 +625,7 @@ func (cc *CoordinatorController) WatchCalicoIPPools(ctx context.Context, logger
 		return err
 	}
 
-	go func() {
-		logger.Info("Starting Calico IPPool controller")
-		if err := calicoController.Start(ctx); err != nil {
-			logger.Sugar().Errorf("Failed to start Calico IPPool controller: %w", err)
-		}
-		logger.Info("Shutdown Calico IPPool controller")
-	}()
-
+	startPodCIDRController(ctx, logger, "Calico IPPool", calicoController)
 	return nil
 }
 
@@ -742,39 +775,6 @@ func (cc *CoordinatorController) fetchCiliumIPPools(coordinator *spiderpoolv2bet
 	return nil
 }
 
-func (cc *CoordinatorController) updateServiceCIDR(logger *zap.Logger, coordCopy *spiderpoolv2beta1.SpiderCoordinator) error {
-	// fetch kubernetes ServiceCIDR
-	if cc.ServiceCIDRLister == nil {
-		// serviceCIDR feature is disable if ServiceCIDRLister is nil
-		return fmt.Errorf("the kubernetes serviceCIDR is disabled")
-	}
-
-	svcPoolList, err := cc.ServiceCIDRLister.List(labels.NewSelector())
-	if err != nil {
-		return err
-	}
-
-	// sort the list to admit serviceCIDR has changed due to the order.
-	sort.Slice(svcPoolList, func(i, j int) bool {
-		return svcPoolList[i].CreationTimestamp.UnixNano() < svcPoolList[j].CreationTimestamp.UnixNano()
-	})
-
-	serviceCIDR := make([]string, 0, len(svcPoolList))
-	for _, p := range svcPoolList {
-		if p.DeletionTimestamp == nil {
-			serviceCIDR = append(serviceCIDR, p.Spec.CIDRs...)
-		}
-	}
-
-	if coordCopy.Status.Phase == Synced && reflect.DeepEqual(coordCopy.Status.ServiceCIDR, serviceCIDR) {
-		return nil
-	}
-
-	logger.Sugar().Debug("Got service cidrs: ", serviceCIDR)
-	coordCopy.Status.ServiceCIDR = serviceCIDR
-	return nil
-}
-
 func fetchType(cniDir string) (string, error) {
 	defaultCniName, err := utils.GetDefaultCniName(cniDir)
 	if err != nil {
@@ -786,13 +786,37 @@ func fetchType(cniDir string) (string, error) {
 		return calico, nil
 	case cilium:
 		return cilium, nil
-	case flannel:
-		return cluster, nil
+	case flannel, "cbr0":
+		return flannel, nil
+	case antrea:
+		return antrea, nil
+	case ovnKubernetes, "ovnkube":
+		return ovnKubernetes, nil
+	case kubeOVN:
+		return kubeOVN, nil
+	case weave:
+		return weave, nil
 	default:
 		return none, nil
 	}
 }
 
+func (cc *CoordinatorController) recordDefaultCNIChanged(ctx context.Context, previous, current string) {
+	var coord spiderpoolv2beta1.SpiderCoordinator
+	if err := cc.APIReader.Get(ctx, types.NamespacedName{Name: cc.DefaultCoordinatorName}, &coord); err != nil {
+		InformerLogger.Sugar().Warnf("failed to get SpiderCoordinator %s: %v", cc.DefaultCoordinatorName, err)
+		return
+	}
+
+	event.EventRecorder.Eventf(
+		&coord,
+		corev1.EventTypeNormal,
+		EventReasonDefaultCNIChanged,
+		"The default CNI has changed from %q to %q, re-evaluate the podCIDR",
+		previous, current,
+	)
+}
+
 func setStatus2NoReady(logger *zap.Logger, reason string, copy *spiderpoolv2beta1.SpiderCoordinator) {
 	if copy.Status.Phase != NotReady {
 		logger.Sugar().Infof("set spidercoordinator phase from %s to NotReady", copy.Status.Phase)
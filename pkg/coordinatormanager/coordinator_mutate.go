@@ -27,6 +27,12 @@ func mutateCoordinator(ctx context.Context, coord *spiderpoolv2beta1.SpiderCoord
 	if coord.Spec.Mode == nil {
 		coord.Spec.Mode = ptr.To(string(coordinator_cmd.ModeAuto))
 	}
+	if coord.Spec.Datapath == nil {
+		coord.Spec.Datapath = ptr.To(string(coordinator_cmd.DatapathRoute))
+	}
+	if coord.Spec.DatapathFallback == nil {
+		coord.Spec.DatapathFallback = ptr.To(false)
+	}
 	if coord.Spec.TunePodRoutes == nil {
 		coord.Spec.TunePodRoutes = ptr.To(true)
 	}
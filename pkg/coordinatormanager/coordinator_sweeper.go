@@ -0,0 +1,468 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/networking/ebpf"
+	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+)
+
+const (
+	// EventReasonCoordinatorOrphanCleaned is the reason of the Event emitted
+	// when a host veth, route, endpoint or rule leaked by coordinator is
+	// cleaned.
+	EventReasonCoordinatorOrphanCleaned = "CoordinatorOrphanCleaned"
+	// EventReasonCoordinatorOrphanCleanFailed is the reason of the Event
+	// emitted when a leaked host veth, route, endpoint or rule fails to be
+	// cleaned.
+	EventReasonCoordinatorOrphanCleanFailed = "CoordinatorOrphanCleanFailed"
+
+	// the types of the artefacts created by coordinator on the host
+	OrphanTypeVeth  = "veth"
+	OrphanTypeRoute = "route"
+	// OrphanTypeEndpoint is an entry of the host maps of the ebpf datapath
+	OrphanTypeEndpoint = "endpoint"
+	OrphanTypeRule     = "rule"
+
+	// defaultHostRuleTable is the default of spec.hostRuleTable
+	defaultHostRuleTable = 500
+)
+
+// hostVethNameRegex matches the host veths created by coordinator in the
+// underlay mode, whose name is "veth" followed by the prefix of the container ID.
+var hostVethNameRegex = regexp.MustCompile(`^veth[0-9a-f]{1,11}$`)
+
+// hostNetlink is the netlink operations of the sweeper in the host netns,
+// which is implemented by *netlink.Handle.
+type hostNetlink interface {
+	LinkList() ([]netlink.Link, error)
+	LinkDel(link netlink.Link) error
+	RouteListFiltered(family int, filter *netlink.Route, filterMask uint64) ([]netlink.Route, error)
+	RouteDel(route *netlink.Route) error
+	RuleList(family int) ([]netlink.Rule, error)
+	RuleDel(rule *netlink.Rule) error
+}
+
+// hostMaps is the host maps of the ebpf datapath, which is implemented by
+// *ebpf.HostRedirect.
+type hostMaps interface {
+	Endpoints() ([]ebpf.HostEndpoint, error)
+	Delete(prefix *net.IPNet) error
+	Close() error
+}
+
+// loadHostMaps loads the host maps pinned by coordinator, it returns nil if
+// no pod on the node uses the ebpf datapath.
+func loadHostMaps() (hostMaps, error) {
+	maps, err := ebpf.LoadHostRedirect(ebpf.DefaultPinPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return maps, nil
+}
+
+// Orphan is a host veth, route, endpoint or rule created by coordinator,
+// whose pod has gone without the CNI DEL.
+type Orphan struct {
+	// Type is one of OrphanTypeVeth, OrphanTypeRoute, OrphanTypeEndpoint and
+	// OrphanTypeRule
+	Type string
+	// Name describes the orphan, like "ip route" or "ip rule" does
+	Name string
+
+	link     netlink.Link
+	route    *netlink.Route
+	endpoint *ebpf.HostEndpoint
+	rule     *netlink.Rule
+}
+
+func (o Orphan) String() string {
+	return fmt.Sprintf("%s %s", o.Type, o.Name)
+}
+
+// OrphanSweeper cleans the host veths, routes in the host rule tables and the
+// policy rules to the host rule tables created by coordinator, as well as the
+// endpoints in the host maps of the ebpf datapath, which are left on the node
+// once the CNI DEL never runs. An artefact is an orphan if the IP
+// of the pod it serves isn't owned by any Pod or SpiderEndpoint on the node.
+// It's cleaned only if it's found in two consecutive sweeps, so that the ones
+// of the pods being set up are never cleaned. Only the routes via the host
+// veths of coordinator or tagged with networking.RouteProtocolCoordinator are
+// considered, so that the routes of the other components are never cleaned.
+type OrphanSweeper struct {
+	NodeName string
+	// Client lists the SpiderCoordinators, SpiderMultusConfigs, SpiderEndpoints
+	// and the Pods on the node from the informer cache, the Pods are listed by
+	// the constant.SpecNodeNameField index.
+	Client   client.Reader
+	Interval time.Duration
+	// OnCleaned is called once an orphan is cleaned, err is not nil if it fails
+	OnCleaned func(orphan Orphan, err error)
+
+	netlink hostNetlink
+	// loadHostMaps is loadHostMaps if it's nil
+	loadHostMaps func() (hostMaps, error)
+	// suspects is the orphans found by the last sweep
+	suspects map[string]struct{}
+}
+
+// Start sweeps the orphans every s.Interval until the ctx is done.
+func (s *OrphanSweeper) Start(ctx context.Context, logger *zap.Logger) {
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.Sweep(ctx, logger); err != nil {
+			logger.Sugar().Warnf("failed to sweep the coordinator orphans: %v", err)
+		}
+	}, s.Interval)
+}
+
+// Sweep cleans the orphans found by both the last and this sweep.
+func (s *OrphanSweeper) Sweep(ctx context.Context, logger *zap.Logger) error {
+	if s.netlink == nil {
+		s.netlink = &netlink.Handle{}
+	}
+	if s.loadHostMaps == nil {
+		s.loadHostMaps = loadHostMaps
+	}
+
+	tables, err := s.hostRuleTables(ctx)
+	if err != nil {
+		return err
+	}
+
+	liveIPs, err := s.liveIPs(ctx)
+	if err != nil {
+		return err
+	}
+
+	maps, err := s.loadHostMaps()
+	if err != nil {
+		return fmt.Errorf("failed to load the host maps of the ebpf datapath: %w", err)
+	}
+	var endpoints []ebpf.HostEndpoint
+	if maps != nil {
+		defer maps.Close()
+		if endpoints, err = maps.Endpoints(); err != nil {
+			return err
+		}
+	}
+
+	orphans, err := s.findOrphans(tables, liveIPs, endpoints)
+	if err != nil {
+		return err
+	}
+
+	suspects := make(map[string]struct{}, len(orphans))
+	for _, orphan := range orphans {
+		if _, ok := s.suspects[orphan.String()]; !ok {
+			logger.Sugar().Debugf("Found the coordinator orphan %s, clean it if it's still found in the next sweep", orphan)
+			suspects[orphan.String()] = struct{}{}
+			continue
+		}
+
+		err := s.clean(orphan, maps)
+		if err != nil {
+			logger.Sugar().Warnf("failed to clean the coordinator orphan %s: %v", orphan, err)
+			// retry in the next sweep
+			suspects[orphan.String()] = struct{}{}
+		} else {
+			logger.Sugar().Infof("Cleaned the coordinator orphan %s", orphan)
+		}
+
+		if s.OnCleaned != nil {
+			s.OnCleaned(orphan, err)
+		}
+	}
+	s.suspects = suspects
+
+	return nil
+}
+
+// hostRuleTables returns the host rule tables of the SpiderCoordinators and
+// SpiderMultusConfigs, including the default one.
+func (s *OrphanSweeper) hostRuleTables(ctx context.Context) ([]int, error) {
+	tables := []int{defaultHostRuleTable}
+
+	var coordList spiderpoolv2beta1.SpiderCoordinatorList
+	if err := s.Client.List(ctx, &coordList); err != nil {
+		return nil, fmt.Errorf("failed to list SpiderCoordinators: %w", err)
+	}
+	for _, coord := range coordList.Items {
+		if coord.Spec.HostRuleTable != nil {
+			tables = append(tables, *coord.Spec.HostRuleTable)
+		}
+	}
+
+	var smcList spiderpoolv2beta1.SpiderMultusConfigList
+	if err := s.Client.List(ctx, &smcList); err != nil {
+		return nil, fmt.Errorf("failed to list SpiderMultusConfigs: %w", err)
+	}
+	for _, smc := range smcList.Items {
+		if smc.Spec.CoordinatorConfig != nil && smc.Spec.CoordinatorConfig.HostRuleTable != nil {
+			tables = append(tables, *smc.Spec.CoordinatorConfig.HostRuleTable)
+		}
+	}
+
+	slices.Sort(tables)
+	return slices.Compact(tables), nil
+}
+
+// liveIPs returns the IPs of the Pods and SpiderEndpoints on the node, the IPs
+// of the secondary NICs allocated by the other IPAMs are found in the network
+// status annotation of Multus.
+func (s *OrphanSweeper) liveIPs(ctx context.Context) (map[string]struct{}, error) {
+	liveIPs := map[string]struct{}{}
+	addIP := func(ip string) {
+		if parsed, _, err := net.ParseCIDR(ip); err == nil {
+			liveIPs[parsed.String()] = struct{}{}
+		} else if parsed := net.ParseIP(ip); parsed != nil {
+			liveIPs[parsed.String()] = struct{}{}
+		}
+	}
+
+	var podList corev1.PodList
+	if err := s.Client.List(ctx, &podList, client.MatchingFields{constant.SpecNodeNameField: s.NodeName}); err != nil {
+		return nil, fmt.Errorf("failed to list Pods on node %s: %w", s.NodeName, err)
+	}
+	for _, pod := range podList.Items {
+		for _, podIP := range pod.Status.PodIPs {
+			addIP(podIP.IP)
+		}
+
+		if status, ok := pod.Annotations[constant.MultusNetworkStatus]; ok {
+			var networks []netv1.NetworkStatus
+			if err := json.Unmarshal([]byte(status), &networks); err != nil {
+				return nil, fmt.Errorf("failed to parse the network status of Pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+			for _, network := range networks {
+				for _, ip := range network.IPs {
+					addIP(ip)
+				}
+			}
+		}
+	}
+
+	var endpointList spiderpoolv2beta1.SpiderEndpointList
+	if err := s.Client.List(ctx, &endpointList); err != nil {
+		return nil, fmt.Errorf("failed to list SpiderEndpoints: %w", err)
+	}
+	for _, endpoint := range endpointList.Items {
+		if endpoint.Status.Current.Node != s.NodeName {
+			continue
+		}
+		for _, detail := range endpoint.Status.Current.IPs {
+			if detail.IPv4 != nil {
+				addIP(*detail.IPv4)
+			}
+			if detail.IPv6 != nil {
+				addIP(*detail.IPv6)
+			}
+		}
+	}
+
+	return liveIPs, nil
+}
+
+// findOrphans returns the orphans in the order they should be cleaned. The
+// routes via the orphan veths are not returned, since they're removed together
+// with the veths, while the endpoints to them are.
+func (s *OrphanSweeper) findOrphans(tables []int, liveIPs map[string]struct{}, endpoints []ebpf.HostEndpoint) ([]Orphan, error) {
+	links, err := s.netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	linkByIndex := make(map[int]netlink.Link, len(links))
+	for _, link := range links {
+		linkByIndex[link.Attrs().Index] = link
+	}
+
+	isHostVeth := func(index int) bool {
+		link, ok := linkByIndex[index]
+		return ok && link.Type() == "veth" && hostVethNameRegex.MatchString(link.Attrs().Name)
+	}
+
+	// the routes via the veths of the main CNI in the overlay mode are owned
+	// by coordinator only if they're tagged
+	isCoordinatorRoute := func(route netlink.Route) bool {
+		return isHostVeth(route.LinkIndex) || route.Protocol == networking.RouteProtocolCoordinator
+	}
+
+	var routes []netlink.Route
+	for _, table := range tables {
+		tableRoutes, err := s.netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list routes of table %d: %w", table, err)
+		}
+		routes = append(routes, tableRoutes...)
+	}
+
+	// a host veth is live if any of its routes is to a live pod IP, and it's
+	// not managed by coordinator if it has no route in the host rule tables.
+	vethLive := map[int]bool{}
+	var deadRoutes []netlink.Route
+	for _, route := range routes {
+		if !isCoordinatorRoute(route) {
+			continue
+		}
+
+		var podIP net.IP
+		switch {
+		case route.Gw != nil && isHostVeth(route.LinkIndex):
+			// the route of the prefix delegated to the pod
+			podIP = route.Gw
+		case route.Gw == nil && isHostMask(route.Dst):
+			podIP = route.Dst.IP
+		default:
+			continue
+		}
+
+		_, live := liveIPs[podIP.String()]
+		if isHostVeth(route.LinkIndex) {
+			vethLive[route.LinkIndex] = vethLive[route.LinkIndex] || live
+		}
+		if !live {
+			deadRoutes = append(deadRoutes, route)
+		}
+	}
+
+	// the pod IPs are endpoints instead of routes in the ebpf datapath. An
+	// endpoint of the delegated prefix is dead if its NIC has gone or it's a
+	// dead host veth.
+	for _, endpoint := range endpoints {
+		if isHostMask(endpoint.Prefix) && isHostVeth(endpoint.Ifindex) {
+			_, live := liveIPs[endpoint.Prefix.IP.String()]
+			vethLive[endpoint.Ifindex] = vethLive[endpoint.Ifindex] || live
+		}
+	}
+	var deadEndpoints []ebpf.HostEndpoint
+	for _, endpoint := range endpoints {
+		_, live := linkByIndex[endpoint.Ifindex]
+		if isHostMask(endpoint.Prefix) {
+			_, live = liveIPs[endpoint.Prefix.IP.String()]
+		} else if isHostVeth(endpoint.Ifindex) {
+			live = vethLive[endpoint.Ifindex]
+		}
+		if !live {
+			deadEndpoints = append(deadEndpoints, endpoint)
+		}
+	}
+
+	var orphans []Orphan
+	for index, live := range vethLive {
+		if !live {
+			link := linkByIndex[index]
+			orphans = append(orphans, Orphan{Type: OrphanTypeVeth, Name: link.Attrs().Name, link: link})
+		}
+	}
+	slices.SortFunc(orphans, func(a, b Orphan) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for i := range deadRoutes {
+		route := deadRoutes[i]
+		if live, ok := vethLive[route.LinkIndex]; ok && !live {
+			continue
+		}
+		orphans = append(orphans, Orphan{Type: OrphanTypeRoute, Name: routeName(route, linkByIndex), route: &route})
+	}
+
+	for i := range deadEndpoints {
+		endpoint := deadEndpoints[i]
+		name := fmt.Sprintf("%s dev %d", endpoint.Prefix, endpoint.Ifindex)
+		if link, ok := linkByIndex[endpoint.Ifindex]; ok {
+			name = fmt.Sprintf("%s dev %s", endpoint.Prefix, link.Attrs().Name)
+		}
+		orphans = append(orphans, Orphan{Type: OrphanTypeEndpoint, Name: name, endpoint: &endpoint})
+	}
+
+	rules, err := s.netlink.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	for i := range rules {
+		rule := rules[i]
+		// the rule "from all lookup <table>" is shared by all pods
+		if !slices.Contains(tables, rule.Table) || rule.Src != nil || !isHostMask(rule.Dst) {
+			continue
+		}
+		if _, live := liveIPs[rule.Dst.IP.String()]; live {
+			continue
+		}
+		orphans = append(orphans, Orphan{Type: OrphanTypeRule, Name: fmt.Sprintf("to %s lookup %d", rule.Dst, rule.Table), rule: &rule})
+	}
+
+	return orphans, nil
+}
+
+// clean removes the orphan, it's not an error if it has gone.
+func (s *OrphanSweeper) clean(orphan Orphan, maps hostMaps) error {
+	var err error
+	switch orphan.Type {
+	case OrphanTypeVeth:
+		err = s.netlink.LinkDel(orphan.link)
+		var linkNotFoundErr netlink.LinkNotFoundError
+		if errors.As(err, &linkNotFoundErr) {
+			return nil
+		}
+	case OrphanTypeRoute:
+		err = s.netlink.RouteDel(orphan.route)
+	case OrphanTypeEndpoint:
+		err = maps.Delete(orphan.endpoint.Prefix)
+	case OrphanTypeRule:
+		err = s.netlink.RuleDel(orphan.rule)
+	default:
+		return fmt.Errorf("unknown orphan type %s", orphan.Type)
+	}
+
+	// the kernel returns ESRCH for the missing routes
+	if err != nil && !os.IsNotExist(err) && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+
+	return nil
+}
+
+func isHostMask(ipNet *net.IPNet) bool {
+	if ipNet == nil {
+		return false
+	}
+	ones, bits := ipNet.Mask.Size()
+	return bits != 0 && ones == bits
+}
+
+func routeName(route netlink.Route, linkByIndex map[int]netlink.Link) string {
+	name := route.Dst.String()
+	if route.Gw != nil {
+		name += " via " + route.Gw.String()
+	}
+	if link, ok := linkByIndex[route.LinkIndex]; ok {
+		name += " dev " + link.Attrs().Name
+	}
+	return fmt.Sprintf("%s table %d", name, route.Table)
+}
@@ -0,0 +1,373 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"net"
+	"slices"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/networking/ebpf"
+	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+)
+
+type fakeHostNetlink struct {
+	links  []netlink.Link
+	routes []netlink.Route
+	rules  []netlink.Rule
+
+	delErr error
+}
+
+func (f *fakeHostNetlink) LinkList() ([]netlink.Link, error) {
+	return f.links, nil
+}
+
+func (f *fakeHostNetlink) LinkDel(link netlink.Link) error {
+	if f.delErr != nil {
+		return f.delErr
+	}
+	f.links = slices.DeleteFunc(f.links, func(l netlink.Link) bool { return l.Attrs().Index == link.Attrs().Index })
+	f.routes = slices.DeleteFunc(f.routes, func(r netlink.Route) bool { return r.LinkIndex == link.Attrs().Index })
+	return nil
+}
+
+func (f *fakeHostNetlink) RouteListFiltered(_ int, filter *netlink.Route, _ uint64) ([]netlink.Route, error) {
+	var routes []netlink.Route
+	for _, r := range f.routes {
+		if r.Table == filter.Table {
+			routes = append(routes, r)
+		}
+	}
+	return routes, nil
+}
+
+func (f *fakeHostNetlink) RouteDel(route *netlink.Route) error {
+	if f.delErr != nil {
+		return f.delErr
+	}
+	n := len(f.routes)
+	f.routes = slices.DeleteFunc(f.routes, func(r netlink.Route) bool { return r.Equal(*route) })
+	if n == len(f.routes) {
+		return syscall.ESRCH
+	}
+	return nil
+}
+
+func (f *fakeHostNetlink) RuleList(_ int) ([]netlink.Rule, error) {
+	return f.rules, nil
+}
+
+func (f *fakeHostNetlink) RuleDel(rule *netlink.Rule) error {
+	if f.delErr != nil {
+		return f.delErr
+	}
+	f.rules = slices.DeleteFunc(f.rules, func(r netlink.Rule) bool { return r.Table == rule.Table && r.Dst.String() == rule.Dst.String() })
+	return nil
+}
+
+type fakeHostMaps struct {
+	endpoints []ebpf.HostEndpoint
+}
+
+func (f *fakeHostMaps) Endpoints() ([]ebpf.HostEndpoint, error) {
+	return f.endpoints, nil
+}
+
+func (f *fakeHostMaps) Delete(prefix *net.IPNet) error {
+	f.endpoints = slices.DeleteFunc(f.endpoints, func(e ebpf.HostEndpoint) bool { return e.Prefix.String() == prefix.String() })
+	return nil
+}
+
+func (f *fakeHostMaps) Close() error {
+	return nil
+}
+
+var _ = Describe("Coordinator orphan sweeper", Label("coordinator_sweeper"), func() {
+	const nodeName = "node1"
+
+	var ctx context.Context
+	var host *fakeHostNetlink
+	var maps *fakeHostMaps
+	var sweeper *OrphanSweeper
+	var cleaned []string
+
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		ipNet.IP = ip
+		return ipNet
+	}
+
+	newVeth := func(index int, name string) netlink.Link {
+		return &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Index: index, Name: name}}
+	}
+
+	newRoute := func(index, table int, dst, gw string) netlink.Route {
+		route := netlink.Route{LinkIndex: index, Table: table, Dst: mustParseCIDR(dst)}
+		if gw != "" {
+			route.Gw = net.ParseIP(gw)
+		}
+		return route
+	}
+
+	// tagged tags the route added by coordinator via the veth of the main CNI
+	tagged := func(route netlink.Route) netlink.Route {
+		route.Protocol = networking.RouteProtocolCoordinator
+		return route
+	}
+
+	newRule := func(table int, dst string) netlink.Rule {
+		rule := *netlink.NewRule()
+		rule.Table = table
+		if dst != "" {
+			rule.Dst = mustParseCIDR(dst)
+		}
+		return rule
+	}
+
+	newSweeper := func(objs ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(spiderpoolv2beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithIndex(&corev1.Pod{}, constant.SpecNodeNameField, func(raw client.Object) []string {
+				return []string{raw.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+
+		cleaned = nil
+		sweeper = &OrphanSweeper{
+			NodeName: nodeName,
+			Client:   fakeClient,
+			OnCleaned: func(orphan Orphan, err error) {
+				if err == nil {
+					cleaned = append(cleaned, orphan.String())
+				}
+			},
+			netlink: host,
+			loadHostMaps: func() (hostMaps, error) {
+				if maps == nil {
+					return nil, nil
+				}
+				return maps, nil
+			},
+		}
+	}
+
+	newPod := func(name, node string, ips ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.PodSpec{NodeName: node},
+		}
+		for _, ip := range ips {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+		}
+		return pod
+	}
+
+	newEndpoint := func(name, node string, ipv4 string) *spiderpoolv2beta1.SpiderEndpoint {
+		return &spiderpoolv2beta1.SpiderEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status: spiderpoolv2beta1.WorkloadEndpointStatus{Current: spiderpoolv2beta1.PodIPAllocation{
+				Node: node,
+				IPs:  []spiderpoolv2beta1.IPAllocationDetail{{NIC: "eth0", IPv4: ptr.To(ipv4)}},
+			}},
+		}
+	}
+
+	sweep := func() {
+		Expect(sweeper.Sweep(ctx, zap.NewNop())).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		maps = nil
+		host = &fakeHostNetlink{
+			links: []netlink.Link{
+				newVeth(10, "veth0123456789a"),
+				newVeth(11, "vethabcdef01234"),
+				// the veth of the other CNIs without routes in the host rule table
+				newVeth(12, "veth12345678"),
+				&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 13, Name: "cali0123456789a"}},
+			},
+			routes: []netlink.Route{
+				newRoute(10, 500, "10.6.0.10/32", ""),
+				newRoute(10, 500, "10.7.0.0/24", "10.6.0.10"),
+				newRoute(11, 500, "10.6.0.11/32", ""),
+				newRoute(11, 500, "fd00:6::11/128", ""),
+				// the overlay mode routes via the veth of the main CNI
+				tagged(newRoute(13, 500, "10.6.0.12/32", "")),
+				tagged(newRoute(13, 500, "10.6.0.13/32", "")),
+				// not owned by coordinator
+				newRoute(13, 500, "0.0.0.0/0", "10.233.0.1"),
+				newRoute(13, 500, "10.6.0.14/32", ""),
+				newRoute(12, 254, "10.6.0.20/32", ""),
+			},
+			rules: []netlink.Rule{
+				newRule(500, ""),
+				newRule(500, "10.6.0.11/32"),
+				newRule(500, "10.6.0.30/32"),
+				newRule(100, "10.6.0.31/32"),
+			},
+		}
+	})
+
+	It("cleans the orphans found in two consecutive sweeps", func() {
+		newSweeper(
+			newPod("pod1", nodeName, "10.6.0.11", "fd00:6::11"),
+			// the IP of the secondary NIC is found in the network status
+			func() *corev1.Pod {
+				pod := newPod("pod2", nodeName)
+				pod.Annotations = map[string]string{
+					constant.MultusNetworkStatus: `[{"name":"default/macvlan","interface":"net1","ips":["10.6.0.12"]}]`,
+				}
+				return pod
+			}(),
+			// the IP on the other node is not live
+			newPod("pod3", "node2", "10.6.0.10"),
+		)
+
+		sweep()
+		Expect(cleaned).To(BeEmpty())
+
+		sweep()
+		Expect(cleaned).To(Equal([]string{
+			"veth veth0123456789a",
+			"route 10.6.0.13/32 dev cali0123456789a table 500",
+			"rule to 10.6.0.30/32 lookup 500",
+		}))
+		Expect(host.links).To(HaveLen(3))
+		Expect(host.routes).To(HaveLen(6))
+		Expect(host.rules).To(HaveLen(3))
+
+		sweep()
+		Expect(cleaned).To(HaveLen(3))
+	})
+
+	It("never cleans the artefacts of the IPs in the SpiderEndpoints on the node", func() {
+		newSweeper(
+			newEndpoint("pod1", nodeName, "10.6.0.10/24"),
+			newEndpoint("pod2", nodeName, "10.6.0.13/24"),
+			newEndpoint("pod3", "node2", "10.6.0.30/24"),
+		)
+
+		sweep()
+		sweep()
+		Expect(cleaned).To(ConsistOf(
+			"veth vethabcdef01234",
+			"route 10.6.0.12/32 dev cali0123456789a table 500",
+			"rule to 10.6.0.11/32 lookup 500",
+			"rule to 10.6.0.30/32 lookup 500",
+		))
+	})
+
+	It("sweeps the host rule tables of the SpiderCoordinators and SpiderMultusConfigs", func() {
+		host.routes = append(host.routes, tagged(newRoute(13, 600, "10.6.0.40/32", "")), tagged(newRoute(13, 700, "10.6.0.41/32", "")))
+		newSweeper(
+			newPod("pod1", nodeName, "10.6.0.10", "10.6.0.11", "fd00:6::11", "10.6.0.12", "10.6.0.13", "10.6.0.30"),
+			&spiderpoolv2beta1.SpiderCoordinator{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       spiderpoolv2beta1.CoordinatorSpec{HostRuleTable: ptr.To(600)},
+			},
+			&spiderpoolv2beta1.SpiderMultusConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "macvlan"},
+				Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+					CoordinatorConfig: &spiderpoolv2beta1.CoordinatorSpec{HostRuleTable: ptr.To(700)},
+				},
+			},
+		)
+
+		sweep()
+		sweep()
+		Expect(cleaned).To(ConsistOf(
+			"route 10.6.0.40/32 dev cali0123456789a table 600",
+			"route 10.6.0.41/32 dev cali0123456789a table 700",
+		))
+	})
+
+	It("cleans the endpoints of the ebpf datapath", func() {
+		newEndpoint := func(prefix string, ifindex int) ebpf.HostEndpoint {
+			_, ipNet, err := net.ParseCIDR(prefix)
+			Expect(err).NotTo(HaveOccurred())
+			return ebpf.HostEndpoint{Prefix: ipNet, Ifindex: ifindex}
+		}
+
+		// the veth of the ebpf datapath has no route in the host rule table
+		host.links = append(host.links, newVeth(14, "veth14abcdef012"), newVeth(15, "veth15abcdef012"))
+		maps = &fakeHostMaps{endpoints: []ebpf.HostEndpoint{
+			newEndpoint("10.6.0.50/32", 14),
+			newEndpoint("10.8.0.0/24", 14),
+			newEndpoint("10.6.0.51/32", 15),
+			newEndpoint("10.9.0.0/24", 15),
+			// the veth has gone
+			newEndpoint("10.6.0.52/32", 16),
+			// the overlay mode endpoints to the veth of the main CNI
+			newEndpoint("10.6.0.53/32", 13),
+			newEndpoint("10.6.0.54/32", 13),
+		}}
+		newSweeper(newPod("pod1", nodeName, "10.6.0.10", "10.6.0.11", "fd00:6::11", "10.6.0.12", "10.6.0.13", "10.6.0.30", "10.6.0.51", "10.6.0.53"))
+
+		sweep()
+		Expect(cleaned).To(BeEmpty())
+
+		sweep()
+		Expect(cleaned).To(Equal([]string{
+			"veth veth14abcdef012",
+			"endpoint 10.6.0.50/32 dev veth14abcdef012",
+			"endpoint 10.8.0.0/24 dev veth14abcdef012",
+			"endpoint 10.6.0.52/32 dev 16",
+			"endpoint 10.6.0.54/32 dev cali0123456789a",
+		}))
+		Expect(maps.endpoints).To(HaveLen(3))
+	})
+
+	It("retries the orphans failed to clean", func() {
+		newSweeper()
+		host.delErr = syscall.EBUSY
+
+		var failed []string
+		sweeper.OnCleaned = func(orphan Orphan, err error) {
+			Expect(err).To(MatchError(syscall.EBUSY))
+			failed = append(failed, orphan.String())
+		}
+
+		sweep()
+		sweep()
+		Expect(failed).NotTo(BeEmpty())
+
+		failed = nil
+		sweep()
+		Expect(failed).NotTo(BeEmpty())
+	})
+
+	It("cleans nothing if the Pods fail to be listed", func() {
+		newSweeper(func() *corev1.Pod {
+			pod := newPod("pod1", nodeName)
+			pod.Annotations = map[string]string{constant.MultusNetworkStatus: "invalid"}
+			return pod
+		}())
+
+		Expect(sweeper.Sweep(ctx, zap.NewNop())).NotTo(Succeed())
+		Expect(sweeper.Sweep(ctx, zap.NewNop())).NotTo(Succeed())
+		Expect(cleaned).To(BeEmpty())
+	})
+})
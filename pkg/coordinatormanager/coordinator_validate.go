@@ -9,16 +9,20 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/sys/unix"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/strings/slices"
 
+	coordinator_cmd "github.com/spidernet-io/spiderpool/cmd/coordinator/cmd"
 	"github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 )
 
 var (
 	podCIDRTypeField     *field.Path = field.NewPath("spec").Child("podCIDRType")
+	datapathField        *field.Path = field.NewPath("spec").Child("datapath")
 	extraCIDRField       *field.Path = field.NewPath("spec").Child("extraCIDR")
+	hostRuleTableField   *field.Path = field.NewPath("spec").Child("hostRuleTable")
 	podMACPrefixField    *field.Path = field.NewPath("spec").Child("podMACPrefix")
 	podRPFilterField     *field.Path = field.NewPath("spec").Child("podRPFilter")
 	policyRoutesField    *field.Path = field.NewPath("spec").Child("policyRoutes")
@@ -67,6 +71,14 @@ func ValidateCoordinatorSpec(spec *spiderpoolv2beta1.CoordinatorSpec, requireOpt
 		}
 	}
 
+	if spec.Datapath != nil && *spec.Datapath != "" && !slices.Contains(coordinator_cmd.SupportedDatapaths, *spec.Datapath) {
+		return field.NotSupported(
+			datapathField,
+			*spec.Datapath,
+			coordinator_cmd.SupportedDatapaths,
+		)
+	}
+
 	if err := validateCoordinatorExtraCIDR(spec.HijackCIDR); err != nil {
 		return err
 	}
@@ -77,6 +89,10 @@ func ValidateCoordinatorSpec(spec *spiderpoolv2beta1.CoordinatorSpec, requireOpt
 		return err
 	}
 
+	if err := ValidateCoordinatorHostRuleTable(hostRuleTableField, spec.HostRuleTable); err != nil {
+		return err
+	}
+
 	if spec.TxQueueLen != nil && *spec.TxQueueLen < 0 {
 		return field.Invalid(txQueueLenField, *spec.TxQueueLen, "txQueueLen can't be less than 0")
 	}
@@ -120,6 +136,22 @@ func validateCoordinatorPodCIDRType(t string) *field.Error {
 	return nil
 }
 
+// ValidateCoordinatorHostRuleTable rejects the unspecified table 0 and the
+// reserved tables default(253), main(254) and local(255), coordinator adds
+// the routes to the pods in the host rule table, and the orphan sweeper
+// deletes the ones of the gone pods from it.
+func ValidateCoordinatorHostRuleTable(fldPath *field.Path, table *int) *field.Error {
+	if table == nil {
+		return nil
+	}
+
+	if *table <= 0 || (*table >= unix.RT_TABLE_DEFAULT && *table <= unix.RT_TABLE_LOCAL) {
+		return field.Invalid(fldPath, *table, "hostRuleTable must be greater than 0, and can't be the reserved table 253, 254 or 255")
+	}
+
+	return nil
+}
+
 func validateCoordinatorExtraCIDR(cidrs []string) *field.Error {
 	if len(cidrs) == 0 {
 		return nil
@@ -0,0 +1,42 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var _ = Describe("Coordinator validate", Label("coordinator_validate"), func() {
+	newCoordinator := func(hostRuleTable int) *spiderpoolv2beta1.SpiderCoordinator {
+		return &spiderpoolv2beta1.SpiderCoordinator{
+			Spec: spiderpoolv2beta1.CoordinatorSpec{
+				PodCIDRType:   ptr.To("auto"),
+				PodRPFilter:   ptr.To(0),
+				HostRuleTable: ptr.To(hostRuleTable),
+			},
+		}
+	}
+
+	It("accepts the custom host rule table", func() {
+		Expect(validateCreateCoordinator(newCoordinator(600))).To(BeEmpty())
+	})
+
+	DescribeTable("rejects the unspecified and reserved host rule tables",
+		func(hostRuleTable int) {
+			errs := validateCreateCoordinator(newCoordinator(hostRuleTable))
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.hostRuleTable"))
+		},
+		Entry("unspecified", 0),
+		Entry("negative", -1),
+		Entry("default", 253),
+		Entry("main", 254),
+		Entry("local", 255),
+	)
+})
@@ -0,0 +1,260 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/cilium/cilium/pkg/ipam/option"
+	ciliumv2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/k8s/client/clientset/versioned"
+	cilium_externalversions "github.com/cilium/cilium/pkg/k8s/client/informers/externalversions"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	stringutil "github.com/spidernet-io/spiderpool/pkg/utils/string"
+)
+
+const (
+	calicoBlockAffinityCRDName = "blockaffinities.crd.projectcalico.org"
+	ciliumNodeCRDName          = "ciliumnodes.cilium.io"
+
+	calicoBlockAffinityConfirmed = "confirmed"
+)
+
+var calicoBlockAffinityGVK = schema.GroupVersionKind{Group: "crd.projectcalico.org", Version: "v1", Kind: "BlockAffinity"}
+
+// updateNodePodCIDRs tracks the pod CIDRs allocated to each node, only the
+// podCIDRType whose IPAM allocates the pod CIDRs by node is supported.
+func (cc *CoordinatorController) updateNodePodCIDRs(ctx context.Context, podCIDRType string, coordinator *spiderpoolv2beta1.SpiderCoordinator) error {
+	var nodePodCIDRs map[string][]string
+	var err error
+	switch podCIDRType {
+	case cluster, flannel, antrea:
+		nodePodCIDRs, err = cc.fetchNodeSpecPodCIDRs(ctx)
+	case calico:
+		nodePodCIDRs, err = cc.fetchCalicoBlockPodCIDRs(ctx)
+	case cilium:
+		nodePodCIDRs, err = cc.fetchCiliumNodePodCIDRs(ctx)
+	default:
+		coordinator.Status.NodePodCIDRs = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	coordinator.Status.NodePodCIDRs = convertNodePodCIDRs(nodePodCIDRs)
+	return nil
+}
+
+func (cc *CoordinatorController) fetchNodeSpecPodCIDRs(ctx context.Context) (map[string][]string, error) {
+	var nodeList corev1.NodeList
+	if err := cc.APIReader.List(ctx, &nodeList); err != nil {
+		return nil, err
+	}
+
+	nodePodCIDRs := make(map[string][]string, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodePodCIDRs[nodeList.Items[i].Name] = podCIDRsOfNode(&nodeList.Items[i])
+	}
+
+	return nodePodCIDRs, nil
+}
+
+// fetchCalicoBlockPodCIDRs fetches the Calico IPAM blocks affine to each node.
+func (cc *CoordinatorController) fetchCalicoBlockPodCIDRs(ctx context.Context) (map[string][]string, error) {
+	items, err := cc.listUnstructured(ctx, calicoBlockAffinityGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	return calicoBlockAffinityPodCIDRs(items), nil
+}
+
+// fetchCiliumNodePodCIDRs fetches the pod CIDRs of the CiliumNodes in the
+// cluster-pool and multi-pool IPAM modes, and the ones of the node spec in the
+// kubernetes IPAM mode.
+func (cc *CoordinatorController) fetchCiliumNodePodCIDRs(ctx context.Context) (map[string][]string, error) {
+	ns, name := stringutil.ParseNsAndName(cc.CiliumConfigMap)
+	ccm, err := cc.ConfigmapLister.ConfigMaps(ns).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ccm.Data["ipam"] {
+	case option.IPAMClusterPool, option.IPAMClusterPoolV2, option.IPAMMultiPool:
+		if cc.CiliumNodeLister == nil {
+			return nil, fmt.Errorf("CiliumNodeLister is unexpected to nil, Is the CRD %s available?", ciliumNodeCRDName)
+		}
+
+		ciliumNodes, err := cc.CiliumNodeLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+
+		return ciliumNodePodCIDRs(ciliumNodes), nil
+	case option.IPAMKubernetes:
+		return cc.fetchNodeSpecPodCIDRs(ctx)
+	default:
+		return nil, nil
+	}
+}
+
+func (cc *CoordinatorController) WatchCalicoBlockAffinities(ctx context.Context, logger *zap.Logger) error {
+	var crd apiextensionsv1.CustomResourceDefinition
+	err := cc.APIReader.Get(ctx, types.NamespacedName{Name: calicoBlockAffinityCRDName}, &crd)
+	if err != nil {
+		logger.Sugar().Warnf("CRD %s no found, can't watch calico blockaffinities resource", calicoBlockAffinityCRDName)
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(calicoBlockAffinityGVK)
+	c, err := newPodCIDRSourceController(constant.KindSpiderCoordinator+"-blockaffinity", "CalicoBlockAffinity", cc.Manager, cc.Workqueue, obj)
+	if err != nil {
+		return err
+	}
+
+	startPodCIDRController(ctx, logger, "Calico BlockAffinity", c)
+	return nil
+}
+
+func (cc *CoordinatorController) WatchCiliumNodes(ctx context.Context, logger *zap.Logger) error {
+	var crd apiextensionsv1.CustomResourceDefinition
+	err := cc.APIReader.Get(ctx, types.NamespacedName{Name: ciliumNodeCRDName}, &crd)
+	if err != nil {
+		logger.Sugar().Warnf("CRD %s no found, can't watch ciliumnodes resource", ciliumNodeCRDName)
+		return nil
+	}
+
+	InformerLogger.Sugar().Infof("Init CiliumNodes Informer")
+	clientSet := versioned.NewForConfigOrDie(ctrl.GetConfigOrDie())
+	ciliumInformer := cilium_externalversions.NewSharedInformerFactory(clientSet, cc.ResyncPeriod)
+	enqueue := func(obj interface{}) {
+		ciliumNode, ok := obj.(*ciliumv2.CiliumNode)
+		if !ok {
+			return
+		}
+
+		cc.Workqueue.Add(fmt.Sprintf("CiliumNode/%v", ciliumNode.Name))
+		InformerLogger.With(zap.String("CiliumNode", ciliumNode.Name)).Debug(messageEnqueueCoordiantor)
+	}
+
+	_, err = ciliumInformer.Cilium().V2().CiliumNodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, ok1 := oldObj.(*ciliumv2.CiliumNode)
+			newNode, ok2 := newObj.(*ciliumv2.CiliumNode)
+			if ok1 && ok2 && slices.Equal(ciliumNodeCIDRs(oldNode), ciliumNodeCIDRs(newNode)) {
+				return
+			}
+			enqueue(newObj)
+		},
+		DeleteFunc: enqueue,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Starting CiliumNode Informer")
+	cc.CiliumNodeLister = ciliumInformer.Cilium().V2().CiliumNodes().Lister()
+	cc.CiliumNodesSynced = ciliumInformer.Cilium().V2().CiliumNodes().Informer().HasSynced
+	ciliumInformer.Start(ctx.Done())
+	return nil
+}
+
+// calicoBlockAffinityPodCIDRs groups the CIDRs of the confirmed Calico block
+// affinities by node.
+func calicoBlockAffinityPodCIDRs(blockAffinities []unstructured.Unstructured) map[string][]string {
+	nodePodCIDRs := map[string][]string{}
+	for _, item := range blockAffinities {
+		node, _, _ := unstructured.NestedString(item.Object, "spec", "node")
+		cidr, _, _ := unstructured.NestedString(item.Object, "spec", "cidr")
+		state, _, _ := unstructured.NestedString(item.Object, "spec", "state")
+		deleted, _, _ := unstructured.NestedString(item.Object, "spec", "deleted")
+		if node == "" || cidr == "" || state != calicoBlockAffinityConfirmed || deleted == "true" {
+			continue
+		}
+
+		if !slices.Contains(nodePodCIDRs[node], cidr) {
+			nodePodCIDRs[node] = append(nodePodCIDRs[node], cidr)
+		}
+	}
+
+	return nodePodCIDRs
+}
+
+func ciliumNodePodCIDRs(ciliumNodes []*ciliumv2.CiliumNode) map[string][]string {
+	nodePodCIDRs := make(map[string][]string, len(ciliumNodes))
+	for _, n := range ciliumNodes {
+		if n.DeletionTimestamp != nil {
+			continue
+		}
+		nodePodCIDRs[n.Name] = ciliumNodeCIDRs(n)
+	}
+
+	return nodePodCIDRs
+}
+
+// ciliumNodeCIDRs returns the pod CIDRs of the CiliumNode in the cluster-pool
+// IPAM mode, and the ones allocated from the pools in the multi-pool IPAM mode.
+func ciliumNodeCIDRs(n *ciliumv2.CiliumNode) []string {
+	cidrs := append([]string{}, n.Spec.IPAM.PodCIDRs...)
+	for _, pool := range n.Spec.IPAM.Pools.Allocated {
+		for _, cidr := range pool.CIDRs {
+			if !slices.Contains(cidrs, string(cidr)) {
+				cidrs = append(cidrs, string(cidr))
+			}
+		}
+	}
+
+	return cidrs
+}
+
+// convertNodePodCIDRs sorts the pod CIDRs by node name to admit they have
+// changed, the nodes without any pod CIDR are skipped.
+func convertNodePodCIDRs(nodePodCIDRs map[string][]string) []spiderpoolv2beta1.NodePodCIDR {
+	result := make([]spiderpoolv2beta1.NodePodCIDR, 0, len(nodePodCIDRs))
+	for node, cidrs := range nodePodCIDRs {
+		if len(cidrs) == 0 {
+			continue
+		}
+		result = append(result, spiderpoolv2beta1.NodePodCIDR{NodeName: node, PodCIDRs: cidrs})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeName < result[j].NodeName
+	})
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// NodePodCIDRs returns the pod CIDRs allocated to the node tracked by the
+// SpiderCoordinator.
+func NodePodCIDRs(coordinator *spiderpoolv2beta1.SpiderCoordinator, nodeName string) []string {
+	for _, n := range coordinator.Status.NodePodCIDRs {
+		if n.NodeName == nodeName {
+			return n.PodCIDRs
+		}
+	}
+
+	return nil
+}
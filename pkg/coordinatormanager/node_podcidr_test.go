@@ -0,0 +1,77 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
+	ciliumv2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var _ = Describe("Node podCIDR", Label("node_podcidr"), func() {
+	newBlockAffinity := func(node, cidr, state, deleted string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"node": node, "cidr": cidr, "state": state, "deleted": deleted},
+		}}
+	}
+
+	It("groups the confirmed Calico blocks by node", func() {
+		nodePodCIDRs := calicoBlockAffinityPodCIDRs([]unstructured.Unstructured{
+			newBlockAffinity("node1", "10.233.64.0/26", calicoBlockAffinityConfirmed, "false"),
+			newBlockAffinity("node2", "10.233.64.64/26", calicoBlockAffinityConfirmed, "false"),
+			newBlockAffinity("node1", "10.233.64.128/26", calicoBlockAffinityConfirmed, "false"),
+			newBlockAffinity("node1", "10.233.64.192/26", "pending", "false"),
+			newBlockAffinity("node2", "10.233.65.0/26", calicoBlockAffinityConfirmed, "true"),
+		})
+		Expect(nodePodCIDRs).To(Equal(map[string][]string{
+			"node1": {"10.233.64.0/26", "10.233.64.128/26"},
+			"node2": {"10.233.64.64/26"},
+		}))
+	})
+
+	It("fetches the pod CIDRs of the CiliumNodes", func() {
+		clusterPool := &ciliumv2.CiliumNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Spec: ciliumv2.NodeSpec{IPAM: ipamTypes.IPAMSpec{
+				PodCIDRs: []string{"10.0.1.0/24"},
+			}},
+		}
+		multiPool := &ciliumv2.CiliumNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+			Spec: ciliumv2.NodeSpec{IPAM: ipamTypes.IPAMSpec{
+				Pools: ipamTypes.IPAMPoolSpec{Allocated: []ipamTypes.IPAMPoolAllocation{
+					{Pool: "default", CIDRs: []ipamTypes.IPAMPodCIDR{"10.10.0.0/27", "10.10.0.32/27"}},
+					{Pool: "blue", CIDRs: []ipamTypes.IPAMPodCIDR{"10.20.0.0/27"}},
+				}},
+			}},
+		}
+
+		Expect(ciliumNodePodCIDRs([]*ciliumv2.CiliumNode{clusterPool, multiPool})).To(Equal(map[string][]string{
+			"node1": {"10.0.1.0/24"},
+			"node2": {"10.10.0.0/27", "10.10.0.32/27", "10.20.0.0/27"},
+		}))
+	})
+
+	It("sorts the pod CIDRs by node name", func() {
+		nodePodCIDRs := convertNodePodCIDRs(map[string][]string{
+			"node2": {"10.244.2.0/24"},
+			"node1": {"10.244.1.0/24", "fd00::100/120"},
+			"node3": nil,
+		})
+		Expect(nodePodCIDRs).To(Equal([]spiderpoolv2beta1.NodePodCIDR{
+			{NodeName: "node1", PodCIDRs: []string{"10.244.1.0/24", "fd00::100/120"}},
+			{NodeName: "node2", PodCIDRs: []string{"10.244.2.0/24"}},
+		}))
+		Expect(convertNodePodCIDRs(map[string][]string{"node1": nil})).To(BeNil())
+
+		coord := &spiderpoolv2beta1.SpiderCoordinator{Status: spiderpoolv2beta1.CoordinatorStatus{NodePodCIDRs: nodePodCIDRs}}
+		Expect(NodePodCIDRs(coord, "node2")).To(Equal([]string{"10.244.2.0/24"}))
+		Expect(NodePodCIDRs(coord, "node3")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,485 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+const (
+	flannelConfigMap  = "kube-flannel-cfg"
+	flannelNetConfKey = "net-conf.json"
+
+	ovnKubeConfigMap  = "ovnkube-config"
+	ovnKubeNetCIDRKey = "net_cidr"
+
+	antreaIPPoolCRDName  = "ippools.crd.antrea.io"
+	kubeOVNSubnetCRDName = "subnets.kubeovn.io"
+
+	weaveDaemonSet           = "weave-net"
+	weaveIPAllocRangeEnv     = "IPALLOC_RANGE"
+	weaveDefaultIPAllocRange = "10.32.0.0/12"
+)
+
+var (
+	flannelNamespaces = []string{"kube-flannel", metav1.NamespaceSystem}
+	ovnKubeNamespaces = []string{"ovn-kubernetes", "openshift-ovn-kubernetes", metav1.NamespaceSystem}
+
+	antreaIPPoolGVK  = schema.GroupVersionKind{Group: "crd.antrea.io", Version: "v1beta1", Kind: "IPPool"}
+	kubeOVNSubnetGVK = schema.GroupVersionKind{Group: "kubeovn.io", Version: "v1", Kind: "Subnet"}
+)
+
+// isPodCIDRConfigMap reports whether the podCIDR of the cluster may be
+// fetched from the ConfigMap.
+func isPodCIDRConfigMap(name string) bool {
+	switch name {
+	case ciliumConfig, kubeadmConfigMap, flannelConfigMap, ovnKubeConfigMap:
+		return true
+	default:
+		return false
+	}
+}
+
+func (cc *CoordinatorController) updatePodCIDRByBackend(ctx context.Context, podCIDRType string, k8sPodCIDR []string, coordinator *spiderpoolv2beta1.SpiderCoordinator) error {
+	var podCIDR []string
+	var err error
+	switch podCIDRType {
+	case flannel:
+		podCIDR, err = cc.fetchFlannelPodCIDR(ctx, k8sPodCIDR)
+	case antrea:
+		podCIDR, err = cc.fetchAntreaPodCIDR(ctx, k8sPodCIDR)
+	case ovnKubernetes:
+		podCIDR, err = cc.fetchOVNKubernetesPodCIDR(k8sPodCIDR)
+	case kubeOVN:
+		podCIDR, err = cc.fetchKubeOVNPodCIDR(ctx)
+	case weave:
+		podCIDR, err = cc.fetchWeavePodCIDR(ctx)
+	default:
+		return fmt.Errorf("%w: unsupported podCIDRType %s", constant.ErrWrongInput, podCIDRType)
+	}
+	if err != nil {
+		return err
+	}
+
+	InformerLogger.Sugar().Debugf("%s podCIDR: %v", podCIDRType, podCIDR)
+	if coordinator.Status.Phase == Synced && reflect.DeepEqual(coordinator.Status.OverlayPodCIDR, podCIDR) {
+		return nil
+	}
+
+	coordinator.Status.OverlayPodCIDR = podCIDR
+	return nil
+}
+
+// fetchFlannelPodCIDR fetches the podCIDR from the net-conf.json of the
+// flannel ConfigMap, and then from the cluster podCIDR or the podCIDR of
+// the Nodes if the ConfigMap is unavailable.
+func (cc *CoordinatorController) fetchFlannelPodCIDR(ctx context.Context, k8sPodCIDR []string) ([]string, error) {
+	cm, err := cc.getConfigMapInNamespaces(flannelConfigMap, flannelNamespaces)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if cm != nil {
+		podCIDR, err := parseFlannelNetConf(cm.Data[flannelNetConfKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s of ConfigMap %s/%s: %w", flannelNetConfKey, cm.Namespace, cm.Name, err)
+		}
+		if len(podCIDR) != 0 {
+			return podCIDR, nil
+		}
+	}
+
+	if len(k8sPodCIDR) != 0 {
+		return k8sPodCIDR, nil
+	}
+
+	var nodeList corev1.NodeList
+	if err := cc.APIReader.List(ctx, &nodeList); err != nil {
+		return nil, err
+	}
+
+	podCIDR := nodePodCIDRs(nodeList.Items)
+	if len(podCIDR) == 0 {
+		return nil, fmt.Errorf("unable to fetch the podCIDR of flannel from ConfigMap %s, the cluster or the Nodes", flannelConfigMap)
+	}
+
+	return podCIDR, nil
+}
+
+// fetchAntreaPodCIDR fetches the cluster podCIDR used by the Node IPAM of
+// Antrea, and the CIDRs of the Antrea IPPools used by its flexible IPAM.
+func (cc *CoordinatorController) fetchAntreaPodCIDR(ctx context.Context, k8sPodCIDR []string) ([]string, error) {
+	podCIDR := append([]string{}, k8sPodCIDR...)
+
+	items, err := cc.listUnstructured(ctx, antreaIPPoolGVK)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return podCIDR, nil
+		}
+		return nil, err
+	}
+
+	for _, item := range items {
+		for _, cidr := range antreaIPPoolCIDRs(item) {
+			if !slices.Contains(podCIDR, cidr) {
+				podCIDR = append(podCIDR, cidr)
+			}
+		}
+	}
+
+	return podCIDR, nil
+}
+
+// fetchOVNKubernetesPodCIDR fetches the podCIDR from the net_cidr of the
+// ovnkube-config ConfigMap, and then from the cluster podCIDR.
+func (cc *CoordinatorController) fetchOVNKubernetesPodCIDR(k8sPodCIDR []string) ([]string, error) {
+	cm, err := cc.getConfigMapInNamespaces(ovnKubeConfigMap, ovnKubeNamespaces)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if cm != nil {
+		podCIDR, err := parseOVNKubeNetCIDR(cm.Data[ovnKubeNetCIDRKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s of ConfigMap %s/%s: %w", ovnKubeNetCIDRKey, cm.Namespace, cm.Name, err)
+		}
+		if len(podCIDR) != 0 {
+			return podCIDR, nil
+		}
+	}
+
+	if len(k8sPodCIDR) == 0 {
+		return nil, fmt.Errorf("unable to fetch the podCIDR of ovn-kubernetes from ConfigMap %s or the cluster", ovnKubeConfigMap)
+	}
+
+	return k8sPodCIDR, nil
+}
+
+// fetchKubeOVNPodCIDR fetches the CIDRs of the Kube-OVN Subnets served by
+// the default OVN provider, the ones of the attachment networks are skipped.
+func (cc *CoordinatorController) fetchKubeOVNPodCIDR(ctx context.Context) ([]string, error) {
+	items, err := cc.listUnstructured(ctx, kubeOVNSubnetGVK)
+	if err != nil {
+		InformerLogger.Error("failed to get kube-ovn subnets", zap.Error(err))
+		return nil, err
+	}
+
+	podCIDR := make([]string, 0, len(items))
+	for _, item := range items {
+		for _, cidr := range kubeOVNSubnetCIDRs(item) {
+			if !slices.Contains(podCIDR, cidr) {
+				podCIDR = append(podCIDR, cidr)
+			}
+		}
+	}
+
+	return podCIDR, nil
+}
+
+// fetchWeavePodCIDR fetches the podCIDR from the IPALLOC_RANGE env of the
+// weave-net DaemonSet.
+func (cc *CoordinatorController) fetchWeavePodCIDR(ctx context.Context) ([]string, error) {
+	var ds appsv1.DaemonSet
+	err := cc.APIReader.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: weaveDaemonSet}, &ds)
+	if err != nil {
+		return nil, err
+	}
+
+	cidr := weaveIPAllocRange(&ds)
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return nil, fmt.Errorf("invalid %s of DaemonSet %s/%s: %w", weaveIPAllocRangeEnv, ds.Namespace, ds.Name, err)
+	}
+
+	return []string{cidr}, nil
+}
+
+func (cc *CoordinatorController) getConfigMapInNamespaces(name string, namespaces []string) (*corev1.ConfigMap, error) {
+	var err error
+	for _, ns := range namespaces {
+		var cm *corev1.ConfigMap
+		cm, err = cc.ConfigmapLister.ConfigMaps(ns).Get(name)
+		if err == nil {
+			return cm, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// listUnstructured lists the resources of the CNI without the typed client,
+// sorted by the creation time to admit the podCIDR has changed.
+func (cc *CoordinatorController) listUnstructured(ctx context.Context, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := cc.Client.List(ctx, list); err != nil {
+		return nil, err
+	}
+
+	items := make([]unstructured.Unstructured, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.GetDeletionTimestamp() == nil {
+			items = append(items, item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].GetCreationTimestamp().UnixNano() < items[j].GetCreationTimestamp().UnixNano()
+	})
+
+	return items, nil
+}
+
+func (cc *CoordinatorController) WatchNodePodCIDRs(ctx context.Context, logger *zap.Logger) error {
+	podCIDRChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok1 := e.ObjectOld.(*corev1.Node)
+			newNode, ok2 := e.ObjectNew.(*corev1.Node)
+			return ok1 && ok2 && !reflect.DeepEqual(oldNode.Spec.PodCIDRs, newNode.Spec.PodCIDRs)
+		},
+	}
+
+	c, err := newPodCIDRSourceController(constant.KindSpiderCoordinator+"-node", "Node", cc.Manager, cc.Workqueue, &corev1.Node{}, podCIDRChanged)
+	if err != nil {
+		return err
+	}
+
+	startPodCIDRController(ctx, logger, "Node", c)
+	return nil
+}
+
+func (cc *CoordinatorController) WatchAntreaIPPools(ctx context.Context, logger *zap.Logger) error {
+	var crd apiextensionsv1.CustomResourceDefinition
+	err := cc.APIReader.Get(ctx, types.NamespacedName{Name: antreaIPPoolCRDName}, &crd)
+	if err != nil {
+		logger.Sugar().Warnf("CRD %s no found, can't watch antrea ippools resource", antreaIPPoolCRDName)
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(antreaIPPoolGVK)
+	c, err := newPodCIDRSourceController(constant.KindSpiderCoordinator+"-antrea", "AntreaIPPool", cc.Manager, cc.Workqueue, obj)
+	if err != nil {
+		return err
+	}
+
+	startPodCIDRController(ctx, logger, "Antrea IPPool", c)
+	return nil
+}
+
+func (cc *CoordinatorController) WatchKubeOVNSubnets(ctx context.Context, logger *zap.Logger) error {
+	var crd apiextensionsv1.CustomResourceDefinition
+	err := cc.APIReader.Get(ctx, types.NamespacedName{Name: kubeOVNSubnetCRDName}, &crd)
+	if err != nil {
+		logger.Sugar().Warnf("CRD %s no found, can't watch kube-ovn subnets resource", kubeOVNSubnetCRDName)
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kubeOVNSubnetGVK)
+	c, err := newPodCIDRSourceController(constant.KindSpiderCoordinator+"-kubeovn", "KubeOVNSubnet", cc.Manager, cc.Workqueue, obj)
+	if err != nil {
+		return err
+	}
+
+	startPodCIDRController(ctx, logger, "Kube-OVN Subnet", c)
+	return nil
+}
+
+func (cc *CoordinatorController) WatchWeaveDaemonSet(ctx context.Context, logger *zap.Logger) error {
+	isWeave := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == metav1.NamespaceSystem && obj.GetName() == weaveDaemonSet
+	})
+
+	c, err := newPodCIDRSourceController(constant.KindSpiderCoordinator+"-weave", "DaemonSet", cc.Manager, cc.Workqueue, &appsv1.DaemonSet{}, isWeave)
+	if err != nil {
+		return err
+	}
+
+	startPodCIDRController(ctx, logger, "Weave DaemonSet", c)
+	return nil
+}
+
+// parseFlannelNetConf parses the "Network" and "IPv6Network" of the flannel
+// net-conf.json.
+func parseFlannelNetConf(data string) ([]string, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, nil
+	}
+
+	var netConf struct {
+		Network     string `json:"Network"`
+		IPv6Network string `json:"IPv6Network"`
+		EnableIPv4  *bool  `json:"EnableIPv4"`
+		EnableIPv6  bool   `json:"EnableIPv6"`
+	}
+	if err := json.Unmarshal([]byte(data), &netConf); err != nil {
+		return nil, err
+	}
+
+	var podCIDR []string
+	if netConf.Network != "" && (netConf.EnableIPv4 == nil || *netConf.EnableIPv4) {
+		podCIDR = append(podCIDR, netConf.Network)
+	}
+	if netConf.IPv6Network != "" && netConf.EnableIPv6 {
+		podCIDR = append(podCIDR, netConf.IPv6Network)
+	}
+
+	for _, cidr := range podCIDR {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, err
+		}
+	}
+
+	return podCIDR, nil
+}
+
+// parseOVNKubeNetCIDR parses the net_cidr of ovn-kubernetes, which is a comma
+// separated list of "<CIDR>[/<host subnet length>]".
+func parseOVNKubeNetCIDR(data string) ([]string, error) {
+	var podCIDR []string
+	for _, entry := range strings.Split(data, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.Count(entry, "/") == 2 {
+			entry = entry[:strings.LastIndex(entry, "/")]
+		}
+
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			return nil, err
+		}
+		podCIDR = append(podCIDR, entry)
+	}
+
+	return podCIDR, nil
+}
+
+// antreaIPPoolCIDRs returns the CIDRs of an Antrea IPPool, the ones of the
+// IP ranges with start and end are derived from the gateway and prefix
+// length of the subnet.
+func antreaIPPoolCIDRs(ipPool unstructured.Unstructured) []string {
+	subnetInfo, _, _ := unstructured.NestedMap(ipPool.Object, "spec", "subnetInfo")
+	ipRanges, _, _ := unstructured.NestedSlice(ipPool.Object, "spec", "ipRanges")
+
+	var cidrs []string
+	for _, r := range ipRanges {
+		ipRange, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		cidr, _, _ := unstructured.NestedString(ipRange, "cidr")
+		if cidr == "" {
+			// v1alpha2 IPPool holds the subnet info in each IP range.
+			info := subnetInfo
+			if _, ok := ipRange["gateway"]; ok {
+				info = ipRange
+			}
+			cidr = cidrOfGateway(info)
+		}
+
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil && !slices.Contains(cidrs, ipNet.String()) {
+			cidrs = append(cidrs, ipNet.String())
+		}
+	}
+
+	return cidrs
+}
+
+func cidrOfGateway(subnetInfo map[string]interface{}) string {
+	gateway, _, _ := unstructured.NestedString(subnetInfo, "gateway")
+	prefixLength, _, _ := unstructured.NestedFieldNoCopy(subnetInfo, "prefixLength")
+	if gateway == "" || prefixLength == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%v", gateway, prefixLength)
+}
+
+// kubeOVNSubnetCIDRs returns the CIDRs of a Kube-OVN Subnet served by the
+// default OVN provider.
+func kubeOVNSubnetCIDRs(subnet unstructured.Unstructured) []string {
+	provider, _, _ := unstructured.NestedString(subnet.Object, "spec", "provider")
+	if provider != "" && provider != "ovn" {
+		return nil
+	}
+
+	cidrBlock, _, _ := unstructured.NestedString(subnet.Object, "spec", "cidrBlock")
+
+	var cidrs []string
+	for _, cidr := range strings.Split(cidrBlock, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if _, _, err := net.ParseCIDR(cidr); err == nil {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+
+	return cidrs
+}
+
+// weaveIPAllocRange returns the IPALLOC_RANGE of the weave container, or the
+// default range of Weave if it's unset.
+func weaveIPAllocRange(ds *appsv1.DaemonSet) string {
+	for _, c := range ds.Spec.Template.Spec.Containers {
+		for _, env := range c.Env {
+			if env.Name == weaveIPAllocRangeEnv && env.Value != "" {
+				return strings.TrimSpace(env.Value)
+			}
+		}
+	}
+
+	return weaveDefaultIPAllocRange
+}
+
+// nodePodCIDRs returns the podCIDRs allocated to the Nodes.
+func nodePodCIDRs(nodes []corev1.Node) []string {
+	var podCIDR []string
+	for i := range nodes {
+		for _, cidr := range podCIDRsOfNode(&nodes[i]) {
+			if !slices.Contains(podCIDR, cidr) {
+				podCIDR = append(podCIDR, cidr)
+			}
+		}
+	}
+
+	sort.Strings(podCIDR)
+	return podCIDR
+}
+
+func podCIDRsOfNode(node *corev1.Node) []string {
+	if len(node.Spec.PodCIDRs) == 0 && node.Spec.PodCIDR != "" {
+		return []string{node.Spec.PodCIDR}
+	}
+
+	return node.Spec.PodCIDRs
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("PodCIDR backends", Label("podcidr"), func() {
+	It("watches the ConfigMaps of the podCIDR", func() {
+		Expect(isPodCIDRConfigMap(flannelConfigMap)).To(BeTrue())
+		Expect(isPodCIDRConfigMap(ovnKubeConfigMap)).To(BeTrue())
+		Expect(isPodCIDRConfigMap("coredns")).To(BeFalse())
+	})
+
+	It("parses the flannel net-conf.json", func() {
+		podCIDR, err := parseFlannelNetConf(`{"Network": "10.244.0.0/16", "EnableIPv6": true, "IPv6Network": "fd00:10:244::/56", "Backend": {"Type": "vxlan"}}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podCIDR).To(Equal([]string{"10.244.0.0/16", "fd00:10:244::/56"}))
+
+		podCIDR, err = parseFlannelNetConf(`{"Network": "10.244.0.0/16", "EnableIPv4": false, "EnableIPv6": true, "IPv6Network": "fd00:10:244::/56"}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podCIDR).To(Equal([]string{"fd00:10:244::/56"}))
+
+		podCIDR, err = parseFlannelNetConf("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podCIDR).To(BeEmpty())
+
+		_, err = parseFlannelNetConf(`{"Network": "10.244.0.0"}`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("parses the net_cidr of ovn-kubernetes", func() {
+		podCIDR, err := parseOVNKubeNetCIDR("10.128.0.0/14/23, fd01::/48/64")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podCIDR).To(Equal([]string{"10.128.0.0/14", "fd01::/48"}))
+
+		podCIDR, err = parseOVNKubeNetCIDR("10.244.0.0/16")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podCIDR).To(Equal([]string{"10.244.0.0/16"}))
+
+		_, err = parseOVNKubeNetCIDR("10.244.0.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fetches the CIDRs of the Antrea IPPool", func() {
+		ipPool := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ipRanges": []interface{}{
+					map[string]interface{}{"cidr": "10.2.0.0/24"},
+					map[string]interface{}{"start": "10.3.0.10", "end": "10.3.0.20"},
+				},
+				"subnetInfo": map[string]interface{}{"gateway": "10.3.0.1", "prefixLength": int64(24)},
+			},
+		}}
+		Expect(antreaIPPoolCIDRs(ipPool)).To(Equal([]string{"10.2.0.0/24", "10.3.0.0/24"}))
+
+		v1alpha2 := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ipRanges": []interface{}{
+					map[string]interface{}{"start": "10.4.0.10", "end": "10.4.0.20", "gateway": "10.4.0.1", "prefixLength": int64(16)},
+				},
+			},
+		}}
+		Expect(antreaIPPoolCIDRs(v1alpha2)).To(Equal([]string{"10.4.0.0/16"}))
+	})
+
+	It("fetches the CIDRs of the Kube-OVN Subnet of the OVN provider", func() {
+		subnet := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"cidrBlock": "10.16.0.0/16,fd00:10:16::/112", "provider": "ovn"},
+		}}
+		Expect(kubeOVNSubnetCIDRs(subnet)).To(Equal([]string{"10.16.0.0/16", "fd00:10:16::/112"}))
+
+		attachment := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"cidrBlock": "172.17.0.0/16", "provider": "macvlan.default"},
+		}}
+		Expect(kubeOVNSubnetCIDRs(attachment)).To(BeEmpty())
+	})
+
+	It("fetches the IPALLOC_RANGE of Weave", func() {
+		ds := &appsv1.DaemonSet{}
+		Expect(weaveIPAllocRange(ds)).To(Equal(weaveDefaultIPAllocRange))
+
+		ds.Spec.Template.Spec.Containers = []corev1.Container{{
+			Name: "weave",
+			Env:  []corev1.EnvVar{{Name: weaveIPAllocRangeEnv, Value: "10.40.0.0/16"}},
+		}}
+		Expect(weaveIPAllocRange(ds)).To(Equal("10.40.0.0/16"))
+	})
+
+	It("collects the podCIDR of the Nodes", func() {
+		nodes := []corev1.Node{
+			{Spec: corev1.NodeSpec{PodCIDRs: []string{"10.244.1.0/24", "fd00::100/120"}}},
+			{Spec: corev1.NodeSpec{PodCIDR: "10.244.0.0/24"}},
+			{Spec: corev1.NodeSpec{PodCIDRs: []string{"10.244.1.0/24"}}},
+		}
+		Expect(nodePodCIDRs(nodes)).To(Equal([]string{"10.244.0.0/24", "10.244.1.0/24", "fd00::100/120"}))
+	})
+
+	It("skips watching the CRDs of the CNI which aren't installed", func() {
+		scheme := runtime.NewScheme()
+		Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+		cc := &CoordinatorController{APIReader: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+		Expect(cc.WatchAntreaIPPools(context.Background(), zap.NewNop())).To(Succeed())
+		Expect(cc.WatchKubeOVNSubnets(context.Background(), zap.NewNop())).To(Succeed())
+	})
+})
@@ -0,0 +1,67 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// newPodCIDRSourceController watches the objects which the podCIDR of the
+// cluster is fetched from, and enqueues "<kind>/<name>" to the SpiderCoordinator
+// workqueue for each of their events.
+func newPodCIDRSourceController(name, kind string, mgr ctrl.Manager, workQueue workqueue.RateLimitingInterface,
+	obj client.Object, predicates ...predicate.Predicate) (controller.Controller, error) {
+	if mgr == nil {
+		return nil, fmt.Errorf("controller-runtime manager %w", constant.ErrMissingRequiredParam)
+	}
+
+	r := &podCIDRSourceReconciler{
+		kind:                       kind,
+		spiderCoordinatorWorkqueue: workQueue,
+	}
+
+	c, err := controller.NewUnmanaged(name, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), obj), &handler.EnqueueRequestForObject{}, predicates...); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+type podCIDRSourceReconciler struct {
+	kind                       string
+	spiderCoordinatorWorkqueue workqueue.RateLimitingInterface
+}
+
+func (r *podCIDRSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	InformerLogger.Sugar().Debugf("Watched %s %v Enqueued", r.kind, req.Name)
+	r.spiderCoordinatorWorkqueue.Add(fmt.Sprintf("%s/%v", r.kind, req.Name))
+	return ctrl.Result{}, nil
+}
+
+func startPodCIDRController(ctx context.Context, logger *zap.Logger, kind string, c controller.Controller) {
+	go func() {
+		logger.Sugar().Infof("Starting %s controller", kind)
+		if err := c.Start(ctx); err != nil {
+			logger.Sugar().Errorf("Failed to start %s controller: %v", kind, err)
+		}
+		logger.Sugar().Infof("Shutdown %s controller", kind)
+	}()
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	"go.uber.org/zap"
+	networkingv1alpha1 "k8s.io/api/networking/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+const serviceCIDRResource = "servicecidrs"
+
+// serviceCIDRGroupVersions is the versions of ServiceCIDR in the order of
+// preference, the first one served by the API server is used.
+var serviceCIDRGroupVersions = []schema.GroupVersion{
+	{Group: "networking.k8s.io", Version: "v1"},
+	{Group: "networking.k8s.io", Version: "v1beta1"},
+	{Group: "networking.k8s.io", Version: "v1alpha1"},
+}
+
+// discoverServiceCIDRResource returns the preferred version of ServiceCIDR
+// served by the API server.
+func discoverServiceCIDRResource(d discovery.DiscoveryInterface) (schema.GroupVersionResource, bool) {
+	for _, gv := range serviceCIDRGroupVersions {
+		resources, err := d.ServerResourcesForGroupVersion(gv.String())
+		if err != nil || resources == nil {
+			continue
+		}
+
+		for _, r := range resources.APIResources {
+			if r.Name == serviceCIDRResource {
+				return gv.WithResource(serviceCIDRResource), true
+			}
+		}
+	}
+
+	return schema.GroupVersionResource{}, false
+}
+
+// setupServiceCIDRInformer starts the informer of the ServiceCIDR version
+// served by the API server, the ServiceCIDR of the cluster is fetched from the
+// kubeadm-config or kube-controller-manager if none is served.
+func (cc *CoordinatorController) setupServiceCIDRInformer(ctx context.Context, d discovery.DiscoveryInterface, dynamicClient dynamic.Interface) error {
+	cc.ServiceCIDRLister = nil
+	cc.ServiceCIDRSynced = nil
+
+	InformerLogger.Debug("Checking if the ServiceCIDR is available in your cluster")
+	gvr, ok := discoverServiceCIDRResource(d)
+	if !ok {
+		InformerLogger.Warn("ServiceCIDR feature is unavailable in your cluster, Don't start the serviceCIDR informer")
+		return nil
+	}
+
+	InformerLogger.Sugar().Infof("the ServiceCIDR %s is available in your cluster, Start the serviceCIDR informer", gvr.GroupVersion())
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, cc.ResyncPeriod)
+	informer := factory.ForResource(gvr)
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			cc.enqueueCoordinatorOnServiceCIDR(obj, "Add")
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCIDRs, oldUsable := serviceCIDRState(oldObj)
+			newCIDRs, newUsable := serviceCIDRState(newObj)
+			if oldUsable == newUsable && slices.Equal(oldCIDRs, newCIDRs) {
+				return
+			}
+			cc.enqueueCoordinatorOnServiceCIDR(newObj, "Update")
+		},
+		DeleteFunc: func(obj interface{}) {
+			cc.enqueueCoordinatorOnServiceCIDR(obj, "Del")
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	cc.ServiceCIDRLister = informer.Lister()
+	cc.ServiceCIDRSynced = informer.Informer().HasSynced
+	factory.Start(ctx.Done())
+	return nil
+}
+
+func (cc *CoordinatorController) enqueueCoordinatorOnServiceCIDR(obj interface{}, operation string) {
+	var name string
+	switch o := obj.(type) {
+	case *unstructured.Unstructured:
+		name = o.GetName()
+	case cache.DeletedFinalStateUnknown:
+		name = o.Key
+	}
+
+	logger := InformerLogger.With(
+		zap.String("ServiceCIDRName", name),
+		zap.String("Operation", operation),
+	)
+
+	cc.Workqueue.Add(fmt.Sprintf("ServiceCIDR/%v", name))
+	logger.Debug(messageEnqueueCoordiantor)
+}
+
+func (cc *CoordinatorController) updateServiceCIDR(logger *zap.Logger, coordCopy *spiderpoolv2beta1.SpiderCoordinator) error {
+	// fetch kubernetes ServiceCIDR
+	if cc.ServiceCIDRLister == nil {
+		// serviceCIDR feature is disable if ServiceCIDRLister is nil
+		return fmt.Errorf("the kubernetes serviceCIDR is disabled")
+	}
+
+	objs, err := cc.ServiceCIDRLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	svcCIDRList := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			svcCIDRList = append(svcCIDRList, u)
+		}
+	}
+
+	serviceCIDR := aggregateServiceCIDRs(svcCIDRList)
+	if len(serviceCIDR) == 0 {
+		return fmt.Errorf("no available ServiceCIDR found")
+	}
+
+	if coordCopy.Status.Phase == Synced && reflect.DeepEqual(coordCopy.Status.ServiceCIDR, serviceCIDR) {
+		return nil
+	}
+
+	logger.Sugar().Debug("Got service cidrs: ", serviceCIDR)
+	coordCopy.Status.ServiceCIDR = serviceCIDR
+	return nil
+}
+
+// aggregateServiceCIDRs returns the CIDRs of all usable ServiceCIDRs, sorted
+// by the creation time to admit serviceCIDR has changed due to the order.
+func aggregateServiceCIDRs(svcCIDRList []*unstructured.Unstructured) []string {
+	sort.SliceStable(svcCIDRList, func(i, j int) bool {
+		ti, tj := svcCIDRList[i].GetCreationTimestamp(), svcCIDRList[j].GetCreationTimestamp()
+		if ti.Equal(&tj) {
+			return svcCIDRList[i].GetName() < svcCIDRList[j].GetName()
+		}
+		return ti.Before(&tj)
+	})
+
+	serviceCIDR := make([]string, 0, len(svcCIDRList))
+	for _, p := range svcCIDRList {
+		cidrs, usable := serviceCIDRState(p)
+		if !usable {
+			continue
+		}
+
+		for _, cidr := range cidrs {
+			if !slices.Contains(serviceCIDR, cidr) {
+				serviceCIDR = append(serviceCIDR, cidr)
+			}
+		}
+	}
+
+	return serviceCIDR
+}
+
+// serviceCIDRState returns the CIDRs of the ServiceCIDR, and whether it's
+// usable, the deleting or terminating one is not.
+func serviceCIDRState(obj interface{}) ([]string, bool) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, false
+	}
+
+	cidrs, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "cidrs")
+	if u.GetDeletionTimestamp() != nil {
+		return cidrs, false
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if condition["type"] == networkingv1alpha1.ServiceCIDRConditionReady &&
+			condition["status"] == "False" &&
+			condition["reason"] == networkingv1alpha1.ServiceCIDRReasonTerminating {
+			return cidrs, false
+		}
+	}
+
+	return cidrs, true
+}
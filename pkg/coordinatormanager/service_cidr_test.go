@@ -0,0 +1,68 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("ServiceCIDR", Label("service_cidr"), func() {
+	newServiceCIDR := func(name string, created time.Time, cidrs ...interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"cidrs": cidrs},
+		}}
+		u.SetName(name)
+		u.SetCreationTimestamp(metav1.NewTime(created))
+		return u
+	}
+
+	It("discovers the preferred version served", func() {
+		d := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+		_, ok := discoverServiceCIDRResource(d)
+		Expect(ok).To(BeFalse())
+
+		d.Resources = []*metav1.APIResourceList{
+			{GroupVersion: "networking.k8s.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "servicecidrs"}, {Name: "ipaddresses"}}},
+			{GroupVersion: "networking.k8s.io/v1beta1", APIResources: []metav1.APIResource{{Name: "servicecidrs"}}},
+			{GroupVersion: "networking.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "ingresses"}}},
+		}
+		gvr, ok := discoverServiceCIDRResource(d)
+		Expect(ok).To(BeTrue())
+		Expect(gvr).To(Equal(schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "servicecidrs"}))
+	})
+
+	It("aggregates the usable ServiceCIDRs", func() {
+		now := time.Now()
+		kubernetes := newServiceCIDR("kubernetes", now, "10.233.0.0/18", "fd00:10:233::/116")
+		extra := newServiceCIDR("extra", now.Add(time.Minute), "10.234.0.0/24", "10.233.0.0/18")
+
+		deleting := newServiceCIDR("deleting", now.Add(-time.Minute), "10.235.0.0/24")
+		deleting.SetDeletionTimestamp(&metav1.Time{Time: now})
+
+		terminating := newServiceCIDR("terminating", now.Add(-time.Minute), "10.236.0.0/24")
+		Expect(unstructured.SetNestedSlice(terminating.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "Terminating"},
+		}, "status", "conditions")).To(Succeed())
+
+		serviceCIDR := aggregateServiceCIDRs([]*unstructured.Unstructured{extra, deleting, kubernetes, terminating})
+		Expect(serviceCIDR).To(Equal([]string{"10.233.0.0/18", "fd00:10:233::/116", "10.234.0.0/24"}))
+	})
+
+	It("reports the state of the ServiceCIDR", func() {
+		cidrs, usable := serviceCIDRState(newServiceCIDR("kubernetes", time.Now(), "10.233.0.0/18"))
+		Expect(cidrs).To(Equal([]string{"10.233.0.0/18"}))
+		Expect(usable).To(BeTrue())
+
+		_, usable = serviceCIDRState("invalid")
+		Expect(usable).To(BeFalse())
+	})
+})
@@ -0,0 +1,194 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package externalipam
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// Prefix is a prefix of the external IPAM, which is imported as a SpiderIPPool.
+type Prefix struct {
+	// ID is the identity of the prefix in the external IPAM.
+	ID string
+	// Prefix is in CIDR notation, like "10.0.0.0/24".
+	Prefix string
+	// Gateway is optional.
+	Gateway string
+}
+
+// Address is an IP address recorded in the external IPAM.
+type Address struct {
+	// ID is the identity of the address in the external IPAM.
+	ID string
+	// IP is the IP address without the prefix length.
+	IP string
+	// Managed means the address is reserved by Spiderpool, so that it could
+	// be released by Spiderpool.
+	Managed bool
+	// Description is the description of the address in the external IPAM.
+	Description string
+}
+
+// ReserveIPRequest describes the IP address to reserve in the external IPAM.
+type ReserveIPRequest struct {
+	// IP is the IP address without the prefix length.
+	IP string
+	// Prefix is the prefix in CIDR notation which contains the IP.
+	Prefix string
+	// PoolName is the SpiderIPPool which allocates the IP.
+	PoolName string
+	// Pod is the Pod in the form of namespace/name.
+	Pod    string
+	PodUID string
+}
+
+// Client is the interface of the external IPAM API client, the REST clients
+// of the different external IPAMs are registered with RegisterClient.
+type Client interface {
+	// ListPrefixes lists the prefixes selected by the prefix filter.
+	ListPrefixes(ctx context.Context) ([]Prefix, error)
+	// ListAddresses lists the IP addresses recorded in the prefix.
+	ListAddresses(ctx context.Context, prefix string) ([]Address, error)
+	// ReserveIP records the IP address as managed by Spiderpool.
+	ReserveIP(ctx context.Context, req *ReserveIPRequest) error
+	// ReleaseIP deletes the IP address managed by Spiderpool.
+	ReleaseIP(ctx context.Context, address Address) error
+}
+
+// ClientConfig is the configuration to create the Client.
+type ClientConfig struct {
+	ServerURL    string
+	Token        string
+	PrefixFilter map[string]string
+	HTTPClient   *http.Client
+}
+
+// ClientFactory creates the Client of an external IPAM driver.
+type ClientFactory func(cfg ClientConfig, logger *zap.Logger) (Client, error)
+
+var (
+	clientsLock sync.RWMutex
+	clients     = map[string]ClientFactory{}
+)
+
+// RegisterClient registers the factory of an external IPAM driver.
+func RegisterClient(driver string, factory ClientFactory) {
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+
+	clients[driver] = factory
+}
+
+// Drivers returns the names of the registered drivers.
+func Drivers() []string {
+	clientsLock.RLock()
+	defer clientsLock.RUnlock()
+
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ValidateConfig validates the external IPAM configuration.
+// Returns nil if the configuration is valid or external IPAM is disabled.
+func ValidateConfig(cfg *spiderpooltypes.ExternalIPAMConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	clientsLock.RLock()
+	_, ok := clients[cfg.Driver]
+	clientsLock.RUnlock()
+	if !ok {
+		return fmt.Errorf("invalid externalIPAM.driver %q: must be one of %v", cfg.Driver, Drivers())
+	}
+
+	u, err := url.Parse(cfg.ServerURL)
+	if err != nil {
+		return fmt.Errorf("invalid externalIPAM.serverUrl %q: %w", cfg.ServerURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid externalIPAM.serverUrl %q: must start with http:// or https://", cfg.ServerURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid externalIPAM.serverUrl %q: host is empty", cfg.ServerURL)
+	}
+
+	if _, err := parsePositiveDuration("syncInterval", cfg.SyncInterval, constant.DefaultExternalIPAMSyncInterval); err != nil {
+		return err
+	}
+	if _, err := parsePositiveDuration("requestTimeout", cfg.RequestTimeout, constant.DefaultExternalIPAMRequestTimeout); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SyncInterval returns the interval of the full synchronization.
+func SyncInterval(cfg *spiderpooltypes.ExternalIPAMConfig) (time.Duration, error) {
+	return parsePositiveDuration("syncInterval", cfg.SyncInterval, constant.DefaultExternalIPAMSyncInterval)
+}
+
+// NewClient creates the Client of the driver in the external IPAM
+// configuration, the token authenticates Spiderpool to the external IPAM.
+func NewClient(cfg *spiderpooltypes.ExternalIPAMConfig, token string, logger *zap.Logger) (Client, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	timeout, err := parsePositiveDuration("requestTimeout", cfg.RequestTimeout, constant.DefaultExternalIPAMRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	clientsLock.RLock()
+	factory := clients[cfg.Driver]
+	clientsLock.RUnlock()
+
+	return factory(ClientConfig{
+		ServerURL:    cfg.ServerURL,
+		Token:        token,
+		PrefixFilter: cfg.PrefixFilter,
+		HTTPClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec
+				},
+			},
+		},
+	}, logger)
+}
+
+func parsePositiveDuration(field, value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid externalIPAM.%s %q: %w", field, value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid externalIPAM.%s %q: must be positive", field, value)
+	}
+
+	return d, nil
+}
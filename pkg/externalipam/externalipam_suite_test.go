@@ -0,0 +1,16 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package externalipam_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestExternalIPAM(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "External IPAM Suite")
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package externalipam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DriverNetBox is the driver of NetBox REST API.
+	DriverNetBox = "netbox"
+
+	netboxPrefixesAPIPath    = "/api/ipam/prefixes/"
+	netboxIPAddressesAPIPath = "/api/ipam/ip-addresses/"
+	netboxPageLimit          = 1000
+
+	// managedDescriptionPrefix marks the addresses reserved by Spiderpool.
+	managedDescriptionPrefix = "spiderpool:"
+)
+
+func init() {
+	RegisterClient(DriverNetBox, func(cfg ClientConfig, logger *zap.Logger) (Client, error) {
+		return NewNetBoxClient(cfg, logger)
+	})
+}
+
+// NetBoxClient calls the IPAM REST API of NetBox.
+type NetBoxClient struct {
+	baseURL      string
+	token        string
+	prefixFilter map[string]string
+	httpClient   *http.Client
+	logger       *zap.Logger
+}
+
+type netboxPage struct {
+	Next    *string           `json:"next"`
+	Results []json.RawMessage `json:"results"`
+}
+
+type netboxPrefix struct {
+	ID           int64          `json:"id"`
+	Prefix       string         `json:"prefix"`
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+}
+
+type netboxIPAddress struct {
+	ID          int64  `json:"id,omitempty"`
+	Address     string `json:"address"`
+	Status      string `json:"status,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewNetBoxClient creates the client of NetBox.
+func NewNetBoxClient(cfg ClientConfig, logger *zap.Logger) (*NetBoxClient, error) {
+	if cfg.ServerURL == "" {
+		return nil, fmt.Errorf("NetBox server URL is required")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &NetBoxClient{
+		baseURL:      strings.TrimSuffix(cfg.ServerURL, "/"),
+		token:        cfg.Token,
+		prefixFilter: cfg.PrefixFilter,
+		httpClient:   httpClient,
+		logger:       logger,
+	}, nil
+}
+
+// ListPrefixes lists the prefixes selected by the prefix filter, the
+// gateway is read from the custom field "gateway" if there is.
+func (c *NetBoxClient) ListPrefixes(ctx context.Context) ([]Prefix, error) {
+	query := url.Values{}
+	for key, value := range c.prefixFilter {
+		query.Set(key, value)
+	}
+
+	var prefixes []Prefix
+	err := c.list(ctx, netboxPrefixesAPIPath, query, func(raw json.RawMessage) error {
+		var p netboxPrefix
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return err
+		}
+
+		prefix := Prefix{ID: strconv.FormatInt(p.ID, 10), Prefix: p.Prefix}
+		if gateway, ok := p.CustomFields["gateway"].(string); ok {
+			// The IP address custom field of NetBox is in CIDR notation.
+			prefix.Gateway = strings.Split(gateway, "/")[0]
+		}
+		prefixes = append(prefixes, prefix)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NetBox prefixes: %w", err)
+	}
+
+	return prefixes, nil
+}
+
+// ListAddresses lists the IP addresses in the prefix.
+func (c *NetBoxClient) ListAddresses(ctx context.Context, prefix string) ([]Address, error) {
+	query := url.Values{}
+	query.Set("parent", prefix)
+
+	var addresses []Address
+	err := c.list(ctx, netboxIPAddressesAPIPath, query, func(raw json.RawMessage) error {
+		var a netboxIPAddress
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return err
+		}
+
+		ip, _, err := net.ParseCIDR(a.Address)
+		if err != nil {
+			return fmt.Errorf("invalid address '%s': %w", a.Address, err)
+		}
+		addresses = append(addresses, Address{
+			ID:          strconv.FormatInt(a.ID, 10),
+			IP:          ip.String(),
+			Managed:     strings.HasPrefix(a.Description, managedDescriptionPrefix),
+			Description: a.Description,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NetBox IP addresses of prefix %s: %w", prefix, err)
+	}
+
+	return addresses, nil
+}
+
+// ReserveIP creates the active IP address with the Spiderpool description.
+func (c *NetBoxClient) ReserveIP(ctx context.Context, req *ReserveIPRequest) error {
+	_, ipNet, err := net.ParseCIDR(req.Prefix)
+	if err != nil {
+		return fmt.Errorf("invalid prefix '%s': %w", req.Prefix, err)
+	}
+	ones, _ := ipNet.Mask.Size()
+
+	body, err := json.Marshal(netboxIPAddress{
+		Address:     fmt.Sprintf("%s/%d", req.IP, ones),
+		Status:      "active",
+		Description: fmt.Sprintf("%s %s (SpiderIPPool %s)", managedDescriptionPrefix, req.Pod, req.PoolName),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.do(ctx, http.MethodPost, c.baseURL+netboxIPAddressesAPIPath, body); err != nil {
+		return fmt.Errorf("failed to reserve NetBox IP address %s: %w", req.IP, err)
+	}
+
+	return nil
+}
+
+// ReleaseIP deletes the IP address reserved by Spiderpool.
+func (c *NetBoxClient) ReleaseIP(ctx context.Context, address Address) error {
+	if !address.Managed {
+		return fmt.Errorf("NetBox IP address %s is not managed by Spiderpool", address.IP)
+	}
+
+	reqURL := fmt.Sprintf("%s%s%s/", c.baseURL, netboxIPAddressesAPIPath, address.ID)
+	if _, err := c.do(ctx, http.MethodDelete, reqURL, nil); err != nil {
+		return fmt.Errorf("failed to release NetBox IP address %s: %w", address.IP, err)
+	}
+
+	return nil
+}
+
+// list gets all the pages of the API, and calls fn with each result.
+func (c *NetBoxClient) list(ctx context.Context, path string, query url.Values, fn func(json.RawMessage) error) error {
+	query.Set("limit", strconv.Itoa(netboxPageLimit))
+	reqURL := c.baseURL + path + "?" + query.Encode()
+
+	for reqURL != "" {
+		data, err := c.do(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return err
+		}
+
+		var page netboxPage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		for _, raw := range page.Results {
+			if err := fn(raw); err != nil {
+				return err
+			}
+		}
+
+		reqURL = ""
+		if page.Next != nil {
+			reqURL = *page.Next
+		}
+	}
+
+	return nil
+}
+
+func (c *NetBoxClient) do(ctx context.Context, method, reqURL string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Token "+c.token)
+	}
+
+	c.logger.Debug("Calling NetBox API", zap.String("method", method), zap.String("url", reqURL))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", method, reqURL, resp.StatusCode, string(data))
+	}
+
+	return data, nil
+}
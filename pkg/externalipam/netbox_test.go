@@ -0,0 +1,190 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package externalipam_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/pkg/externalipam"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// mockNetBox serves the NetBox IPAM API with the addresses in memory.
+type mockNetBox struct {
+	lock      sync.Mutex
+	server    *httptest.Server
+	prefixes  []map[string]any
+	addresses map[int]map[string]any
+	nextID    int
+	queries   []string
+}
+
+func newMockNetBox() *mockNetBox {
+	m := &mockNetBox{addresses: map[int]map[string]any{}, nextID: 1}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ipam/prefixes/", func(w http.ResponseWriter, r *http.Request) {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		Expect(r.Header.Get("Authorization")).To(Equal("Token secret"))
+		m.queries = append(m.queries, r.URL.RawQuery)
+
+		// Serve two pages to verify the pagination.
+		if r.URL.Query().Get("offset") == "" && len(m.prefixes) > 1 {
+			next := fmt.Sprintf("%s/api/ipam/prefixes/?offset=1", m.server.URL)
+			_ = json.NewEncoder(w).Encode(map[string]any{"next": next, "results": m.prefixes[:1]})
+			return
+		}
+		start := 0
+		if r.URL.Query().Get("offset") != "" {
+			start = 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"next": nil, "results": m.prefixes[start:]})
+	})
+	mux.HandleFunc("/api/ipam/ip-addresses/", func(w http.ResponseWriter, r *http.Request) {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		Expect(r.Header.Get("Authorization")).To(Equal("Token secret"))
+
+		switch r.Method {
+		case http.MethodGet:
+			results := []map[string]any{}
+			for _, a := range m.addresses {
+				results = append(results, a)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"next": nil, "results": results})
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			address := map[string]any{}
+			Expect(json.Unmarshal(body, &address)).To(Succeed())
+			address["id"] = m.nextID
+			m.addresses[m.nextID] = address
+			m.nextID++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(address)
+		case http.MethodDelete:
+			var id int
+			_, err := fmt.Sscanf(r.URL.Path, "/api/ipam/ip-addresses/%d/", &id)
+			Expect(err).NotTo(HaveOccurred())
+			if _, ok := m.addresses[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(m.addresses, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	m.server = httptest.NewServer(mux)
+
+	return m
+}
+
+func (m *mockNetBox) addAddress(address, description string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.addresses[m.nextID] = map[string]any{"id": m.nextID, "address": address, "description": description}
+	m.nextID++
+}
+
+func (m *mockNetBox) addressList() map[string]string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	result := map[string]string{}
+	for _, a := range m.addresses {
+		result[a["address"].(string)] = a["description"].(string)
+	}
+	return result
+}
+
+var _ = Describe("NetBox client", Label("external_ipam_test"), func() {
+	var mock *mockNetBox
+	var c externalipam.Client
+
+	BeforeEach(func() {
+		mock = newMockNetBox()
+		DeferCleanup(mock.server.Close)
+
+		var err error
+		c, err = externalipam.NewClient(&spiderpooltypes.ExternalIPAMConfig{
+			Enabled:      true,
+			Driver:       externalipam.DriverNetBox,
+			ServerURL:    mock.server.URL,
+			PrefixFilter: map[string]string{"tag": "kubernetes"},
+		}, "secret", zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("lists the prefixes of all pages", func() {
+		mock.prefixes = []map[string]any{
+			{"id": 1, "prefix": "10.6.0.0/24", "custom_fields": map[string]any{"gateway": "10.6.0.1/24"}},
+			{"id": 2, "prefix": "fd00:6::/120"},
+		}
+
+		prefixes, err := c.ListPrefixes(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes).To(Equal([]externalipam.Prefix{
+			{ID: "1", Prefix: "10.6.0.0/24", Gateway: "10.6.0.1"},
+			{ID: "2", Prefix: "fd00:6::/120"},
+		}))
+		Expect(mock.queries[0]).To(ContainSubstring("tag=kubernetes"))
+	})
+
+	It("reserves, lists and releases the addresses", func() {
+		mock.addAddress("10.6.0.1/24", "gateway")
+
+		err := c.ReserveIP(context.Background(), &externalipam.ReserveIPRequest{
+			IP:       "10.6.0.10",
+			Prefix:   "10.6.0.0/24",
+			PoolName: "pool",
+			Pod:      "default/pod",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mock.addressList()).To(HaveKeyWithValue("10.6.0.10/24", "spiderpool: default/pod (SpiderIPPool pool)"))
+
+		addresses, err := c.ListAddresses(context.Background(), "10.6.0.0/24")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addresses).To(HaveLen(2))
+
+		for _, address := range addresses {
+			if address.IP == "10.6.0.1" {
+				Expect(address.Managed).To(BeFalse())
+				Expect(c.ReleaseIP(context.Background(), address)).To(MatchError(ContainSubstring("not managed by Spiderpool")))
+				continue
+			}
+			Expect(address.Managed).To(BeTrue())
+			Expect(c.ReleaseIP(context.Background(), address)).To(Succeed())
+		}
+		Expect(mock.addressList()).To(Equal(map[string]string{"10.6.0.1/24": "gateway"}))
+	})
+
+	It("returns the error of the API", func() {
+		err := c.ReleaseIP(context.Background(), externalipam.Address{ID: "100", IP: "10.6.0.100", Managed: true})
+		Expect(err).To(MatchError(ContainSubstring("status 404")))
+	})
+
+	DescribeTable("validates the configuration",
+		func(cfg spiderpooltypes.ExternalIPAMConfig, errMsg string) {
+			err := externalipam.ValidateConfig(&cfg)
+			if errMsg == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(errMsg)))
+		},
+		Entry("disabled", spiderpooltypes.ExternalIPAMConfig{}, ""),
+		Entry("valid", spiderpooltypes.ExternalIPAMConfig{Enabled: true, Driver: "netbox", ServerURL: "https://netbox.example.com", SyncInterval: "1m"}, ""),
+		Entry("unknown driver", spiderpooltypes.ExternalIPAMConfig{Enabled: true, Driver: "unknown", ServerURL: "https://netbox.example.com"}, "externalIPAM.driver"),
+		Entry("invalid server URL", spiderpooltypes.ExternalIPAMConfig{Enabled: true, Driver: "netbox", ServerURL: "netbox"}, "externalIPAM.serverUrl"),
+		Entry("invalid sync interval", spiderpooltypes.ExternalIPAMConfig{Enabled: true, Driver: "netbox", ServerURL: "https://netbox.example.com", SyncInterval: "0s"}, "externalIPAM.syncInterval"),
+	)
+})
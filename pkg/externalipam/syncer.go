@@ -0,0 +1,507 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package externalipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+const (
+	subnetQueueLength = 1024
+
+	// DefaultMaxImportPrefixSize is the default of the max IPs of the
+	// SpiderIPPool imported from a prefix.
+	DefaultMaxImportPrefixSize = 65536
+)
+
+var logger *zap.Logger
+
+type SyncerConfig struct {
+	// Interval is the duration between two rounds of full synchronization.
+	Interval time.Duration
+	// ImportPrefixes creates SpiderIPPools for the prefixes of the external IPAM.
+	ImportPrefixes bool
+	// MaxImportPrefixSize is the max IPs of the SpiderIPPool imported from a
+	// prefix, only the first ones of the larger prefixes like an IPv6 /64 are
+	// imported. It's DefaultMaxImportPrefixSize if it's not positive.
+	MaxImportPrefixSize int64
+}
+
+// Syncer reserves the IPs allocated by SpiderIPPools in the external IPAM,
+// and releases them once they are released by SpiderIPPools. The addresses
+// recorded by others in the external IPAM are conflicts, which are written
+// back as SpiderReservedIPs so that Spiderpool never allocates them.
+type Syncer struct {
+	config     SyncerConfig
+	client     client.Client
+	apiReader  client.Reader
+	extClient  Client
+	leader     election.SpiderLeaseElector
+	subnetCh   chan string
+	prefixLock sync.RWMutex
+	// prefixes is indexed by the normalized CIDR.
+	prefixes map[string]Prefix
+}
+
+func NewSyncer(config SyncerConfig, client client.Client, apiReader client.Reader, extClient Client, leader election.SpiderLeaseElector) (*Syncer, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
+	if extClient == nil {
+		return nil, fmt.Errorf("external IPAM client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("spiderpool controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	logger = logutils.Logger.Named("External-IPAM-Syncer")
+
+	if config.MaxImportPrefixSize <= 0 {
+		config.MaxImportPrefixSize = DefaultMaxImportPrefixSize
+	}
+
+	return &Syncer{
+		config:    config,
+		client:    client,
+		apiReader: apiReader,
+		extClient: extClient,
+		leader:    leader,
+		subnetCh:  make(chan string, subnetQueueLength),
+		prefixes:  map[string]Prefix{},
+	}, nil
+}
+
+// EventHandler returns the handler of SpiderIPPool events, which triggers the
+// synchronization of the subnet once the IPPool allocates or releases IPs.
+func (s *Syncer) EventHandler() toolscache.ResourceEventHandlerFuncs {
+	return toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPool, ok1 := oldObj.(*spiderpoolv2beta1.SpiderIPPool)
+			newPool, ok2 := newObj.(*spiderpoolv2beta1.SpiderIPPool)
+			if !ok1 || !ok2 {
+				return
+			}
+			if reflect.DeepEqual(oldPool.Status.AllocatedIPs, newPool.Status.AllocatedIPs) {
+				return
+			}
+			s.enqueueSubnet(newPool.Spec.Subnet)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pool, ok := obj.(*spiderpoolv2beta1.SpiderIPPool); ok {
+				s.enqueueSubnet(pool.Spec.Subnet)
+			}
+		},
+	}
+}
+
+func (s *Syncer) enqueueSubnet(subnet string) {
+	select {
+	case s.subnetCh <- normalizeCIDR(subnet):
+	default:
+		logger.Sugar().Warnf("external IPAM sync queue is full, subnet %s is left to the next full synchronization", subnet)
+	}
+}
+
+// Start runs the synchronization until the context is done, only the
+// elected spiderpool-controller does the work.
+func (s *Syncer) Start(ctx context.Context) {
+	logger.Sugar().Infof("running external IPAM synchronization with interval %v", s.config.Interval)
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	synced := false
+	for {
+		if !synced && s.leader.IsElected() {
+			if err := s.SyncAll(ctx); err != nil {
+				logger.Sugar().Errorf("failed to synchronize with external IPAM: %v", err)
+			}
+			synced = true
+		}
+
+		select {
+		case <-ticker.C:
+			if !s.leader.IsElected() {
+				synced = false
+				continue
+			}
+			if err := s.SyncAll(ctx); err != nil {
+				logger.Sugar().Errorf("failed to synchronize with external IPAM: %v", err)
+			}
+		case subnet := <-s.subnetCh:
+			if !s.leader.IsElected() {
+				continue
+			}
+			if err := s.SyncSubnet(ctx, subnet); err != nil {
+				logger.Sugar().Errorf("failed to synchronize subnet %s with external IPAM: %v", subnet, err)
+			}
+		case <-ctx.Done():
+			logger.Warn("receive ctx done, stop external IPAM synchronization!")
+			return
+		}
+	}
+}
+
+// SyncAll refreshes the prefixes of the external IPAM, imports them as
+// SpiderIPPools if required, and synchronizes all the prefixes.
+func (s *Syncer) SyncAll(ctx context.Context) error {
+	prefixes, err := s.refreshPrefixes(ctx)
+	if err != nil {
+		return err
+	}
+
+	var poolList spiderpoolv2beta1.SpiderIPPoolList
+	if err := s.apiReader.List(ctx, &poolList); err != nil {
+		return fmt.Errorf("failed to list SpiderIPPools: %w", err)
+	}
+
+	poolsOfSubnet := map[string][]*spiderpoolv2beta1.SpiderIPPool{}
+	for i := range poolList.Items {
+		subnet := normalizeCIDR(poolList.Items[i].Spec.Subnet)
+		poolsOfSubnet[subnet] = append(poolsOfSubnet[subnet], &poolList.Items[i])
+	}
+
+	var errs []error
+	for subnet, prefix := range prefixes {
+		pools := poolsOfSubnet[subnet]
+		if len(pools) == 0 && s.config.ImportPrefixes {
+			pool, err := s.importPrefix(ctx, prefix)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			pools = append(pools, pool)
+		}
+
+		if err := s.syncPrefix(ctx, prefix, pools); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SyncSubnet synchronizes the prefix of the subnet, it does nothing if the
+// subnet is not a prefix of the external IPAM.
+func (s *Syncer) SyncSubnet(ctx context.Context, subnet string) error {
+	subnet = normalizeCIDR(subnet)
+
+	s.prefixLock.RLock()
+	prefix, ok := s.prefixes[subnet]
+	known := len(s.prefixes) > 0
+	s.prefixLock.RUnlock()
+	if !known {
+		prefixes, err := s.refreshPrefixes(ctx)
+		if err != nil {
+			return err
+		}
+		prefix, ok = prefixes[subnet]
+	}
+	if !ok {
+		return nil
+	}
+
+	var poolList spiderpoolv2beta1.SpiderIPPoolList
+	if err := s.apiReader.List(ctx, &poolList); err != nil {
+		return fmt.Errorf("failed to list SpiderIPPools: %w", err)
+	}
+
+	var pools []*spiderpoolv2beta1.SpiderIPPool
+	for i := range poolList.Items {
+		if normalizeCIDR(poolList.Items[i].Spec.Subnet) == subnet {
+			pools = append(pools, &poolList.Items[i])
+		}
+	}
+
+	return s.syncPrefix(ctx, prefix, pools)
+}
+
+func (s *Syncer) refreshPrefixes(ctx context.Context) (map[string]Prefix, error) {
+	list, err := s.extClient.ListPrefixes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make(map[string]Prefix, len(list))
+	for _, prefix := range list {
+		if _, _, err := net.ParseCIDR(prefix.Prefix); err != nil {
+			logger.Sugar().Warnf("external IPAM returns invalid prefix '%s'", prefix.Prefix)
+			continue
+		}
+		prefixes[normalizeCIDR(prefix.Prefix)] = prefix
+	}
+
+	s.prefixLock.Lock()
+	s.prefixes = prefixes
+	s.prefixLock.Unlock()
+
+	return prefixes, nil
+}
+
+// syncPrefix synchronizes the IPs allocated by all the SpiderIPPools of the
+// prefix, since the SpiderIPPools of a SpiderSubnet share the same subnet.
+func (s *Syncer) syncPrefix(ctx context.Context, prefix Prefix, pools []*spiderpoolv2beta1.SpiderIPPool) error {
+	addresses, err := s.extClient.ListAddresses(ctx, prefix.Prefix)
+	if err != nil {
+		return err
+	}
+
+	managed := map[string]Address{}
+	unmanaged := map[string]Address{}
+	for _, address := range addresses {
+		ip := net.ParseIP(address.IP)
+		if ip == nil {
+			continue
+		}
+		if address.Managed {
+			managed[ip.String()] = address
+		} else {
+			unmanaged[ip.String()] = address
+		}
+	}
+
+	allocated := map[string]struct{}{}
+	// the IPs allocated by the SpiderIPPool failed to parse are unknown, so
+	// none of the prefix is released
+	parseFailed := false
+	var errs []error
+	for _, pool := range pools {
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse allocated IPs of SpiderIPPool %s: %w", pool.Name, err))
+			parseFailed = true
+			continue
+		}
+
+		for ipStr, record := range records {
+			ip := net.ParseIP(ipStr)
+			if ip == nil {
+				continue
+			}
+			allocated[ip.String()] = struct{}{}
+
+			if address, ok := unmanaged[ip.String()]; ok {
+				logger.Sugar().Warnf("IP %s allocated by SpiderIPPool %s conflicts with external IPAM address '%s'", ip, pool.Name, address.Description)
+				event.EventRecorder.Eventf(pool, corev1.EventTypeWarning, constant.EventReasonExternalIPAMConflict,
+					"IP %s allocated to Pod %s conflicts with the external IPAM address '%s'", ip, record.NamespacedName, address.Description)
+				continue
+			}
+			if _, ok := managed[ip.String()]; ok {
+				continue
+			}
+
+			err := s.extClient.ReserveIP(ctx, &ReserveIPRequest{
+				IP:       ip.String(),
+				Prefix:   prefix.Prefix,
+				PoolName: pool.Name,
+				Pod:      record.NamespacedName,
+				PodUID:   record.PodUID,
+			})
+			if err != nil {
+				event.EventRecorder.Eventf(pool, corev1.EventTypeWarning, constant.EventReasonExternalIPAMSyncFailed,
+					"failed to reserve IP %s in external IPAM: %v", ip, err)
+				errs = append(errs, err)
+				continue
+			}
+			logger.Sugar().Infof("reserved IP %s of SpiderIPPool %s in external IPAM", ip, pool.Name)
+		}
+	}
+
+	for ip, address := range managed {
+		if parseFailed {
+			logger.Sugar().Warnf("skip releasing the IPs of prefix %s in external IPAM, since the allocated IPs of some SpiderIPPools failed to parse", prefix.Prefix)
+			break
+		}
+		if _, ok := allocated[ip]; ok {
+			continue
+		}
+		if err := s.extClient.ReleaseIP(ctx, address); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		logger.Sugar().Infof("released IP %s in external IPAM", ip)
+	}
+
+	if err := s.syncConflicts(ctx, prefix, unmanaged); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncConflicts writes the addresses recorded by others in the external
+// IPAM to the SpiderReservedIP of the prefix.
+func (s *Syncer) syncConflicts(ctx context.Context, prefix Prefix, unmanaged map[string]Address) error {
+	_, ipNet, err := net.ParseCIDR(prefix.Prefix)
+	if err != nil {
+		return err
+	}
+	version := ipVersion(ipNet.IP)
+
+	ips := make([]net.IP, 0, len(unmanaged))
+	for ip := range unmanaged {
+		ips = append(ips, net.ParseIP(ip))
+	}
+	ranges, err := spiderpoolip.ConvertIPsToIPRanges(version, ips)
+	if err != nil {
+		return err
+	}
+
+	name := constant.ExternalIPAMReservedIPNamePrefix + cidrName(prefix.Prefix)
+	var rIP spiderpoolv2beta1.SpiderReservedIP
+	err = s.apiReader.Get(ctx, client.ObjectKey{Name: name}, &rIP)
+	if apierrors.IsNotFound(err) {
+		if len(ranges) == 0 {
+			return nil
+		}
+		rIP = spiderpoolv2beta1.SpiderReservedIP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{constant.LabelExternalIPAMPrefix: prefix.ID},
+			},
+			Spec: spiderpoolv2beta1.ReservedIPSpec{
+				IPVersion: ptr.To(version),
+				IPs:       ranges,
+			},
+		}
+		if err := s.client.Create(ctx, &rIP); err != nil {
+			return fmt.Errorf("failed to create SpiderReservedIP %s: %w", name, err)
+		}
+		logger.Sugar().Infof("created SpiderReservedIP %s with the external IPAM conflicts %v", name, ranges)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get SpiderReservedIP %s: %w", name, err)
+	}
+
+	if len(ranges) == 0 {
+		if err := s.client.Delete(ctx, &rIP); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete SpiderReservedIP %s: %w", name, err)
+		}
+		logger.Sugar().Infof("deleted SpiderReservedIP %s since the external IPAM conflicts are resolved", name)
+		return nil
+	}
+
+	if reflect.DeepEqual(rIP.Spec.IPs, ranges) {
+		return nil
+	}
+	rIP.Spec.IPs = ranges
+	if err := s.client.Update(ctx, &rIP); err != nil {
+		return fmt.Errorf("failed to update SpiderReservedIP %s: %w", name, err)
+	}
+	logger.Sugar().Infof("updated SpiderReservedIP %s with the external IPAM conflicts %v", name, ranges)
+
+	return nil
+}
+
+// importPrefix creates the SpiderIPPool of the prefix, with all the usable
+// IPs of the prefix, up to s.config.MaxImportPrefixSize.
+func (s *Syncer) importPrefix(ctx context.Context, prefix Prefix) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	_, ipNet, err := net.ParseCIDR(prefix.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	version := ipVersion(ipNet.IP)
+
+	pool := &spiderpoolv2beta1.SpiderIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   constant.ExternalIPAMPoolNamePrefix + cidrName(prefix.Prefix),
+			Labels: map[string]string{constant.LabelExternalIPAMPrefix: prefix.ID},
+		},
+		Spec: spiderpoolv2beta1.IPPoolSpec{
+			IPVersion: ptr.To(version),
+			Subnet:    ipNet.String(),
+			IPs:       []string{usableIPRange(ipNet, s.config.MaxImportPrefixSize)},
+		},
+	}
+	if gateway := net.ParseIP(prefix.Gateway); gateway != nil && ipNet.Contains(gateway) {
+		pool.Spec.Gateway = ptr.To(gateway.String())
+		pool.Spec.ExcludeIPs = []string{gateway.String()}
+	}
+
+	if err := s.client.Create(ctx, pool); err != nil {
+		return nil, fmt.Errorf("failed to import external IPAM prefix %s as SpiderIPPool %s: %w", prefix.Prefix, pool.Name, err)
+	}
+	logger.Sugar().Infof("imported external IPAM prefix %s as SpiderIPPool %s", prefix.Prefix, pool.Name)
+
+	return pool, nil
+}
+
+// usableIPRange returns the IP range of the subnet, the network and
+// broadcast addresses of IPv4 subnet are excluded. The range is truncated to
+// the first maxSize IPs.
+func usableIPRange(ipNet *net.IPNet, maxSize int64) string {
+	ones, bits := ipNet.Mask.Size()
+	first := new(big.Int).SetBytes(ipNet.IP)
+	last := new(big.Int).Add(first, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits-ones)), big.NewInt(1)))
+	if ipNet.IP.To4() != nil && bits-ones > 1 {
+		first.Add(first, big.NewInt(1))
+		last.Sub(last, big.NewInt(1))
+	}
+
+	if maxLast := new(big.Int).Add(first, big.NewInt(maxSize-1)); last.Cmp(maxLast) > 0 {
+		logger.Sugar().Warnf("the prefix %s has more than %d usable IPs, only the first %d are imported", ipNet, maxSize, maxSize)
+		last = maxLast
+	}
+
+	return fmt.Sprintf("%s-%s", bigIntToIP(first, len(ipNet.IP)), bigIntToIP(last, len(ipNet.IP)))
+}
+
+func bigIntToIP(i *big.Int, length int) net.IP {
+	ip := make(net.IP, length)
+	i.FillBytes(ip)
+	return ip
+}
+
+func ipVersion(ip net.IP) types.IPVersion {
+	if ip.To4() != nil {
+		return constant.IPv4
+	}
+
+	return constant.IPv6
+}
+
+// cidrName converts the CIDR to a valid name of Kubernetes object.
+func cidrName(cidr string) string {
+	return strings.NewReplacer(".", "-", ":", "-", "/", "-").Replace(normalizeCIDR(cidr))
+}
+
+func normalizeCIDR(cidr string) string {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return cidr
+	}
+
+	return ipNet.String()
+}
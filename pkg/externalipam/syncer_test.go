@@ -0,0 +1,200 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package externalipam_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	electionmock "github.com/spidernet-io/spiderpool/pkg/election/mock"
+	"github.com/spidernet-io/spiderpool/pkg/externalipam"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("External IPAM synchronization", Label("external_ipam_test"), func() {
+	var mock *mockNetBox
+	var extClient externalipam.Client
+	var scheme *runtime.Scheme
+	var mockCtrl *gomock.Controller
+	var leader *electionmock.MockSpiderLeaseElector
+
+	BeforeEach(func() {
+		mock = newMockNetBox()
+		DeferCleanup(mock.server.Close)
+		mock.prefixes = []map[string]any{
+			{"id": 7, "prefix": "10.6.0.0/24", "custom_fields": map[string]any{"gateway": "10.6.0.1/24"}},
+		}
+
+		var err error
+		extClient, err = externalipam.NewClient(&spiderpooltypes.ExternalIPAMConfig{
+			Enabled:   true,
+			Driver:    externalipam.DriverNetBox,
+			ServerURL: mock.server.URL,
+		}, "secret", zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+
+		scheme = runtime.NewScheme()
+		Expect(spiderpoolv2beta1.AddToScheme(scheme)).To(Succeed())
+
+		mockCtrl = gomock.NewController(GinkgoT())
+		leader = electionmock.NewMockSpiderLeaseElector(mockCtrl)
+	})
+
+	newPool := func(allocatedIPs string) *spiderpoolv2beta1.SpiderIPPool {
+		return &spiderpoolv2beta1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+			Spec: spiderpoolv2beta1.IPPoolSpec{
+				IPVersion: ptr.To(constant.IPv4),
+				Subnet:    "10.6.0.0/24",
+				IPs:       []string{"10.6.0.10-10.6.0.100"},
+			},
+			Status: spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: ptr.To(allocatedIPs)},
+		}
+	}
+
+	It("imports the prefixes as SpiderIPPools", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{ImportPrefixes: true}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(syncer.SyncAll(context.Background())).To(Succeed())
+
+		var pool spiderpoolv2beta1.SpiderIPPool
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Name: "external-ipam-10-6-0-0-24"}, &pool)).To(Succeed())
+		Expect(pool.Labels).To(HaveKeyWithValue(constant.LabelExternalIPAMPrefix, "7"))
+		Expect(pool.Spec.IPVersion).To(Equal(ptr.To(constant.IPv4)))
+		Expect(pool.Spec.Subnet).To(Equal("10.6.0.0/24"))
+		Expect(pool.Spec.IPs).To(Equal([]string{"10.6.0.1-10.6.0.254"}))
+		Expect(pool.Spec.Gateway).To(Equal(ptr.To("10.6.0.1")))
+		Expect(pool.Spec.ExcludeIPs).To(Equal([]string{"10.6.0.1"}))
+	})
+
+	It("imports the first IPs of the large prefixes", func() {
+		mock.prefixes = []map[string]any{{"id": 8, "prefix": "fd00:6::/64"}}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{ImportPrefixes: true}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(syncer.SyncAll(context.Background())).To(Succeed())
+
+		var pool spiderpoolv2beta1.SpiderIPPool
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Name: "external-ipam-fd00-6---64"}, &pool)).To(Succeed())
+		Expect(pool.Spec.Subnet).To(Equal("fd00:6::/64"))
+		Expect(pool.Spec.IPs).To(Equal([]string{"fd00:6::-fd00:6::ffff"}))
+	})
+
+	It("does not import the prefix with existing SpiderIPPool", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPool(`{}`)).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{ImportPrefixes: true}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(syncer.SyncAll(context.Background())).To(Succeed())
+
+		var poolList spiderpoolv2beta1.SpiderIPPoolList
+		Expect(fakeClient.List(context.Background(), &poolList)).To(Succeed())
+		Expect(poolList.Items).To(HaveLen(1))
+	})
+
+	It("reserves and releases the allocations, and writes back the conflicts", func() {
+		mock.addAddress("10.6.0.12/24", "spiderpool: default/old (SpiderIPPool underlay)")
+		mock.addAddress("10.6.0.11/24", "database")
+		mock.addAddress("10.6.0.20/24", "router")
+
+		allocatedIPs := `{"10.6.0.10":{"pod":"default/pod-a","podUid":"uid-a"},"10.6.0.11":{"pod":"default/pod-b","podUid":"uid-b"}}`
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPool(allocatedIPs)).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(syncer.SyncAll(context.Background())).To(Succeed())
+		Expect(mock.addressList()).To(Equal(map[string]string{
+			"10.6.0.10/24": "spiderpool: default/pod-a (SpiderIPPool underlay)",
+			"10.6.0.11/24": "database",
+			"10.6.0.20/24": "router",
+		}))
+
+		var rIP spiderpoolv2beta1.SpiderReservedIP
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Name: "external-ipam-conflict-10-6-0-0-24"}, &rIP)).To(Succeed())
+		Expect(rIP.Labels).To(HaveKeyWithValue(constant.LabelExternalIPAMPrefix, "7"))
+		Expect(rIP.Spec.IPVersion).To(Equal(ptr.To(constant.IPv4)))
+		Expect(rIP.Spec.IPs).To(Equal([]string{"10.6.0.11", "10.6.0.20"}))
+
+		// The conflicts are resolved in the external IPAM.
+		mock.lock.Lock()
+		for id, a := range mock.addresses {
+			if a["description"] != "spiderpool: default/pod-a (SpiderIPPool underlay)" {
+				delete(mock.addresses, id)
+			}
+		}
+		mock.lock.Unlock()
+
+		Expect(syncer.SyncSubnet(context.Background(), "10.6.0.0/24")).To(Succeed())
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: "external-ipam-conflict-10-6-0-0-24"}, &rIP)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(mock.addressList()).To(Equal(map[string]string{
+			"10.6.0.10/24": "spiderpool: default/pod-a (SpiderIPPool underlay)",
+			"10.6.0.11/24": "spiderpool: default/pod-b (SpiderIPPool underlay)",
+		}))
+	})
+
+	It("releases nothing if the allocated IPs of any SpiderIPPool fail to parse", func() {
+		mock.addAddress("10.6.0.12/24", "spiderpool: default/old (SpiderIPPool underlay)")
+
+		brokenPool := newPool(`invalid`)
+		brokenPool.Name = "broken"
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPool(`{}`), brokenPool).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(syncer.SyncAll(context.Background())).NotTo(Succeed())
+		Expect(mock.addressList()).To(HaveKey("10.6.0.12/24"))
+	})
+
+	It("ignores the subnet which is not a prefix of external IPAM", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(syncer.SyncSubnet(context.Background(), "172.16.0.0/16")).To(Succeed())
+		Expect(mock.addressList()).To(BeEmpty())
+	})
+
+	It("synchronizes the subnet once the SpiderIPPool allocates IPs", func() {
+		leader.EXPECT().IsElected().Return(true).AnyTimes()
+
+		oldPool := newPool(`{}`)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(oldPool).Build()
+		syncer, err := externalipam.NewSyncer(externalipam.SyncerConfig{Interval: time.Hour}, fakeClient, fakeClient, extClient, leader)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go syncer.Start(ctx)
+
+		newPool := oldPool.DeepCopy()
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(newPool), newPool)).To(Succeed())
+		newPool.Status.AllocatedIPs = ptr.To(`{"10.6.0.30":{"pod":"default/pod-c","podUid":"uid-c"}}`)
+		Expect(fakeClient.Update(ctx, newPool)).To(Succeed())
+
+		syncer.EventHandler().OnUpdate(oldPool, newPool)
+		Eventually(mock.addressList).Should(HaveKey("10.6.0.30/24"))
+	})
+
+	It("requires the parameters", func() {
+		_, err := externalipam.NewSyncer(externalipam.SyncerConfig{}, nil, nil, extClient, leader)
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+	})
+})
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/limiter"
@@ -78,6 +79,8 @@ type SpiderGC struct {
 	nodeMgr     nodemanager.NodeManager
 	leader      election.SpiderLeaseElector
 	iaasClient  iaasclient.Client
+	// iaasReleaseQueue persists the IaaS releases which fail, to retry them in the IaaS reconciler
+	iaasReleaseQueue iaasreconciler.PendingReleaseQueue
 
 	informerFactory informers.SharedInformerFactory
 	gcLimiter       limiter.Limiter
@@ -93,6 +96,7 @@ func NewGCManager(clientSet *kubernetes.Clientset, config *GarbageCollectionConf
 	nodeMgr nodemanager.NodeManager,
 	spiderControllerLeader election.SpiderLeaseElector,
 	iaasClient iaasclient.Client,
+	iaasReleaseQueue iaasreconciler.PendingReleaseQueue,
 ) (GCManager, error) {
 	if clientSet == nil {
 		return nil, fmt.Errorf("k8s ClientSet must be specified")
@@ -134,10 +138,11 @@ func NewGCManager(clientSet *kubernetes.Clientset, config *GarbageCollectionConf
 		kubevirtMgr: kubevirtMgr,
 		nodeMgr:     nodeMgr,
 
-		leader:     spiderControllerLeader,
-		iaasClient: iaasClient,
-		gcLimiter:  limiter.NewLimiter(limiter.LimiterConfig{}),
-		Locker:     lock.Mutex{},
+		leader:           spiderControllerLeader,
+		iaasClient:       iaasClient,
+		iaasReleaseQueue: iaasReleaseQueue,
+		gcLimiter:        limiter.NewLimiter(limiter.LimiterConfig{}),
+		Locker:           lock.Mutex{},
 	}
 
 	return spiderGC, nil
@@ -210,3 +215,9 @@ func (s *SpiderGC) Health() bool {
 
 	return true
 }
+
+// releaseIaaSIP calls the IaaS provider to release the IP, the release is
+// added to the pending queue to retry if it fails, see iaasreconciler.ReleaseIP.
+func (s *SpiderGC) releaseIaaSIP(ctx context.Context, req *iaasclient.ReleaseIPRequest) error {
+	return iaasreconciler.ReleaseIP(ctx, s.iaasClient, s.iaasReleaseQueue, req)
+}
@@ -5,7 +5,9 @@ package gcmanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
@@ -402,14 +405,18 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 						if endpoint != nil {
 							nodeName = endpoint.Status.Current.Node
 						}
-						if releaseErr := s.iaasClient.ReleaseIP(ctx, &iaasclient.ReleaseIPRequest{
+						if releaseErr := s.releaseIaaSIP(ctx, &iaasclient.ReleaseIPRequest{
 							PodName:      podName,
 							PodNamespace: podNS,
 							PodUID:       poolIPAllocation.PodUID,
 							NodeName:     nodeName,
-							Subnet:       pool.Spec.Subnet,
+							Subnet:       convert.IPPoolSubnetOfIP(&pool, net.ParseIP(poolIP)).Subnet,
 							IPAddress:    poolIP,
-						}); releaseErr != nil {
+							IPVersion:    *pool.Spec.IPVersion,
+							Prefix:       poolIPAllocation.Prefix,
+						}); errors.Is(releaseErr, iaasreconciler.ErrReleasePending) {
+							scanAllLogger.Sugar().Warnf("failed to release IaaS IP '%s', retry it later, error: '%v'", poolIP, releaseErr)
+						} else if releaseErr != nil {
 							scanAllLogger.Sugar().Errorf("failed to release IaaS IP '%s', error: '%v'", poolIP, releaseErr)
 						} else {
 							scanAllLogger.Sugar().Infof("scan all successfully released IaaS IP %s", poolIP)
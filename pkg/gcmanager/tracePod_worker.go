@@ -5,6 +5,7 @@ package gcmanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
+	iaasutils "github.com/spidernet-io/spiderpool/pkg/iaas/utils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
@@ -165,10 +168,10 @@ func (s *SpiderGC) releaseIPPoolIPExecutor(ctx context.Context, workerIndex int)
 				// Release IPs from IaaS provider after releasing from internal IPPools
 				if s.iaasClient != nil {
 					for _, detail := range endpoint.Status.Current.IPs {
-						if detail.IPv4 != nil {
-							ip, subnet, err := net.ParseCIDR(*detail.IPv4)
+						for _, address := range iaasutils.GetEndpointAddresses(detail) {
+							ip, subnet, err := net.ParseCIDR(address.Address)
 							if err != nil {
-								log.Sugar().Errorf("failed to parse CIDR '%s', error: %v, skip releasing IaaS IP '%s'", *detail.IPv4, err, *detail.IPv4)
+								log.Sugar().Errorf("failed to parse CIDR '%s', error: %v, skip releasing IaaS IP '%s'", address.Address, err, address.Address)
 								continue
 							}
 							req := &iaasclient.ReleaseIPRequest{
@@ -178,11 +181,18 @@ func (s *SpiderGC) releaseIPPoolIPExecutor(ctx context.Context, workerIndex int)
 								NodeName:     endpoint.Status.Current.Node,
 								Subnet:       subnet.String(),
 								IPAddress:    ip.String(),
+								IPVersion:    address.IPVersion,
+								Prefix:       address.Prefix,
 							}
-							if err := s.iaasClient.ReleaseIP(ctx, req); err != nil {
-								log.Sugar().Errorf("failed to release IaaS IP '%s' for '%s/%s', error: %v",
+							if err := s.releaseIaaSIP(ctx, req); err != nil {
+								if !errors.Is(err, iaasreconciler.ErrReleasePending) {
+									log.Sugar().Errorf("failed to release IaaS IP '%s' for '%s/%s', error: %v",
+										ip.String(), podCache.Namespace, podCache.PodName, err)
+									return err
+								}
+								log.Sugar().Warnf("failed to release IaaS IP '%s' for '%s/%s', retry it later, error: %v",
 									ip.String(), podCache.Namespace, podCache.PodName, err)
-								return err
+								continue
 							}
 							log.Sugar().Infof("successfully released IaaS IP '%s' for '%s/%s'",
 								ip.String(), podCache.Namespace, podCache.PodName)
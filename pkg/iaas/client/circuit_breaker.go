@@ -0,0 +1,289 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// ErrCircuitOpen is returned without calling the IaaS provider while the
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("IaaS provider circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed calls the provider normally.
+	CircuitClosed CircuitState = iota
+	// CircuitHalfOpen lets a single call probe the provider.
+	CircuitHalfOpen
+	// CircuitOpen fails the calls without calling the provider.
+	CircuitOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitHalfOpen:
+		return "half-open"
+	case CircuitOpen:
+		return "open"
+	}
+
+	return "unknown"
+}
+
+// CircuitBreaker wraps the AllocateIPs and ReleaseIP calls of a Client, so
+// that the CNI calls fail fast once the provider is unavailable instead of
+// waiting for the request timeout.
+type CircuitBreaker struct {
+	Client
+
+	failureThreshold int
+	openDuration     time.Duration
+	maxOpenDuration  time.Duration
+	logger           *zap.Logger
+
+	lock sync.Mutex
+	// state is guarded by lock, as well as the fields below.
+	state               CircuitState
+	consecutiveFailures int
+	lastError           error
+	openedAt            time.Time
+	currentOpenDuration time.Duration
+	probing             bool
+
+	// now is replaced in unit tests.
+	now func() time.Time
+}
+
+// IsCircuitBreakerEnabled returns whether the circuit breaker is configured.
+func IsCircuitBreakerEnabled(cfg *spiderpooltypes.IaaSProviderConfig) bool {
+	return cfg.CircuitBreaker.FailureThreshold > 0
+}
+
+// NewCircuitBreaker wraps the client with the circuit breaker configuration.
+func NewCircuitBreaker(client Client, cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (*CircuitBreaker, error) {
+	if client == nil {
+		return nil, fmt.Errorf("IaaS client %w", constant.ErrMissingRequiredParam)
+	}
+
+	openDuration, maxOpenDuration, err := circuitBreakerDurations(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &CircuitBreaker{
+		Client:              client,
+		failureThreshold:    cfg.CircuitBreaker.FailureThreshold,
+		openDuration:        openDuration,
+		maxOpenDuration:     maxOpenDuration,
+		logger:              logger,
+		currentOpenDuration: openDuration,
+		now:                 time.Now,
+	}
+	metric.IaaSCircuitBreakerState.Record(int64(CircuitClosed))
+
+	return b, nil
+}
+
+func circuitBreakerDurations(cfg *spiderpooltypes.IaaSProviderConfig) (time.Duration, time.Duration, error) {
+	openDuration := constant.DefaultIaaSCircuitBreakerOpenDuration
+	if cfg.CircuitBreaker.OpenDuration != "" {
+		d, err := time.ParseDuration(cfg.CircuitBreaker.OpenDuration)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid iaasNetworkProvider.circuitBreaker.openDuration %q: %w", cfg.CircuitBreaker.OpenDuration, err)
+		}
+		if d <= 0 {
+			return 0, 0, fmt.Errorf("invalid iaasNetworkProvider.circuitBreaker.openDuration %q: duration must be positive", cfg.CircuitBreaker.OpenDuration)
+		}
+		openDuration = d
+	}
+
+	maxOpenDuration := constant.DefaultIaaSCircuitBreakerMaxOpenDuration
+	if cfg.CircuitBreaker.MaxOpenDuration != "" {
+		d, err := time.ParseDuration(cfg.CircuitBreaker.MaxOpenDuration)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid iaasNetworkProvider.circuitBreaker.maxOpenDuration %q: %w", cfg.CircuitBreaker.MaxOpenDuration, err)
+		}
+		maxOpenDuration = d
+	}
+	if maxOpenDuration < openDuration {
+		return 0, 0, fmt.Errorf("invalid iaasNetworkProvider.circuitBreaker.maxOpenDuration %v: must not be less than openDuration %v", maxOpenDuration, openDuration)
+	}
+
+	return openDuration, maxOpenDuration, nil
+}
+
+// AllocateIPs calls the IaaS provider to allocate IPs if the circuit allows.
+func (b *CircuitBreaker) AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*AllocateIPResponse, error) {
+	if err := b.allow(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := b.Client.AllocateIPs(ctx, req)
+	b.record(ctx, err)
+
+	return resp, err
+}
+
+// ReleaseIP calls the IaaS provider to release an IP if the circuit allows.
+func (b *CircuitBreaker) ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error {
+	if err := b.allow(ctx); err != nil {
+		return err
+	}
+
+	err := b.Client.ReleaseIP(ctx, req)
+	b.record(ctx, err)
+
+	return err
+}
+
+// State returns the current state of the circuit breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.state
+}
+
+// Ready returns an error with the last provider failure while the circuit is
+// open, which is the readiness condition of spiderpool-agent. Once the open
+// duration elapses, the next call is allowed to probe the provider, so it's
+// ready again even if there is no call to move the circuit to half-open.
+func (b *CircuitBreaker) Ready() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state != CircuitOpen || !b.now().Before(b.openedAt.Add(b.currentOpenDuration)) {
+		return nil
+	}
+
+	return fmt.Errorf("%w since %s after %d consecutive failures, last error: %v",
+		ErrCircuitOpen, b.openedAt.Format(time.RFC3339), b.consecutiveFailures, b.lastError)
+}
+
+func (b *CircuitBreaker) allow(ctx context.Context) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case CircuitClosed:
+		return nil
+	case CircuitOpen:
+		retryAfter := b.openedAt.Add(b.currentOpenDuration).Sub(b.now())
+		if retryAfter <= 0 {
+			b.setState(CircuitHalfOpen)
+			b.probing = true
+			return nil
+		}
+		return b.reject(ctx, fmt.Errorf("%w, retry after %v, last error: %v", ErrCircuitOpen, retryAfter.Round(time.Second), b.lastError))
+	default:
+		if !b.probing {
+			b.probing = true
+			return nil
+		}
+		return b.reject(ctx, fmt.Errorf("%w, probing the provider, last error: %v", ErrCircuitOpen, b.lastError))
+	}
+}
+
+func (b *CircuitBreaker) reject(ctx context.Context, err error) error {
+	metric.IaaSCircuitBreakerRejectedCounts.Add(ctx, 1)
+	return err
+}
+
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+	}
+
+	if err != nil && !isProviderFailure(ctx, err) {
+		// The call did not reach the provider, or the provider rejected the
+		// request itself, neither proves the provider is available or not.
+		if ctx.Err() != nil || errors.Is(err, ErrParentBudgetInsufficient) {
+			return
+		}
+		err = nil
+	}
+
+	if err == nil {
+		if b.state != CircuitClosed {
+			b.logger.Info("IaaS provider recovered, close the circuit breaker")
+		}
+		b.consecutiveFailures = 0
+		b.currentOpenDuration = b.openDuration
+		b.setState(CircuitClosed)
+		return
+	}
+
+	b.consecutiveFailures++
+	b.lastError = err
+	switch b.state {
+	case CircuitHalfOpen:
+		b.currentOpenDuration *= 2
+		if b.currentOpenDuration > b.maxOpenDuration {
+			b.currentOpenDuration = b.maxOpenDuration
+		}
+		b.open()
+	case CircuitClosed:
+		if b.consecutiveFailures >= b.failureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.now()
+	b.setState(CircuitOpen)
+	b.logger.Warn("IaaS provider is unavailable, open the circuit breaker",
+		zap.Int("consecutiveFailures", b.consecutiveFailures),
+		zap.Duration("openDuration", b.currentOpenDuration),
+		zap.Error(b.lastError))
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	b.state = state
+	metric.IaaSCircuitBreakerState.Record(int64(state))
+}
+
+// isProviderFailure returns whether the error means the IaaS provider is
+// unavailable, like the connection failure, the timeout and the 5xx status.
+func isProviderFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrParentBudgetInsufficient) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+			return true
+		}
+		return false
+	}
+
+	return true
+}
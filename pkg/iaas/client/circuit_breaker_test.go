@@ -0,0 +1,216 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// stubClient returns the queued errors of the calls in order.
+type stubClient struct {
+	*parentNicMacCache
+
+	errs  []error
+	calls int
+}
+
+func (s *stubClient) next() error {
+	s.calls++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func (s *stubClient) AllocateIPs(context.Context, *AllocateIPRequest) (*AllocateIPResponse, error) {
+	if err := s.next(); err != nil {
+		return nil, err
+	}
+	return &AllocateIPResponse{}, nil
+}
+
+func (s *stubClient) ReleaseIP(context.Context, *ReleaseIPRequest) error {
+	return s.next()
+}
+
+func (s *stubClient) ListAssignments(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	return &ListAssignmentsResponse{}, nil
+}
+
+var _ = Describe("IaaS circuit breaker", Label("unitest"), func() {
+	var stub *stubClient
+	var breaker *CircuitBreaker
+	var now time.Time
+	var unavailable error
+
+	BeforeEach(func() {
+		stub = &stubClient{}
+		cfg := &spiderpooltypes.IaaSProviderConfig{
+			ServerURL: "http://localhost:8080",
+			CircuitBreaker: spiderpooltypes.IaaSCircuitBreakerConfig{
+				FailureThreshold: 2,
+				OpenDuration:     "10s",
+				MaxOpenDuration:  "30s",
+			},
+		}
+		Expect(IsCircuitBreakerEnabled(cfg)).To(BeTrue())
+
+		var err error
+		breaker, err = NewCircuitBreaker(stub, cfg, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		now = time.Now()
+		breaker.now = func() time.Time { return now }
+		unavailable = fmt.Errorf("iaas allocate API call failed: %w", errors.New("connection refused"))
+	})
+
+	It("opens after the consecutive failures and fails fast", func() {
+		stub.errs = []error{unavailable, unavailable}
+
+		_, err := breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).To(MatchError(unavailable))
+		Expect(breaker.State()).To(Equal(CircuitClosed))
+		Expect(breaker.ReleaseIP(context.Background(), &ReleaseIPRequest{})).To(MatchError(unavailable))
+		Expect(breaker.State()).To(Equal(CircuitOpen))
+		Expect(breaker.Ready()).To(MatchError(ErrCircuitOpen))
+
+		_, err = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).To(MatchError(ErrCircuitOpen))
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+		Expect(stub.calls).To(Equal(2))
+	})
+
+	It("probes the provider after the open duration with backoff", func() {
+		stub.errs = []error{unavailable, unavailable, unavailable}
+		for range 2 {
+			_, _ = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		}
+		Expect(breaker.State()).To(Equal(CircuitOpen))
+
+		// The probe fails, so the open duration is doubled.
+		now = now.Add(10 * time.Second)
+		_, err := breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).To(MatchError(unavailable))
+		Expect(breaker.State()).To(Equal(CircuitOpen))
+
+		now = now.Add(10 * time.Second)
+		_, err = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).To(MatchError(ErrCircuitOpen))
+
+		now = now.Add(10 * time.Second)
+		_, err = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(breaker.State()).To(Equal(CircuitClosed))
+		Expect(breaker.Ready()).To(Succeed())
+		Expect(stub.calls).To(Equal(4))
+	})
+
+	It("is ready again once the open duration elapses without any call", func() {
+		stub.errs = []error{unavailable, unavailable}
+		for range 2 {
+			_, _ = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		}
+		Expect(breaker.Ready()).To(MatchError(ErrCircuitOpen))
+
+		// The provider recovers, but no call comes to probe it.
+		now = now.Add(5 * time.Second)
+		Expect(breaker.Ready()).To(MatchError(ErrCircuitOpen))
+		now = now.Add(5 * time.Second)
+		Expect(breaker.Ready()).To(Succeed())
+		Expect(breaker.State()).To(Equal(CircuitOpen))
+		Expect(stub.calls).To(Equal(2))
+
+		_, err := breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(breaker.State()).To(Equal(CircuitClosed))
+		Expect(breaker.Ready()).To(Succeed())
+	})
+
+	It("is not ready during the doubled open duration after a failed probe", func() {
+		stub.errs = []error{unavailable, unavailable, unavailable}
+		for range 2 {
+			_, _ = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		}
+
+		now = now.Add(10 * time.Second)
+		Expect(breaker.Ready()).To(Succeed())
+		_, err := breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		Expect(err).To(MatchError(unavailable))
+		Expect(breaker.Ready()).To(MatchError(ErrCircuitOpen))
+
+		now = now.Add(10 * time.Second)
+		Expect(breaker.Ready()).To(MatchError(ErrCircuitOpen))
+		now = now.Add(10 * time.Second)
+		Expect(breaker.Ready()).To(Succeed())
+	})
+
+	It("lets only one call probe the provider", func() {
+		stub.errs = []error{unavailable, unavailable}
+		for range 2 {
+			_, _ = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+		}
+
+		now = now.Add(10 * time.Second)
+		Expect(breaker.allow(context.Background())).To(Succeed())
+		Expect(breaker.State()).To(Equal(CircuitHalfOpen))
+		Expect(breaker.allow(context.Background())).To(MatchError(ErrCircuitOpen))
+	})
+
+	DescribeTable("does not count the errors which are not caused by the provider availability",
+		func(err error) {
+			stub.errs = []error{err, err, err}
+			for range 3 {
+				_, _ = breaker.AllocateIPs(context.Background(), &AllocateIPRequest{})
+			}
+			Expect(breaker.State()).To(Equal(CircuitClosed))
+		},
+		Entry("client error", &StatusError{API: "allocate", StatusCode: 409, Body: "conflict"}),
+		Entry("parent budget", fmt.Errorf("%w: 1s remaining", ErrParentBudgetInsufficient)),
+		Entry("gRPC invalid argument", status.Error(codes.InvalidArgument, "invalid subnet")),
+	)
+
+	DescribeTable("counts the errors of the provider availability",
+		func(err error) {
+			Expect(isProviderFailure(context.Background(), err)).To(BeTrue())
+		},
+		Entry("server error", &StatusError{API: "release", StatusCode: 503}),
+		Entry("rate limit", &StatusError{API: "release", StatusCode: 429}),
+		Entry("gRPC unavailable", fmt.Errorf("iaas AllocateIPs call failed: %w", status.Error(codes.Unavailable, "connection refused"))),
+		Entry("timeout", errors.New("provider-interaction timeout: context deadline exceeded")),
+	)
+
+	It("does not count the error of the canceled parent context", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(isProviderFailure(ctx, unavailable)).To(BeFalse())
+	})
+
+	DescribeTable("validates the configuration",
+		func(cbCfg spiderpooltypes.IaaSCircuitBreakerConfig, errMsg string) {
+			err := ValidateConfig(&spiderpooltypes.IaaSProviderConfig{ServerURL: "http://localhost:8080", CircuitBreaker: cbCfg})
+			if errMsg == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(errMsg)))
+		},
+		Entry("disabled", spiderpooltypes.IaaSCircuitBreakerConfig{}, ""),
+		Entry("valid", spiderpooltypes.IaaSCircuitBreakerConfig{FailureThreshold: 5, OpenDuration: "10s", MaxOpenDuration: "2m"}, ""),
+		Entry("negative threshold", spiderpooltypes.IaaSCircuitBreakerConfig{FailureThreshold: -1}, "circuitBreaker.failureThreshold"),
+		Entry("invalid open duration", spiderpooltypes.IaaSCircuitBreakerConfig{FailureThreshold: 5, OpenDuration: "0s"}, "circuitBreaker.openDuration"),
+		Entry("max less than open duration", spiderpooltypes.IaaSCircuitBreakerConfig{FailureThreshold: 5, OpenDuration: "1m", MaxOpenDuration: "10s"}, "circuitBreaker.maxOpenDuration"),
+	)
+})
@@ -6,7 +6,6 @@ package client
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,18 +13,17 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
-	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
 )
 
 const (
 	allocateAPIPath           = "/v1/apis/network.iaas.io/ipam/allocate-ips"
 	releaseAPIPath            = "/v1/apis/network.iaas.io/ipam/release-ip"
+	listAssignmentsAPIPath    = "/v1/apis/network.iaas.io/ipam/list-assignments"
 	requestTimeoutMsHeader    = "X-Request-Timeout-Ms"
 	nanosecondsPerMillisecond = int64(time.Millisecond)
 )
@@ -39,6 +37,17 @@ func isTimeoutError(err error) bool {
 	return strings.Contains(errStr, "timeout") || strings.Contains(errStr, "deadline exceeded")
 }
 
+// StatusError is returned if the IaaS provider API returns a non-2xx status.
+type StatusError struct {
+	API        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("iaas %s API returned status %d: %s", e.API, e.StatusCode, e.Body)
+}
+
 func setRequestTimeoutHeader(httpReq *http.Request) {
 	deadline, ok := httpReq.Context().Deadline()
 	if !ok {
@@ -60,11 +69,20 @@ type Client interface {
 	AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*AllocateIPResponse, error)
 	// ReleaseIPs calls the IaaS provider to release IPs
 	ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error
+	// ListAssignments calls the IaaS provider to list a page of the bound IPs
+	ListAssignments(ctx context.Context, req *ListAssignmentsRequest) (*ListAssignmentsResponse, error)
 	// GetCachedParentNicMac returns the cached parent NIC MAC for the given key,
-	// or empty string if not cached. Key is SpiderMultusConfig namespace/name.
+	// or empty string if not cached or expired. Key is SpiderMultusConfig
+	// namespace/name or the subnet CIDR.
 	GetCachedParentNicMac(key string) (string, bool)
 	// CacheParentNicMac stores a parent NIC MAC for the given key.
-	CacheParentNicMac(key string, mac string)
+	CacheParentNicMac(key string, entry ParentNicMacEntry)
+	// InvalidateParentNicMac removes the parent NIC MACs resolved from the
+	// SpiderMultusConfig namespace/name.
+	InvalidateParentNicMac(owner string) int
+	// InvalidateParentNicMacByIface removes the parent NIC MACs of the master
+	// interface which are different from mac.
+	InvalidateParentNicMacByIface(iface, mac string) int
 }
 
 // IaaSClient implements the Client interface
@@ -74,46 +92,7 @@ type IaaSClient struct {
 	httpTimeout time.Duration
 	logger      *zap.Logger
 
-	// parentNicMacCache caches key -> parent NIC MAC address.
-	// Keys use SpiderMultusConfig namespace/name.
-	parentNicMacCache sync.Map
-}
-
-// ValidateConfig validates the IaaS provider configuration.
-// Returns nil if the configuration is valid or IaaS integration is disabled (URL is empty).
-func ValidateConfig(cfg *spiderpooltypes.IaaSProviderConfig) error {
-	if cfg.ServerURL == "" {
-		return nil
-	}
-	u, err := url.Parse(cfg.ServerURL)
-	if err != nil {
-		return fmt.Errorf("invalid iaasNetworkProvider.serverUrl %q: %w", cfg.ServerURL, err)
-	}
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return fmt.Errorf("invalid iaasNetworkProvider.serverUrl %q: must start with http:// or https://", cfg.ServerURL)
-	}
-	if u.Host == "" {
-		return fmt.Errorf("invalid iaasNetworkProvider.serverUrl %q: host is empty", cfg.ServerURL)
-	}
-
-	// Validate HTTPRequestTimeout if set
-	if cfg.HTTPRequestTimeout != "" {
-		timeout, err := time.ParseDuration(cfg.HTTPRequestTimeout)
-		if err != nil {
-			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: %w", cfg.HTTPRequestTimeout, err)
-		}
-		if timeout <= 0 {
-			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: timeout must be positive", cfg.HTTPRequestTimeout)
-		}
-		if timeout >= constant.IaaSTimeoutStaticLimit {
-			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: timeout %v must be less than static limit %v", cfg.HTTPRequestTimeout, timeout, constant.IaaSTimeoutStaticLimit)
-		}
-		if timeout >= constant.DefaultCNIClientTimeout {
-			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: timeout %v must be less than CNI client timeout %v", cfg.HTTPRequestTimeout, timeout, constant.DefaultCNIClientTimeout)
-		}
-	}
-
-	return nil
+	*parentNicMacCache
 }
 
 // NewClient creates a new IaaS client with mTLS configuration
@@ -125,31 +104,22 @@ func NewClient(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (*Ia
 		return nil, err
 	}
 
-	// TODO: enable mTLS certificate authentication
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: true, //nolint:gosec
-	}
-
-	timeout := constant.DefaultIaaSProviderTimeout
-	if cfg.HTTPRequestTimeout != "" {
-		parsed, err := time.ParseDuration(cfg.HTTPRequestTimeout)
-		if err != nil {
-			return nil, fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: %w", cfg.HTTPRequestTimeout, err)
-		}
-		timeout = parsed
+	timeout, err := requestTimeout(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: tlsConfig,
-		},
+	cache, err := newParentNicMacCache(cfg, logger)
+	if err != nil {
+		return nil, err
 	}
 
 	return &IaaSClient{
-		baseURL:     cfg.ServerURL,
-		httpClient:  httpClient,
-		httpTimeout: timeout,
-		logger:      logger,
+		parentNicMacCache: cache,
+		baseURL:           cfg.ServerURL,
+		httpClient:        newHTTPClient(),
+		httpTimeout:       timeout,
+		logger:            logger,
 	}, nil
 }
 
@@ -160,10 +130,8 @@ func (c *IaaSClient) AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*
 	// API call). Sending the request with insufficient budget risks the provider
 	// starting work (consuming a rate-limit slot) and then being cancelled
 	// mid-flight, causing state inconsistency.
-	if deadline, ok := ctx.Deadline(); ok {
-		if remaining := time.Until(deadline); remaining < constant.IaaSProviderWorstCase {
-			return nil, fmt.Errorf("parent budget insufficient: %v remaining is less than provider worst-case %v", remaining.Round(time.Millisecond), constant.IaaSProviderWorstCase)
-		}
+	if err := checkParentBudget(ctx); err != nil {
+		return nil, err
 	}
 
 	// Derive a child context bounded by the configured HTTP request timeout.
@@ -233,7 +201,7 @@ func (c *IaaSClient) AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*
 			zap.Int("statusCode", resp.StatusCode),
 			zap.String("response", string(respBody)),
 		)
-		return nil, fmt.Errorf("iaas allocate API returned status %d: %s", resp.StatusCode, string(respBody))
+		return nil, &StatusError{API: "allocate", StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	// Unmarshal response
@@ -275,6 +243,8 @@ func (c *IaaSClient) ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error
 		IPAddress:    req.IPAddress,
 		Subnet:       req.Subnet,
 		ParentNicMac: req.ParentNicMac,
+		IPVersion:    req.IPVersion,
+		Prefix:       req.Prefix,
 	}
 
 	if err := c.releaseSingleIP(ctx, reqURL, singleReq); err != nil {
@@ -296,10 +266,8 @@ func (c *IaaSClient) ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error
 func (c *IaaSClient) releaseSingleIP(ctx context.Context, reqURL string, req *ReleaseIPRequest) error {
 	// Same minimum-budget guard as AllocateIPs: fail fast rather than sending
 	// a request that cannot complete within the provider's worst-case time.
-	if deadline, ok := ctx.Deadline(); ok {
-		if remaining := time.Until(deadline); remaining < constant.IaaSProviderWorstCase {
-			return fmt.Errorf("parent budget insufficient: %v remaining is less than provider worst-case %v", remaining.Round(time.Millisecond), constant.IaaSProviderWorstCase)
-		}
+	if err := checkParentBudget(ctx); err != nil {
+		return err
 	}
 
 	// Derive a child context bounded by the configured HTTP request timeout.
@@ -343,23 +311,74 @@ func (c *IaaSClient) releaseSingleIP(ctx context.Context, reqURL string, req *Re
 			zap.String("response", string(respBody)),
 			zap.String("ipAddresses", req.IPAddress),
 		)
-		return fmt.Errorf("iaas release API returned status %d: %s", resp.StatusCode, string(respBody))
+		return &StatusError{API: "release", StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	return nil
 }
 
-// GetCachedParentNicMac returns the cached parent NIC MAC for the given key, or empty string if not cached.
-func (c *IaaSClient) GetCachedParentNicMac(key string) (string, bool) {
-	if v, ok := c.parentNicMacCache.Load(key); ok {
-		return v.(string), true
+// ListAssignments calls the IaaS provider to list a page of the IP assignments
+// on the cloud platform, the next page is requested with the returned
+// continue token.
+func (c *IaaSClient) ListAssignments(ctx context.Context, req *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, c.httpTimeout)
+	defer cancel()
+
+	c.logger.Debug(
+		"Calling IaaS list assignments API",
+		zap.String("url", c.baseURL),
+		zap.String("nodeName", req.NodeName),
+		zap.String("continue", req.Continue),
+	)
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal list assignments request: %w", err)
+	}
+
+	reqURL, err := url.JoinPath(c.baseURL, listAssignmentsAPIPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct list assignments URL: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodPost, reqURL, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list assignments request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	setRequestTimeoutHeader(httpReq)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error(
+			"IaaS list assignments API call failed",
+			zap.Error(err),
+			zap.String("url", reqURL),
+		)
+		return nil, fmt.Errorf("iaas list assignments API call failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list assignments response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.Error(
+			"IaaS list assignments API returned non-success status",
+			zap.Int("statusCode", resp.StatusCode),
+			zap.String("response", string(respBody)),
+		)
+		return nil, &StatusError{API: "list assignments", StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var listResp ListAssignmentsResponse
+	if err := json.Unmarshal(respBody, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal list assignments response: %w", err)
 	}
-	return "", false
-}
 
-// CacheParentNicMac stores a parent NIC MAC for the given key.
-func (c *IaaSClient) CacheParentNicMac(key string, mac string) {
-	c.parentNicMacCache.Store(key, mac)
+	return &listResp, nil
 }
 
 // Close closes the IaaS client
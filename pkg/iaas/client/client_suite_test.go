@@ -4,13 +4,24 @@
 package client_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 )
 
 func TestClient(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Client Suite")
 }
+
+var _ = BeforeSuite(func() {
+	_, err := metric.InitMetric(context.TODO(), constant.SpiderpoolAgent, false, false)
+	Expect(err).NotTo(HaveOccurred())
+	err = metric.InitSpiderpoolAgentMetrics(context.TODO(), nil)
+	Expect(err).NotTo(HaveOccurred())
+})
@@ -5,6 +5,8 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -353,6 +355,120 @@ var _ = Describe("IaaS Client Context Deadline Handling", Label("unitest"), func
 	})
 })
 
+var _ = Describe("IaaS Client IPv6", Label("unitest"), func() {
+	var client *IaaSClient
+	var server *httptest.Server
+	var bodyCh chan []byte
+
+	BeforeEach(func() {
+		logger, err := zap.NewDevelopment()
+		Expect(err).NotTo(HaveOccurred())
+
+		bodyCh = make(chan []byte, 1)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodyCh <- body
+			if r.URL.Path == releaseAPIPath {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"nodeName":"test-node","iaasIPsAllocationResponse":[` +
+				`{"ipAddress":"fd00:10::1:0:0:1","subnet":"fd00:10::/64","macAddress":"02:00:00:00:00:01","vlanId":100,"delegatedPrefix":"fd00:10::1:0:0:0/80"}]}`))
+		}))
+		DeferCleanup(server.Close)
+
+		client, err = NewClient(&spiderpooltypes.IaaSProviderConfig{ServerURL: server.URL}, logger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("allocates IPv6 address with the delegated prefix", Label("ipv6"), func() {
+		resp, err := client.AllocateIPs(context.Background(), &AllocateIPRequest{
+			NodeName: "test-node",
+			IaaSIPsAllocationRequest: []IaaSIPAllocationItem{
+				{IPAddress: "fd00:10::1:0:0:1", Subnet: "fd00:10::/64", ParentNicMac: "00:11:22:33:44:55", IPVersion: constant.IPv6},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.IaaSIPsAllocationResponse).To(HaveLen(1))
+		Expect(resp.IaaSIPsAllocationResponse[0].DelegatedPrefix).To(Equal("fd00:10::1:0:0:0/80"))
+
+		var req AllocateIPRequest
+		Expect(json.Unmarshal(<-bodyCh, &req)).To(Succeed())
+		Expect(req.IaaSIPsAllocationRequest[0].IPVersion).To(Equal(int64(constant.IPv6)))
+	})
+
+	It("releases IPv6 address with the delegated prefix", Label("ipv6"), func() {
+		err := client.ReleaseIP(context.Background(), &ReleaseIPRequest{
+			NodeName:  "test-node",
+			Subnet:    "fd00:10::/64",
+			IPAddress: "fd00:10::1:0:0:1",
+			IPVersion: constant.IPv6,
+			Prefix:    "fd00:10::1:0:0:0/80",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var req ReleaseIPRequest
+		Expect(json.Unmarshal(<-bodyCh, &req)).To(Succeed())
+		Expect(req.IPVersion).To(Equal(int64(constant.IPv6)))
+		Expect(req.Prefix).To(Equal("fd00:10::1:0:0:0/80"))
+	})
+})
+
+var _ = Describe("IaaS Client ListAssignments", Label("unitest"), func() {
+	It("lists a page of the IP assignments", Label("list-assignments"), func() {
+		logger, err := zap.NewDevelopment()
+		Expect(err).NotTo(HaveOccurred())
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal(listAssignmentsAPIPath))
+
+			var req ListAssignmentsRequest
+			Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+			Expect(req.Limit).To(Equal(int64(100)))
+			Expect(req.Continue).To(Equal("page-2"))
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"iaasIPAssignments":[{"nodeName":"node-a","subnet":"fd00:10::/64","ipAddress":"fd00:10::5","ipVersion":6,"podUID":"uid-a"}],"continue":"page-3"}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&spiderpooltypes.IaaSProviderConfig{ServerURL: server.URL}, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.ListAssignments(context.Background(), &ListAssignmentsRequest{Limit: 100, Continue: "page-2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Continue).To(Equal("page-3"))
+		Expect(resp.IaaSIPAssignments).To(Equal([]IaaSIPAssignment{
+			{NodeName: "node-a", Subnet: "fd00:10::/64", IPAddress: "fd00:10::5", IPVersion: constant.IPv6, PodUID: "uid-a"},
+		}))
+	})
+
+	It("returns error on non-success status", Label("list-assignments"), func() {
+		logger, err := zap.NewDevelopment()
+		Expect(err).NotTo(HaveOccurred())
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotImplemented)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&spiderpooltypes.IaaSProviderConfig{ServerURL: server.URL}, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.ListAssignments(context.Background(), &ListAssignmentsRequest{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("status 501"))
+	})
+
+	It("validates the reconcile interval", func() {
+		Expect(ValidateConfig(&spiderpooltypes.IaaSProviderConfig{ServerURL: "http://127.0.0.1:8080", ReconcileInterval: "0s"})).To(Succeed())
+		Expect(ValidateConfig(&spiderpooltypes.IaaSProviderConfig{ServerURL: "http://127.0.0.1:8080", ReconcileInterval: "-1m"})).NotTo(Succeed())
+		Expect(ValidateConfig(&spiderpooltypes.IaaSProviderConfig{ServerURL: "http://127.0.0.1:8080", ReconcileInterval: "abc"})).NotTo(Succeed())
+	})
+})
+
 var _ = Describe("IaaS Client Timeout Errors", Label("unitest"), func() {
 	var logger *zap.Logger
 
@@ -0,0 +1,241 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	// DriverREST is the default driver, which calls the Spiderpool IaaS REST API.
+	DriverREST = "rest"
+	// DriverHTTP calls any HTTP API with the configured request and response templates.
+	DriverHTTP = "http"
+	// DriverGRPC calls the IaaSProvider gRPC service in api/v1/iaas/iaas_provider.proto.
+	DriverGRPC = "grpc"
+	// DriverExec runs a local binary with the JSON request on stdin.
+	DriverExec = "exec"
+)
+
+// ErrParentBudgetInsufficient is returned without calling the provider if the
+// parent context can not cover the provider worst-case completion time.
+var ErrParentBudgetInsufficient = errors.New("parent budget insufficient")
+
+// DriverFactory creates the Client of a driver from the IaaS provider configuration.
+type DriverFactory func(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error)
+
+var (
+	driversLock sync.RWMutex
+	drivers     = map[string]DriverFactory{}
+)
+
+func init() {
+	RegisterDriver(DriverREST, func(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error) {
+		return NewClient(cfg, logger)
+	})
+}
+
+// RegisterDriver registers the factory of a driver with the name, which is
+// selected by the driver of IaaS provider configuration.
+func RegisterDriver(name string, factory DriverFactory) {
+	driversLock.Lock()
+	defer driversLock.Unlock()
+
+	drivers[name] = factory
+}
+
+// Drivers returns the names of the registered drivers.
+func Drivers() []string {
+	driversLock.RLock()
+	defer driversLock.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// IsEnabled returns whether the IaaS provider integration is configured.
+func IsEnabled(cfg *spiderpooltypes.IaaSProviderConfig) bool {
+	return cfg.ServerURL != "" || cfg.Driver != ""
+}
+
+// NewDriver creates the Client of the driver selected by the IaaS provider configuration.
+func NewDriver(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	driversLock.RLock()
+	factory := drivers[driverName(cfg)]
+	driversLock.RUnlock()
+
+	return factory(cfg, logger)
+}
+
+// ValidateConfig validates the IaaS provider configuration.
+// Returns nil if the configuration is valid or IaaS integration is disabled.
+func ValidateConfig(cfg *spiderpooltypes.IaaSProviderConfig) error {
+	if !IsEnabled(cfg) {
+		return nil
+	}
+
+	name := driverName(cfg)
+	driversLock.RLock()
+	_, ok := drivers[name]
+	driversLock.RUnlock()
+	if !ok {
+		return fmt.Errorf("invalid iaasNetworkProvider.driver %q: must be one of %v", cfg.Driver, Drivers())
+	}
+
+	switch name {
+	case DriverREST, DriverHTTP:
+		if err := validateServerURL(cfg.ServerURL); err != nil {
+			return err
+		}
+	case DriverGRPC:
+		if err := validateGRPCConfig(&cfg.GRPC); err != nil {
+			return err
+		}
+	case DriverExec:
+		if !filepath.IsAbs(cfg.Exec.Path) {
+			return fmt.Errorf("invalid iaasNetworkProvider.exec.path %q: must be an absolute path", cfg.Exec.Path)
+		}
+	}
+
+	if name == DriverHTTP {
+		if cfg.HTTP.Allocate.Path == "" || cfg.HTTP.Release.Path == "" {
+			return fmt.Errorf("invalid iaasNetworkProvider.http: the paths of allocate and release are required")
+		}
+		if _, err := newHTTPOperations(&cfg.HTTP); err != nil {
+			return fmt.Errorf("invalid iaasNetworkProvider.http: %w", err)
+		}
+	}
+
+	// Validate HTTPRequestTimeout if set
+	if cfg.HTTPRequestTimeout != "" {
+		timeout, err := time.ParseDuration(cfg.HTTPRequestTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: %w", cfg.HTTPRequestTimeout, err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: timeout must be positive", cfg.HTTPRequestTimeout)
+		}
+		if timeout >= constant.IaaSTimeoutStaticLimit {
+			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: timeout %v must be less than static limit %v", cfg.HTTPRequestTimeout, timeout, constant.IaaSTimeoutStaticLimit)
+		}
+		if timeout >= constant.DefaultCNIClientTimeout {
+			return fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: timeout %v must be less than CNI client timeout %v", cfg.HTTPRequestTimeout, timeout, constant.DefaultCNIClientTimeout)
+		}
+	}
+
+	if cfg.ReconcileInterval != "" {
+		interval, err := time.ParseDuration(cfg.ReconcileInterval)
+		if err != nil {
+			return fmt.Errorf("invalid iaasNetworkProvider.reconcileInterval %q: %w", cfg.ReconcileInterval, err)
+		}
+		if interval < 0 {
+			return fmt.Errorf("invalid iaasNetworkProvider.reconcileInterval %q: interval must not be negative", cfg.ReconcileInterval)
+		}
+	}
+
+	if _, err := parentNicMacCacheTTL(cfg); err != nil {
+		return err
+	}
+	if path := cfg.ParentNicMacCache.PersistPath; path != "" && !filepath.IsAbs(path) {
+		return fmt.Errorf("invalid iaasNetworkProvider.parentNicMacCache.persistPath %q: must be an absolute path", path)
+	}
+
+	if cfg.CircuitBreaker.FailureThreshold < 0 {
+		return fmt.Errorf("invalid iaasNetworkProvider.circuitBreaker.failureThreshold %d: must not be negative", cfg.CircuitBreaker.FailureThreshold)
+	}
+	if _, _, err := circuitBreakerDurations(cfg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func driverName(cfg *spiderpooltypes.IaaSProviderConfig) string {
+	if cfg.Driver == "" {
+		return DriverREST
+	}
+
+	return cfg.Driver
+}
+
+func validateServerURL(serverURL string) error {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return fmt.Errorf("invalid iaasNetworkProvider.serverUrl %q: %w", serverURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid iaasNetworkProvider.serverUrl %q: must start with http:// or https://", serverURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid iaasNetworkProvider.serverUrl %q: host is empty", serverURL)
+	}
+
+	return nil
+}
+
+// requestTimeout returns the timeout of a single provider call, which
+// applies to all drivers.
+func requestTimeout(cfg *spiderpooltypes.IaaSProviderConfig) (time.Duration, error) {
+	if cfg.HTTPRequestTimeout == "" {
+		return constant.DefaultIaaSProviderTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(cfg.HTTPRequestTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid iaasNetworkProvider.httpRequestTimeout %q: %w", cfg.HTTPRequestTimeout, err)
+	}
+
+	return timeout, nil
+}
+
+// checkParentBudget fails fast if the parent context does not have enough
+// remaining budget to cover the provider's worst-case completion time
+// (rate-limit wait + cloud API call). Sending the request with insufficient
+// budget risks the provider starting work (consuming a rate-limit slot) and
+// then being cancelled mid-flight, causing state inconsistency.
+func checkParentBudget(ctx context.Context) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < constant.IaaSProviderWorstCase {
+			return fmt.Errorf("%w: %v remaining is less than provider worst-case %v", ErrParentBudgetInsufficient, remaining.Round(time.Millisecond), constant.IaaSProviderWorstCase)
+		}
+	}
+
+	return nil
+}
+
+func newHTTPClient() *http.Client {
+	// TODO: enable mTLS certificate authentication
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+}
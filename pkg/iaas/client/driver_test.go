@@ -0,0 +1,440 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	iaasv1 "github.com/spidernet-io/spiderpool/api/v1/iaas"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("IaaS Drivers", Label("unitest"), func() {
+	var logger *zap.Logger
+
+	BeforeEach(func() {
+		logger = zap.NewNop()
+	})
+
+	Describe("registry", Label("driver"), func() {
+		It("registers the built-in drivers", func() {
+			Expect(Drivers()).To(Equal([]string{DriverExec, DriverGRPC, DriverHTTP, DriverREST}))
+		})
+
+		It("selects the rest driver by default", func() {
+			cfg := &spiderpooltypes.IaaSProviderConfig{ServerURL: "http://localhost:8080"}
+			Expect(IsEnabled(cfg)).To(BeTrue())
+
+			c, err := NewDriver(cfg, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(BeAssignableToTypeOf(&IaaSClient{}))
+		})
+
+		It("is disabled without server URL and driver", func() {
+			Expect(IsEnabled(&spiderpooltypes.IaaSProviderConfig{})).To(BeFalse())
+		})
+
+		It("selects the registered driver", func() {
+			expected := &IaaSClient{}
+			RegisterDriver("fake", func(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error) {
+				return expected, nil
+			})
+			DeferCleanup(func() {
+				driversLock.Lock()
+				delete(drivers, "fake")
+				driversLock.Unlock()
+			})
+
+			c, err := NewDriver(&spiderpooltypes.IaaSProviderConfig{Driver: "fake"}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(BeIdenticalTo(expected))
+		})
+
+		DescribeTable("rejects the invalid driver configuration",
+			func(cfg spiderpooltypes.IaaSProviderConfig, errMsg string) {
+				err := ValidateConfig(&cfg)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(errMsg))
+			},
+			Entry("unknown driver", spiderpooltypes.IaaSProviderConfig{Driver: "unknown"}, "invalid iaasNetworkProvider.driver"),
+			Entry("grpc without address", spiderpooltypes.IaaSProviderConfig{Driver: DriverGRPC}, "grpc.address"),
+			Entry("grpc with unknown codec", spiderpooltypes.IaaSProviderConfig{Driver: DriverGRPC, GRPC: spiderpooltypes.IaaSGRPCDriverConfig{Address: "127.0.0.1:9090", Codec: "xml"}}, "grpc.codec"),
+			Entry("grpc with cert but no key", spiderpooltypes.IaaSProviderConfig{Driver: DriverGRPC, GRPC: spiderpooltypes.IaaSGRPCDriverConfig{Address: "127.0.0.1:9090", CertFile: "/tmp/client.crt"}}, "certFile and keyFile"),
+			Entry("exec with relative path", spiderpooltypes.IaaSProviderConfig{Driver: DriverExec, Exec: spiderpooltypes.IaaSExecDriverConfig{Path: "provider"}}, "exec.path"),
+			Entry("http without release path", spiderpooltypes.IaaSProviderConfig{
+				Driver:    DriverHTTP,
+				ServerURL: "http://localhost:8080",
+				HTTP: spiderpooltypes.IaaSHTTPDriverConfig{
+					Allocate: spiderpooltypes.IaaSHTTPOperationConfig{Path: "/allocate"},
+				},
+			}, "paths of allocate and release are required"),
+			Entry("http with invalid template", spiderpooltypes.IaaSProviderConfig{
+				Driver:    DriverHTTP,
+				ServerURL: "http://localhost:8080",
+				HTTP: spiderpooltypes.IaaSHTTPDriverConfig{
+					Allocate: spiderpooltypes.IaaSHTTPOperationConfig{Path: "/allocate", RequestTemplate: "{{ .nodeName"},
+					Release:  spiderpooltypes.IaaSHTTPOperationConfig{Path: "/release"},
+				},
+			}, "failed to parse request template of allocate"),
+		)
+	})
+
+	Describe("http driver", Label("driver", "http"), func() {
+		It("converts the requests and responses with the templates", func() {
+			var allocateBody map[string]any
+			var releasePath, authHeader string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authHeader = r.Header.Get("Authorization")
+				switch r.URL.Path {
+				case "/nodes/node1/ips":
+					body, err := io.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(json.Unmarshal(body, &allocateBody)).To(Succeed())
+					_, _ = w.Write([]byte(`{"addresses":[{"ip":"10.0.0.10","cidr":"10.0.0.0/24","mac":"00:11:22:33:44:55","vlan":100}]}`))
+				default:
+					Expect(r.Method).To(Equal(http.MethodDelete))
+					releasePath = r.URL.Path
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			cfg := &spiderpooltypes.IaaSProviderConfig{
+				Driver:    DriverHTTP,
+				ServerURL: server.URL,
+				HTTP: spiderpooltypes.IaaSHTTPDriverConfig{
+					Headers: map[string]string{"Authorization": "Bearer token"},
+					Allocate: spiderpooltypes.IaaSHTTPOperationConfig{
+						Path:            "/nodes/{{ .nodeName }}/ips",
+						RequestTemplate: `{"pod":{{ toJson .podName }},"ips":[{{ range $i, $item := .iaasIPsAllocationRequest }}{{ if $i }},{{ end }}{{ toJson $item.ipAddress }}{{ end }}]}`,
+						ResponseTemplate: `{"nodeName":{{ toJson .request.nodeName }},"iaasIPsAllocationResponse":[` +
+							`{{ range $i, $a := .response.addresses }}{{ if $i }},{{ end }}` +
+							`{"ipAddress":{{ toJson $a.ip }},"subnet":{{ toJson $a.cidr }},"macAddress":{{ toJson $a.mac }},"vlanId":{{ $a.vlan }}}{{ end }}]}`,
+					},
+					Release: spiderpooltypes.IaaSHTTPOperationConfig{
+						Method: http.MethodDelete,
+						Path:   "/nodes/{{ .nodeName }}/ips/{{ .ipAddress }}",
+					},
+				},
+			}
+
+			c, err := NewDriver(cfg, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := c.AllocateIPs(context.Background(), &AllocateIPRequest{
+				PodName:  "pod1",
+				NodeName: "node1",
+				IaaSIPsAllocationRequest: []IaaSIPAllocationItem{
+					{IPAddress: "10.0.0.10", Subnet: "10.0.0.0/24", ParentNicMac: "aa:bb:cc:dd:ee:ff"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allocateBody).To(Equal(map[string]any{"pod": "pod1", "ips": []any{"10.0.0.10"}}))
+			Expect(authHeader).To(Equal("Bearer token"))
+			Expect(resp.NodeName).To(Equal("node1"))
+			Expect(resp.IaaSIPsAllocationResponse).To(Equal([]IaaSIPAllocationResult{
+				{IPAddress: "10.0.0.10", Subnet: "10.0.0.0/24", MacAddress: "00:11:22:33:44:55", VlanID: 100},
+			}))
+
+			err = c.ReleaseIP(context.Background(), &ReleaseIPRequest{NodeName: "node1", IPAddress: "10.0.0.10", Subnet: "10.0.0.0/24"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(releasePath).To(Equal("/nodes/node1/ips/10.0.0.10"))
+
+			_, err = c.ListAssignments(context.Background(), &ListAssignmentsRequest{})
+			Expect(err).To(MatchError(ContainSubstring("listAssignments operation is not configured")))
+		})
+
+		It("returns error on non-success status", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte("ip in use"))
+			}))
+			defer server.Close()
+
+			c, err := NewDriver(&spiderpooltypes.IaaSProviderConfig{
+				Driver:    DriverHTTP,
+				ServerURL: server.URL,
+				HTTP: spiderpooltypes.IaaSHTTPDriverConfig{
+					Allocate: spiderpooltypes.IaaSHTTPOperationConfig{Path: "/allocate"},
+					Release:  spiderpooltypes.IaaSHTTPOperationConfig{Path: "/release"},
+				},
+			}, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = c.ReleaseIP(context.Background(), &ReleaseIPRequest{NodeName: "node1", IPAddress: "10.0.0.10"})
+			Expect(err).To(MatchError(ContainSubstring("status 409: ip in use")))
+		})
+	})
+
+	Describe("grpc driver", Label("driver", "grpc"), func() {
+		var provider *fakeIaaSProvider
+
+		serve := func(opts ...grpc.ServerOption) string {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			server := grpc.NewServer(opts...)
+			iaasv1.RegisterIaaSProviderServer(server, provider)
+			go func() { _ = server.Serve(listener) }()
+			DeferCleanup(server.Stop)
+
+			return listener.Addr().String()
+		}
+
+		newGRPCDriver := func(cfg spiderpooltypes.IaaSGRPCDriverConfig) Client {
+			c, err := NewDriver(&spiderpooltypes.IaaSProviderConfig{Driver: DriverGRPC, GRPC: cfg}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(c.(*GRPCDriver).Close)
+			return c
+		}
+
+		callProvider := func(c Client) {
+			resp, err := c.AllocateIPs(context.Background(), &AllocateIPRequest{
+				NodeName:                 "node1",
+				IaaSIPsAllocationRequest: []IaaSIPAllocationItem{{IPAddress: "fd00:10::10", Subnet: "fd00:10::/64", IPVersion: 6}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.NodeName).To(Equal("node1"))
+			Expect(resp.IaaSIPsAllocationResponse[0].MacAddress).To(Equal("00:11:22:33:44:55"))
+			Expect(resp.IaaSIPsAllocationResponse[0].VlanID).To(Equal(int64(100)))
+
+			Expect(c.ReleaseIP(context.Background(), &ReleaseIPRequest{NodeName: "node1", IPAddress: "fd00:10::10"})).To(Succeed())
+
+			list, err := c.ListAssignments(context.Background(), &ListAssignmentsRequest{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.IaaSIPAssignments).To(HaveLen(1))
+			Expect(list.IaaSIPAssignments[0].PodUID).To(Equal("uid1"))
+
+			Expect(provider.methods).To(Equal([]string{
+				iaasv1.IaaSProvider_AllocateIPs_FullMethodName,
+				iaasv1.IaaSProvider_ReleaseIP_FullMethodName,
+				iaasv1.IaaSProvider_ListAssignments_FullMethodName,
+			}))
+			Expect(provider.allocated.GetIaasIpsAllocationRequest()[0].GetIpVersion()).To(Equal(int32(6)))
+			Expect(provider.listLimit).To(Equal(int32(10)))
+		}
+
+		BeforeEach(func() {
+			provider = &fakeIaaSProvider{}
+		})
+
+		It("calls the IaaSProvider service with the protobuf codec", func() {
+			addr := serve()
+			callProvider(newGRPCDriver(spiderpooltypes.IaaSGRPCDriverConfig{Address: addr, Insecure: true}))
+		})
+
+		It("calls the IaaSProvider service with the json codec", func() {
+			addr := serve(grpc.ForceServerCodec(jsonCodec{}))
+			callProvider(newGRPCDriver(spiderpooltypes.IaaSGRPCDriverConfig{Address: addr, Insecure: true, Codec: GRPCCodecJSON}))
+		})
+
+		Context("with TLS", func() {
+			var dir string
+
+			BeforeEach(func() {
+				dir = GinkgoT().TempDir()
+				writeTestCertificates(dir)
+			})
+
+			serveTLS := func(clientAuth tls.ClientAuthType) string {
+				cert, err := tls.LoadX509KeyPair(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+				Expect(err).NotTo(HaveOccurred())
+				ca, err := os.ReadFile(filepath.Join(dir, "ca.crt"))
+				Expect(err).NotTo(HaveOccurred())
+				pool := x509.NewCertPool()
+				Expect(pool.AppendCertsFromPEM(ca)).To(BeTrue())
+
+				return serve(grpc.Creds(credentials.NewTLS(&tls.Config{
+					Certificates: []tls.Certificate{cert},
+					ClientCAs:    pool,
+					ClientAuth:   clientAuth,
+					MinVersion:   tls.VersionTLS12,
+				})))
+			}
+
+			It("verifies the provider with the CA and presents the client certificate", func() {
+				addr := serveTLS(tls.RequireAndVerifyClientCert)
+				callProvider(newGRPCDriver(spiderpooltypes.IaaSGRPCDriverConfig{
+					Address:    addr,
+					CAFile:     filepath.Join(dir, "ca.crt"),
+					CertFile:   filepath.Join(dir, "client.crt"),
+					KeyFile:    filepath.Join(dir, "client.key"),
+					ServerName: "iaas-provider",
+				}))
+			})
+
+			It("fails to verify the provider without the CA by default", func() {
+				addr := serveTLS(tls.NoClientCert)
+				c := newGRPCDriver(spiderpooltypes.IaaSGRPCDriverConfig{Address: addr, ServerName: "iaas-provider"})
+
+				_, err := c.ListAssignments(context.Background(), &ListAssignmentsRequest{})
+				Expect(err).To(MatchError(ContainSubstring("certificate")))
+				Expect(provider.methods).To(BeEmpty())
+			})
+
+			It("fails to create the driver with an invalid CA file", func() {
+				Expect(os.WriteFile(filepath.Join(dir, "invalid.crt"), []byte("invalid"), 0o600)).To(Succeed())
+				_, err := NewDriver(&spiderpooltypes.IaaSProviderConfig{
+					Driver: DriverGRPC,
+					GRPC:   spiderpooltypes.IaaSGRPCDriverConfig{Address: "127.0.0.1:9090", CAFile: filepath.Join(dir, "invalid.crt")},
+				}, logger)
+				Expect(err).To(MatchError(ContainSubstring("no PEM certificate found")))
+			})
+		})
+	})
+
+	Describe("exec driver", Label("driver", "exec"), func() {
+		var script string
+
+		BeforeEach(func() {
+			script = filepath.Join(GinkgoT().TempDir(), "provider.sh")
+			content := `#!/bin/sh
+input=$(cat)
+case "$2" in
+allocate)
+  echo '{"nodeName":"node1","iaasIPsAllocationResponse":[{"ipAddress":"10.0.0.10","subnet":"10.0.0.0/24","macAddress":"00:11:22:33:44:55"}]}'
+  ;;
+list-assignments)
+  echo '{"iaasIPAssignments":[],"continue":""}'
+  ;;
+release)
+  echo "$input" | grep -q '"ipAddress":"10.0.0.10"' && exit 0
+  echo "unknown ip" >&2
+  exit 3
+  ;;
+esac
+`
+			Expect(os.WriteFile(script, []byte(content), 0o755)).To(Succeed())
+		})
+
+		It("runs the binary with the JSON request", func() {
+			c, err := NewDriver(&spiderpooltypes.IaaSProviderConfig{
+				Driver: DriverExec,
+				Exec:   spiderpooltypes.IaaSExecDriverConfig{Path: script, Args: []string{"--region=test"}},
+			}, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := c.AllocateIPs(context.Background(), &AllocateIPRequest{NodeName: "node1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.IaaSIPsAllocationResponse).To(HaveLen(1))
+			Expect(resp.IaaSIPsAllocationResponse[0].IPAddress).To(Equal("10.0.0.10"))
+
+			list, err := c.ListAssignments(context.Background(), &ListAssignmentsRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.IaaSIPAssignments).To(BeEmpty())
+
+			Expect(c.ReleaseIP(context.Background(), &ReleaseIPRequest{NodeName: "node1", IPAddress: "10.0.0.10"})).To(Succeed())
+
+			err = c.ReleaseIP(context.Background(), &ReleaseIPRequest{NodeName: "node1", IPAddress: "10.0.0.11"})
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("%s exec failed", execReleaseOperation))))
+			Expect(err).To(MatchError(ContainSubstring("unknown ip")))
+		})
+	})
+})
+
+type fakeIaaSProvider struct {
+	iaasv1.UnimplementedIaaSProviderServer
+
+	methods   []string
+	allocated *iaasv1.AllocateIPRequest
+	listLimit int32
+}
+
+func (p *fakeIaaSProvider) AllocateIPs(_ context.Context, req *iaasv1.AllocateIPRequest) (*iaasv1.AllocateIPResponse, error) {
+	p.methods = append(p.methods, iaasv1.IaaSProvider_AllocateIPs_FullMethodName)
+	p.allocated = req
+
+	return &iaasv1.AllocateIPResponse{
+		NodeName: req.GetNodeName(),
+		IaasIpsAllocationResponse: []*iaasv1.IaaSIPAllocationResult{
+			{IpAddress: "fd00:10::10", Subnet: "fd00:10::/64", MacAddress: "00:11:22:33:44:55", VlanId: 100},
+		},
+	}, nil
+}
+
+func (p *fakeIaaSProvider) ReleaseIP(context.Context, *iaasv1.ReleaseIPRequest) (*iaasv1.ReleaseIPResponse, error) {
+	p.methods = append(p.methods, iaasv1.IaaSProvider_ReleaseIP_FullMethodName)
+	return &iaasv1.ReleaseIPResponse{}, nil
+}
+
+func (p *fakeIaaSProvider) ListAssignments(_ context.Context, req *iaasv1.ListAssignmentsRequest) (*iaasv1.ListAssignmentsResponse, error) {
+	p.methods = append(p.methods, iaasv1.IaaSProvider_ListAssignments_FullMethodName)
+	p.listLimit = req.GetLimit()
+
+	return &iaasv1.ListAssignmentsResponse{
+		IaasIpAssignments: []*iaasv1.IaaSIPAssignment{{NodeName: "node1", IpAddress: "fd00:10::10", Subnet: "fd00:10::/64", PodUid: "uid1"}},
+	}, nil
+}
+
+// writeTestCertificates writes a CA, a server certificate for "iaas-provider"
+// and a client certificate signed by the CA into dir.
+func writeTestCertificates(dir string) {
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		return key
+	}
+	writePEM := func(name, blockType string, der []byte) {
+		Expect(os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)).To(Succeed())
+	}
+
+	caKey := newKey()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "iaas-provider-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	Expect(err).NotTo(HaveOccurred())
+	writePEM("ca.crt", "CERTIFICATE", caDER)
+	ca, err := x509.ParseCertificate(caDER)
+	Expect(err).NotTo(HaveOccurred())
+
+	for i, name := range []string{"server", "client"} {
+		key := newKey()
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: "iaas-" + name},
+			DNSNames:     []string{"iaas-provider"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		Expect(err).NotTo(HaveOccurred())
+		writePEM(name+".crt", "CERTIFICATE", der)
+
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		writePEM(name+".key", "EC PRIVATE KEY", keyDER)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	execAllocateOperation        = "allocate"
+	execReleaseOperation         = "release"
+	execListAssignmentsOperation = "list-assignments"
+)
+
+func init() {
+	RegisterDriver(DriverExec, func(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error) {
+		return NewExecDriver(cfg, logger)
+	})
+}
+
+// ExecDriver runs a local binary for every call, with the operation as the
+// last argument, the JSON request on stdin and the JSON response on stdout.
+// A non-zero exit code means the call failed, and stderr is the reason.
+type ExecDriver struct {
+	*parentNicMacCache
+
+	path    string
+	args    []string
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+// NewExecDriver creates the exec driver of IaaS provider.
+func NewExecDriver(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (*ExecDriver, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	timeout, err := requestTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := newParentNicMacCache(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecDriver{
+		parentNicMacCache: cache,
+		path:              cfg.Exec.Path,
+		args:              cfg.Exec.Args,
+		timeout:           timeout,
+		logger:            logger,
+	}, nil
+}
+
+// AllocateIPs calls the IaaS provider to allocate IPs
+func (d *ExecDriver) AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*AllocateIPResponse, error) {
+	if err := checkParentBudget(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp AllocateIPResponse
+	if err := d.run(ctx, execAllocateOperation, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ReleaseIP calls the IaaS provider to release an IP.
+func (d *ExecDriver) ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error {
+	if err := checkParentBudget(ctx); err != nil {
+		return err
+	}
+
+	return d.run(ctx, execReleaseOperation, req, nil)
+}
+
+// ListAssignments calls the IaaS provider to list a page of the IP assignments.
+func (d *ExecDriver) ListAssignments(ctx context.Context, req *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	var resp ListAssignmentsResponse
+	if err := d.run(ctx, execListAssignmentsOperation, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (d *ExecDriver) run(ctx context.Context, operation string, req, resp any) error {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", operation, err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	args := append(append([]string{}, d.args...), operation)
+	cmd := exec.CommandContext(runCtx, d.path, args...) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	d.logger.Debug("Running IaaS exec driver", zap.String("path", d.path), zap.String("operation", operation))
+	if err := cmd.Run(); err != nil {
+		d.logger.Error("IaaS exec driver failed",
+			zap.String("operation", operation),
+			zap.String("stderr", stderr.String()),
+			zap.Error(err))
+		return fmt.Errorf("iaas %s exec failed: %w: %s", operation, err, strings.TrimSpace(stderr.String()))
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", operation, err)
+	}
+
+	return nil
+}
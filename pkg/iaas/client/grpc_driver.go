@@ -0,0 +1,310 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	iaasv1 "github.com/spidernet-io/spiderpool/api/v1/iaas"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	GRPCCodecProto = "proto"
+	GRPCCodecJSON  = "json"
+)
+
+func init() {
+	RegisterDriver(DriverGRPC, func(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error) {
+		return NewGRPCDriver(cfg, logger)
+	})
+}
+
+// jsonCodec encodes the messages of the IaaSProvider service with the
+// proto3 JSON mapping, it's used only if the "json" codec is configured
+// and the providers should serve the "json" content subtype.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal %T: not a proto message", v)
+	}
+
+	return protojson.Marshal(msg)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal %T: not a proto message", v)
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+func (jsonCodec) Name() string {
+	return GRPCCodecJSON
+}
+
+// GRPCDriver calls the IaaSProvider gRPC service defined in
+// api/v1/iaas/iaas_provider.proto.
+type GRPCDriver struct {
+	*parentNicMacCache
+
+	conn    *grpc.ClientConn
+	client  iaasv1.IaaSProviderClient
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+// NewGRPCDriver creates the gRPC driver of IaaS provider, the connection is
+// established lazily by the first call.
+func NewGRPCDriver(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (*GRPCDriver, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	timeout, err := requestTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := grpcTransportCredentials(&cfg.GRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := newParentNicMacCache(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if cfg.GRPC.Codec == GRPCCodecJSON {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})))
+	}
+
+	conn, err := grpc.NewClient(cfg.GRPC.Address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client of IaaS provider %s: %w", cfg.GRPC.Address, err)
+	}
+
+	return &GRPCDriver{
+		parentNicMacCache: cache,
+		conn:              conn,
+		client:            iaasv1.NewIaaSProviderClient(conn),
+		timeout:           timeout,
+		logger:            logger,
+	}, nil
+}
+
+func validateGRPCConfig(cfg *spiderpooltypes.IaaSGRPCDriverConfig) error {
+	if cfg.Address == "" {
+		return fmt.Errorf("invalid iaasNetworkProvider.grpc.address: address is empty")
+	}
+
+	switch cfg.Codec {
+	case "", GRPCCodecProto, GRPCCodecJSON:
+	default:
+		return fmt.Errorf("invalid iaasNetworkProvider.grpc.codec %q: must be one of %q and %q", cfg.Codec, GRPCCodecProto, GRPCCodecJSON)
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return fmt.Errorf("invalid iaasNetworkProvider.grpc: certFile and keyFile must be set together")
+	}
+
+	return nil
+}
+
+// grpcTransportCredentials verifies the provider with the configured CA
+// bundle or the system roots, and presents the client certificate if any.
+func grpcTransportCredentials(cfg *spiderpooltypes.IaaSGRPCDriverConfig) (credentials.TransportCredentials, error) {
+	if cfg.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read iaasNetworkProvider.grpc.caFile: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("invalid iaasNetworkProvider.grpc.caFile %q: no PEM certificate found", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load iaasNetworkProvider.grpc client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// AllocateIPs calls the IaaS provider to allocate IPs
+func (d *GRPCDriver) AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*AllocateIPResponse, error) {
+	if err := checkParentBudget(ctx); err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := d.callContext(ctx, iaasv1.IaaSProvider_AllocateIPs_FullMethodName)
+	defer cancel()
+
+	resp, err := d.client.AllocateIPs(reqCtx, toPBAllocateIPRequest(req))
+	if err != nil {
+		return nil, d.callError(iaasv1.IaaSProvider_AllocateIPs_FullMethodName, err)
+	}
+
+	return fromPBAllocateIPResponse(resp), nil
+}
+
+// ReleaseIP calls the IaaS provider to release an IP.
+func (d *GRPCDriver) ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error {
+	if err := checkParentBudget(ctx); err != nil {
+		return err
+	}
+
+	reqCtx, cancel := d.callContext(ctx, iaasv1.IaaSProvider_ReleaseIP_FullMethodName)
+	defer cancel()
+
+	if _, err := d.client.ReleaseIP(reqCtx, toPBReleaseIPRequest(req)); err != nil {
+		return d.callError(iaasv1.IaaSProvider_ReleaseIP_FullMethodName, err)
+	}
+
+	return nil
+}
+
+// ListAssignments calls the IaaS provider to list a page of the IP assignments.
+func (d *GRPCDriver) ListAssignments(ctx context.Context, req *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	reqCtx, cancel := d.callContext(ctx, iaasv1.IaaSProvider_ListAssignments_FullMethodName)
+	defer cancel()
+
+	resp, err := d.client.ListAssignments(reqCtx, &iaasv1.ListAssignmentsRequest{
+		NodeName: req.NodeName,
+		Limit:    int32(req.Limit), //nolint:gosec
+		Continue: req.Continue,
+	})
+	if err != nil {
+		return nil, d.callError(iaasv1.IaaSProvider_ListAssignments_FullMethodName, err)
+	}
+
+	return fromPBListAssignmentsResponse(resp), nil
+}
+
+func (d *GRPCDriver) callContext(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	d.logger.Debug("Calling IaaS gRPC driver", zap.String("method", method))
+	return context.WithTimeout(ctx, d.timeout)
+}
+
+func (d *GRPCDriver) callError(method string, err error) error {
+	d.logger.Error("IaaS gRPC driver call failed", zap.String("method", method), zap.Error(err))
+	return fmt.Errorf("iaas %s call failed: %w", method, err)
+}
+
+// Close closes the connection to the IaaS provider.
+func (d *GRPCDriver) Close() error {
+	return d.conn.Close()
+}
+
+func toPBAllocateIPRequest(req *AllocateIPRequest) *iaasv1.AllocateIPRequest {
+	items := make([]*iaasv1.IaaSIPAllocationItem, 0, len(req.IaaSIPsAllocationRequest))
+	for _, item := range req.IaaSIPsAllocationRequest {
+		items = append(items, &iaasv1.IaaSIPAllocationItem{
+			IpAddress:    item.IPAddress,
+			Subnet:       item.Subnet,
+			ParentNicMac: item.ParentNicMac,
+			IpVersion:    int32(item.IPVersion), //nolint:gosec
+			Prefix:       item.Prefix,
+		})
+	}
+
+	return &iaasv1.AllocateIPRequest{
+		PodName:                  req.PodName,
+		PodNamespace:             req.PodNamespace,
+		PodUid:                   req.PodUID,
+		NodeName:                 req.NodeName,
+		IaasIpsAllocationRequest: items,
+	}
+}
+
+func fromPBAllocateIPResponse(resp *iaasv1.AllocateIPResponse) *AllocateIPResponse {
+	results := make([]IaaSIPAllocationResult, 0, len(resp.GetIaasIpsAllocationResponse()))
+	for _, result := range resp.GetIaasIpsAllocationResponse() {
+		results = append(results, IaaSIPAllocationResult{
+			ParentNicMac:    result.GetParentNicMac(),
+			Subnet:          result.GetSubnet(),
+			IPAddress:       result.GetIpAddress(),
+			MacAddress:      result.GetMacAddress(),
+			VlanID:          int64(result.GetVlanId()),
+			DelegatedPrefix: result.GetDelegatedPrefix(),
+		})
+	}
+
+	return &AllocateIPResponse{
+		PodName:                   resp.GetPodName(),
+		PodNamespace:              resp.GetPodNamespace(),
+		NodeName:                  resp.GetNodeName(),
+		IaaSIPsAllocationResponse: results,
+	}
+}
+
+func toPBReleaseIPRequest(req *ReleaseIPRequest) *iaasv1.ReleaseIPRequest {
+	return &iaasv1.ReleaseIPRequest{
+		PodName:      req.PodName,
+		PodNamespace: req.PodNamespace,
+		PodUid:       req.PodUID,
+		NodeName:     req.NodeName,
+		ParentNicMac: req.ParentNicMac,
+		Subnet:       req.Subnet,
+		IpAddress:    req.IPAddress,
+		IpVersion:    int32(req.IPVersion), //nolint:gosec
+		Prefix:       req.Prefix,
+	}
+}
+
+func fromPBListAssignmentsResponse(resp *iaasv1.ListAssignmentsResponse) *ListAssignmentsResponse {
+	assignments := make([]IaaSIPAssignment, 0, len(resp.GetIaasIpAssignments()))
+	for _, assignment := range resp.GetIaasIpAssignments() {
+		assignments = append(assignments, IaaSIPAssignment{
+			NodeName:     assignment.GetNodeName(),
+			ParentNicMac: assignment.GetParentNicMac(),
+			Subnet:       assignment.GetSubnet(),
+			IPAddress:    assignment.GetIpAddress(),
+			IPVersion:    int64(assignment.GetIpVersion()),
+			Prefix:       assignment.GetPrefix(),
+			PodName:      assignment.GetPodName(),
+			PodNamespace: assignment.GetPodNamespace(),
+			PodUID:       assignment.GetPodUid(),
+		})
+	}
+
+	return &ListAssignmentsResponse{
+		IaaSIPAssignments: assignments,
+		Continue:          resp.GetContinue(),
+	}
+}
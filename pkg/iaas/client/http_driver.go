@@ -0,0 +1,277 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.uber.org/zap"
+
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+func init() {
+	RegisterDriver(DriverHTTP, func(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (Client, error) {
+		return NewHTTPDriver(cfg, logger)
+	})
+}
+
+var templateFuncs = template.FuncMap{
+	"toJson": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// httpOperation is an HTTP call of the generic HTTP driver with the parsed templates.
+type httpOperation struct {
+	name     string
+	method   string
+	path     *template.Template
+	request  *template.Template
+	response *template.Template
+}
+
+// HTTPDriver calls the HTTP API of any IaaS provider, the requests and
+// responses are converted from and to the Spiderpool API with Go templates.
+type HTTPDriver struct {
+	*parentNicMacCache
+
+	baseURL     string
+	headers     map[string]string
+	operations  map[string]*httpOperation
+	httpClient  *http.Client
+	httpTimeout time.Duration
+	logger      *zap.Logger
+}
+
+// NewHTTPDriver creates the generic HTTP driver of IaaS provider.
+func NewHTTPDriver(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (*HTTPDriver, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	timeout, err := requestTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := newHTTPOperations(&cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := newParentNicMacCache(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPDriver{
+		parentNicMacCache: cache,
+		baseURL:           strings.TrimSuffix(cfg.ServerURL, "/"),
+		headers:           cfg.HTTP.Headers,
+		operations:        operations,
+		httpClient:        newHTTPClient(),
+		httpTimeout:       timeout,
+		logger:            logger,
+	}, nil
+}
+
+func newHTTPOperations(cfg *spiderpooltypes.IaaSHTTPDriverConfig) (map[string]*httpOperation, error) {
+	operations := map[string]*httpOperation{}
+	for name, opCfg := range map[string]spiderpooltypes.IaaSHTTPOperationConfig{
+		"allocate":        cfg.Allocate,
+		"release":         cfg.Release,
+		"listAssignments": cfg.ListAssignments,
+	} {
+		if opCfg.Path == "" {
+			continue
+		}
+
+		op := &httpOperation{name: name, method: opCfg.Method}
+		if op.method == "" {
+			op.method = http.MethodPost
+		}
+
+		var err error
+		if op.path, err = template.New(name + ".path").Funcs(templateFuncs).Parse(opCfg.Path); err != nil {
+			return nil, fmt.Errorf("failed to parse path template of %s: %w", name, err)
+		}
+		if opCfg.RequestTemplate != "" {
+			if op.request, err = template.New(name + ".request").Funcs(templateFuncs).Parse(opCfg.RequestTemplate); err != nil {
+				return nil, fmt.Errorf("failed to parse request template of %s: %w", name, err)
+			}
+		}
+		if opCfg.ResponseTemplate != "" {
+			if op.response, err = template.New(name + ".response").Funcs(templateFuncs).Parse(opCfg.ResponseTemplate); err != nil {
+				return nil, fmt.Errorf("failed to parse response template of %s: %w", name, err)
+			}
+		}
+		operations[name] = op
+	}
+
+	return operations, nil
+}
+
+// AllocateIPs calls the IaaS provider to allocate IPs
+func (d *HTTPDriver) AllocateIPs(ctx context.Context, req *AllocateIPRequest) (*AllocateIPResponse, error) {
+	if err := checkParentBudget(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp AllocateIPResponse
+	if err := d.call(ctx, "allocate", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ReleaseIP calls the IaaS provider to release an IP.
+func (d *HTTPDriver) ReleaseIP(ctx context.Context, req *ReleaseIPRequest) error {
+	if err := checkParentBudget(ctx); err != nil {
+		return err
+	}
+
+	return d.call(ctx, "release", req, nil)
+}
+
+// ListAssignments calls the IaaS provider to list a page of the IP assignments.
+func (d *HTTPDriver) ListAssignments(ctx context.Context, req *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	var resp ListAssignmentsResponse
+	if err := d.call(ctx, "listAssignments", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// call renders the request of the operation with the JSON fields of req,
+// and converts the response into resp if it's not nil.
+func (d *HTTPDriver) call(ctx context.Context, name string, req any, resp any) error {
+	op, ok := d.operations[name]
+	if !ok {
+		return fmt.Errorf("iaas %s operation is not configured for HTTP driver", name)
+	}
+
+	data, err := toTemplateData(req)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s request: %w", name, err)
+	}
+
+	path, err := renderTemplate(op.path, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s path: %w", name, err)
+	}
+
+	var reqBody []byte
+	if op.request != nil {
+		rendered, err := renderTemplate(op.request, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s request: %w", name, err)
+		}
+		reqBody = []byte(rendered)
+	} else if reqBody, err = json.Marshal(req); err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", name, err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, d.httpTimeout)
+	defer cancel()
+
+	reqURL := d.baseURL + "/" + strings.TrimPrefix(path, "/")
+	var body io.Reader
+	if op.method != http.MethodGet && op.method != http.MethodDelete || op.request != nil {
+		body = bytes.NewBuffer(reqBody)
+	}
+	httpReq, err := http.NewRequestWithContext(reqCtx, op.method, reqURL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", name, err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range d.headers {
+		httpReq.Header.Set(key, value)
+	}
+	setRequestTimeoutHeader(httpReq)
+
+	d.logger.Debug("Calling IaaS HTTP driver", zap.String("operation", name), zap.String("method", op.method), zap.String("url", reqURL))
+
+	httpResp, err := d.httpClient.Do(httpReq)
+	if err != nil {
+		d.logger.Error("IaaS HTTP driver call failed", zap.String("operation", name), zap.String("url", reqURL), zap.Error(err))
+		return fmt.Errorf("iaas %s API call failed: %w", name, err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", name, err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		d.logger.Error("IaaS HTTP driver returned non-success status",
+			zap.String("operation", name),
+			zap.Int("statusCode", httpResp.StatusCode),
+			zap.String("response", string(respBody)))
+		return &StatusError{API: name, StatusCode: httpResp.StatusCode, Body: string(respBody)}
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	if op.response != nil {
+		var decoded any
+		if len(bytes.TrimSpace(respBody)) > 0 {
+			if err := json.Unmarshal(respBody, &decoded); err != nil {
+				return fmt.Errorf("failed to unmarshal %s response: %w", name, err)
+			}
+		}
+		rendered, err := renderTemplate(op.response, map[string]any{"request": data, "response": decoded})
+		if err != nil {
+			return fmt.Errorf("failed to render %s response: %w", name, err)
+		}
+		respBody = []byte(rendered)
+	}
+
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", name, err)
+	}
+
+	return nil
+}
+
+// toTemplateData converts the request to the generic JSON value, so that
+// the templates refer to the fields with the names of the Spiderpool API.
+func toTemplateData(req any) (any, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var data any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func renderTemplate(tmpl *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Option("missingkey=zero").Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
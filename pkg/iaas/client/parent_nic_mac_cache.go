@@ -0,0 +1,267 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// ParentNicMacEntry is a cached parent NIC MAC address.
+type ParentNicMacEntry struct {
+	MAC string `json:"mac"`
+	// Owner is the SpiderMultusConfig namespace/name which the MAC is resolved
+	// from, the entry is invalidated once the SpiderMultusConfig changes.
+	Owner string `json:"owner,omitempty"`
+	// Iface is the master interface on the node, the entry is invalidated
+	// once the interface is removed or its MAC address changes.
+	Iface string `json:"iface,omitempty"`
+	// ExpireAt is zero if the entry never expires.
+	ExpireAt time.Time `json:"expireAt,omitempty"`
+}
+
+type parentNicMacCacheFile struct {
+	Entries map[string]ParentNicMacEntry `json:"entries"`
+}
+
+// parentNicMacCache caches key -> parent NIC MAC address for all drivers.
+// Keys use SpiderMultusConfig namespace/name or the subnet CIDR.
+type parentNicMacCache struct {
+	lock        sync.Mutex
+	entries     map[string]ParentNicMacEntry
+	ttl         time.Duration
+	persistPath string
+	logger      *zap.Logger
+
+	// now is replaced in unit tests.
+	now func() time.Time
+}
+
+func parentNicMacCacheTTL(cfg *spiderpooltypes.IaaSProviderConfig) (time.Duration, error) {
+	if cfg.ParentNicMacCache.TTL == "" {
+		return constant.DefaultIaaSParentNicMacCacheTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(cfg.ParentNicMacCache.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid iaasNetworkProvider.parentNicMacCache.ttl %q: %w", cfg.ParentNicMacCache.TTL, err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("invalid iaasNetworkProvider.parentNicMacCache.ttl %q: ttl must not be negative", cfg.ParentNicMacCache.TTL)
+	}
+
+	return ttl, nil
+}
+
+// newParentNicMacCache creates the cache, and restores the entries from the
+// persist file if there is.
+func newParentNicMacCache(cfg *spiderpooltypes.IaaSProviderConfig, logger *zap.Logger) (*parentNicMacCache, error) {
+	ttl, err := parentNicMacCacheTTL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &parentNicMacCache{
+		entries:     map[string]ParentNicMacEntry{},
+		ttl:         ttl,
+		persistPath: cfg.ParentNicMacCache.PersistPath,
+		logger:      logger,
+		now:         time.Now,
+	}
+	c.restore()
+
+	return c, nil
+}
+
+// GetCachedParentNicMac returns the cached parent NIC MAC for the given key, or empty string if not cached.
+// The expiration of a hit entry is refreshed, so the entries used by the IP
+// allocation and release of the live Pods do not expire.
+func (c *parentNicMacCache) GetCachedParentNicMac(key string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if c.expired(entry) {
+		delete(c.entries, key)
+		c.recordSize()
+		return "", false
+	}
+
+	if c.ttl > 0 {
+		refreshed := entry
+		refreshed.ExpireAt = c.now().Add(c.ttl)
+		c.entries[key] = refreshed
+		if !c.freshEnough(entry) {
+			c.persist()
+		}
+	}
+
+	return entry.MAC, true
+}
+
+// CacheParentNicMac stores a parent NIC MAC for the given key.
+func (c *parentNicMacCache) CacheParentNicMac(key string, entry ParentNicMacEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.ttl > 0 {
+		entry.ExpireAt = c.now().Add(c.ttl)
+	}
+
+	old, ok := c.entries[key]
+	c.entries[key] = entry
+	c.recordSize()
+
+	if ok && old.MAC == entry.MAC && old.Owner == entry.Owner && old.Iface == entry.Iface && c.freshEnough(old) {
+		return
+	}
+	c.persist()
+}
+
+// freshEnough reports whether the persisted expiration of the entry is still
+// more than the half of TTL away. Only the refreshed expiration beyond that is
+// persisted, to avoid writing the file on each IP allocation.
+func (c *parentNicMacCache) freshEnough(entry ParentNicMacEntry) bool {
+	return c.ttl == 0 || entry.ExpireAt.Sub(c.now()) > c.ttl/2
+}
+
+// InvalidateParentNicMac removes the entries resolved from the
+// SpiderMultusConfig namespace/name, returns the number of removed entries.
+func (c *parentNicMacCache) InvalidateParentNicMac(owner string) int {
+	return c.invalidate(func(key string, entry ParentNicMacEntry) bool {
+		return key == owner || entry.Owner == owner
+	})
+}
+
+// InvalidateParentNicMacByIface removes the entries of the master interface
+// whose MAC address is not mac, the empty mac removes all the entries of the
+// interface. Returns the number of removed entries.
+func (c *parentNicMacCache) InvalidateParentNicMacByIface(iface, mac string) int {
+	return c.invalidate(func(_ string, entry ParentNicMacEntry) bool {
+		return entry.Iface == iface && entry.MAC != mac
+	})
+}
+
+func (c *parentNicMacCache) invalidate(match func(key string, entry ParentNicMacEntry) bool) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	count := 0
+	for key, entry := range c.entries {
+		if match(key, entry) {
+			delete(c.entries, key)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+
+	c.recordSize()
+	c.persist()
+
+	return count
+}
+
+func (c *parentNicMacCache) expired(entry ParentNicMacEntry) bool {
+	return !entry.ExpireAt.IsZero() && c.now().After(entry.ExpireAt)
+}
+
+func (c *parentNicMacCache) recordSize() {
+	metric.IaaSParentNicMacCacheEntries.Record(int64(len(c.entries)))
+}
+
+// restore loads the unexpired entries from the persist file, so that the IP
+// release after the restart of spiderpool-agent does not depend on the Pod.
+func (c *parentNicMacCache) restore() {
+	if c.persistPath == "" {
+		return
+	}
+
+	data, err := os.ReadFile(c.persistPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			c.logger.Warn("Failed to read parentNicMac cache file", zap.String("path", c.persistPath), zap.Error(err))
+		}
+		return
+	}
+
+	var file parentNicMacCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		c.logger.Warn("Failed to unmarshal parentNicMac cache file, discard it", zap.String("path", c.persistPath), zap.Error(err))
+		return
+	}
+
+	for key, entry := range file.Entries {
+		if c.expired(entry) {
+			continue
+		}
+		c.entries[key] = entry
+	}
+	c.recordSize()
+	c.logger.Info("Restored parentNicMac cache", zap.String("path", c.persistPath), zap.Int("count", len(c.entries)))
+}
+
+// persist writes all the unexpired entries to the persist file, the caller
+// must hold the lock.
+func (c *parentNicMacCache) persist() {
+	if c.persistPath == "" {
+		return
+	}
+
+	file := parentNicMacCacheFile{Entries: make(map[string]ParentNicMacEntry, len(c.entries))}
+	for key, entry := range c.entries {
+		if c.expired(entry) {
+			delete(c.entries, key)
+			continue
+		}
+		file.Entries[key] = entry
+	}
+	c.recordSize()
+
+	if err := writeFileAtomic(c.persistPath, file); err != nil {
+		c.logger.Warn("Failed to persist parentNicMac cache", zap.String("path", c.persistPath), zap.Error(err))
+	}
+}
+
+func writeFileAtomic(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
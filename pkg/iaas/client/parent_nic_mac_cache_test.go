@@ -0,0 +1,147 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("Parent NIC MAC cache", Label("unitest"), func() {
+	var cfg *spiderpooltypes.IaaSProviderConfig
+	var now time.Time
+
+	newCache := func() *parentNicMacCache {
+		c, err := newParentNicMacCache(cfg, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		c.now = func() time.Time { return now }
+		return c
+	}
+
+	BeforeEach(func() {
+		cfg = &spiderpooltypes.IaaSProviderConfig{ServerURL: "http://localhost:8080"}
+		now = time.Now()
+	})
+
+	It("expires the entries after TTL", func() {
+		cfg.ParentNicMacCache.TTL = "1h"
+		c := newCache()
+		c.CacheParentNicMac("tenant-a/provider-net", ParentNicMacEntry{MAC: "02:00:00:00:00:01"})
+
+		mac, ok := c.GetCachedParentNicMac("tenant-a/provider-net")
+		Expect(ok).To(BeTrue())
+		Expect(mac).To(Equal("02:00:00:00:00:01"))
+
+		now = now.Add(2 * time.Hour)
+		_, ok = c.GetCachedParentNicMac("tenant-a/provider-net")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("refreshes the expiration of the entries on use", func() {
+		cfg.ParentNicMacCache.TTL = "1h"
+		cfg.ParentNicMacCache.PersistPath = filepath.Join(GinkgoT().TempDir(), "parent-nic-mac.json")
+		c := newCache()
+		c.CacheParentNicMac("10.0.1.0/24", ParentNicMacEntry{MAC: "02:00:00:00:00:01"})
+
+		for range 3 {
+			now = now.Add(40 * time.Minute)
+			mac, ok := c.GetCachedParentNicMac("10.0.1.0/24")
+			Expect(ok).To(BeTrue())
+			Expect(mac).To(Equal("02:00:00:00:00:01"))
+		}
+
+		// The refreshed expiration is persisted as well.
+		restored := newCache()
+		_, ok := restored.GetCachedParentNicMac("10.0.1.0/24")
+		Expect(ok).To(BeTrue())
+
+		now = now.Add(2 * time.Hour)
+		_, ok = c.GetCachedParentNicMac("10.0.1.0/24")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("never expires the entries with zero TTL", func() {
+		cfg.ParentNicMacCache.TTL = "0s"
+		c := newCache()
+		c.CacheParentNicMac("tenant-a/provider-net", ParentNicMacEntry{MAC: "02:00:00:00:00:01"})
+
+		now = now.Add(24 * 365 * time.Hour)
+		_, ok := c.GetCachedParentNicMac("tenant-a/provider-net")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("invalidates the entries by owner and interface", func() {
+		c := newCache()
+		c.CacheParentNicMac("tenant-a/net1", ParentNicMacEntry{MAC: "02:00:00:00:00:01", Owner: "tenant-a/net1", Iface: "eth1"})
+		c.CacheParentNicMac("10.0.1.0/24", ParentNicMacEntry{MAC: "02:00:00:00:00:01", Owner: "tenant-a/net1", Iface: "eth1"})
+		c.CacheParentNicMac("tenant-a/net2", ParentNicMacEntry{MAC: "02:00:00:00:00:02", Owner: "tenant-a/net2", Iface: "eth2"})
+		c.CacheParentNicMac("10.0.2.0/24", ParentNicMacEntry{MAC: "02:00:00:00:00:02", Owner: "tenant-a/net2", Iface: "eth2"})
+
+		Expect(c.InvalidateParentNicMac("tenant-a/net1")).To(Equal(2))
+		_, ok := c.GetCachedParentNicMac("10.0.1.0/24")
+		Expect(ok).To(BeFalse())
+
+		// The MAC address of the interface does not change.
+		Expect(c.InvalidateParentNicMacByIface("eth2", "02:00:00:00:00:02")).To(Equal(0))
+		Expect(c.InvalidateParentNicMacByIface("eth2", "02:00:00:00:00:03")).To(Equal(2))
+		_, ok = c.GetCachedParentNicMac("tenant-a/net2")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("restores the entries from the persist file", func() {
+		cfg.ParentNicMacCache.PersistPath = filepath.Join(GinkgoT().TempDir(), "cache", "parent-nic-mac.json")
+		c := newCache()
+		c.CacheParentNicMac("10.0.1.0/24", ParentNicMacEntry{MAC: "02:00:00:00:00:01", Owner: "tenant-a/net1", Iface: "eth1"})
+		c.CacheParentNicMac("10.0.2.0/24", ParentNicMacEntry{MAC: "02:00:00:00:00:02", Owner: "tenant-a/net2", Iface: "eth2"})
+		Expect(c.InvalidateParentNicMac("tenant-a/net2")).To(Equal(1))
+
+		restored := newCache()
+		mac, ok := restored.GetCachedParentNicMac("10.0.1.0/24")
+		Expect(ok).To(BeTrue())
+		Expect(mac).To(Equal("02:00:00:00:00:01"))
+		_, ok = restored.GetCachedParentNicMac("10.0.2.0/24")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("discards the expired and corrupted persist file", func() {
+		cfg.ParentNicMacCache.TTL = "1h"
+		cfg.ParentNicMacCache.PersistPath = filepath.Join(GinkgoT().TempDir(), "parent-nic-mac.json")
+		// The entry is cached two hours ago.
+		now = now.Add(-2 * time.Hour)
+		c := newCache()
+		c.CacheParentNicMac("10.0.1.0/24", ParentNicMacEntry{MAC: "02:00:00:00:00:01"})
+
+		restored, err := newParentNicMacCache(cfg, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.entries).To(BeEmpty())
+
+		Expect(os.WriteFile(cfg.ParentNicMacCache.PersistPath, []byte("{"), 0o600)).To(Succeed())
+		restored, err = newParentNicMacCache(cfg, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.entries).To(BeEmpty())
+	})
+
+	DescribeTable("validates the configuration",
+		func(cacheCfg spiderpooltypes.IaaSParentNicMacCacheConfig, errMsg string) {
+			cfg.ParentNicMacCache = cacheCfg
+			err := ValidateConfig(cfg)
+			if errMsg == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(errMsg)))
+		},
+		Entry("default", spiderpooltypes.IaaSParentNicMacCacheConfig{}, ""),
+		Entry("valid", spiderpooltypes.IaaSParentNicMacCacheConfig{TTL: "12h", PersistPath: "/var/run/spidernet/iaas-parent-nic-mac.json"}, ""),
+		Entry("negative TTL", spiderpooltypes.IaaSParentNicMacCacheConfig{TTL: "-1h"}, "parentNicMacCache.ttl"),
+		Entry("relative persist path", spiderpooltypes.IaaSParentNicMacCacheConfig{PersistPath: "cache.json"}, "parentNicMacCache.persistPath"),
+	)
+})
@@ -25,6 +25,11 @@ type IaaSIPAllocationItem struct {
 	Subnet string `json:"subnet"`
 	// ParentNicMac is required
 	ParentNicMac string `json:"parentNicMac"`
+	// IPVersion is optional, 4 or 6, IPv4 is assumed if it's empty
+	IPVersion int64 `json:"ipVersion,omitempty"`
+	// Prefix is optional, it's the prefix delegated to the interface
+	// if the IPPool works in prefix delegation mode
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // AllocateIPResponse represents the response from IaaS IP allocation API
@@ -51,6 +56,9 @@ type IaaSIPAllocationResult struct {
 	MacAddress string `json:"macAddress"`
 	// VlanID is the VLAN ID
 	VlanID int64 `json:"vlanId"`
+	// DelegatedPrefix is optional, it's the prefix the provider delegates
+	// to the interface, which must contain the allocated IP address
+	DelegatedPrefix string `json:"delegatedPrefix,omitempty"`
 }
 
 // ReleaseIPRequest represents the request body for IaaS IP release API
@@ -69,4 +77,48 @@ type ReleaseIPRequest struct {
 	Subnet string `json:"subnet"`
 	// IPAddress is the IP being released
 	IPAddress string `json:"ipAddress"`
+	// IPVersion is optional, 4 or 6, IPv4 is assumed if it's empty
+	IPVersion int64 `json:"ipVersion,omitempty"`
+	// Prefix is optional, it's the prefix delegated to the interface
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// ListAssignmentsRequest represents the request body for IaaS IP assignment list API
+type ListAssignmentsRequest struct {
+	// NodeName is optional, the assignments of all nodes are listed if it's empty
+	NodeName string `json:"nodeName,omitempty"`
+	// Limit is optional, it's the maximum number of assignments in a page
+	Limit int64 `json:"limit,omitempty"`
+	// Continue is optional, it's the token returned by the previous page
+	Continue string `json:"continue,omitempty"`
+}
+
+// ListAssignmentsResponse represents the response from IaaS IP assignment list API
+type ListAssignmentsResponse struct {
+	// IaaSIPAssignments contains the IP assignments in this page
+	IaaSIPAssignments []IaaSIPAssignment `json:"iaasIPAssignments"`
+	// Continue is the token to get the next page, it's empty for the last page
+	Continue string `json:"continue,omitempty"`
+}
+
+// IaaSIPAssignment represents an IP address bound on the cloud platform
+type IaaSIPAssignment struct {
+	// NodeName is the node which the IP is bound to
+	NodeName string `json:"nodeName"`
+	// ParentNicMac is the parent NIC MAC address
+	ParentNicMac string `json:"parentNicMac,omitempty"`
+	// Subnet is the subnet CIDR
+	Subnet string `json:"subnet"`
+	// IPAddress is the bound IP address
+	IPAddress string `json:"ipAddress"`
+	// IPVersion is optional, 4 or 6, IPv4 is assumed if it's empty
+	IPVersion int64 `json:"ipVersion,omitempty"`
+	// Prefix is optional, it's the prefix delegated to the interface
+	Prefix string `json:"prefix,omitempty"`
+	// PodName is optional, it's the Pod in the allocation request
+	PodName string `json:"podName,omitempty"`
+	// PodNamespace is optional, it's the Pod namespace in the allocation request
+	PodNamespace string `json:"podNamespace,omitempty"`
+	// PodUID is optional, it's the Pod UID in the allocation request
+	PodUID string `json:"podUID,omitempty"`
 }
@@ -0,0 +1,185 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	"github.com/spidernet-io/spiderpool/pkg/utils/retry"
+)
+
+// ErrReleasePending means the IaaS IP release failed and was added to the
+// pending queue, the reconciler retries it later.
+var ErrReleasePending = errors.New("IaaS IP release is pending")
+
+// ReleaseIP calls the IaaS provider to release the IP. Once the release
+// fails, it is added to the queue and an error wrapping ErrReleasePending
+// is returned, so that the caller could go on releasing the IP from
+// Spiderpool. Any other error means the release is neither done nor queued.
+func ReleaseIP(ctx context.Context, iaasClient iaasclient.Client, queue PendingReleaseQueue, req *iaasclient.ReleaseIPRequest) error {
+	releaseErr := iaasClient.ReleaseIP(ctx, req)
+	if releaseErr == nil {
+		return nil
+	}
+
+	if queue == nil {
+		return releaseErr
+	}
+	if err := queue.Add(ctx, req); err != nil {
+		return fmt.Errorf("%w, and failed to add it to pending queue: %v", releaseErr, err)
+	}
+
+	return fmt.Errorf("%w: %v", ErrReleasePending, releaseErr)
+}
+
+// PendingReleaseQueue persists the IaaS IP releases which failed, so that
+// they survive the restart of spiderpool-agent and spiderpool-controller and
+// could be retried by the reconciler.
+type PendingReleaseQueue interface {
+	// Add records the release request, the previous request of the same IP is overwritten.
+	Add(ctx context.Context, req *iaasclient.ReleaseIPRequest) error
+	// List returns all the pending release requests.
+	List(ctx context.Context) ([]*iaasclient.ReleaseIPRequest, error)
+	// Remove deletes the release request of the IP.
+	Remove(ctx context.Context, req *iaasclient.ReleaseIPRequest) error
+}
+
+type pendingReleaseQueue struct {
+	client    client.Client
+	apiReader client.Reader
+	namespace string
+}
+
+// NewPendingReleaseQueue returns a PendingReleaseQueue backed by the ConfigMap
+// constant.IaaSPendingReleaseConfigMapName in the namespace.
+func NewPendingReleaseQueue(client client.Client, apiReader client.Reader, namespace string) (PendingReleaseQueue, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &pendingReleaseQueue{
+		client:    client,
+		apiReader: apiReader,
+		namespace: namespace,
+	}, nil
+}
+
+func (q *pendingReleaseQueue) Add(ctx context.Context, req *iaasclient.ReleaseIPRequest) error {
+	key, err := pendingReleaseKey(req.IPAddress)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal release request of IP %s: %w", req.IPAddress, err)
+	}
+
+	return retry.RetryOnConflictWithContext(ctx, retry.DefaultRetry, func(ctx context.Context) error {
+		var cm corev1.ConfigMap
+		err := q.apiReader.Get(ctx, client.ObjectKey{Namespace: q.namespace, Name: constant.IaaSPendingReleaseConfigMapName}, &cm)
+		if apierrors.IsNotFound(err) {
+			cm = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: q.namespace,
+					Name:      constant.IaaSPendingReleaseConfigMapName,
+				},
+				Data: map[string]string{key: string(data)},
+			}
+			err = q.client.Create(ctx, &cm)
+			if apierrors.IsAlreadyExists(err) {
+				// Let the conflict retry get the ConfigMap again.
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = string(data)
+
+		return q.client.Update(ctx, &cm)
+	})
+}
+
+func (q *pendingReleaseQueue) List(ctx context.Context) ([]*iaasclient.ReleaseIPRequest, error) {
+	var cm corev1.ConfigMap
+	err := q.apiReader.Get(ctx, client.ObjectKey{Namespace: q.namespace, Name: constant.IaaSPendingReleaseConfigMapName}, &cm)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	reqs := make([]*iaasclient.ReleaseIPRequest, 0, len(keys))
+	for _, key := range keys {
+		var req iaasclient.ReleaseIPRequest
+		if err := json.Unmarshal([]byte(cm.Data[key]), &req); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pending release '%s': %w", key, err)
+		}
+		reqs = append(reqs, &req)
+	}
+
+	return reqs, nil
+}
+
+func (q *pendingReleaseQueue) Remove(ctx context.Context, req *iaasclient.ReleaseIPRequest) error {
+	key, err := pendingReleaseKey(req.IPAddress)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflictWithContext(ctx, retry.DefaultRetry, func(ctx context.Context) error {
+		var cm corev1.ConfigMap
+		err := q.apiReader.Get(ctx, client.ObjectKey{Namespace: q.namespace, Name: constant.IaaSPendingReleaseConfigMapName}, &cm)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		if _, ok := cm.Data[key]; !ok {
+			return nil
+		}
+		delete(cm.Data, key)
+
+		return q.client.Update(ctx, &cm)
+	})
+}
+
+// pendingReleaseKey converts the IP address to a valid ConfigMap data key,
+// the colons of IPv6 address are replaced with dashes.
+func pendingReleaseKey(ipAddress string) (string, error) {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address '%s' of pending release", ipAddress)
+	}
+
+	return strings.ReplaceAll(ip.String(), ":", "-"), nil
+}
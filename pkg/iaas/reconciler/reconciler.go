@@ -0,0 +1,314 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasutils "github.com/spidernet-io/spiderpool/pkg/iaas/utils"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+const defaultListAssignmentsLimit = 500
+
+var logger *zap.Logger
+
+type ReconcilerConfig struct {
+	// Interval is the duration between two rounds of reconciliation.
+	Interval time.Duration
+	// ListAssignmentsLimit is the page size of listing IaaS IP assignments.
+	ListAssignmentsLimit int64
+}
+
+// Reconciler periodically compares the IP assignments on the cloud platform
+// with SpiderIPPools and SpiderEndpoints. It retries the pending IaaS IP
+// releases, and reports the cloud assignments which are not tracked by
+// Spiderpool as orphans with events and metrics.
+type Reconciler struct {
+	config     ReconcilerConfig
+	apiReader  client.Reader
+	iaasClient iaasclient.Client
+	queue      PendingReleaseQueue
+	leader     election.SpiderLeaseElector
+}
+
+// ReconcileResult is the summary of a round of reconciliation.
+type ReconcileResult struct {
+	// PendingReleases are the releases still failed to retry in this round.
+	PendingReleases []*iaasclient.ReleaseIPRequest
+	// OrphanedAssignments are the cloud assignments not tracked by Spiderpool.
+	OrphanedAssignments []iaasclient.IaaSIPAssignment
+}
+
+func NewReconciler(config ReconcilerConfig, apiReader client.Reader, iaasClient iaasclient.Client, queue PendingReleaseQueue, leader election.SpiderLeaseElector) (*Reconciler, error) {
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
+	if iaasClient == nil {
+		return nil, fmt.Errorf("IaaS client %w", constant.ErrMissingRequiredParam)
+	}
+	if queue == nil {
+		return nil, fmt.Errorf("pending release queue %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("spiderpool controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	if config.ListAssignmentsLimit <= 0 {
+		config.ListAssignmentsLimit = defaultListAssignmentsLimit
+	}
+
+	logger = logutils.Logger.Named("IaaS-Reconciler")
+
+	return &Reconciler{
+		config:     config,
+		apiReader:  apiReader,
+		iaasClient: iaasClient,
+		queue:      queue,
+		leader:     leader,
+	}, nil
+}
+
+// Start runs the reconciliation with the interval until the context is done,
+// only the elected spiderpool-controller does the work.
+func (r *Reconciler) Start(ctx context.Context) {
+	if r.config.Interval <= 0 {
+		logger.Warn("IaaS reconciliation is disabled")
+		return
+	}
+
+	logger.Sugar().Infof("running IaaS reconciliation with interval %v", r.config.Interval)
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !r.leader.IsElected() {
+				continue
+			}
+			if _, err := r.Reconcile(ctx); err != nil {
+				logger.Sugar().Errorf("failed to reconcile IaaS IP assignments: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Warn("receive ctx done, stop IaaS reconciliation!")
+			return
+		}
+	}
+}
+
+// Reconcile does a round of reconciliation.
+func (r *Reconciler) Reconcile(ctx context.Context) (*ReconcileResult, error) {
+	assignments, err := r.listAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, poolOfSubnet, err := r.trackedIPs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	retried, pendingReleases, err := r.retryPendingReleases(ctx, assignments, tracked)
+	if err != nil {
+		return nil, err
+	}
+	metric.IaaSPendingReleaseCounts.Record(int64(len(pendingReleases)))
+
+	// The assignments were listed before retrying, don't report the IPs
+	// released or still pending in this round as orphans.
+	for _, ip := range retried {
+		tracked[ip] = ""
+	}
+
+	var orphans []iaasclient.IaaSIPAssignment
+	for _, assignment := range assignments {
+		ip := net.ParseIP(assignment.IPAddress)
+		if ip == nil {
+			logger.Sugar().Warnf("IaaS provider returns invalid IP address '%s' of assignment", assignment.IPAddress)
+			continue
+		}
+
+		podUID, ok := tracked[ip.String()]
+		if ok && (podUID == "" || assignment.PodUID == "" || podUID == assignment.PodUID) {
+			continue
+		}
+
+		orphans = append(orphans, assignment)
+		logger.Sugar().Warnf("IaaS IP assignment %s on node '%s' is not tracked by Spiderpool", ip, assignment.NodeName)
+
+		if pool, ok := poolOfSubnet[normalizeCIDR(assignment.Subnet)]; ok {
+			event.EventRecorder.Eventf(pool, corev1.EventTypeWarning, constant.EventReasonIaaSOrphanedAssignment,
+				"IaaS IP assignment %s on node %s is not tracked by Spiderpool", ip, assignment.NodeName)
+		} else {
+			event.EventRecorder.Eventf(&corev1.ObjectReference{Kind: constant.KindNode, Name: assignment.NodeName},
+				corev1.EventTypeWarning, constant.EventReasonIaaSOrphanedAssignment,
+				"IaaS IP assignment %s in subnet %s is not tracked by Spiderpool", ip, assignment.Subnet)
+		}
+	}
+	metric.IaaSOrphanedAssignmentCounts.Record(int64(len(orphans)))
+
+	return &ReconcileResult{
+		PendingReleases:     pendingReleases,
+		OrphanedAssignments: orphans,
+	}, nil
+}
+
+// retryPendingReleases calls the IaaS provider to release the IPs in the
+// queue, it returns the IPs retried in this round and the releases which
+// still fail. Before retrying, the queued release is dropped if the IP has
+// been released by the provider, or has been reassigned to another Pod in
+// Spiderpool or on the cloud platform.
+func (r *Reconciler) retryPendingReleases(ctx context.Context, assignments []iaasclient.IaaSIPAssignment, tracked map[string]string) ([]string, []*iaasclient.ReleaseIPRequest, error) {
+	reqs, err := r.queue.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pending IaaS releases: %w", err)
+	}
+
+	assigned := make(map[string]iaasclient.IaaSIPAssignment, len(assignments))
+	for _, assignment := range assignments {
+		if ip := net.ParseIP(assignment.IPAddress); ip != nil {
+			assigned[ip.String()] = assignment
+		}
+	}
+
+	var retried []string
+	var pending []*iaasclient.ReleaseIPRequest
+	for _, req := range reqs {
+		ip := net.ParseIP(req.IPAddress)
+		if ip == nil {
+			logger.Sugar().Warnf("skip the pending release of invalid IP address '%s'", req.IPAddress)
+			continue
+		}
+
+		if podUID := tracked[ip.String()]; podUID != "" && podUID != req.PodUID {
+			logger.Sugar().Infof("IaaS IP '%s' has been reassigned to Pod %s in Spiderpool, drop the pending release", ip, podUID)
+			r.removePendingRelease(ctx, req)
+			continue
+		}
+
+		assignment, ok := assigned[ip.String()]
+		if !ok {
+			logger.Sugar().Infof("IaaS IP '%s' has been released by the IaaS provider, drop the pending release", ip)
+			r.removePendingRelease(ctx, req)
+			continue
+		}
+		if assignment.PodUID != "" && assignment.PodUID != req.PodUID {
+			logger.Sugar().Infof("IaaS IP '%s' has been reassigned to Pod %s by the IaaS provider, drop the pending release", ip, assignment.PodUID)
+			r.removePendingRelease(ctx, req)
+			continue
+		}
+
+		retried = append(retried, ip.String())
+		if err := r.iaasClient.ReleaseIP(ctx, req); err != nil {
+			logger.Sugar().Errorf("failed to retry releasing IaaS IP '%s', error: %v", req.IPAddress, err)
+			pending = append(pending, req)
+			continue
+		}
+
+		if r.removePendingRelease(ctx, req) {
+			logger.Sugar().Infof("successfully retried releasing IaaS IP '%s'", req.IPAddress)
+		}
+	}
+
+	return retried, pending, nil
+}
+
+func (r *Reconciler) removePendingRelease(ctx context.Context, req *iaasclient.ReleaseIPRequest) bool {
+	if err := r.queue.Remove(ctx, req); err != nil {
+		logger.Sugar().Errorf("failed to remove IaaS IP '%s' from pending queue, error: %v", req.IPAddress, err)
+		return false
+	}
+
+	return true
+}
+
+func (r *Reconciler) listAssignments(ctx context.Context) ([]iaasclient.IaaSIPAssignment, error) {
+	var assignments []iaasclient.IaaSIPAssignment
+	req := &iaasclient.ListAssignmentsRequest{Limit: r.config.ListAssignmentsLimit}
+	for {
+		resp, err := r.iaasClient.ListAssignments(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list IaaS IP assignments: %w", err)
+		}
+		assignments = append(assignments, resp.IaaSIPAssignments...)
+
+		if resp.Continue == "" {
+			return assignments, nil
+		}
+		req = &iaasclient.ListAssignmentsRequest{Limit: r.config.ListAssignmentsLimit, Continue: resp.Continue}
+	}
+}
+
+// trackedIPs returns the IPs allocated in SpiderIPPools or recorded in
+// SpiderEndpoints with the Pod UID, and the SpiderIPPools of the subnets.
+func (r *Reconciler) trackedIPs(ctx context.Context) (map[string]string, map[string]*spiderpoolv2beta1.SpiderIPPool, error) {
+	var poolList spiderpoolv2beta1.SpiderIPPoolList
+	if err := r.apiReader.List(ctx, &poolList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list SpiderIPPools: %w", err)
+	}
+
+	tracked := map[string]string{}
+	poolOfSubnet := map[string]*spiderpoolv2beta1.SpiderIPPool{}
+	for i := range poolList.Items {
+		pool := &poolList.Items[i]
+		poolOfSubnet[normalizeCIDR(pool.Spec.Subnet)] = pool
+		for _, secondary := range pool.Spec.SecondarySubnets {
+			poolOfSubnet[normalizeCIDR(secondary.Subnet)] = pool
+		}
+
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse allocated IPs of SpiderIPPool %s: %w", pool.Name, err)
+		}
+		for ipStr, record := range records {
+			if ip := net.ParseIP(ipStr); ip != nil {
+				tracked[ip.String()] = record.PodUID
+			}
+		}
+	}
+
+	var endpointList spiderpoolv2beta1.SpiderEndpointList
+	if err := r.apiReader.List(ctx, &endpointList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list SpiderEndpoints: %w", err)
+	}
+	for _, endpoint := range endpointList.Items {
+		for _, detail := range endpoint.Status.Current.IPs {
+			for _, address := range iaasutils.GetEndpointAddresses(detail) {
+				ip, _, err := net.ParseCIDR(address.Address)
+				if err != nil {
+					continue
+				}
+				if _, ok := tracked[ip.String()]; !ok {
+					tracked[ip.String()] = endpoint.Status.Current.UID
+				}
+			}
+		}
+	}
+
+	return tracked, poolOfSubnet, nil
+}
+
+func normalizeCIDR(cidr string) string {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return cidr
+	}
+
+	return ipNet.String()
+}
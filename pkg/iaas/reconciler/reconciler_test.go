@@ -0,0 +1,277 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	electionmock "github.com/spidernet-io/spiderpool/pkg/election/mock"
+	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	"github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+type fakeIaaSClient struct {
+	pages           []*iaasclient.ListAssignmentsResponse
+	listRequests    []*iaasclient.ListAssignmentsRequest
+	releaseRequests []*iaasclient.ReleaseIPRequest
+	releaseErr      map[string]error
+}
+
+func (f *fakeIaaSClient) AllocateIPs(context.Context, *iaasclient.AllocateIPRequest) (*iaasclient.AllocateIPResponse, error) {
+	return &iaasclient.AllocateIPResponse{}, nil
+}
+
+func (f *fakeIaaSClient) ReleaseIP(_ context.Context, req *iaasclient.ReleaseIPRequest) error {
+	f.releaseRequests = append(f.releaseRequests, req)
+	return f.releaseErr[req.IPAddress]
+}
+
+func (f *fakeIaaSClient) ListAssignments(_ context.Context, req *iaasclient.ListAssignmentsRequest) (*iaasclient.ListAssignmentsResponse, error) {
+	f.listRequests = append(f.listRequests, req)
+	if len(f.listRequests) > len(f.pages) {
+		return nil, fmt.Errorf("unexpected page")
+	}
+
+	return f.pages[len(f.listRequests)-1], nil
+}
+
+func (f *fakeIaaSClient) GetCachedParentNicMac(string) (string, bool) {
+	return "", false
+}
+
+func (f *fakeIaaSClient) CacheParentNicMac(string, iaasclient.ParentNicMacEntry) {}
+
+func (f *fakeIaaSClient) InvalidateParentNicMac(string) int {
+	return 0
+}
+
+func (f *fakeIaaSClient) InvalidateParentNicMacByIface(string, string) int {
+	return 0
+}
+
+var _ = Describe("IaaS reconciliation", Label("iaas_reconciler_test"), func() {
+	var scheme *runtime.Scheme
+	var fakeClient client.Client
+	var queue reconciler.PendingReleaseQueue
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(spiderpoolv2beta1.AddToScheme(scheme)).To(Succeed())
+
+		allocatedIPs := `{"10.0.1.2":{"pod":"default/pod-a","podUid":"uid-a"},"10.0.1.3":{"pod":"default/pod-b","podUid":"uid-b"}}`
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool-v4"},
+				Spec: spiderpoolv2beta1.IPPoolSpec{
+					IPVersion: ptr.To(constant.IPv4),
+					Subnet:    "10.0.1.0/24",
+					IPs:       []string{"10.0.1.2-10.0.1.10"},
+				},
+				Status: spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: &allocatedIPs},
+			},
+			&spiderpoolv2beta1.SpiderEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-c", Namespace: "default"},
+				Status: spiderpoolv2beta1.WorkloadEndpointStatus{
+					Current: spiderpoolv2beta1.PodIPAllocation{
+						UID:  "uid-c",
+						Node: "node-a",
+						IPs: []spiderpoolv2beta1.IPAllocationDetail{{
+							NIC:  "eth0",
+							IPv6: ptr.To("fd00:10::c/64"),
+						}},
+					},
+				},
+			},
+		).Build()
+
+		var err error
+		queue, err = reconciler.NewPendingReleaseQueue(fakeClient, fakeClient, "kube-system")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("PendingReleaseQueue", func() {
+		It("persists the pending releases in ConfigMap", func() {
+			ctx := context.Background()
+			v4 := &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.5", IPVersion: constant.IPv4}
+			v6 := &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "fd00:10::/64", IPAddress: "fd00:10::5", IPVersion: constant.IPv6}
+			Expect(queue.Add(ctx, v4)).To(Succeed())
+			Expect(queue.Add(ctx, v6)).To(Succeed())
+
+			var cm corev1.ConfigMap
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "kube-system", Name: constant.IaaSPendingReleaseConfigMapName}, &cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKey("10.0.1.5"))
+			Expect(cm.Data).To(HaveKey("fd00-10--5"))
+
+			reqs, err := queue.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(ConsistOf(v4, v6))
+
+			Expect(queue.Remove(ctx, v6)).To(Succeed())
+			reqs, err = queue.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(ConsistOf(v4))
+		})
+
+		It("lists nothing without ConfigMap", func() {
+			reqs, err := queue.List(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(BeEmpty())
+			Expect(queue.Remove(context.Background(), &iaasclient.ReleaseIPRequest{IPAddress: "10.0.1.5"})).To(Succeed())
+		})
+
+		It("rejects the invalid IP address", func() {
+			Expect(queue.Add(context.Background(), &iaasclient.ReleaseIPRequest{IPAddress: "invalid"})).NotTo(Succeed())
+		})
+	})
+
+	Describe("ReleaseIP", func() {
+		It("adds the failed release to the pending queue", func() {
+			ctx := context.Background()
+			iaasClient := &fakeIaaSClient{releaseErr: map[string]error{"10.0.1.5": fmt.Errorf("provider is unavailable")}}
+
+			Expect(reconciler.ReleaseIP(ctx, iaasClient, queue, &iaasclient.ReleaseIPRequest{IPAddress: "10.0.1.4"})).To(Succeed())
+			err := reconciler.ReleaseIP(ctx, iaasClient, queue, &iaasclient.ReleaseIPRequest{IPAddress: "10.0.1.5"})
+			Expect(err).To(MatchError(reconciler.ErrReleasePending))
+
+			reqs, err := queue.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(HaveLen(1))
+			Expect(reqs[0].IPAddress).To(Equal("10.0.1.5"))
+		})
+
+		It("returns the release error without queue", func() {
+			iaasClient := &fakeIaaSClient{releaseErr: map[string]error{"10.0.1.5": fmt.Errorf("provider is unavailable")}}
+			err := reconciler.ReleaseIP(context.Background(), iaasClient, nil, &iaasclient.ReleaseIPRequest{IPAddress: "10.0.1.5"})
+			Expect(err).To(HaveOccurred())
+			Expect(err).NotTo(MatchError(reconciler.ErrReleasePending))
+		})
+	})
+
+	Describe("Reconcile", func() {
+		It("retries the pending releases and reports the orphaned assignments", func() {
+			ctx := context.Background()
+			Expect(queue.Add(ctx, &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.20"})).To(Succeed())
+			Expect(queue.Add(ctx, &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.21"})).To(Succeed())
+
+			iaasClient := &fakeIaaSClient{
+				releaseErr: map[string]error{"10.0.1.21": fmt.Errorf("provider is unavailable")},
+				pages: []*iaasclient.ListAssignmentsResponse{
+					{
+						IaaSIPAssignments: []iaasclient.IaaSIPAssignment{
+							{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.2", PodUID: "uid-a"},
+							{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.3", PodUID: "uid-stale"},
+							{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.20"},
+							{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.21"},
+						},
+						Continue: "page-2",
+					},
+					{
+						IaaSIPAssignments: []iaasclient.IaaSIPAssignment{
+							{NodeName: "node-a", Subnet: "fd00:10::/64", IPAddress: "fd00:10:0::c", IPVersion: constant.IPv6},
+							{NodeName: "node-b", Subnet: "172.16.0.0/24", IPAddress: "172.16.0.9"},
+						},
+					},
+				},
+			}
+
+			mockCtrl := gomock.NewController(GinkgoT())
+			leader := electionmock.NewMockSpiderLeaseElector(mockCtrl)
+			r, err := reconciler.NewReconciler(reconciler.ReconcilerConfig{ListAssignmentsLimit: 4}, fakeClient, iaasClient, queue, leader)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := r.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(iaasClient.releaseRequests).To(HaveLen(2))
+			Expect(result.PendingReleases).To(HaveLen(1))
+			Expect(result.PendingReleases[0].IPAddress).To(Equal("10.0.1.21"))
+			reqs, err := queue.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(HaveLen(1))
+			Expect(reqs[0].IPAddress).To(Equal("10.0.1.21"))
+
+			Expect(iaasClient.listRequests).To(HaveLen(2))
+			Expect(iaasClient.listRequests[0].Limit).To(Equal(int64(4)))
+			Expect(iaasClient.listRequests[1].Continue).To(Equal("page-2"))
+
+			orphanIPs := []string{}
+			for _, orphan := range result.OrphanedAssignments {
+				orphanIPs = append(orphanIPs, orphan.IPAddress)
+			}
+			Expect(orphanIPs).To(ConsistOf("10.0.1.3", "172.16.0.9"))
+		})
+
+		It("drops the pending releases of the IPs released or reassigned", func() {
+			ctx := context.Background()
+			// reassigned to Pod uid-a in SpiderIPPool
+			Expect(queue.Add(ctx, &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.2", PodUID: "uid-old"})).To(Succeed())
+			// reassigned to Pod uid-new by the IaaS provider
+			Expect(queue.Add(ctx, &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.30", PodUID: "uid-old"})).To(Succeed())
+			// released by the IaaS provider
+			Expect(queue.Add(ctx, &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.31", PodUID: "uid-old"})).To(Succeed())
+			// still assigned to the deleted Pod
+			Expect(queue.Add(ctx, &iaasclient.ReleaseIPRequest{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.32", PodUID: "uid-old"})).To(Succeed())
+
+			iaasClient := &fakeIaaSClient{
+				pages: []*iaasclient.ListAssignmentsResponse{{
+					IaaSIPAssignments: []iaasclient.IaaSIPAssignment{
+						{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.2", PodUID: "uid-a"},
+						{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.3", PodUID: "uid-b"},
+						{NodeName: "node-b", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.30", PodUID: "uid-new"},
+						{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.32", PodUID: "uid-old"},
+					},
+				}},
+			}
+
+			mockCtrl := gomock.NewController(GinkgoT())
+			r, err := reconciler.NewReconciler(reconciler.ReconcilerConfig{}, fakeClient, iaasClient, queue, electionmock.NewMockSpiderLeaseElector(mockCtrl))
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := r.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.PendingReleases).To(BeEmpty())
+
+			Expect(iaasClient.releaseRequests).To(HaveLen(1))
+			Expect(iaasClient.releaseRequests[0].IPAddress).To(Equal("10.0.1.32"))
+			reqs, err := queue.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(BeEmpty())
+
+			orphanIPs := []string{}
+			for _, orphan := range result.OrphanedAssignments {
+				orphanIPs = append(orphanIPs, orphan.IPAddress)
+			}
+			Expect(orphanIPs).To(ConsistOf("10.0.1.30"))
+		})
+
+		It("fails when the provider fails to list assignments", func() {
+			iaasClient := &fakeIaaSClient{}
+			mockCtrl := gomock.NewController(GinkgoT())
+			r, err := reconciler.NewReconciler(reconciler.ReconcilerConfig{}, fakeClient, iaasClient, queue, electionmock.NewMockSpiderLeaseElector(mockCtrl))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = r.Reconcile(context.Background())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("requires the parameters", func() {
+			_, err := reconciler.NewReconciler(reconciler.ReconcilerConfig{}, fakeClient, nil, queue, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		})
+	})
+})
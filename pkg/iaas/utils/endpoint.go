@@ -0,0 +1,40 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// EndpointAddress is an IP address of an interface recorded in SpiderEndpoint,
+// with the prefix delegated to the interface if there is.
+type EndpointAddress struct {
+	IPVersion int64
+	// Address is in CIDR notation, like "10.0.0.1/24"
+	Address string
+	Prefix  string
+}
+
+// GetEndpointAddresses returns the IPv4 and IPv6 addresses of the interface
+// allocation detail in order.
+func GetEndpointAddresses(detail v2beta1.IPAllocationDetail) []EndpointAddress {
+	var addresses []EndpointAddress
+	if detail.IPv4 != nil {
+		address := EndpointAddress{IPVersion: constant.IPv4, Address: *detail.IPv4}
+		if detail.IPv4Prefix != nil {
+			address.Prefix = *detail.IPv4Prefix
+		}
+		addresses = append(addresses, address)
+	}
+	if detail.IPv6 != nil {
+		address := EndpointAddress{IPVersion: constant.IPv6, Address: *detail.IPv6}
+		if detail.IPv6Prefix != nil {
+			address.Prefix = *detail.IPv6Prefix
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
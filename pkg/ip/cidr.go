@@ -56,6 +56,29 @@ func IsCIDROverlap(version types.IPVersion, subnet1, subnet2 string) (bool, erro
 	return containsCIDR(subnet1, subnet2) || containsCIDR(subnet2, subnet1), nil
 }
 
+// FindOverlappedCIDRs returns the indexes of the first pair of overlapped
+// subnets between two groups, or -1 if there is none. Identical subnets are
+// not regarded as overlapped if ignoreIdentical is true.
+func FindOverlappedCIDRs(version types.IPVersion, subnets1, subnets2 []string, ignoreIdentical bool) (int, int, error) {
+	for i, subnet1 := range subnets1 {
+		for j, subnet2 := range subnets2 {
+			if ignoreIdentical && subnet1 == subnet2 {
+				continue
+			}
+
+			overlap, err := IsCIDROverlap(version, subnet1, subnet2)
+			if err != nil {
+				return -1, -1, err
+			}
+			if overlap {
+				return i, j, nil
+			}
+		}
+	}
+
+	return -1, -1, nil
+}
+
 func containsCIDR(subnet1 string, subnet2 string) bool {
 	// Ignore the error returned here. The format of the subnet should be
 	// verified in external IsCIDR.
@@ -148,6 +148,34 @@ var _ = Describe("CIDR", Label("cidr_test"), func() {
 		})
 	})
 
+	Describe("Test FindOverlappedCIDRs", func() {
+		It("inputs invalid CIDR address", func() {
+			i, j, err := spiderpoolip.FindOverlappedCIDRs(constant.IPv4, []string{constant.InvalidCIDR}, []string{"172.18.40.0/24"}, false)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(i).To(Equal(-1))
+			Expect(j).To(Equal(-1))
+		})
+
+		It("finds the overlapped CIDR addresses", func() {
+			i, j, err := spiderpoolip.FindOverlappedCIDRs(constant.IPv4, []string{"172.18.40.0/24", "172.18.41.0/24"}, []string{"172.18.42.0/24", "172.18.41.0/25"}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(i).To(Equal(1))
+			Expect(j).To(Equal(1))
+		})
+
+		It("ignores the identical CIDR addresses", func() {
+			i, j, err := spiderpoolip.FindOverlappedCIDRs(constant.IPv6, []string{"abcd:1234::/120"}, []string{"abcd:1234::/120"}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(i).To(Equal(-1))
+			Expect(j).To(Equal(-1))
+
+			i, j, err = spiderpoolip.FindOverlappedCIDRs(constant.IPv6, []string{"abcd:1234::/120"}, []string{"abcd:1234::/120"}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(i).To(Equal(0))
+			Expect(j).To(Equal(0))
+		})
+	})
+
 	Describe("Test IsCIDR", func() {
 		When("Verifying", func() {
 			It("inputs invalid IP version", func() {
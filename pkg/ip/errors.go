@@ -12,4 +12,5 @@ var (
 	ErrInvalidCIDRFormat    = errors.New("invalid CIDR format")
 	ErrInvalidRouteFormat   = errors.New("invalid route format")
 	ErrInvalidIP            = errors.New("invalid IP")
+	ErrInvalidPrefixLength  = errors.New("invalid prefix length")
 )
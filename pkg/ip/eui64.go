@@ -0,0 +1,84 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+)
+
+// GenEUI64IP generates the IPv6 address in the subnet with the modified
+// EUI-64 interface identifier derived from the MAC address, the mask length
+// of the subnet must be no more than 64.
+func GenEUI64IP(subnet *net.IPNet, mac net.HardwareAddr) (net.IP, error) {
+	if err := isInterfaceIDSubnet(subnet); err != nil {
+		return nil, err
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address '%s' for EUI-64", mac)
+	}
+
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, subnet.IP.To16()[:8])
+	ip[8] = mac[0] ^ 0x02
+	ip[9] = mac[1]
+	ip[10] = mac[2]
+	ip[11] = 0xff
+	ip[12] = 0xfe
+	ip[13] = mac[3]
+	ip[14] = mac[4]
+	ip[15] = mac[5]
+
+	return ip, nil
+}
+
+// ParseEUI64MAC returns the MAC address from which the modified EUI-64
+// interface identifier of the IPv6 address is derived, it returns nil if
+// the IP address is not an IPv6 address with such an interface identifier.
+func ParseEUI64MAC(ip net.IP) net.HardwareAddr {
+	if ip.To4() != nil {
+		return nil
+	}
+
+	ip = ip.To16()
+	if ip == nil || ip[11] != 0xff || ip[12] != 0xfe {
+		return nil
+	}
+
+	return net.HardwareAddr{ip[8] ^ 0x02, ip[9], ip[10], ip[13], ip[14], ip[15]}
+}
+
+// GenStableIP generates the IPv6 address in the subnet with a stable
+// interface identifier hashed from the subnet, the key and the secret, in
+// the spirit of RFC 7217. The secret keeps the addresses unpredictable to
+// those who know the key. The mask length of the subnet must be no more
+// than 64.
+func GenStableIP(subnet *net.IPNet, key string, secret []byte) (net.IP, error) {
+	if err := isInterfaceIDSubnet(subnet); err != nil {
+		return nil, err
+	}
+
+	data := append(append([]byte{}, subnet.IP.To16()[:8]...), key...)
+	sum := sha256.Sum256(append(data, secret...))
+
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, subnet.IP.To16()[:8])
+	copy(ip[8:], sum[:8])
+
+	return ip, nil
+}
+
+func isInterfaceIDSubnet(subnet *net.IPNet) error {
+	if subnet == nil || subnet.IP.To4() != nil {
+		return fmt.Errorf("%w: subnet %v is not IPv6", ErrInvalidCIDRFormat, subnet)
+	}
+
+	ones, _ := subnet.Mask.Size()
+	if ones > 64 {
+		return fmt.Errorf("%w: the mask length of subnet %s is more than 64", ErrInvalidPrefixLength, subnet)
+	}
+
+	return nil
+}
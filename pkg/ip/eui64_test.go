@@ -0,0 +1,77 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip_test
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+)
+
+var _ = Describe("IPv6 interface identifier", Label("eui64_test"), func() {
+	var subnet *net.IPNet
+
+	BeforeEach(func() {
+		_, subnet, _ = net.ParseCIDR("abcd:1234::/64")
+	})
+
+	Describe("Test GenEUI64IP", func() {
+		It("inputs the subnet longer than /64", func() {
+			_, longSubnet, _ := net.ParseCIDR("abcd:1234::/80")
+			ip, err := spiderpoolip.GenEUI64IP(longSubnet, net.HardwareAddr{0x0a, 0x00, 0xac, 0x12, 0x28, 0x0a})
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidPrefixLength))
+			Expect(ip).To(BeNil())
+		})
+
+		It("inputs IPv4 subnet", func() {
+			_, v4Subnet, _ := net.ParseCIDR("172.18.40.0/24")
+			ip, err := spiderpoolip.GenEUI64IP(v4Subnet, net.HardwareAddr{0x0a, 0x00, 0xac, 0x12, 0x28, 0x0a})
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(ip).To(BeNil())
+		})
+
+		It("generates the IPv6 address and parses the MAC address back", func() {
+			mac := net.HardwareAddr{0x0a, 0x00, 0xac, 0x12, 0x28, 0x0a}
+			ip, err := spiderpoolip.GenEUI64IP(subnet, mac)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.String()).To(Equal("abcd:1234::800:acff:fe12:280a"))
+			Expect(spiderpoolip.ParseEUI64MAC(ip)).To(Equal(mac))
+		})
+	})
+
+	Describe("Test ParseEUI64MAC", func() {
+		It("parses the IP addresses without EUI-64 interface identifier", func() {
+			Expect(spiderpoolip.ParseEUI64MAC(net.ParseIP("abcd:1234::10"))).To(BeNil())
+			Expect(spiderpoolip.ParseEUI64MAC(net.ParseIP("172.18.40.10"))).To(BeNil())
+		})
+	})
+
+	Describe("Test GenStableIP", func() {
+		It("generates the same IPv6 address with the same key", func() {
+			ip1, err := spiderpoolip.GenStableIP(subnet, "default/pod-0/eth0", []byte("secret"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.Contains(ip1)).To(BeTrue())
+
+			ip2, err := spiderpoolip.GenStableIP(subnet, "default/pod-0/eth0", []byte("secret"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip2).To(Equal(ip1))
+
+			ip3, err := spiderpoolip.GenStableIP(subnet, "default/pod-1/eth0", []byte("secret"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip3).NotTo(Equal(ip1))
+		})
+
+		It("generates the different IPv6 address with the different secret", func() {
+			ip1, err := spiderpoolip.GenStableIP(subnet, "default/pod-0/eth0", []byte("secret"))
+			Expect(err).NotTo(HaveOccurred())
+
+			ip2, err := spiderpoolip.GenStableIP(subnet, "default/pod-0/eth0", []byte("another"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip2).NotTo(Equal(ip1))
+		})
+	})
+})
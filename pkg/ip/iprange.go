@@ -77,6 +77,153 @@ func ParseIPRange(version types.IPVersion, ipRange string) ([]net.IP, error) {
 	return ips, nil
 }
 
+// SplitIPRangesByCIDR splits the IP ranges of the specified IP version by
+// the CIDRs they pertain to, the result is in the same sequence as the CIDRs
+// and an IP range across multiple CIDRs will be cut into pieces. The CIDRs
+// must not overlap with each other. It returns an error if any IP address
+// of the IP ranges doesn't pertain to the CIDRs.
+func SplitIPRangesByCIDR(version types.IPVersion, cidrs []string, ipRanges []string) ([][]string, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ipNet, err := ParseCIDR(version, cidr)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+
+	result := make([][]string, len(cidrs))
+	for _, r := range ipRanges {
+		if err := IsIPRange(version, r); err != nil {
+			return nil, err
+		}
+
+		arr := strings.Split(r, "-")
+		start := ipToInt(net.ParseIP(arr[0]))
+		end := ipToInt(net.ParseIP(arr[len(arr)-1]))
+
+		covered := big.NewInt(0)
+		for i, ipNet := range ipNets {
+			first := ipToInt(ipNet.IP)
+			last := new(big.Int).Or(first, ipToInt(net.IP(invertMask(ipNet.Mask))))
+
+			low, high := start, end
+			if low.Cmp(first) < 0 {
+				low = first
+			}
+			if high.Cmp(last) > 0 {
+				high = last
+			}
+			if low.Cmp(high) > 0 {
+				continue
+			}
+
+			covered.Add(covered, new(big.Int).Sub(high, low))
+			covered.Add(covered, big.NewInt(1))
+			if low.Cmp(high) == 0 {
+				result[i] = append(result[i], intToIP(low).String())
+			} else {
+				result[i] = append(result[i], fmt.Sprintf("%s-%s", intToIP(low), intToIP(high)))
+			}
+		}
+
+		total := new(big.Int).Sub(end, start)
+		if covered.Cmp(total.Add(total, big.NewInt(1))) != 0 {
+			return nil, fmt.Errorf("IP range '%s' doesn't pertain to the CIDRs %v", r, cidrs)
+		}
+	}
+
+	return result, nil
+}
+
+// FindAvailablePrefix returns the first prefix of the specified IP version
+// and length which is aligned to its own size, inside one of the IP ranges
+// and doesn't overlap with any of the unavailable IP ranges. The IP ranges
+// are traversed in order, it returns nil if there is no such prefix.
+func FindAvailablePrefix(version types.IPVersion, ipRanges, unavailableIPRanges []string, prefixLength int) (*net.IPNet, error) {
+	if err := IsIPVersion(version); err != nil {
+		return nil, err
+	}
+
+	bits := 32
+	if version == constant.IPv6 {
+		bits = 128
+	}
+	if prefixLength <= 0 || prefixLength > bits {
+		return nil, fmt.Errorf("%w: prefix length %d of IPv%d", ErrInvalidPrefixLength, prefixLength, version)
+	}
+
+	parse := func(ipRanges []string) ([]Range, error) {
+		ranges := make([]Range, 0, len(ipRanges))
+		for _, r := range ipRanges {
+			if err := IsIPRange(version, r); err != nil {
+				return nil, err
+			}
+
+			arr := strings.Split(r, "-")
+			ranges = append(ranges, Range{
+				Raw:   r,
+				Start: ipToInt(net.ParseIP(arr[0])),
+				End:   ipToInt(net.ParseIP(arr[len(arr)-1])),
+			})
+		}
+
+		return ranges, nil
+	}
+
+	ranges, err := parse(ipRanges)
+	if err != nil {
+		return nil, err
+	}
+	unavailableRanges, err := parse(unavailableIPRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+	for _, r := range removeExcludeIPRange(ranges, unavailableRanges) {
+		// round the start up to the boundary of the prefix
+		first := new(big.Int).Add(r.Start, new(big.Int).Sub(size, big.NewInt(1)))
+		first.Div(first, size).Mul(first, size)
+		last := new(big.Int).Add(first, new(big.Int).Sub(size, big.NewInt(1)))
+		if last.Cmp(r.End) > 0 {
+			continue
+		}
+
+		ip := make(net.IP, bits/8)
+		first.FillBytes(ip)
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(prefixLength, bits)}, nil
+	}
+
+	return nil, nil
+}
+
+// CIDRToIPRange converts the CIDR of the specified IP version into an IP
+// range covering all IP addresses of it.
+func CIDRToIPRange(version types.IPVersion, subnet string) (string, error) {
+	ipNet, err := ParseCIDR(version, subnet)
+	if err != nil {
+		return "", err
+	}
+
+	first := ipToInt(ipNet.IP)
+	last := new(big.Int).Or(first, ipToInt(net.IP(invertMask(ipNet.Mask))))
+	if first.Cmp(last) == 0 {
+		return intToIP(first).String(), nil
+	}
+
+	return fmt.Sprintf("%s-%s", intToIP(first), intToIP(last)), nil
+}
+
+func invertMask(mask net.IPMask) net.IPMask {
+	inverted := make(net.IPMask, len(mask))
+	for i := range mask {
+		inverted[i] = ^mask[i]
+	}
+
+	return inverted
+}
+
 // ConvertIPsToIPRanges converts the IP address slices of the specified
 // IP version into a group of distinct, sorted and merged IP ranges.
 func ConvertIPsToIPRanges(version types.IPVersion, ips []net.IP) ([]string, error) {
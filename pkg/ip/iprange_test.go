@@ -232,6 +232,130 @@ var _ = Describe("IP range", Label("ip_range_test"), func() {
 		})
 	})
 
+	Describe("Test SplitIPRangesByCIDR", func() {
+		It("inputs invalid CIDR address", func() {
+			ranges, err := spiderpoolip.SplitIPRangesByCIDR(constant.IPv4, []string{constant.InvalidCIDR}, []string{"172.18.40.10"})
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(ranges).To(BeEmpty())
+		})
+
+		It("inputs the IP ranges out of the CIDRs", func() {
+			ranges, err := spiderpoolip.SplitIPRangesByCIDR(constant.IPv4, []string{"172.18.40.0/24"}, []string{"172.18.40.250-172.18.41.2"})
+			Expect(err).To(HaveOccurred())
+			Expect(ranges).To(BeEmpty())
+		})
+
+		It("splits IPv4 IP ranges", func() {
+			ranges, err := spiderpoolip.SplitIPRangesByCIDR(
+				constant.IPv4,
+				[]string{"172.18.41.0/24", "172.18.40.0/24"},
+				[]string{"172.18.40.10", "172.18.40.250-172.18.41.2"},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal(
+				[][]string{
+					{"172.18.41.0-172.18.41.2"},
+					{"172.18.40.10", "172.18.40.250-172.18.40.255"},
+				},
+			))
+		})
+
+		It("splits IPv6 IP ranges", func() {
+			ranges, err := spiderpoolip.SplitIPRangesByCIDR(
+				constant.IPv6,
+				[]string{"abcd:1234::/120", "abcd:1234::100/120"},
+				[]string{"abcd:1234::fe-abcd:1234::101"},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal(
+				[][]string{
+					{"abcd:1234::fe-abcd:1234::ff"},
+					{"abcd:1234::100-abcd:1234::101"},
+				},
+			))
+		})
+	})
+
+	Describe("Test FindAvailablePrefix", func() {
+		It("inputs invalid IP version", func() {
+			prefix, err := spiderpoolip.FindAvailablePrefix(constant.InvalidIPVersion, []string{"172.18.40.0-172.18.40.255"}, nil, 30)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPVersion))
+			Expect(prefix).To(BeNil())
+		})
+
+		It("inputs invalid prefix length", func() {
+			prefix, err := spiderpoolip.FindAvailablePrefix(constant.IPv4, []string{"172.18.40.0-172.18.40.255"}, nil, 33)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidPrefixLength))
+			Expect(prefix).To(BeNil())
+		})
+
+		It("inputs invalid IP ranges", func() {
+			prefix, err := spiderpoolip.FindAvailablePrefix(constant.IPv4, constant.InvalidIPRanges, nil, 30)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPRangeFormat))
+			Expect(prefix).To(BeNil())
+		})
+
+		It("finds the aligned IPv4 prefix", func() {
+			prefix, err := spiderpoolip.FindAvailablePrefix(
+				constant.IPv4,
+				[]string{"172.18.40.1-172.18.40.7", "172.18.40.10-172.18.40.30"},
+				[]string{"172.18.40.12", "172.18.40.17"},
+				30,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefix.String()).To(Equal("172.18.40.4/30"))
+
+			prefix, err = spiderpoolip.FindAvailablePrefix(
+				constant.IPv4,
+				[]string{"172.18.40.1-172.18.40.7", "172.18.40.10-172.18.40.30"},
+				[]string{"172.18.40.4-172.18.40.7", "172.18.40.12", "172.18.40.17"},
+				30,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefix.String()).To(Equal("172.18.40.20/30"))
+		})
+
+		It("finds the aligned IPv6 prefix", func() {
+			prefix, err := spiderpoolip.FindAvailablePrefix(
+				constant.IPv6,
+				[]string{"abcd:1234::-abcd:1234::3:ffff:ffff:ffff"},
+				[]string{"abcd:1234::-abcd:1234::ffff:ffff", "abcd:1234::1:0:0:5"},
+				80,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefix.String()).To(Equal("abcd:1234:0:0:2::/80"))
+		})
+
+		It("runs out of the prefixes", func() {
+			prefix, err := spiderpoolip.FindAvailablePrefix(
+				constant.IPv4,
+				[]string{"172.18.40.1-172.18.40.6"},
+				[]string{"172.18.40.5"},
+				30,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefix).To(BeNil())
+		})
+	})
+
+	Describe("Test CIDRToIPRange", func() {
+		It("inputs invalid CIDR", func() {
+			ipRange, err := spiderpoolip.CIDRToIPRange(constant.IPv4, constant.InvalidCIDR)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(ipRange).To(BeEmpty())
+		})
+
+		It("converts CIDRs", func() {
+			ipRange, err := spiderpoolip.CIDRToIPRange(constant.IPv4, "172.18.40.4/30")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ipRange).To(Equal("172.18.40.4-172.18.40.7"))
+
+			ipRange, err = spiderpoolip.CIDRToIPRange(constant.IPv6, "abcd:1234::/128")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ipRange).To(Equal("abcd:1234::"))
+		})
+	})
+
 	Describe("Test ContainsIPRange", func() {
 		When("Verifying", func() {
 			It("inputs invalid IP version", func() {
@@ -6,12 +6,14 @@ package ipam
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"net"
 	goslices "slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/vishvananda/netlink"
@@ -535,7 +537,9 @@ func (i *ipam) genToBeAllocatedSet(ctx context.Context, addArgs *models.IpamAddA
 	logger.Info("All IPPool candidates are valid")
 
 	// sort IPPool candidates
-	sortPoolCandidates(preliminary)
+	if err := i.sortPoolCandidates(ctx, preliminary, pod); err != nil {
+		return nil, err
+	}
 
 	return preliminary, nil
 }
@@ -608,7 +612,8 @@ func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, ni
 		for _, ipPool := range c.PToIPPool {
 			if oldRes.IP.IPPool == ipPool.Name && *oldRes.IP.Nic == nic {
 				logger.Sugar().Infof("Reuse allocated IPv%d IP %s for NIC %s from IPPool %s", c.IPVersion, *oldRes.IP.Address, nic, ipPool.Name)
-				oldRes.Routes = convert.ConvertSpecRoutesToOAIRoutes(nic, ipPool.Spec.Routes)
+				oldIP, _, _ := net.ParseCIDR(*oldRes.IP.Address)
+				oldRes.Routes = convert.ConvertSpecRoutesToOAIRoutes(nic, convert.IPPoolSubnetOfIP(ipPool, oldIP).Routes)
 				oldRes.CleanGateway = cleanGateway
 				return oldRes, nil
 			}
@@ -626,10 +631,12 @@ func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, ni
 		}
 
 		logger.Sugar().Infof("Allocate IPv%d IP %s to NIC %s from IPPool %s", c.IPVersion, *ip.Address, nic, pool)
+		allocatedIP, _, _ := net.ParseCIDR(*ip.Address)
 		result = &types.AllocationResult{
 			IP:           ip,
-			Routes:       convert.ConvertSpecRoutesToOAIRoutes(nic, c.PToIPPool[pool].Spec.Routes),
+			Routes:       convert.ConvertSpecRoutesToOAIRoutes(nic, convert.IPPoolSubnetOfIP(c.PToIPPool[pool], allocatedIP).Routes),
 			CleanGateway: cleanGateway,
+			Subnet:       ippoolmanager.AutoPoolOwnerSubnet(c.PToIPPool[pool]),
 		}
 
 		break
@@ -764,6 +771,30 @@ func (i *ipam) selectByPod(ctx context.Context, version types.IPVersion, ipPool
 		}
 	}
 
+	// namespace quota
+	podName := pod.Name
+	if i.config.EnableKubevirtStaticIP && podTopController.APIVersion == kubevirtv1.SchemeGroupVersion.String() && podTopController.Kind == constant.KindKubevirtVMI {
+		podName = podTopController.Name
+	}
+	if err := ippoolmanager.CheckNamespaceIPQuota(ipPool, pod.Namespace, pod.Namespace+"/"+podName); err != nil {
+		return err
+	}
+	if subnetName, ok := ipPool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]; ok && i.config.EnableSpiderSubnet {
+		subnet, err := i.subnetManager.GetSubnetByName(ctx, subnetName, constant.UseCache)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err == nil && subnet.Spec.NamespaceQuota != nil {
+			poolList, err := i.ipPoolManager.ListIPPools(ctx, constant.UseCache, client.MatchingLabels{constant.LabelIPPoolOwnerSpiderSubnet: subnetName})
+			if err != nil {
+				return err
+			}
+			if err := ippoolmanager.CheckSubnetNamespaceIPQuota(subnet, poolList.Items, ipPool, pod.Namespace, pod.Namespace+"/"+podName); err != nil {
+				return err
+			}
+		}
+	}
+
 	// pod affinity
 	if ipPool.Spec.PodAffinity != nil {
 		if ippoolmanager.IsAutoCreatedIPPool(ipPool) {
@@ -997,26 +1028,51 @@ func (i *ipam) IsDetectGatewayReachableForKubeVirtPod(ctx context.Context, pod *
 	return true, nil
 }
 
-// sortPoolCandidates would sort IPPool candidates sequence depends on the IPPool multiple affinities.
-func sortPoolCandidates(preliminary ToBeAllocateds) {
+// sortPoolCandidates would sort IPPool candidates sequence depends on the IPPool multiple affinities,
+// and then the selection policy of the NIC.
+func (i *ipam) sortPoolCandidates(ctx context.Context, preliminary ToBeAllocateds, pod *corev1.Pod) error {
+	logger := logutils.FromContext(ctx)
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var topologyValue string
 	for _, toBeAllocate := range preliminary {
+		policy := toBeAllocate.Selection
+		if policy == "" {
+			policy = i.config.PoolSelection
+		}
+
+		if policy == constant.PoolSelectionSpread && topologyValue == "" {
+			node, err := i.nodeManager.GetNodeByName(ctx, pod.Spec.NodeName, constant.UseCache)
+			if err != nil {
+				return fmt.Errorf("failed to get Node %s to spread IPPools: %w", pod.Spec.NodeName, err)
+			}
+			topologyValue = node.Labels[i.config.PoolSpreadTopologyKey]
+			if topologyValue == "" {
+				topologyValue = node.Name
+			}
+		}
+
 		for _, poolCandidate := range (*toBeAllocate).PoolCandidates {
 			// new IPPool candidate names
 			poolNameList := []string{}
 
-			// collect all IPPool resource from PoolCandidate.PToIPPool
+			// collect all IPPool resource from PoolCandidate.PToIPPool with the original sequence
 			pools := []*spiderpoolv2beta1.SpiderIPPool{}
-			for _, tmpPool := range poolCandidate.PToIPPool {
-				pools = append(pools, tmpPool.DeepCopy())
+			for _, poolName := range poolCandidate.Pools {
+				if tmpPool, ok := poolCandidate.PToIPPool[poolName]; ok {
+					pools = append(pools, tmpPool.DeepCopy())
+				}
 			}
-			// make it order with ippoolmanager.ByPoolPriority interface rules
-			sort.Sort(ippoolmanager.ByPoolPriority(pools))
+			orderPoolsByPolicy(pools, policy, topologyValue, rnd)
 			for _, tmpPool := range pools {
 				poolNameList = append(poolNameList, tmpPool.Name)
 			}
 
 			// set the new IPPool candidate names to PoolCandidate.Pools
 			(*poolCandidate).Pools = poolNameList
+			logger.Sugar().Debugf("Sorted IPv%d IPPool candidates of NIC %s with policy '%s': %v", poolCandidate.IPVersion, toBeAllocate.NIC, policy, poolNameList)
 		}
 	}
+
+	return nil
 }
@@ -9,10 +9,12 @@ import (
 	"time"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	sigscache "sigs.k8s.io/controller-runtime/pkg/cache"
 	sigsclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 )
 
@@ -32,13 +34,32 @@ type IPAMConfig struct {
 	OperationRetries     int
 	OperationGapDuration time.Duration
 
+	// PoolSelection is the default policy to pick IPPools from the
+	// candidates of a NIC, PoolSpreadTopologyKey is the label of Node
+	// used by the "spread" policy.
+	PoolSelection         string
+	PoolSpreadTopologyKey string
+
 	MultusClusterNetwork *string
 	AgentNamespace       string
 	IaaSClient           client.Client
 	APIReader            sigsclient.Reader
+	// IaaSReleaseQueue persists the IaaS releases which fail in CNI DEL,
+	// to retry them in the IaaS reconciler of spiderpool-controller.
+	IaaSReleaseQueue iaasreconciler.PendingReleaseQueue
+	// MultusConfigInformer watches the SpiderMultusConfigs to invalidate
+	// the parent NIC MAC cache of IaaSClient.
+	MultusConfigInformer sigscache.Informer
 }
 
 func setDefaultsForIPAMConfig(config IPAMConfig) IPAMConfig {
+	if config.PoolSelection == "" {
+		config.PoolSelection = constant.PoolSelectionFillFirst
+	}
+	if config.PoolSpreadTopologyKey == "" {
+		config.PoolSpreadTopologyKey = constant.DefaultPoolSelectionSpreadTopologyKey
+	}
+
 	return config
 }
 
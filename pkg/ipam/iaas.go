@@ -5,23 +5,33 @@ package ipam
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/vishvananda/netlink"
 	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	toolscache "k8s.io/client-go/tools/cache"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	iaasutils "github.com/spidernet-io/spiderpool/pkg/iaas/utils"
 	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
 )
 
+// linkResubscribeInterval is the interval to subscribe the link events again
+// once the subscription fails.
+const linkResubscribeInterval = 5 * time.Second
+
 // callIaaSAllocate calls the IaaS provider API to allocate IPs
 func (i *ipam) callIaaSAllocate(ctx context.Context, pod *corev1.Pod, results []*spiderpooltypes.AllocationResult) (*iaasclient.AllocateIPResponse, error) {
 	if i.config.IaaSClient == nil {
@@ -68,11 +78,16 @@ func (i *ipam) callIaaSAllocate(ctx context.Context, pod *corev1.Pod, results []
 		ipStr := ip.String()
 		ipToResult[ipStr] = result
 
-		req.IaaSIPsAllocationRequest = append(req.IaaSIPsAllocationRequest, iaasclient.IaaSIPAllocationItem{
+		item := iaasclient.IaaSIPAllocationItem{
 			IPAddress:    ipStr,
 			Subnet:       subnet,
 			ParentNicMac: parentMac,
-		})
+			Prefix:       result.IP.Prefix,
+		}
+		if result.IP.Version != nil {
+			item.IPVersion = *result.IP.Version
+		}
+		req.IaaSIPsAllocationRequest = append(req.IaaSIPsAllocationRequest, item)
 	}
 
 	if len(req.IaaSIPsAllocationRequest) == 0 {
@@ -95,6 +110,15 @@ func (i *ipam) callIaaSAllocate(ctx context.Context, pod *corev1.Pod, results []
 			zap.String("podUID", string(pod.UID)),
 			zap.Error(err),
 		)
+		// Attribute the failure to the IaaS provider in the Pod events, the
+		// open circuit breaker fails the allocation without calling the provider.
+		if errors.Is(err, iaasclient.ErrCircuitOpen) {
+			event.EventRecorder.Eventf(pod, corev1.EventTypeWarning, constant.EventReasonIaaSProviderUnavailable,
+				"IaaS provider is unavailable, the IP allocation fails fast: %v", err)
+		} else {
+			event.EventRecorder.Eventf(pod, corev1.EventTypeWarning, constant.EventReasonIaaSAllocateFailed,
+				"IaaS provider failed to allocate IPs: %v", err)
+		}
 		return nil, fmt.Errorf("iaas allocate failed: %w", err)
 	}
 
@@ -103,13 +127,27 @@ func (i *ipam) callIaaSAllocate(ctx context.Context, pod *corev1.Pod, results []
 		zap.Any("response", resp.IaaSIPsAllocationResponse),
 	)
 
-	// Merge IaaS response data (MAC, VLAN) into results via the pre-built index
+	// Merge IaaS response data (MAC, VLAN, delegated prefix) into results via the pre-built index
 	for _, iaasResult := range resp.IaaSIPsAllocationResponse {
-		result, ok := ipToResult[iaasResult.IPAddress]
+		// the provider may return IPv6 addresses in another textual form
+		ipStr := iaasResult.IPAddress
+		if ip := net.ParseIP(ipStr); ip != nil {
+			ipStr = ip.String()
+		}
+		result, ok := ipToResult[ipStr]
 		if !ok {
 			logger.Error("IaaS response contains unknown IP", zap.String("ip", iaasResult.IPAddress))
 			return nil, fmt.Errorf("iaas response contains unknown IP %s", iaasResult.IPAddress)
 		}
+		if iaasResult.DelegatedPrefix != "" {
+			_, prefix, err := net.ParseCIDR(iaasResult.DelegatedPrefix)
+			if err != nil || !prefix.Contains(net.ParseIP(ipStr)) {
+				logger.Error("IaaS response contains invalid delegated prefix",
+					zap.String("ip", ipStr), zap.String("delegatedPrefix", iaasResult.DelegatedPrefix))
+				return nil, fmt.Errorf("iaas response contains invalid delegated prefix %s for IP %s", iaasResult.DelegatedPrefix, ipStr)
+			}
+			result.IP.Prefix = prefix.String()
+		}
 		if iaasResult.MacAddress != "" {
 			result.IP.Mac = iaasResult.MacAddress
 		}
@@ -121,8 +159,9 @@ func (i *ipam) callIaaSAllocate(ctx context.Context, pod *corev1.Pod, results []
 	return resp, nil
 }
 
-// callIaaSRelease calls the IaaS provider API to release IPs for all IPv4 addresses in the endpoint.
-// It releases each IP individually and aggregates any errors.
+// callIaaSRelease calls the IaaS provider API to release IPs for all IPv4 and IPv6 addresses in the endpoint.
+// It releases each IP individually and aggregates any errors, the failed releases added to
+// IaaSReleaseQueue are retried by the IaaS reconciler and are not reported as errors.
 func (i *ipam) callIaaSRelease(ctx context.Context, endpoint *v2beta1.SpiderEndpoint) error {
 	if i.config.IaaSClient == nil {
 		return nil
@@ -136,88 +175,97 @@ func (i *ipam) callIaaSRelease(ctx context.Context, endpoint *v2beta1.SpiderEndp
 	var pod *corev1.Pod // lazy-loaded on first cache miss
 	var errs []error
 	for _, detail := range endpoint.Status.Current.IPs {
-		// Only handle IPv4 for now
-		if detail.IPv4 == nil {
-			continue
-		}
-
-		ip, subnetCIDR, err := net.ParseCIDR(*detail.IPv4)
-		if err != nil {
-			logger.Error("failed to parse CIDR", zap.String("ip", *detail.IPv4), zap.Error(err))
-			errs = append(errs, fmt.Errorf("failed to parse CIDR %s: %w", *detail.IPv4, err))
-			continue
-		}
-		subnet := subnetCIDR.String()
-		ipStr := ip.String()
-
-		var parentNicMac string
-		if cached, ok := i.config.IaaSClient.GetCachedParentNicMac(subnet); ok {
-			logger.Debug("parentNicMac cache hit by subnet", zap.String("subnet", subnet))
-			parentNicMac = cached
-		} else {
-			if pod == nil {
-				pod, err = i.podManager.GetPodByName(ctx, endpoint.Namespace, endpoint.Name, true)
+		for _, address := range iaasutils.GetEndpointAddresses(detail) {
+			ip, subnetCIDR, err := net.ParseCIDR(address.Address)
+			if err != nil {
+				logger.Error("failed to parse CIDR", zap.String("ip", address.Address), zap.Error(err))
+				errs = append(errs, fmt.Errorf("failed to parse CIDR %s: %w", address.Address, err))
+				continue
+			}
+			subnet := subnetCIDR.String()
+			ipStr := ip.String()
+
+			var parentNicMac string
+			if cached, ok := i.config.IaaSClient.GetCachedParentNicMac(subnet); ok {
+				logger.Debug("parentNicMac cache hit by subnet", zap.String("subnet", subnet))
+				parentNicMac = cached
+			} else {
+				if pod == nil {
+					pod, err = i.podManager.GetPodByName(ctx, endpoint.Namespace, endpoint.Name, true)
+					if err != nil {
+						logger.Error("Failed to get pod for IaaS release eligibility check",
+							zap.String("nic", detail.NIC), zap.String("subnet", subnet), zap.Error(err))
+						errs = append(errs, fmt.Errorf("failed to get pod %s/%s: %w", endpoint.Namespace, endpoint.Name, err))
+						continue
+					}
+					if pod == nil {
+						logger.Warn("Pod is unavailable for IaaS release eligibility check, skipping non-cached IP",
+							zap.String("nic", detail.NIC), zap.String("subnet", subnet))
+						continue
+					}
+				}
+				var eligible bool
+				parentNicMac, eligible, err = i.getProviderParentNicMacFromMultus(ctx, pod, detail.NIC, subnet)
 				if err != nil {
-					logger.Error("Failed to get pod for IaaS release eligibility check",
-						zap.String("nic", detail.NIC), zap.String("subnet", subnet), zap.Error(err))
-					errs = append(errs, fmt.Errorf("failed to get pod %s/%s: %w", endpoint.Namespace, endpoint.Name, err))
+					logger.Warn("Failed to determine IaaS release eligibility, skipping IP",
+						zap.String("nic", detail.NIC),
+						zap.String("subnet", subnet),
+						zap.Error(err))
 					continue
 				}
-				if pod == nil {
-					logger.Warn("Pod is unavailable for IaaS release eligibility check, skipping non-cached IP",
-						zap.String("nic", detail.NIC), zap.String("subnet", subnet))
+				if !eligible {
+					logger.Debug("Skipping IaaS release for non-provider network",
+						zap.String("nic", detail.NIC),
+						zap.String("subnet", subnet))
 					continue
 				}
 			}
-			var eligible bool
-			parentNicMac, eligible, err = i.getProviderParentNicMacFromMultus(ctx, pod, detail.NIC, subnet)
-			if err != nil {
-				logger.Warn("Failed to determine IaaS release eligibility, skipping IP",
-					zap.String("nic", detail.NIC),
-					zap.String("subnet", subnet),
-					zap.Error(err))
-				continue
-			}
-			if !eligible {
-				logger.Debug("Skipping IaaS release for non-provider network",
-					zap.String("nic", detail.NIC),
-					zap.String("subnet", subnet))
-				continue
-			}
-		}
 
-		req := &iaasclient.ReleaseIPRequest{
-			PodName:      endpoint.Name,
-			PodNamespace: endpoint.Namespace,
-			PodUID:       endpoint.Status.Current.UID,
-			NodeName:     endpoint.Status.Current.Node,
-			IPAddress:    ipStr,
-			Subnet:       subnet,
-			ParentNicMac: parentNicMac,
-		}
-
-		logger.Debug(
-			"Calling IaaS release API",
-			zap.String("podUID", endpoint.Status.Current.UID),
-			zap.String("nodeName", endpoint.Status.Current.Node),
-			zap.String("ipAddress", ipStr),
-			zap.String("subnet", subnet),
-			zap.String("parentNicMac", parentNicMac),
-		)
+			req := &iaasclient.ReleaseIPRequest{
+				PodName:      endpoint.Name,
+				PodNamespace: endpoint.Namespace,
+				PodUID:       endpoint.Status.Current.UID,
+				NodeName:     endpoint.Status.Current.Node,
+				IPAddress:    ipStr,
+				Subnet:       subnet,
+				ParentNicMac: parentNicMac,
+				IPVersion:    address.IPVersion,
+				Prefix:       address.Prefix,
+			}
 
-		if err := i.config.IaaSClient.ReleaseIP(ctx, req); err != nil {
-			logger.Error(
-				"IaaS release API failed",
+			logger.Debug(
+				"Calling IaaS release API",
 				zap.String("podUID", endpoint.Status.Current.UID),
+				zap.String("nodeName", endpoint.Status.Current.Node),
 				zap.String("ipAddress", ipStr),
 				zap.String("subnet", subnet),
-				zap.Error(err),
+				zap.String("parentNicMac", parentNicMac),
 			)
-			errs = append(errs, fmt.Errorf("failed to release IP %s: %w", ipStr, err))
-			continue
-		}
 
-		logger.Info("IaaS release API succeeded", zap.String("ipAddress", ipStr))
+			if err := iaasreconciler.ReleaseIP(ctx, i.config.IaaSClient, i.config.IaaSReleaseQueue, req); err != nil {
+				if errors.Is(err, iaasreconciler.ErrReleasePending) {
+					logger.Warn(
+						"IaaS release API failed, retry it later",
+						zap.String("podUID", endpoint.Status.Current.UID),
+						zap.String("ipAddress", ipStr),
+						zap.String("subnet", subnet),
+						zap.Error(err),
+					)
+					continue
+				}
+				logger.Error(
+					"IaaS release API failed",
+					zap.String("podUID", endpoint.Status.Current.UID),
+					zap.String("ipAddress", ipStr),
+					zap.String("subnet", subnet),
+					zap.Error(err),
+				)
+				errs = append(errs, fmt.Errorf("failed to release IP %s: %w", ipStr, err))
+				continue
+			}
+
+			logger.Info("IaaS release API succeeded", zap.String("ipAddress", ipStr))
+		}
 	}
 
 	if len(errs) > 0 {
@@ -260,21 +308,21 @@ func (i *ipam) getProviderParentNicMacFromMultus(ctx context.Context, pod *corev
 		}
 	}
 
-	// Step 3: check IaaS client cache using SpiderMultusConfig namespace/name as key
+	// Step 3: extract master interface name from CNI config
+	masterIface, err := getMasterIfaceFromMultusConfig(smc)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get master interface from SpiderMultusConfig %s/%s: %w", netInfo.Namespace, netInfo.Name, err)
+	}
+
+	// Step 4: check IaaS client cache using SpiderMultusConfig namespace/name as key
 	cacheKey := netInfo.Namespace + "/" + netInfo.Name
 	if cached, ok := i.config.IaaSClient.GetCachedParentNicMac(cacheKey); ok {
 		if subnet != "" {
-			i.config.IaaSClient.CacheParentNicMac(subnet, cached)
+			i.config.IaaSClient.CacheParentNicMac(subnet, iaasclient.ParentNicMacEntry{MAC: cached, Owner: cacheKey, Iface: masterIface})
 		}
 		return cached, true, nil
 	}
 
-	// Step 4: extract master interface name from CNI config
-	masterIface, err := getMasterIfaceFromMultusConfig(smc)
-	if err != nil {
-		return "", false, fmt.Errorf("failed to get master interface from SpiderMultusConfig %s/%s: %w", netInfo.Namespace, netInfo.Name, err)
-	}
-
 	// Step 5: get MAC address of the master interface via netlink (host netns)
 	link, err := netlink.LinkByName(masterIface)
 	if err != nil {
@@ -283,11 +331,13 @@ func (i *ipam) getProviderParentNicMacFromMultus(ctx context.Context, pod *corev
 
 	mac := link.Attrs().HardwareAddr.String()
 
-	// Step 6: store in IaaS client cache for future lookups
+	// Step 6: store in IaaS client cache for future lookups, the entries are
+	// invalidated once the SpiderMultusConfig or the master interface changes.
+	entry := iaasclient.ParentNicMacEntry{MAC: mac, Owner: cacheKey, Iface: masterIface}
 	if subnet != "" {
-		i.config.IaaSClient.CacheParentNicMac(subnet, mac)
+		i.config.IaaSClient.CacheParentNicMac(subnet, entry)
 	}
-	i.config.IaaSClient.CacheParentNicMac(cacheKey, mac)
+	i.config.IaaSClient.CacheParentNicMac(cacheKey, entry)
 
 	return mac, true, nil
 }
@@ -347,7 +397,7 @@ func (i *ipam) prewarmParentNicMacCache(ctx context.Context) {
 		}
 
 		mac := link.Attrs().HardwareAddr.String()
-		i.config.IaaSClient.CacheParentNicMac(cacheKey, mac)
+		i.config.IaaSClient.CacheParentNicMac(cacheKey, iaasclient.ParentNicMacEntry{MAC: mac, Owner: cacheKey, Iface: masterIface})
 		count++
 		logger.Debug("Prewarmed parentNicMac cache",
 			zap.String("smc", cacheKey),
@@ -358,6 +408,125 @@ func (i *ipam) prewarmParentNicMacCache(ctx context.Context) {
 	logger.Info("Finished prewarming parentNicMac cache", zap.Int("count", count))
 }
 
+// parentNicMacCacheEventHandler invalidates the cached parent NIC MACs once
+// the master interface of a provider-managed VLAN SpiderMultusConfig changes,
+// or the SpiderMultusConfig is deleted.
+func (i *ipam) parentNicMacCacheEventHandler(ctx context.Context) toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSMC, ok := oldObj.(*v2beta1.SpiderMultusConfig)
+			if !ok {
+				return
+			}
+			newSMC, ok := newObj.(*v2beta1.SpiderMultusConfig)
+			if !ok {
+				return
+			}
+			i.onSpiderMultusConfigUpdate(ctx, oldSMC, newSMC)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			smc, ok := obj.(*v2beta1.SpiderMultusConfig)
+			if !ok {
+				return
+			}
+			i.invalidateParentNicMac(ctx, smc.Namespace+"/"+smc.Name, "SpiderMultusConfig deleted")
+		},
+	}
+}
+
+func (i *ipam) onSpiderMultusConfigUpdate(ctx context.Context, oldSMC, newSMC *v2beta1.SpiderMultusConfig) {
+	if !isProviderVLANSpiderMultusConfig(oldSMC) {
+		return
+	}
+
+	cacheKey := newSMC.Namespace + "/" + newSMC.Name
+	if !isProviderVLANSpiderMultusConfig(newSMC) {
+		i.invalidateParentNicMac(ctx, cacheKey, "SpiderMultusConfig is not provider-managed VLAN")
+		return
+	}
+
+	oldMaster, _ := getMasterIfaceFromMultusConfig(oldSMC)
+	newMaster, _ := getMasterIfaceFromMultusConfig(newSMC)
+	if oldMaster != newMaster {
+		i.invalidateParentNicMac(ctx, cacheKey, "master interface changed")
+	}
+}
+
+func (i *ipam) invalidateParentNicMac(ctx context.Context, cacheKey, reason string) {
+	count := i.config.IaaSClient.InvalidateParentNicMac(cacheKey)
+	if count > 0 {
+		logutils.FromContext(ctx).Info("Invalidated parentNicMac cache",
+			zap.String("smc", cacheKey),
+			zap.String("reason", reason),
+			zap.Int("count", count))
+	}
+}
+
+// watchParentNicLinks subscribes the link events of the node, so that the
+// cached parent NIC MACs are invalidated once the NIC is removed or replaced.
+func (i *ipam) watchParentNicLinks(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	for {
+		updates := make(chan netlink.LinkUpdate)
+		done := make(chan struct{})
+		err := netlink.LinkSubscribeWithOptions(updates, done, netlink.LinkSubscribeOptions{
+			ErrorCallback: func(err error) {
+				logger.Warn("Link subscription of parentNicMac cache failed", zap.Error(err))
+			},
+		})
+		if err != nil {
+			logger.Error("Failed to subscribe link events for parentNicMac cache", zap.Error(err))
+		} else {
+			i.consumeLinkUpdates(ctx, updates)
+		}
+		close(done)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(linkResubscribeInterval):
+		}
+	}
+}
+
+func (i *ipam) consumeLinkUpdates(ctx context.Context, updates <-chan netlink.LinkUpdate) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			i.handleLinkUpdate(ctx, update)
+		}
+	}
+}
+
+func (i *ipam) handleLinkUpdate(ctx context.Context, update netlink.LinkUpdate) {
+	if update.Link == nil {
+		return
+	}
+
+	attrs := update.Link.Attrs()
+	mac := attrs.HardwareAddr.String()
+	if update.Header.Type == unix.RTM_DELLINK {
+		mac = ""
+	}
+
+	count := i.config.IaaSClient.InvalidateParentNicMacByIface(attrs.Name, mac)
+	if count > 0 {
+		logutils.FromContext(ctx).Info("Invalidated parentNicMac cache of the changed link",
+			zap.String("link", attrs.Name),
+			zap.String("mac", mac),
+			zap.Int("count", count))
+	}
+}
+
 // getMasterIfaceFromMultusConfig extracts the first master interface name from a SpiderMultusConfig
 func getMasterIfaceFromMultusConfig(smc *v2beta1.SpiderMultusConfig) (string, error) {
 	if smc.Spec.CniType == nil {
@@ -5,42 +5,67 @@ package ipam
 
 import (
 	"context"
+	"fmt"
+	"net"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	electionmock "github.com/spidernet-io/spiderpool/pkg/election/mock"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	iaasclient "github.com/spidernet-io/spiderpool/pkg/iaas/client"
+	iaasreconciler "github.com/spidernet-io/spiderpool/pkg/iaas/reconciler"
 	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
 )
 
 type fakeIaaSClient struct {
 	allocateRequests []*iaasclient.AllocateIPRequest
+	releaseRequests  []*iaasclient.ReleaseIPRequest
+	delegatedPrefix  map[string]string
 	cache            map[string]string
+	invalidated      []string
+	allocateErr      error
+	releaseErr       error
+	assignments      []iaasclient.IaaSIPAssignment
 }
 
 func (f *fakeIaaSClient) AllocateIPs(_ context.Context, req *iaasclient.AllocateIPRequest) (*iaasclient.AllocateIPResponse, error) {
 	f.allocateRequests = append(f.allocateRequests, req)
+	if f.allocateErr != nil {
+		return nil, f.allocateErr
+	}
 	response := make([]iaasclient.IaaSIPAllocationResult, 0, len(req.IaaSIPsAllocationRequest))
 	for _, item := range req.IaaSIPsAllocationRequest {
 		response = append(response, iaasclient.IaaSIPAllocationResult{
-			IPAddress:  item.IPAddress,
-			MacAddress: "02:00:00:00:00:01",
-			VlanID:     100,
+			IPAddress:       item.IPAddress,
+			MacAddress:      "02:00:00:00:00:01",
+			VlanID:          100,
+			DelegatedPrefix: f.delegatedPrefix[item.IPAddress],
 		})
 	}
 	return &iaasclient.AllocateIPResponse{IaaSIPsAllocationResponse: response}, nil
 }
 
-func (f *fakeIaaSClient) ReleaseIP(context.Context, *iaasclient.ReleaseIPRequest) error {
-	return nil
+func (f *fakeIaaSClient) ReleaseIP(_ context.Context, req *iaasclient.ReleaseIPRequest) error {
+	f.releaseRequests = append(f.releaseRequests, req)
+	return f.releaseErr
+}
+
+func (f *fakeIaaSClient) ListAssignments(context.Context, *iaasclient.ListAssignmentsRequest) (*iaasclient.ListAssignmentsResponse, error) {
+	return &iaasclient.ListAssignmentsResponse{IaaSIPAssignments: f.assignments}, nil
 }
 
 func (f *fakeIaaSClient) GetCachedParentNicMac(key string) (string, bool) {
@@ -48,8 +73,18 @@ func (f *fakeIaaSClient) GetCachedParentNicMac(key string) (string, bool) {
 	return value, ok
 }
 
-func (f *fakeIaaSClient) CacheParentNicMac(key, mac string) {
-	f.cache[key] = mac
+func (f *fakeIaaSClient) CacheParentNicMac(key string, entry iaasclient.ParentNicMacEntry) {
+	f.cache[key] = entry.MAC
+}
+
+func (f *fakeIaaSClient) InvalidateParentNicMac(owner string) int {
+	f.invalidated = append(f.invalidated, owner)
+	return 1
+}
+
+func (f *fakeIaaSClient) InvalidateParentNicMacByIface(iface, mac string) int {
+	f.invalidated = append(f.invalidated, iface+"="+mac)
+	return 1
 }
 
 var _ = Describe("IaaS provider network filtering", Label("ipam_iaas_test"), func() {
@@ -134,6 +169,7 @@ var _ = Describe("IaaS provider network filtering", Label("ipam_iaas_test"), fun
 				IPAddress:    "10.0.1.2",
 				Subnet:       "10.0.1.0/24",
 				ParentNicMac: "02:00:00:00:00:02",
+				IPVersion:    4,
 			},
 		))
 		Expect(results[0].IP.Mac).To(BeEmpty())
@@ -179,4 +215,319 @@ var _ = Describe("IaaS provider network filtering", Label("ipam_iaas_test"), fun
 		Expect(response).To(BeNil())
 		Expect(client.allocateRequests).To(BeEmpty())
 	})
+
+	It("submits IPv6 results and merges the delegated prefix", func() {
+		scheme := runtime.NewScheme()
+		Expect(v2beta1.AddToScheme(scheme)).To(Succeed())
+
+		vlanType := constant.VlanCNI
+		apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v2beta1.SpiderMultusConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider-net", Namespace: "tenant-a"},
+				Spec: v2beta1.MultusCNIConfigSpec{
+					CniType:    &vlanType,
+					VlanConfig: &v2beta1.SpiderVlanCniConfig{VlanMode: ptr.To(constant.VlanModeAuto), Master: []string{"eth2"}},
+				},
+			},
+		).Build()
+		client := &fakeIaaSClient{
+			cache:           map[string]string{"tenant-a/provider-net": "02:00:00:00:00:02"},
+			delegatedPrefix: map[string]string{"fd00:10::1:0:0:1": "fd00:10:0:0:1::/80"},
+		}
+		instance := &ipam{config: IPAMConfig{
+			AgentNamespace: "kube-system",
+			APIReader:      apiReader,
+			IaaSClient:     client,
+		}}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-a",
+				Namespace: "tenant-a",
+				Annotations: map[string]string{
+					constant.MultusDefaultNetAnnot: "tenant-a/provider-net",
+				},
+			},
+		}
+		results := []*spiderpooltypes.AllocationResult{
+			{IP: &models.IPConfig{Address: ptr.To("10.0.1.2/24"), Nic: ptr.To("eth0"), Version: ptr.To[int64](4)}},
+			{IP: &models.IPConfig{Address: ptr.To("fd00:10::1:0:0:1/64"), Nic: ptr.To("eth0"), Version: ptr.To[int64](6)}},
+		}
+
+		_, err := instance.callIaaSAllocate(context.Background(), pod, results)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.allocateRequests).To(HaveLen(1))
+		Expect(client.allocateRequests[0].IaaSIPsAllocationRequest).To(ConsistOf(
+			iaasclient.IaaSIPAllocationItem{
+				IPAddress:    "10.0.1.2",
+				Subnet:       "10.0.1.0/24",
+				ParentNicMac: "02:00:00:00:00:02",
+				IPVersion:    4,
+			},
+			iaasclient.IaaSIPAllocationItem{
+				IPAddress:    "fd00:10::1:0:0:1",
+				Subnet:       "fd00:10::/64",
+				ParentNicMac: "02:00:00:00:00:02",
+				IPVersion:    6,
+			},
+		))
+		Expect(results[0].IP.Prefix).To(BeEmpty())
+		Expect(results[1].IP.Prefix).To(Equal("fd00:10:0:0:1::/80"))
+		Expect(results[1].IP.Mac).To(Equal("02:00:00:00:00:01"))
+	})
+
+	It("rejects the delegated prefix which does not contain the IP address", func() {
+		scheme := runtime.NewScheme()
+		Expect(v2beta1.AddToScheme(scheme)).To(Succeed())
+
+		vlanType := constant.VlanCNI
+		apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v2beta1.SpiderMultusConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider-net", Namespace: "tenant-a"},
+				Spec: v2beta1.MultusCNIConfigSpec{
+					CniType:    &vlanType,
+					VlanConfig: &v2beta1.SpiderVlanCniConfig{VlanMode: ptr.To(constant.VlanModeAuto), Master: []string{"eth2"}},
+				},
+			},
+		).Build()
+		client := &fakeIaaSClient{
+			cache:           map[string]string{"tenant-a/provider-net": "02:00:00:00:00:02"},
+			delegatedPrefix: map[string]string{"fd00:10::1:0:0:1": "fd00:10::2:0:0:0/80"},
+		}
+		instance := &ipam{config: IPAMConfig{
+			AgentNamespace: "kube-system",
+			APIReader:      apiReader,
+			IaaSClient:     client,
+		}}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-a",
+				Namespace: "tenant-a",
+				Annotations: map[string]string{
+					constant.MultusDefaultNetAnnot: "tenant-a/provider-net",
+				},
+			},
+		}
+		results := []*spiderpooltypes.AllocationResult{
+			{IP: &models.IPConfig{Address: ptr.To("fd00:10::1:0:0:1/64"), Nic: ptr.To("eth0"), Version: ptr.To[int64](6)}},
+		}
+
+		_, err := instance.callIaaSAllocate(context.Background(), pod, results)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("releases both IPv4 and IPv6 addresses of the endpoint", func() {
+		client := &fakeIaaSClient{
+			cache: map[string]string{
+				"10.0.1.0/24":  "02:00:00:00:00:02",
+				"fd00:10::/64": "02:00:00:00:00:02",
+			},
+		}
+		instance := &ipam{config: IPAMConfig{IaaSClient: client}}
+		endpoint := &v2beta1.SpiderEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "tenant-a"},
+			Status: v2beta1.WorkloadEndpointStatus{
+				Current: v2beta1.PodIPAllocation{
+					UID:  "pod-uid",
+					Node: "node-a",
+					IPs: []v2beta1.IPAllocationDetail{{
+						NIC:        "eth0",
+						IPv4:       ptr.To("10.0.1.2/24"),
+						IPv6:       ptr.To("fd00:10::1:0:0:1/64"),
+						IPv6Prefix: ptr.To("fd00:10::1:0:0:0/80"),
+					}},
+				},
+			},
+		}
+
+		Expect(instance.callIaaSRelease(context.Background(), endpoint)).To(Succeed())
+		Expect(client.releaseRequests).To(HaveLen(2))
+		Expect(client.releaseRequests[0].IPAddress).To(Equal("10.0.1.2"))
+		Expect(client.releaseRequests[0].IPVersion).To(Equal(int64(4)))
+		Expect(client.releaseRequests[1].IPAddress).To(Equal("fd00:10::1:0:0:1"))
+		Expect(client.releaseRequests[1].IPVersion).To(Equal(int64(6)))
+		Expect(client.releaseRequests[1].Prefix).To(Equal("fd00:10::1:0:0:0/80"))
+	})
+
+	It("retries the IaaS release failed in CNI DEL by the reconciler", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(v2beta1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		queue, err := iaasreconciler.NewPendingReleaseQueue(k8sClient, k8sClient, "kube-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		client := &fakeIaaSClient{
+			cache:      map[string]string{"10.0.1.0/24": "02:00:00:00:00:02"},
+			releaseErr: fmt.Errorf("provider is unavailable"),
+			assignments: []iaasclient.IaaSIPAssignment{
+				{NodeName: "node-a", Subnet: "10.0.1.0/24", IPAddress: "10.0.1.2", PodUID: "pod-uid"},
+			},
+		}
+		instance := &ipam{config: IPAMConfig{IaaSClient: client, IaaSReleaseQueue: queue}}
+		endpoint := &v2beta1.SpiderEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "tenant-a"},
+			Status: v2beta1.WorkloadEndpointStatus{
+				Current: v2beta1.PodIPAllocation{
+					UID:  "pod-uid",
+					Node: "node-a",
+					IPs: []v2beta1.IPAllocationDetail{{
+						NIC:  "eth0",
+						IPv4: ptr.To("10.0.1.2/24"),
+					}},
+				},
+			},
+		}
+
+		// the failed release is queued, and CNI DEL goes on releasing the IP from the IPPool
+		Expect(instance.callIaaSRelease(ctx, endpoint)).To(Succeed())
+		reqs, err := queue.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reqs).To(HaveLen(1))
+		Expect(reqs[0].IPAddress).To(Equal("10.0.1.2"))
+		Expect(reqs[0].PodUID).To(Equal("pod-uid"))
+		Expect(reqs[0].ParentNicMac).To(Equal("02:00:00:00:00:02"))
+
+		// the IaaS provider recovers
+		client.releaseErr = nil
+		r, err := iaasreconciler.NewReconciler(iaasreconciler.ReconcilerConfig{}, k8sClient, client, queue,
+			electionmock.NewMockSpiderLeaseElector(gomock.NewController(GinkgoT())))
+		Expect(err).NotTo(HaveOccurred())
+		result, err := r.Reconcile(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.PendingReleases).To(BeEmpty())
+		Expect(result.OrphanedAssignments).To(BeEmpty())
+
+		Expect(client.releaseRequests).To(HaveLen(2))
+		Expect(client.releaseRequests[1].IPAddress).To(Equal("10.0.1.2"))
+		reqs, err = queue.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reqs).To(BeEmpty())
+	})
+
+	It("fails CNI DEL once the failed IaaS release could not be queued", func() {
+		client := &fakeIaaSClient{
+			cache:      map[string]string{"10.0.1.0/24": "02:00:00:00:00:02"},
+			releaseErr: fmt.Errorf("provider is unavailable"),
+		}
+		instance := &ipam{config: IPAMConfig{IaaSClient: client}}
+		endpoint := &v2beta1.SpiderEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "tenant-a"},
+			Status: v2beta1.WorkloadEndpointStatus{
+				Current: v2beta1.PodIPAllocation{
+					UID: "pod-uid",
+					IPs: []v2beta1.IPAllocationDetail{{NIC: "eth0", IPv4: ptr.To("10.0.1.2/24")}},
+				},
+			},
+		}
+
+		Expect(instance.callIaaSRelease(context.Background(), endpoint)).NotTo(Succeed())
+	})
+
+	It("invalidates the parentNicMac cache once the SpiderMultusConfig changes", func() {
+		client := &fakeIaaSClient{cache: map[string]string{}}
+		instance := &ipam{config: IPAMConfig{IaaSClient: client}}
+		handler := instance.parentNicMacCacheEventHandler(context.Background())
+
+		vlanType := constant.VlanCNI
+		macvlanType := constant.MacvlanCNI
+		oldSMC := &v2beta1.SpiderMultusConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "provider-net", Namespace: "tenant-a"},
+			Spec: v2beta1.MultusCNIConfigSpec{
+				CniType:    &vlanType,
+				VlanConfig: &v2beta1.SpiderVlanCniConfig{VlanMode: ptr.To(constant.VlanModeAuto), Master: []string{"eth2"}},
+			},
+		}
+
+		// Only the annotations change.
+		newSMC := oldSMC.DeepCopy()
+		newSMC.Annotations = map[string]string{"foo": "bar"}
+		handler.OnUpdate(oldSMC, newSMC)
+		Expect(client.invalidated).To(BeEmpty())
+
+		newSMC.Spec.VlanConfig.Master = []string{"eth3"}
+		handler.OnUpdate(oldSMC, newSMC)
+		Expect(client.invalidated).To(Equal([]string{"tenant-a/provider-net"}))
+
+		newSMC = oldSMC.DeepCopy()
+		newSMC.Spec.CniType = &macvlanType
+		handler.OnUpdate(oldSMC, newSMC)
+		Expect(client.invalidated).To(HaveLen(2))
+
+		// The SpiderMultusConfig was not provider-managed VLAN.
+		handler.OnUpdate(newSMC, oldSMC)
+		Expect(client.invalidated).To(HaveLen(2))
+
+		handler.OnDelete(toolscache.DeletedFinalStateUnknown{Obj: oldSMC})
+		Expect(client.invalidated).To(Equal([]string{"tenant-a/provider-net", "tenant-a/provider-net", "tenant-a/provider-net"}))
+	})
+
+	It("invalidates the parentNicMac cache once the link changes", func() {
+		client := &fakeIaaSClient{cache: map[string]string{}}
+		instance := &ipam{config: IPAMConfig{IaaSClient: client}}
+
+		mac, err := net.ParseMAC("02:00:00:00:00:03")
+		Expect(err).NotTo(HaveOccurred())
+		link := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth2", HardwareAddr: mac}}
+
+		update := netlink.LinkUpdate{Link: link}
+		update.Header.Type = unix.RTM_NEWLINK
+		instance.handleLinkUpdate(context.Background(), update)
+
+		update.Header.Type = unix.RTM_DELLINK
+		instance.handleLinkUpdate(context.Background(), update)
+
+		instance.handleLinkUpdate(context.Background(), netlink.LinkUpdate{})
+		Expect(client.invalidated).To(Equal([]string{"eth2=02:00:00:00:00:03", "eth2="}))
+	})
+
+	It("attributes the allocation failure to the IaaS provider in the Pod events", func() {
+		scheme := runtime.NewScheme()
+		Expect(v2beta1.AddToScheme(scheme)).To(Succeed())
+
+		vlanType := constant.VlanCNI
+		apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v2beta1.SpiderMultusConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider-net", Namespace: "tenant-a"},
+				Spec: v2beta1.MultusCNIConfigSpec{
+					CniType:    &vlanType,
+					VlanConfig: &v2beta1.SpiderVlanCniConfig{VlanMode: ptr.To(constant.VlanModeAuto), Master: []string{"eth2"}},
+				},
+			},
+		).Build()
+		client := &fakeIaaSClient{
+			cache:       map[string]string{"tenant-a/provider-net": "02:00:00:00:00:02"},
+			allocateErr: fmt.Errorf("%w, retry after 10s", iaasclient.ErrCircuitOpen),
+		}
+		instance := &ipam{config: IPAMConfig{
+			AgentNamespace: "kube-system",
+			APIReader:      apiReader,
+			IaaSClient:     client,
+		}}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod-a",
+				Namespace:   "tenant-a",
+				Annotations: map[string]string{constant.MultusDefaultNetAnnot: "tenant-a/provider-net"},
+			},
+		}
+		results := []*spiderpooltypes.AllocationResult{
+			{IP: &models.IPConfig{Address: ptr.To("10.0.1.2/24"), Nic: ptr.To("eth0"), Version: ptr.To[int64](4)}},
+		}
+
+		recorder := record.NewFakeRecorder(10)
+		event.EventRecorder = recorder
+		DeferCleanup(func() {
+			event.EventRecorder = record.NewFakeRecorder(event.FakeRecorderBufferSize)
+		})
+
+		_, err := instance.callIaaSAllocate(context.Background(), pod, results)
+		Expect(err).To(MatchError(iaasclient.ErrCircuitOpen))
+		Expect(recorder.Events).To(Receive(ContainSubstring(constant.EventReasonIaaSProviderUnavailable)))
+
+		client.allocateErr = fmt.Errorf("iaas allocate API call failed: connection refused")
+		_, err = instance.callIaaSAllocate(context.Background(), pod, results)
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring(constant.EventReasonIaaSAllocateFailed)))
+	})
 })
@@ -79,6 +79,9 @@ func NewIPAM(
 	if kubevirtManager == nil {
 		return nil, fmt.Errorf("kubevirt manager %w", constant.ErrMissingRequiredParam)
 	}
+	if err := ValidatePoolSelection(config.PoolSelection); err != nil {
+		return nil, err
+	}
 
 	i := &ipam{
 		config:          setDefaultsForIPAMConfig(config),
@@ -98,9 +101,16 @@ func NewIPAM(
 }
 
 func (i *ipam) Start(ctx context.Context) error {
-	// Prewarm parentNicMac cache by listing all vlan-type SpiderMultusConfigs
+	// Prewarm parentNicMac cache by listing all vlan-type SpiderMultusConfigs,
+	// and invalidate it once the SpiderMultusConfigs or the node links change
 	if i.config.IaaSClient != nil {
 		i.prewarmParentNicMacCache(ctx)
+		if i.config.MultusConfigInformer != nil {
+			if _, err := i.config.MultusConfigInformer.AddEventHandler(i.parentNicMacCacheEventHandler(ctx)); err != nil {
+				return fmt.Errorf("failed to watch SpiderMultusConfigs for parentNicMac cache: %w", err)
+			}
+		}
+		go i.watchParentNicLinks(ctx)
 	}
 
 	errCh := make(chan error)
@@ -0,0 +1,124 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var poolSelectionPolicies = []string{
+	constant.PoolSelectionFillFirst,
+	constant.PoolSelectionLeastUtilized,
+	constant.PoolSelectionWeighted,
+	constant.PoolSelectionSpread,
+}
+
+// ValidatePoolSelection checks the policy to pick IPPools from the
+// candidates of a NIC, the empty one means the default.
+func ValidatePoolSelection(policy string) error {
+	if policy == "" || slices.Contains(poolSelectionPolicies, policy) {
+		return nil
+	}
+
+	return fmt.Errorf("%w, unknown IPPool selection policy '%s', it should be one of %v", constant.ErrWrongInput, policy, poolSelectionPolicies)
+}
+
+// orderPoolsByPolicy sorts the IPPools by the affinity priority, and then
+// reorders the IPPools of the same priority by the policy. The topology value
+// identifies the Node of the Pod for the "spread" policy.
+func orderPoolsByPolicy(pools []*spiderpoolv2beta1.SpiderIPPool, policy, topologyValue string, rnd *rand.Rand) {
+	// make it order with ippoolmanager.ByPoolPriority interface rules, and the IPPools with
+	// the same priority keep the original sequence, such as the SpiderSubnet fallback IPPools.
+	byPriority := ippoolmanager.ByPoolPriority(pools)
+	sort.Stable(byPriority)
+
+	if policy == "" || policy == constant.PoolSelectionFillFirst {
+		return
+	}
+
+	for start := 0; start < len(pools); {
+		end := start + 1
+		for end < len(pools) && !byPriority.Less(start, end) {
+			end++
+		}
+
+		tier := pools[start:end]
+		switch policy {
+		case constant.PoolSelectionLeastUtilized:
+			sort.SliceStable(tier, func(i, j int) bool {
+				return poolUtilization(tier[i]) < poolUtilization(tier[j])
+			})
+		case constant.PoolSelectionWeighted:
+			shuffleByWeight(tier, rnd)
+		case constant.PoolSelectionSpread:
+			rotateByTopology(tier, topologyValue)
+		}
+
+		start = end
+	}
+}
+
+// poolUtilization returns the ratio of the allocated IP addresses of the
+// IPPool, the IPPool without the IP counts is regarded as full.
+func poolUtilization(pool *spiderpoolv2beta1.SpiderIPPool) float64 {
+	if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount == 0 {
+		return 1
+	}
+
+	var allocated int64
+	if pool.Status.AllocatedIPCount != nil {
+		allocated = *pool.Status.AllocatedIPCount
+	}
+
+	return float64(allocated) / float64(*pool.Status.TotalIPCount)
+}
+
+// shuffleByWeight shuffles the IPPools so that the chance of an IPPool to be
+// the first one is in proportion to its weight, which is the weighted random
+// sampling without replacement. The IPPools with weight 0 keep their order
+// after the others.
+func shuffleByWeight(pools []*spiderpoolv2beta1.SpiderIPPool, rnd *rand.Rand) {
+	keys := make(map[*spiderpoolv2beta1.SpiderIPPool]float64, len(pools))
+	for _, pool := range pools {
+		weight := int32(1)
+		if pool.Spec.Weight != nil {
+			weight = *pool.Spec.Weight
+		}
+		if weight <= 0 {
+			keys[pool] = -1
+			continue
+		}
+		keys[pool] = math.Pow(rnd.Float64(), 1/float64(weight))
+	}
+
+	sort.SliceStable(pools, func(i, j int) bool {
+		return keys[pools[i]] > keys[pools[j]]
+	})
+}
+
+// rotateByTopology rotates the IPPools by the hash of the topology value, so
+// that the Pods of the Nodes with different topology values start with
+// different IPPools, and the ones of the same topology value share the same
+// order.
+func rotateByTopology(pools []*spiderpoolv2beta1.SpiderIPPool, topologyValue string) {
+	if len(pools) < 2 {
+		return
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(topologyValue))
+	n := int(h.Sum32() % uint32(len(pools)))
+
+	rotated := append(append([]*spiderpoolv2beta1.SpiderIPPool{}, pools[n:]...), pools[:n]...)
+	copy(pools, rotated)
+}
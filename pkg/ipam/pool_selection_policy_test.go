@@ -0,0 +1,117 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"math/rand"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var _ = Describe("IPPool selection policy", Label("pool_selection"), func() {
+	newPool := func(name string, allocated, total int64) *spiderpoolv2beta1.SpiderIPPool {
+		return &spiderpoolv2beta1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: spiderpoolv2beta1.IPPoolStatus{
+				AllocatedIPCount: ptr.To(allocated),
+				TotalIPCount:     ptr.To(total),
+			},
+		}
+	}
+
+	names := func(pools []*spiderpoolv2beta1.SpiderIPPool) []string {
+		var res []string
+		for _, p := range pools {
+			res = append(res, p.Name)
+		}
+		return res
+	}
+
+	var pools []*spiderpoolv2beta1.SpiderIPPool
+	var rnd *rand.Rand
+
+	BeforeEach(func() {
+		pools = []*spiderpoolv2beta1.SpiderIPPool{
+			newPool("pool1", 8, 10),
+			newPool("pool2", 1, 10),
+			newPool("pool3", 5, 10),
+		}
+		rnd = rand.New(rand.NewSource(1))
+	})
+
+	It("validates the policies", func() {
+		Expect(ValidatePoolSelection("")).To(Succeed())
+		Expect(ValidatePoolSelection(constant.PoolSelectionSpread)).To(Succeed())
+		Expect(ValidatePoolSelection("roundRobin")).To(MatchError(constant.ErrWrongInput))
+	})
+
+	It("keeps the order with the fill-first policy", func() {
+		orderPoolsByPolicy(pools, constant.PoolSelectionFillFirst, "", rnd)
+		Expect(names(pools)).To(Equal([]string{"pool1", "pool2", "pool3"}))
+	})
+
+	It("puts the least utilized IPPool first", func() {
+		pools = append(pools, &spiderpoolv2beta1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool4"}})
+		orderPoolsByPolicy(pools, constant.PoolSelectionLeastUtilized, "", rnd)
+		Expect(names(pools)).To(Equal([]string{"pool2", "pool3", "pool1", "pool4"}))
+	})
+
+	It("reorders the IPPools of the same priority only", func() {
+		pools[0].Spec.NodeName = []string{"node1"}
+		orderPoolsByPolicy(pools, constant.PoolSelectionLeastUtilized, "", rnd)
+		Expect(names(pools)).To(Equal([]string{"pool1", "pool2", "pool3"}))
+	})
+
+	It("picks the IPPools in proportion to the weight", func() {
+		first := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			candidates := []*spiderpoolv2beta1.SpiderIPPool{
+				newPool("pool1", 0, 10),
+				newPool("pool2", 0, 10),
+				newPool("pool3", 0, 10),
+			}
+			candidates[0].Spec.Weight = ptr.To(int32(0))
+			candidates[1].Spec.Weight = ptr.To(int32(9))
+
+			orderPoolsByPolicy(candidates, constant.PoolSelectionWeighted, "", rnd)
+			Expect(candidates[2].Name).To(Equal("pool1"))
+			first[candidates[0].Name]++
+		}
+
+		Expect(first["pool2"]).To(BeNumerically(">", 800))
+		Expect(first["pool3"]).To(BeNumerically(">", 50))
+	})
+
+	It("spreads the IPPools by the topology value", func() {
+		orderPoolsByPolicy(pools, constant.PoolSelectionSpread, "zone-a", rnd)
+		orderA := names(pools)
+		Expect(orderA).To(ConsistOf("pool1", "pool2", "pool3"))
+
+		again := []*spiderpoolv2beta1.SpiderIPPool{
+			newPool("pool1", 0, 10),
+			newPool("pool2", 0, 10),
+			newPool("pool3", 0, 10),
+		}
+		orderPoolsByPolicy(again, constant.PoolSelectionSpread, "zone-a", rnd)
+		Expect(names(again)).To(Equal(orderA))
+
+		starts := map[string]bool{}
+		for _, zone := range []string{"zone-a", "zone-b", "zone-c", "zone-d", "zone-e", "zone-f"} {
+			candidates := []*spiderpoolv2beta1.SpiderIPPool{
+				newPool("pool1", 0, 10),
+				newPool("pool2", 0, 10),
+				newPool("pool3", 0, 10),
+			}
+			orderPoolsByPolicy(candidates, constant.PoolSelectionSpread, zone, rnd)
+			starts[candidates[0].Name] = true
+		}
+		Expect(len(starts)).To(BeNumerically(">", 1))
+	})
+})
@@ -6,6 +6,7 @@ package ipam
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -131,7 +132,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 		return nil, err
 	}
 
-	var v4PoolCandidate, v6PoolCandidate *spiderpoolv2beta1.SpiderIPPool
+	var v4PoolCandidates, v6PoolCandidates []*spiderpoolv2beta1.SpiderIPPool
 	var errV4, errV6 error
 	var wg sync.WaitGroup
 
@@ -142,7 +143,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 			defer wg.Done()
 
 			if !slices.Contains(constant.K8sAPIVersions, podController.APIVersion) || !slices.Contains(constant.K8sKinds, podController.Kind) {
-				v4PoolCandidate, errV4 = i.applyThirdControllerAutoPool(ctx, subnetItem.IPv4[0], podController, types.AutoPoolProperty{
+				v4PoolCandidates, errV4 = i.applyThirdControllerAutoPools(ctx, subnetItem.IPv4, podController, types.AutoPoolProperty{
 					DesiredIPNumber:     poolIPNum,
 					IPVersion:           constant.IPv4,
 					IsReclaimIPPool:     subnetAnnoConfig.ReclaimIPPool,
@@ -150,7 +151,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 					AnnoPoolIPNumberVal: strconv.Itoa(poolIPNum),
 				})
 			} else {
-				v4PoolCandidate, errV4 = i.findAppAutoPool(ctx, subnetItem.IPv4[0], nic, constant.LabelValueIPVersionV4, poolIPNum, podController)
+				v4PoolCandidates, errV4 = i.findAppAutoPools(ctx, subnetItem.IPv4, nic, constant.LabelValueIPVersionV4, poolIPNum, podController)
 			}
 
 			if nil != errV4 {
@@ -166,7 +167,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 			defer wg.Done()
 
 			if !slices.Contains(constant.K8sAPIVersions, podController.APIVersion) || !slices.Contains(constant.K8sKinds, podController.Kind) {
-				v6PoolCandidate, errV6 = i.applyThirdControllerAutoPool(ctx, subnetItem.IPv6[0], podController, types.AutoPoolProperty{
+				v6PoolCandidates, errV6 = i.applyThirdControllerAutoPools(ctx, subnetItem.IPv6, podController, types.AutoPoolProperty{
 					DesiredIPNumber:     poolIPNum,
 					IPVersion:           constant.IPv6,
 					IsReclaimIPPool:     subnetAnnoConfig.ReclaimIPPool,
@@ -174,7 +175,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 					AnnoPoolIPNumberVal: strconv.Itoa(poolIPNum),
 				})
 			} else {
-				v6PoolCandidate, errV6 = i.findAppAutoPool(ctx, subnetItem.IPv6[0], nic, constant.LabelValueIPVersionV6, poolIPNum, podController)
+				v6PoolCandidates, errV6 = i.findAppAutoPools(ctx, subnetItem.IPv6, nic, constant.LabelValueIPVersionV6, poolIPNum, podController)
 			}
 
 			if nil != errV6 {
@@ -189,33 +190,42 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 		return nil, multierr.Append(errV4, errV6)
 	}
 
-	if v4PoolCandidate != nil {
-		logger.Sugar().Debugf("add IPv4 subnet IPPool '%s' to PoolCandidates", v4PoolCandidate.Name)
-		result.PoolCandidates = append(result.PoolCandidates, &PoolCandidate{
-			IPVersion: constant.IPv4,
-			Pools:     []string{v4PoolCandidate.Name},
-			PToIPPool: PoolNameToIPPool{v4PoolCandidate.Name: v4PoolCandidate},
-		})
+	if len(v4PoolCandidates) != 0 {
+		candidate := newSubnetPoolCandidate(constant.IPv4, v4PoolCandidates)
+		logger.Sugar().Debugf("add IPv4 subnet IPPools %v to PoolCandidates", candidate.Pools)
+		result.PoolCandidates = append(result.PoolCandidates, candidate)
 	}
-	if v6PoolCandidate != nil {
-		logger.Sugar().Debugf("add IPv6 subnet IPPool '%s' to PoolCandidates", v6PoolCandidate.Name)
-		result.PoolCandidates = append(result.PoolCandidates, &PoolCandidate{
-			IPVersion: constant.IPv6,
-			Pools:     []string{v6PoolCandidate.Name},
-			PToIPPool: PoolNameToIPPool{v6PoolCandidate.Name: v6PoolCandidate},
-		})
+	if len(v6PoolCandidates) != 0 {
+		candidate := newSubnetPoolCandidate(constant.IPv6, v6PoolCandidates)
+		logger.Sugar().Debugf("add IPv6 subnet IPPools %v to PoolCandidates", candidate.Pools)
+		result.PoolCandidates = append(result.PoolCandidates, candidate)
 	}
 
 	return result, nil
 }
 
-// findAppAutoPool only fetches kubernetes basic controller(like Deployment, StatefulSet etc...) corresponding auto-created IPPools.
-func (i *ipam) findAppAutoPool(ctx context.Context, subnetName, ifName, labelIPPoolIPVersionValue string, desiredIPNumber int, podController types.PodTopController) (*spiderpoolv2beta1.SpiderIPPool, error) {
+// newSubnetPoolCandidate generates the PoolCandidate with the auto-created IPPools, which keeps the SpiderSubnets sequence.
+func newSubnetPoolCandidate(ipVersion types.IPVersion, pools []*spiderpoolv2beta1.SpiderIPPool) *PoolCandidate {
+	candidate := &PoolCandidate{
+		IPVersion: ipVersion,
+		PToIPPool: PoolNameToIPPool{},
+	}
+	for _, pool := range pools {
+		candidate.Pools = append(candidate.Pools, pool.Name)
+		candidate.PToIPPool[pool.Name] = pool
+	}
+
+	return candidate
+}
+
+// findAppAutoPools only fetches kubernetes basic controller(like Deployment, StatefulSet etc...) corresponding auto-created IPPools.
+// The SpiderSubnets are in order, and the auto-created IPPools in the latter fallback SpiderSubnets supply the IPs that the former
+// exhausted SpiderSubnets couldn't. The returned IPPools keep the SpiderSubnets sequence.
+func (i *ipam) findAppAutoPools(ctx context.Context, subnetNames []string, ifName, labelIPPoolIPVersionValue string, desiredIPNumber int, podController types.PodTopController) ([]*spiderpoolv2beta1.SpiderIPPool, error) {
 	log := logutils.FromContext(ctx)
 
-	var pool *spiderpoolv2beta1.SpiderIPPool
+	var pools []*spiderpoolv2beta1.SpiderIPPool
 	matchLabels := client.MatchingLabels{
-		constant.LabelIPPoolOwnerSpiderSubnet:         subnetName,
 		constant.LabelIPPoolOwnerApplicationGV:        applicationinformers.ApplicationLabelGV(podController.APIVersion),
 		constant.LabelIPPoolOwnerApplicationKind:      podController.Kind,
 		constant.LabelIPPoolOwnerApplicationNamespace: podController.Namespace,
@@ -225,40 +235,87 @@ func (i *ipam) findAppAutoPool(ctx context.Context, subnetName, ifName, labelIPP
 		constant.LabelIPPoolIPVersion:                 labelIPPoolIPVersionValue,
 	}
 	for j := 1; j <= i.config.OperationRetries; j++ {
-		poolList, err := i.ipPoolManager.ListIPPools(ctx, constant.UseCache, matchLabels)
-		if nil != err {
-			return nil, fmt.Errorf("failed to get auto-created IPPoolList with labels '%v', error: %w", matchLabels, err)
+		pools = nil
+		var totalIPNumber int
+		for _, subnetName := range subnetNames {
+			matchLabels[constant.LabelIPPoolOwnerSpiderSubnet] = subnetName
+			poolList, err := i.ipPoolManager.ListIPPools(ctx, constant.UseCache, matchLabels)
+			if nil != err {
+				return nil, fmt.Errorf("failed to get auto-created IPPoolList with labels '%v', error: %w", matchLabels, err)
+			}
+
+			if len(poolList.Items) > 1 {
+				return nil, fmt.Errorf("it's invalid for '%s/%s/%s' corresponding SpiderSubnet '%s' owns multiple matchLables '%v' corresponding IPPools '%v' for one specify application",
+					podController.Kind, podController.Namespace, podController.Name, subnetName, matchLabels, poolList.Items)
+			}
+			if len(poolList.Items) == 1 {
+				pool := poolList.Items[0].DeepCopy()
+				log.Sugar().Debugf("found SpiderSubnet '%s' IPPool '%s' with matchLabel '%v'", subnetName, pool.Name, matchLabels)
+				pools = append(pools, pool)
+				totalIPNumber += getPoolTotalIPNumber(pool)
+			}
 		}
 
-		if len(poolList.Items) == 0 {
-			log.Sugar().Warnf("fetch SubnetIPPool %d times: no IPPool retrieved from SpiderSubnet '%s' with matchLabel '%v', wait for a second and get a retry",
-				j, subnetName, matchLabels)
+		if len(pools) == 0 {
+			log.Sugar().Warnf("fetch SubnetIPPool %d times: no IPPool retrieved from SpiderSubnets '%v' with matchLabel '%v', wait for a second and get a retry",
+				j, subnetNames, matchLabels)
 			time.Sleep(i.config.OperationGapDuration)
-		} else if len(poolList.Items) == 1 {
-			pool = poolList.Items[0].DeepCopy()
-			log.Sugar().Debugf("found SpiderSubnet '%s' IPPool '%s' with matchLabel '%v'", subnetName, pool.Name, matchLabels)
+			continue
+		}
 
-			// we fetched Auto-created IPPool but it doesn't have any IPs, just wait for a while and let the IPPool informer to allocate IPs for it
-			if !isPoolIPsDesired(pool, desiredIPNumber) {
-				log.Sugar().Warnf("fetch SubnetIPPool %d times: retrieved IPPool '%s' but doesn't have the desiredIPNumber IPs, wait for a second and get a retry", j, pool.Name)
-				time.Sleep(i.config.OperationGapDuration)
-				continue
+		// we fetched Auto-created IPPools but they don't have enough IPs, just wait for a while and let the spiderpool-controller to allocate IPs for them
+		if totalIPNumber < desiredIPNumber {
+			log.Sugar().Warnf("fetch SubnetIPPool %d times: retrieved IPPools '%v' but they only have %d IPs less than the desiredIPNumber %d, wait for a second and get a retry",
+				j, pools, totalIPNumber, desiredIPNumber)
+			time.Sleep(i.config.OperationGapDuration)
+			continue
+		}
+		break
+	}
+	if len(pools) == 0 {
+		return nil, fmt.Errorf("no matching auto-created IPPool candidate with matchLables '%v' in SpiderSubnets '%v'", matchLabels, subnetNames)
+	}
+
+	return pools, nil
+}
+
+// applyThirdControllerAutoPools will fetch or reconcile third-party controller corresponding auto-created IPPools in the ordered SpiderSubnets.
+// Once the former SpiderSubnet is exhausted, the auto-created IPPool in the next SpiderSubnet supplies the rest IPs.
+// The returned IPPools keep the SpiderSubnets sequence.
+func (i *ipam) applyThirdControllerAutoPools(ctx context.Context, subnetNames []string, podController types.PodTopController, autoPoolProperty types.AutoPoolProperty) ([]*spiderpoolv2beta1.SpiderIPPool, error) {
+	log := logutils.FromContext(ctx)
+
+	var pools []*spiderpoolv2beta1.SpiderIPPool
+	remainingIPNumber := autoPoolProperty.DesiredIPNumber
+	for index, subnetName := range subnetNames {
+		property := autoPoolProperty
+		property.DesiredIPNumber = remainingIPNumber
+		property.IsFallback = index != 0
+
+		pool, err := i.applyThirdControllerAutoPool(ctx, subnetName, podController, property)
+		if nil != err {
+			if !errors.Is(err, constant.ErrFreeIPsNotEnough) || index == len(subnetNames)-1 {
+				return nil, err
 			}
-			break
-		} else {
-			return nil, fmt.Errorf("it's invalid for '%s/%s/%s' corresponding SpiderSubnet '%s' owns multiple matchLables '%v' corresponding IPPools '%v' for one specify application",
-				podController.Kind, podController.Namespace, podController.Name, subnetName, matchLabels, poolList.Items)
+			log.Sugar().Warnf("failed to reconcile third-party controller auto-created IPPool in SpiderSubnet %s, fall back to the next SpiderSubnet: %v", subnetName, err)
 		}
+		if pool == nil {
+			continue
+		}
+
+		pools = append(pools, pool)
+		remainingIPNumber = max(0, remainingIPNumber-getPoolTotalIPNumber(pool))
 	}
-	if pool == nil {
-		return nil, fmt.Errorf("no matching auto-created IPPool candidate with matchLables '%v'", matchLabels)
+	if len(pools) == 0 {
+		return nil, fmt.Errorf("no matching third-party controller auto-created IPPool candidate in SpiderSubnets '%v'", subnetNames)
 	}
 
-	return pool, nil
+	return pools, nil
 }
 
-// applyThirdControllerAutoPool will fetch or reconcile third-party controller corresponding auto-created IPPools,
+// applyThirdControllerAutoPool will fetch or reconcile third-party controller corresponding auto-created IPPool in the given SpiderSubnet,
 // and the kubernetes basic controller like Deployment,StatefulSet etc... We'll reconcile their auto-created IPPools in spiderpool-controller component.
+// If the SpiderSubnet free IPs are not enough, it returns the previous auto-created IPPool together with the error.
 func (i *ipam) applyThirdControllerAutoPool(ctx context.Context, subnetName string, podController types.PodTopController, autoPoolProperty types.AutoPoolProperty) (*spiderpoolv2beta1.SpiderIPPool, error) {
 	log := logutils.FromContext(ctx)
 
@@ -294,15 +351,24 @@ func (i *ipam) applyThirdControllerAutoPool(ctx context.Context, subnetName stri
 			}
 		}
 
-		pool, err = i.subnetManager.ReconcileAutoIPPool(ctx, pool, subnetName, podController, autoPoolProperty)
+		// the fallback SpiderSubnet is not needed
+		if pool == nil && autoPoolProperty.DesiredIPNumber == 0 {
+			return nil, nil
+		}
+
+		newPool, err := i.subnetManager.ReconcileAutoIPPool(ctx, pool, subnetName, podController, autoPoolProperty)
 		if nil != err {
 			if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
 				log.Sugar().Warnf("fetch SubnetIPPool %d times: apply auto-created IPPool conflict: %v", j, err)
 				time.Sleep(i.config.OperationGapDuration)
 				continue
 			}
+			if errors.Is(err, constant.ErrFreeIPsNotEnough) {
+				return pool, fmt.Errorf("failed to check SpiderSubnet %s third-party controller auto-created IPPool whether need to be scaled: %w", subnetName, err)
+			}
 			return nil, fmt.Errorf("failed to check SpiderSubnet %s third-party controller auto-created IPPool whether need to be scaled: %w", subnetName, err)
 		}
+		pool = newPool
 		break
 	}
 	if pool == nil {
@@ -351,9 +417,14 @@ func (i *ipam) getPoolFromPodAnnoPools(ctx context.Context, anno, currentNIC str
 
 	var tt ToBeAllocateds
 	for _, v := range annoPodIPPools {
+		if err := ValidatePoolSelection(v.Selection); err != nil {
+			return nil, fmt.Errorf("%w: %w", errPrefix, err)
+		}
+
 		t := &ToBeAllocated{
 			NIC:          v.NIC,
 			CleanGateway: v.CleanGateway,
+			Selection:    v.Selection,
 		}
 		if len(v.IPv4Pools) != 0 {
 			t.PoolCandidates = append(t.PoolCandidates, &PoolCandidate{
@@ -406,9 +477,14 @@ func (i *ipam) getPoolFromPodAnnoPool(ctx context.Context, anno, nic string, cle
 		}
 	}
 
+	if err := ValidatePoolSelection(annoPodIPPool.Selection); err != nil {
+		return nil, fmt.Errorf("%w: %w", errPrefix, err)
+	}
+
 	t := &ToBeAllocated{
 		NIC:          nic,
 		CleanGateway: cleanGateway,
+		Selection:    annoPodIPPool.Selection,
 	}
 	if len(annoPodIPPool.IPv4Pools) != 0 {
 		t.PoolCandidates = append(t.PoolCandidates, &PoolCandidate{
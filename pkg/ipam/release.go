@@ -153,6 +153,8 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, uid, nic string, endpoint
 
 	// Call IaaS provider to release IPs first (before releasing from internal IPPools)
 	// to avoid IP conflicts where the IP could be re-allocated before IaaS release completes.
+	// The failed releases added to the pending queue are retried by the IaaS reconciler,
+	// which drops them once the IPs are re-allocated to other Pods.
 	if err := i.callIaaSRelease(ctx, endpoint); err != nil {
 		return err
 	}
@@ -34,6 +34,9 @@ type ToBeAllocated struct {
 	NIC            string
 	CleanGateway   bool
 	PoolCandidates []*PoolCandidate
+	// Selection is the policy to pick IPPools from the candidates, the
+	// default one of IPAMConfig is used if it is empty.
+	Selection string
 }
 
 func (t *ToBeAllocated) Pools() []string {
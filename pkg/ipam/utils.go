@@ -149,18 +149,14 @@ func getAutoPoolIPNumber(pod *corev1.Pod, podController types.PodTopController)
 	return poolIPNum, nil
 }
 
-// isPoolIPsDesired checks the auto-created IPPool's IPs whether matches its AutoDesiredIPCount
-func isPoolIPsDesired(pool *spiderpoolv2beta1.SpiderIPPool, desiredIPCount int) bool {
+// getPoolTotalIPNumber returns the auto-created IPPool's total IP number
+func getPoolTotalIPNumber(pool *spiderpoolv2beta1.SpiderIPPool) int {
 	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
 	if nil != err {
-		return false
-	}
-
-	if len(totalIPs) == desiredIPCount {
-		return true
+		return 0
 	}
 
-	return false
+	return len(totalIPs)
 }
 
 func IsMultipleNicWithNoName(anno map[string]string) bool {
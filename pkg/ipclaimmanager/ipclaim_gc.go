@@ -0,0 +1,116 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var gcLogger *zap.Logger
+
+type IPClaimGCConfig struct {
+	Interval time.Duration
+}
+
+// IPClaimGC periodically deletes the expired SpiderIPClaims, so that their
+// IP addresses are visible to the other IPClaims and users again. IPAM
+// ignores the expired IPClaims even if they are not deleted yet.
+type IPClaimGC struct {
+	config IPClaimGCConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+
+	// now is replaced in unit tests.
+	now func() time.Time
+}
+
+func NewIPClaimGC(config IPClaimGCConfig, client client.Client, leader election.SpiderLeaseElector) (*IPClaimGC, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("spiderpool controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	if config.Interval <= 0 {
+		config.Interval = constant.DefaultIPClaimGCInterval
+	}
+
+	gcLogger = logutils.Logger.Named("IPClaim-GC")
+
+	return &IPClaimGC{
+		config: config,
+		client: client,
+		leader: leader,
+		now:    time.Now,
+	}, nil
+}
+
+// Start deletes the expired IPClaims with the interval on the leader until
+// the context is done.
+func (g *IPClaimGC) Start(ctx context.Context) {
+	gcLogger.Sugar().Infof("running IPClaim GC with interval %v", g.config.Interval)
+	ticker := time.NewTicker(g.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !g.leader.IsElected() {
+				continue
+			}
+			if _, err := g.CleanExpiredIPClaims(ctx); err != nil {
+				gcLogger.Sugar().Errorf("failed to clean expired IPClaims: %v", err)
+			}
+		case <-ctx.Done():
+			gcLogger.Warn("receive ctx done, stop IPClaim GC!")
+			return
+		}
+	}
+}
+
+// CleanExpiredIPClaims deletes the expired IPClaims and returns the number
+// of the deleted ones.
+func (g *IPClaimGC) CleanExpiredIPClaims(ctx context.Context) (int, error) {
+	var claimList spiderpoolv2beta1.SpiderIPClaimList
+	if err := g.client.List(ctx, &claimList); err != nil {
+		return 0, fmt.Errorf("failed to list IPClaims: %w", err)
+	}
+
+	now := g.now()
+	deleted := 0
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+		if claim.DeletionTimestamp != nil || !IsIPClaimExpired(claim, now) {
+			continue
+		}
+
+		if err := g.client.Delete(ctx, claim); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete expired IPClaim %s/%s: %w", claim.Namespace, claim.Name, err)
+		}
+		deleted++
+
+		gcLogger.Sugar().Infof("deleted IPClaim %s/%s expired after TTL %v", claim.Namespace, claim.Name, claim.Spec.TTL.Duration)
+		event.EventRecorder.Eventf(claim, corev1.EventTypeNormal, constant.EventReasonIPClaimExpired,
+			"IPs %v of IPPool %s held for %s %s are released after TTL %v", claim.Spec.IPs, claim.Spec.IPPool, claim.Spec.Owner.Kind, claim.Spec.Owner.Name, claim.Spec.TTL.Duration)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ipclaimmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var _ = Describe("IPClaimGC", Label("ipclaim_gc_test"), func() {
+	Describe("New IPClaimGC", func() {
+		It("inputs nil client", func() {
+			gc, err := ipclaimmanager.NewIPClaimGC(ipclaimmanager.IPClaimGCConfig{}, nil, mockLeaderElector)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(gc).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			gc, err := ipclaimmanager.NewIPClaimGC(ipclaimmanager.IPClaimGCConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(gc).To(BeNil())
+		})
+	})
+
+	Describe("CleanExpiredIPClaims", func() {
+		var ctx context.Context
+
+		newIPClaim := func(name string, age time.Duration, ttl *metav1.Duration) *spiderpoolv2beta1.SpiderIPClaim {
+			claim := &spiderpoolv2beta1.SpiderIPClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         "gc",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+				},
+				Spec: spiderpoolv2beta1.IPClaimSpec{
+					IPPool: "ippool",
+					IPs:    []string{"172.18.40.10"},
+					Owner: spiderpoolv2beta1.IPClaimOwner{
+						Kind: constant.KindPod,
+						Name: name,
+					},
+					TTL: ttl,
+				},
+			}
+			err := fakeClient.Create(ctx, claim)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				err := fakeClient.Delete(context.TODO(), claim)
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			})
+
+			return claim
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+		})
+
+		It("deletes the expired IPClaims only", func() {
+			expired := newIPClaim("expired", time.Hour, &metav1.Duration{Duration: time.Minute})
+			unexpired := newIPClaim("unexpired", time.Minute, &metav1.Duration{Duration: time.Hour})
+			permanent := newIPClaim("permanent", time.Hour, nil)
+
+			gc, err := ipclaimmanager.NewIPClaimGC(ipclaimmanager.IPClaimGCConfig{}, fakeClient, mockLeaderElector)
+			Expect(err).NotTo(HaveOccurred())
+
+			deleted, err := gc.CleanExpiredIPClaims(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(1))
+
+			var claim spiderpoolv2beta1.SpiderIPClaim
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: expired.Namespace, Name: expired.Name}, &claim)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: unexpired.Namespace, Name: unexpired.Name}, &claim)
+			Expect(err).NotTo(HaveOccurred())
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: permanent.Namespace, Name: permanent.Name}, &claim)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	electionmock "github.com/spidernet-io/spiderpool/pkg/election/mock"
+	"github.com/spidernet-io/spiderpool/pkg/ipclaimmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var (
+	mockCtrl          *gomock.Controller
+	mockLeaderElector *electionmock.MockSpiderLeaseElector
+
+	scheme         *runtime.Scheme
+	fakeClient     client.Client
+	ipClaimWebhook *ipclaimmanager.IPClaimWebhook
+)
+
+func TestIPClaimManager(t *testing.T) {
+	mockCtrl = gomock.NewController(t)
+	defer mockCtrl.Finish()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "IPClaimManager Suite", Label("ipclaimmanager", "unittest"))
+}
+
+var _ = BeforeSuite(func() {
+	scheme = runtime.NewScheme()
+	err := spiderpoolv2beta1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = corev1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	mockLeaderElector = electionmock.NewMockSpiderLeaseElector(mockCtrl)
+
+	ipclaimmanager.WebhookLogger = logutils.Logger.Named("IPClaim-Webhook")
+	ipClaimWebhook = &ipclaimmanager.IPClaimWebhook{
+		APIReader: fakeClient,
+	}
+})
@@ -0,0 +1,257 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var (
+	ippoolField *field.Path = field.NewPath("spec").Child("ippool")
+	ipsField    *field.Path = field.NewPath("spec").Child("ips")
+	ownerField  *field.Path = field.NewPath("spec").Child("owner")
+	ttlField    *field.Path = field.NewPath("spec").Child("ttl")
+)
+
+func (cw *IPClaimWebhook) validateCreateIPClaim(ctx context.Context, claim *spiderpoolv2beta1.SpiderIPClaim) field.ErrorList {
+	if err := validateIPClaimTTL(claim); err != nil {
+		return field.ErrorList{err}
+	}
+
+	var errs field.ErrorList
+	if err := cw.validateIPClaimIPs(ctx, claim); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func (cw *IPClaimWebhook) validateUpdateIPClaim(ctx context.Context, oldClaim, newClaim *spiderpoolv2beta1.SpiderIPClaim) field.ErrorList {
+	if err := validateIPClaimShouldNotBeChanged(oldClaim, newClaim); err != nil {
+		return field.ErrorList{err}
+	}
+
+	if err := validateIPClaimTTL(newClaim); err != nil {
+		return field.ErrorList{err}
+	}
+
+	var errs field.ErrorList
+	if err := cw.validateIPClaimIPs(ctx, newClaim); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func validateIPClaimShouldNotBeChanged(oldClaim, newClaim *spiderpoolv2beta1.SpiderIPClaim) *field.Error {
+	if newClaim.Spec.IPPool != oldClaim.Spec.IPPool {
+		return field.Forbidden(
+			ippoolField,
+			"is not changeable",
+		)
+	}
+
+	if newClaim.Spec.Owner != oldClaim.Spec.Owner {
+		return field.Forbidden(
+			ownerField,
+			"is not changeable",
+		)
+	}
+
+	return nil
+}
+
+func validateIPClaimTTL(claim *spiderpoolv2beta1.SpiderIPClaim) *field.Error {
+	if claim.Spec.TTL != nil && claim.Spec.TTL.Duration <= 0 {
+		return field.Invalid(
+			ttlField,
+			claim.Spec.TTL.Duration.String(),
+			"must be positive",
+		)
+	}
+
+	return nil
+}
+
+// validateIPClaimIPs checks the claimed IP addresses are the available IP
+// addresses of the IPPool, and not claimed by the other effective IPClaims.
+func (cw *IPClaimWebhook) validateIPClaimIPs(ctx context.Context, claim *spiderpoolv2beta1.SpiderIPClaim) *field.Error {
+	var ipPool spiderpoolv2beta1.SpiderIPPool
+	if err := cw.APIReader.Get(ctx, apitypes.NamespacedName{Name: claim.Spec.IPPool}, &ipPool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(ippoolField, claim.Spec.IPPool)
+		}
+
+		return field.InternalError(ippoolField, fmt.Errorf("failed to get IPPool %s: %w", claim.Spec.IPPool, err))
+	}
+
+	if ipPool.DeletionTimestamp != nil {
+		return field.Forbidden(
+			ippoolField,
+			fmt.Sprintf("IPPool %s is terminating", ipPool.Name),
+		)
+	}
+
+	if ipPool.Spec.IPVersion == nil {
+		return field.InternalError(ippoolField, fmt.Errorf("'spec.ipVersion' of IPPool %s is not generated", ipPool.Name))
+	}
+
+	if ipPool.Spec.PrefixLength != nil {
+		return field.Forbidden(
+			ippoolField,
+			fmt.Sprintf("IPPool %s delegates prefixes, which can not be claimed", ipPool.Name),
+		)
+	}
+
+	if err := cw.validateIPClaimNamespace(ctx, claim, &ipPool); err != nil {
+		return err
+	}
+
+	version := *ipPool.Spec.IPVersion
+	for i, r := range claim.Spec.IPs {
+		if err := spiderpoolip.IsIPRange(version, r); err != nil {
+			return field.Invalid(
+				ipsField.Index(i),
+				r,
+				err.Error(),
+			)
+		}
+
+		ips, err := spiderpoolip.ParseIPRange(version, r)
+		if err != nil {
+			return field.Invalid(ipsField.Index(i), r, err.Error())
+		}
+		for _, ip := range ips {
+			included, err := ipRangesContainIP(version, ipPool.Spec.IPs, ip.String())
+			if err != nil {
+				return field.InternalError(ipsField.Index(i), err)
+			}
+			if !included {
+				return field.Invalid(
+					ipsField.Index(i),
+					r,
+					fmt.Sprintf("IP %s is not in 'spec.ips' of IPPool %s", ip, ipPool.Name),
+				)
+			}
+
+			excluded, err := ipRangesContainIP(version, ipPool.Spec.ExcludeIPs, ip.String())
+			if err != nil {
+				return field.InternalError(ipsField.Index(i), err)
+			}
+			if excluded {
+				return field.Invalid(
+					ipsField.Index(i),
+					r,
+					fmt.Sprintf("IP %s is in 'spec.excludeIPs' of IPPool %s", ip, ipPool.Name),
+				)
+			}
+		}
+	}
+
+	var claimList spiderpoolv2beta1.SpiderIPClaimList
+	if err := cw.APIReader.List(ctx, &claimList); err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to list IPClaims: %w", err))
+	}
+
+	now := time.Now()
+	for _, c := range claimList.Items {
+		if c.Spec.IPPool != claim.Spec.IPPool || (c.Namespace == claim.Namespace && c.Name == claim.Name) {
+			continue
+		}
+		if !IsIPClaimEffective(&c, now) {
+			continue
+		}
+
+		for i, r := range claim.Spec.IPs {
+			for _, claimed := range c.Spec.IPs {
+				overlap, err := spiderpoolip.IsIPRangeOverlap(version, r, claimed)
+				if err != nil {
+					return field.InternalError(ipsField.Index(i), err)
+				}
+				if overlap {
+					return field.Forbidden(
+						ipsField.Index(i),
+						fmt.Sprintf("overlaps with IP %s claimed by IPClaim %s/%s", claimed, c.Namespace, c.Name),
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateIPClaimNamespace checks the Pods in the namespace of the IPClaim are
+// allowed to use the IPPool, by its 'spec.namespaceName' or, if it's empty,
+// its 'spec.namespaceAffinity'.
+func (cw *IPClaimWebhook) validateIPClaimNamespace(ctx context.Context, claim *spiderpoolv2beta1.SpiderIPClaim, ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
+	if len(ipPool.Spec.NamespaceName) != 0 {
+		if !slices.Contains(ipPool.Spec.NamespaceName, claim.Namespace) {
+			return field.Forbidden(
+				ippoolField,
+				fmt.Sprintf("IPPool %s is not available to namespace %s, which is not in its 'spec.namespaceName'", ipPool.Name, claim.Namespace),
+			)
+		}
+
+		return nil
+	}
+
+	if ipPool.Spec.NamespaceAffinity == nil {
+		return nil
+	}
+
+	var namespace corev1.Namespace
+	if err := cw.APIReader.Get(ctx, apitypes.NamespacedName{Name: claim.Namespace}, &namespace); err != nil {
+		return field.InternalError(ippoolField, fmt.Errorf("failed to get Namespace %s: %w", claim.Namespace, err))
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ipPool.Spec.NamespaceAffinity)
+	if err != nil {
+		return field.InternalError(ippoolField, fmt.Errorf("invalid 'spec.namespaceAffinity' of IPPool %s: %w", ipPool.Name, err))
+	}
+	if !selector.Matches(labels.Set(namespace.Labels)) {
+		return field.Forbidden(
+			ippoolField,
+			fmt.Sprintf("IPPool %s is not available to namespace %s, which doesn't match its 'spec.namespaceAffinity'", ipPool.Name, claim.Namespace),
+		)
+	}
+
+	return nil
+}
+
+func ipRangesContainIP(version types.IPVersion, ipRanges []string, ip string) (bool, error) {
+	for _, r := range ipRanges {
+		contains, err := spiderpoolip.IPRangeContainsIP(version, r, ip)
+		if err != nil {
+			return false, err
+		}
+		if contains {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
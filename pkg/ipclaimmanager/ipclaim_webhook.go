@@ -0,0 +1,106 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var WebhookLogger *zap.Logger
+
+type IPClaimWebhook struct {
+	APIReader client.Reader
+}
+
+func (cw *IPClaimWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookLogger == nil {
+		WebhookLogger = logutils.Logger.Named("IPClaim-Webhook")
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&spiderpoolv2beta1.SpiderIPClaim{}).
+		WithValidator(cw).
+		Complete()
+}
+
+var _ webhook.CustomValidator = (*IPClaimWebhook)(nil)
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (cw *IPClaimWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	claim := obj.(*spiderpoolv2beta1.SpiderIPClaim)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("IPClaimNamespace", claim.Namespace),
+		zap.String("IPClaimName", claim.Name),
+		zap.String("Operation", "CREATE"),
+	)
+	logger.Sugar().Debugf("Request IPClaim: %+v", *claim)
+
+	if errs := cw.validateCreateIPClaim(logutils.IntoContext(ctx, logger), claim); len(errs) != 0 {
+		logger.Sugar().Errorf("Failed to create IPClaim: %v", errs.ToAggregate().Error())
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: constant.SpiderpoolAPIGroup, Kind: constant.KindSpiderIPClaim},
+			claim.Name,
+			errs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (cw *IPClaimWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldClaim := oldObj.(*spiderpoolv2beta1.SpiderIPClaim)
+	newClaim := newObj.(*spiderpoolv2beta1.SpiderIPClaim)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("IPClaimNamespace", newClaim.Namespace),
+		zap.String("IPClaimName", newClaim.Name),
+		zap.String("Operation", "UPDATE"),
+	)
+	logger.Sugar().Debugf("Request old IPClaim: %+v", *oldClaim)
+	logger.Sugar().Debugf("Request new IPClaim: %+v", *newClaim)
+
+	if newClaim.DeletionTimestamp != nil {
+		if oldClaim.DeletionTimestamp == nil {
+			return nil, nil
+		}
+
+		return nil, apierrors.NewForbidden(
+			schema.GroupResource{},
+			"",
+			errors.New("cannot update a terminating IPClaim"),
+		)
+	}
+
+	if errs := cw.validateUpdateIPClaim(logutils.IntoContext(ctx, logger), oldClaim, newClaim); len(errs) != 0 {
+		logger.Sugar().Errorf("Failed to update IPClaim: %v", errs.ToAggregate().Error())
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: constant.SpiderpoolAPIGroup, Kind: constant.KindSpiderIPClaim},
+			newClaim.Name,
+			errs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (cw *IPClaimWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
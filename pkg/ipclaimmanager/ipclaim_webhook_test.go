@@ -0,0 +1,255 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var _ = Describe("IPClaimWebhook", Label("ipclaim_webhook_test"), func() {
+	var ctx context.Context
+
+	var count uint64
+	var ipPoolT *spiderpoolv2beta1.SpiderIPPool
+	var claimT *spiderpoolv2beta1.SpiderIPClaim
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		atomic.AddUint64(&count, 1)
+		ipPoolT = &spiderpoolv2beta1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("ippool-%v", count),
+			},
+			Spec: spiderpoolv2beta1.IPPoolSpec{
+				IPVersion:  ptr.To(constant.IPv4),
+				Subnet:     "172.18.40.0/24",
+				IPs:        []string{"172.18.40.10-172.18.40.20"},
+				ExcludeIPs: []string{"172.18.40.15"},
+			},
+		}
+		claimT = &spiderpoolv2beta1.SpiderIPClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("ipclaim-%v", count),
+				Namespace: "default",
+			},
+			Spec: spiderpoolv2beta1.IPClaimSpec{
+				IPPool: ipPoolT.Name,
+				IPs:    []string{"172.18.40.10-172.18.40.11"},
+				Owner: spiderpoolv2beta1.IPClaimOwner{
+					Kind: constant.KindStatefulSet,
+					Name: "sts",
+				},
+			},
+		}
+
+		DeferCleanup(func() {
+			err := fakeClient.Delete(ctx, ipPoolT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+	})
+
+	createIPPool := func() {
+		err := fakeClient.Create(ctx, ipPoolT)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	createIPClaim := func(claim *spiderpoolv2beta1.SpiderIPClaim) {
+		err := fakeClient.Create(ctx, claim)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			err := fakeClient.Delete(context.TODO(), claim)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+	}
+
+	Describe("ValidateCreate", func() {
+		It("creates the IPClaim", func() {
+			createIPPool()
+
+			warns, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+
+		It("failed to create the IPClaim of the non-existent IPPool", func() {
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.ippool"))
+		})
+
+		It("failed to create the IPClaim of the IPPool delegating prefixes", func() {
+			ipPoolT.Spec.PrefixLength = ptr.To(int32(28))
+			createIPPool()
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("delegates prefixes"))
+		})
+
+		It("failed to create the IPClaim in the namespace out of the namespaceName of the IPPool", func() {
+			ipPoolT.Spec.NamespaceName = []string{"kube-system"}
+			createIPPool()
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("'spec.namespaceName'"))
+
+			ipPoolT.Spec.NamespaceName = append(ipPoolT.Spec.NamespaceName, claimT.Namespace)
+			Expect(fakeClient.Update(ctx, ipPoolT)).To(Succeed())
+			_, err = ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to create the IPClaim in the namespace unmatched with the namespaceAffinity of the IPPool", func() {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: claimT.Namespace, Labels: map[string]string{"tenant": "a"}}}
+			Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(fakeClient.Delete(context.TODO(), namespace)).To(Succeed())
+			})
+
+			ipPoolT.Spec.NamespaceAffinity = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "b"}}
+			createIPPool()
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("'spec.namespaceAffinity'"))
+
+			ipPoolT.Spec.NamespaceAffinity = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}}
+			Expect(fakeClient.Update(ctx, ipPoolT)).To(Succeed())
+			_, err = ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to create the IPClaim with the invalid IP range", func() {
+			createIPPool()
+			claimT.Spec.IPs = []string{constant.InvalidIPRange}
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("failed to create the IPClaim with the IP out of the IPPool", func() {
+			createIPPool()
+			claimT.Spec.IPs = []string{"172.18.40.20-172.18.40.21"}
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("is not in 'spec.ips'"))
+		})
+
+		It("failed to create the IPClaim with the excluded IP", func() {
+			createIPPool()
+			claimT.Spec.IPs = []string{"172.18.40.15"}
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("is in 'spec.excludeIPs'"))
+		})
+
+		It("failed to create the IPClaim with the non-positive TTL", func() {
+			createIPPool()
+			claimT.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.ttl"))
+		})
+
+		It("failed to create the IPClaim overlapping with the other IPClaim", func() {
+			createIPPool()
+			other := claimT.DeepCopy()
+			other.Name = claimT.Name + "-other"
+			other.Spec.IPs = []string{"172.18.40.11"}
+			createIPClaim(other)
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("overlaps with IP 172.18.40.11"))
+		})
+
+		It("creates the IPClaim overlapping with the expired IPClaim", func() {
+			createIPPool()
+			other := claimT.DeepCopy()
+			other.Name = claimT.Name + "-expired"
+			other.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			other.Spec.TTL = &metav1.Duration{Duration: time.Minute}
+			createIPClaim(other)
+
+			_, err := ipClaimWebhook.ValidateCreate(ctx, claimT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		It("updates the IPs and TTL of the IPClaim", func() {
+			createIPPool()
+			createIPClaim(claimT)
+
+			newClaimT := claimT.DeepCopy()
+			newClaimT.Spec.IPs = []string{"172.18.40.12"}
+			newClaimT.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+
+			_, err := ipClaimWebhook.ValidateUpdate(ctx, claimT, newClaimT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to change the owner of the IPClaim", func() {
+			newClaimT := claimT.DeepCopy()
+			newClaimT.Spec.Owner.Name = "another"
+
+			_, err := ipClaimWebhook.ValidateUpdate(ctx, claimT, newClaimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.owner"))
+		})
+
+		It("failed to change the IPPool of the IPClaim", func() {
+			newClaimT := claimT.DeepCopy()
+			newClaimT.Spec.IPPool = "another"
+
+			_, err := ipClaimWebhook.ValidateUpdate(ctx, claimT, newClaimT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.ippool"))
+		})
+
+		It("passes the IPClaim to be terminating", func() {
+			newClaimT := claimT.DeepCopy()
+			now := metav1.Now()
+			newClaimT.SetDeletionTimestamp(&now)
+
+			_, err := ipClaimWebhook.ValidateUpdate(ctx, claimT, newClaimT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to update the terminating IPClaim", func() {
+			now := metav1.Now()
+			claimT.SetDeletionTimestamp(&now)
+			newClaimT := claimT.DeepCopy()
+
+			_, err := ipClaimWebhook.ValidateUpdate(ctx, claimT, newClaimT)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		It("deletes the IPClaim", func() {
+			_, err := ipClaimWebhook.ValidateDelete(ctx, claimT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,49 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// IsIPClaimExpired returns whether the TTL of the SpiderIPClaim is passed.
+func IsIPClaimExpired(claim *spiderpoolv2beta1.SpiderIPClaim, now time.Time) bool {
+	if claim.Spec.TTL == nil {
+		return false
+	}
+
+	return !now.Before(claim.CreationTimestamp.Add(claim.Spec.TTL.Duration))
+}
+
+// IsIPClaimEffective returns whether the SpiderIPClaim still holds its IP
+// addresses, which is neither terminating nor expired.
+func IsIPClaimEffective(claim *spiderpoolv2beta1.SpiderIPClaim, now time.Time) bool {
+	return claim.DeletionTimestamp == nil && !IsIPClaimExpired(claim, now)
+}
+
+// IsIPClaimOwner returns whether the Pod is the owner of the SpiderIPClaim,
+// or is controlled by the owner. The controller is the top controller of
+// the Pod, and the VirtualMachine owner matches the Pods of the
+// VirtualMachineInstance named after it.
+func IsIPClaimOwner(claim *spiderpoolv2beta1.SpiderIPClaim, pod *corev1.Pod, podController types.PodTopController) bool {
+	if claim.Namespace != pod.Namespace {
+		return false
+	}
+
+	owner := claim.Spec.Owner
+	switch owner.Kind {
+	case constant.KindPod:
+		return owner.Name == pod.Name
+	case constant.KindKubevirtVM:
+		return podController.Kind == constant.KindKubevirtVMI && podController.Name == owner.Name
+	default:
+		return podController.Kind == owner.Kind && podController.Name == owner.Name
+	}
+}
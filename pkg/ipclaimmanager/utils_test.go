@@ -0,0 +1,87 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipclaimmanager_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ipclaimmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("IPClaim utils", Label("ipclaim_utils_test"), func() {
+	var claim *spiderpoolv2beta1.SpiderIPClaim
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		claim = &spiderpoolv2beta1.SpiderIPClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: spiderpoolv2beta1.IPClaimSpec{
+				Owner: spiderpoolv2beta1.IPClaimOwner{
+					Kind: constant.KindPod,
+					Name: "pod",
+				},
+			},
+		}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod",
+			},
+		}
+	})
+
+	podController := func(kind, name string) types.PodTopController {
+		return types.PodTopController{
+			AppNamespacedName: types.AppNamespacedName{
+				Kind:      kind,
+				Namespace: "default",
+				Name:      name,
+			},
+		}
+	}
+
+	DescribeTable("IsIPClaimOwner",
+		func(ownerKind, ownerName string, controller types.PodTopController, namespace string, expected bool) {
+			claim.Spec.Owner = spiderpoolv2beta1.IPClaimOwner{Kind: ownerKind, Name: ownerName}
+			pod.Namespace = namespace
+			Expect(ipclaimmanager.IsIPClaimOwner(claim, pod, controller)).To(Equal(expected))
+		},
+		Entry("the Pod", constant.KindPod, "pod", podController(constant.KindPod, "pod"), "default", true),
+		Entry("another Pod", constant.KindPod, "another", podController(constant.KindPod, "pod"), "default", false),
+		Entry("the Pod in another namespace", constant.KindPod, "pod", podController(constant.KindPod, "pod"), "another", false),
+		Entry("the controller", constant.KindDeployment, "deploy", podController(constant.KindDeployment, "deploy"), "default", true),
+		Entry("another kind of controller", constant.KindStatefulSet, "deploy", podController(constant.KindDeployment, "deploy"), "default", false),
+		Entry("the VirtualMachine", constant.KindKubevirtVM, "vm", podController(constant.KindKubevirtVMI, "vm"), "default", true),
+	)
+
+	It("checks the expiration of IPClaim", func() {
+		now := time.Now()
+		Expect(ipclaimmanager.IsIPClaimExpired(claim, now)).To(BeFalse())
+		Expect(ipclaimmanager.IsIPClaimEffective(claim, now)).To(BeTrue())
+
+		claim.Spec.TTL = &metav1.Duration{Duration: 2 * time.Hour}
+		Expect(ipclaimmanager.IsIPClaimExpired(claim, now)).To(BeFalse())
+
+		claim.Spec.TTL = &metav1.Duration{Duration: time.Minute}
+		Expect(ipclaimmanager.IsIPClaimExpired(claim, now)).To(BeTrue())
+		Expect(ipclaimmanager.IsIPClaimEffective(claim, now)).To(BeFalse())
+	})
+
+	It("ignores the terminating IPClaim", func() {
+		deletionTimestamp := metav1.Now()
+		claim.SetDeletionTimestamp(&deletionTimestamp)
+		Expect(ipclaimmanager.IsIPClaimEffective(claim, time.Now())).To(BeFalse())
+	})
+})
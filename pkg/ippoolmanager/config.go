@@ -5,6 +5,7 @@ package ippoolmanager
 
 const (
 	defaultMaxAllocatedIPs = 5000
+	defaultCoordinatorName = "default"
 )
 
 type IPPoolManagerConfig struct {
@@ -12,6 +13,15 @@ type IPPoolManagerConfig struct {
 	EnableKubevirtStaticIP    bool
 	EnableGatewayDetection    bool
 	EnableIPConflictDetection bool
+
+	// DefaultCoordinatorName is the SpiderCoordinator whose 'spec.podMACPrefix'
+	// derives the EUI-64 IPv6 addresses, unless the SpiderMultusConfig of the
+	// NIC overrides it.
+	DefaultCoordinatorName string
+	AgentNamespace         string
+	MultusClusterNetwork   *string
+	// IPv6StableSecret is hashed into the 'stable' IPv6 addresses.
+	IPv6StableSecret []byte
 }
 
 func setDefaultsForIPPoolManagerConfig(config IPPoolManagerConfig) IPPoolManagerConfig {
@@ -20,5 +30,9 @@ func setDefaultsForIPPoolManagerConfig(config IPPoolManagerConfig) IPPoolManager
 		config.MaxAllocatedIPs = &maxAllocatedIPs
 	}
 
+	if config.DefaultCoordinatorName == "" {
+		config.DefaultCoordinatorName = defaultCoordinatorName
+	}
+
 	return config
 }
@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"reflect"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -34,6 +36,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
 )
 
 var informerLogger *zap.Logger
@@ -316,18 +319,43 @@ func (ic *IPPoolController) syncHandler(ctx context.Context, pool *spiderpoolv2b
 		informerLogger.Sugar().Infof("initial SpiderIPPool '%s' status AllocatedIPCount to 0", pool.Name)
 	}
 
-	subnet, err := spiderpoolip.NewCIDR(pool.Spec.Subnet, pool.Spec.IPs, pool.Spec.ExcludeIPs)
-	if err != nil {
-		return fmt.Errorf("%w: failed to calculate SpiderIPPool '%s' total IP count, error: %w", constant.ErrWrongInput, pool.Name, err)
+	var total int
+	var subnetIPCounts []spiderpoolv2beta1.SubnetIPCount
+	if len(pool.Spec.SecondarySubnets) == 0 {
+		subnet, err := spiderpoolip.NewCIDR(pool.Spec.Subnet, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+		if err != nil {
+			return fmt.Errorf("%w: failed to calculate SpiderIPPool '%s' total IP count, error: %w", constant.ErrWrongInput, pool.Name, err)
+		}
+		total = subnet.TotalIPInt()
+	} else {
+		totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+		if err != nil {
+			return fmt.Errorf("%w: failed to calculate SpiderIPPool '%s' total IP count, error: %w", constant.ErrWrongInput, pool.Name, err)
+		}
+		total = len(totalIPs)
+
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			return fmt.Errorf("%w: failed to unmarshal the allocated IPs of SpiderIPPool '%s': %w", constant.ErrWrongInput, pool.Name, err)
+		}
+		allocatedIPs := make([]net.IP, 0, len(records))
+		for ip := range records {
+			allocatedIPs = append(allocatedIPs, net.ParseIP(ip))
+		}
+		subnetIPCounts = GenSubnetIPCounts(SubnetCIDRs(pool.Spec.Subnet, pool.Spec.SecondarySubnets), totalIPs, allocatedIPs)
 	}
-	total := subnet.TotalIPInt()
+
 	if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount != int64(total) {
 		needUpdate = true
 		pool.Status.TotalIPCount = ptr.To(int64(total))
 	}
+	if !reflect.DeepEqual(pool.Status.SubnetIPCounts, subnetIPCounts) {
+		needUpdate = true
+		pool.Status.SubnetIPCounts = subnetIPCounts
+	}
 
 	if needUpdate {
-		err = ic.client.Status().Update(ctx, pool)
+		err := ic.client.Status().Update(ctx, pool)
 		if nil != err {
 			return fmt.Errorf("failed to update pool: %w", err)
 		}
@@ -6,8 +6,11 @@ package ippoolmanager
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"net"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -20,7 +23,9 @@ import (
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	iaasutils "github.com/spidernet-io/spiderpool/pkg/iaas/utils"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/ipclaimmanager"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
@@ -111,7 +116,7 @@ func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, nic string, p
 		}
 
 		logger.Debug("Generate a random IP address")
-		allocatedIP, err := im.genRandomIP(ctx, ipPool, pod, podController)
+		allocatedIP, err := im.genRandomIP(ctx, ipPool, nic, pod, podController)
 		if err != nil {
 			return err
 		}
@@ -144,7 +149,7 @@ func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, nic string, p
 	return ipConfig, nil
 }
 
-func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, pod *corev1.Pod, podController types.PodTopController) (net.IP, error) {
+func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, nic string, pod *corev1.Pod, podController types.PodTopController) (net.IP, error) {
 	logger := logutils.FromContext(ctx)
 
 	var tmpPod *corev1.Pod
@@ -182,18 +187,104 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 		used = append(used, ip)
 	}
 
+	if err := checkNamespaceIPQuota(ipPool, allocatedRecords, pod.Namespace, key); err != nil {
+		return nil, err
+	}
+	if err := im.checkSubnetNamespaceIPQuota(ctx, ipPool, pod.Namespace, key); err != nil {
+		return nil, err
+	}
+
 	usedIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, used)
 	if err != nil {
 		return nil, err
 	}
 
+	ownedIPs, claimedIPs, err := im.assembleClaimedIPs(ctx, ipPool, pod, podController)
+	if err != nil {
+		return nil, err
+	}
+	reservedIPs = append(reservedIPs, claimedIPs...)
+
 	unAvailableIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, ipPool.Spec.ExcludeIPs)
 	if err != nil {
 		return nil, err
 	}
 
-	availableIPs := spiderpoolip.FindAvailableIPs(ipPool.Spec.IPs, append(unAvailableIPs, append(reservedIPs, usedIPs...)...), 1)
-	if len(availableIPs) == 0 {
+	// allocate IP addresses from 'spec.subnet' and then the secondary subnets in order
+	ipRanges := ipPool.Spec.IPs
+	if len(ipPool.Spec.SecondarySubnets) != 0 {
+		rangesByCIDR, err := spiderpoolip.SplitIPRangesByCIDR(*ipPool.Spec.IPVersion, SubnetCIDRs(ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets), ipPool.Spec.IPs)
+		if err != nil {
+			return nil, err
+		}
+
+		ipRanges = []string{}
+		for _, r := range rangesByCIDR {
+			ipRanges = append(ipRanges, r...)
+		}
+	}
+
+	var resIP net.IP
+	var resPrefix string
+	if ipPool.Spec.PrefixLength != nil {
+		// in prefix delegation mode, the whole prefix of every allocation is unavailable
+		unAvailableIPRanges := append([]string{}, ipPool.Spec.ExcludeIPs...)
+		for _, ip := range reservedIPs {
+			unAvailableIPRanges = append(unAvailableIPRanges, ip.String())
+		}
+		for ip, record := range allocatedRecords {
+			if record.Prefix == "" {
+				unAvailableIPRanges = append(unAvailableIPRanges, ip)
+				continue
+			}
+
+			prefixRange, err := spiderpoolip.CIDRToIPRange(*ipPool.Spec.IPVersion, record.Prefix)
+			if err != nil {
+				return nil, err
+			}
+			unAvailableIPRanges = append(unAvailableIPRanges, prefixRange)
+		}
+
+		prefix, err := spiderpoolip.FindAvailablePrefix(*ipPool.Spec.IPVersion, ipRanges, unAvailableIPRanges, int(*ipPool.Spec.PrefixLength))
+		if err != nil {
+			return nil, err
+		}
+		if prefix != nil {
+			resIP = prefix.IP
+			resPrefix = prefix.String()
+			// the first IPv6 address of the prefix is the Subnet-Router anycast
+			// address, except for the point-to-point ones (RFC 6164)
+			if ones, bits := prefix.Mask.Size(); bits == 128 && ones < 127 {
+				resIP = spiderpoolip.NextIP(prefix.IP)
+			}
+		}
+	} else {
+		unAvailableIPs = append(unAvailableIPs, append(reservedIPs, usedIPs...)...)
+		// the IP addresses claimed for the Pod take precedence over any other
+		for _, ip := range ownedIPs {
+			if !slices.ContainsFunc(unAvailableIPs, ip.Equal) && ipRangesContainIP(ipRanges, ip) {
+				logger.Sugar().Infof("Allocate the IP %s claimed for the Pod %s from IPPool %s", ip, key, ipPool.Name)
+				resIP = ip
+				break
+			}
+		}
+
+		if resIP == nil && ipPool.Spec.IPv6AddressMode != nil && *ipPool.Spec.IPv6AddressMode != constant.IPv6AddressModeRandom {
+			resIP, err = im.genIPv6AddressByMode(ctx, ipPool, ipRanges, unAvailableIPs, key+"/"+nic, pod, nic)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if resIP == nil {
+			availableIPs := spiderpoolip.FindAvailableIPs(ipRanges, unAvailableIPs, 1)
+			if len(availableIPs) != 0 {
+				resIP = availableIPs[0]
+			}
+		}
+	}
+
+	if resIP == nil {
 		// traverse the usedIPs to find the previous allocated IPs if there be
 		// reference issue: https://github.com/spidernet-io/spiderpool/issues/2517
 		allocatedIPFromRecords, hasFound := findAllocatedIPFromRecords(allocatedRecords, key, string(pod.UID))
@@ -201,13 +292,23 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 			return nil, constant.ErrIPUsedOut
 		}
 
-		availableIPs, err = spiderpoolip.ParseIPRange(*ipPool.Spec.IPVersion, allocatedIPFromRecords)
+		availableIPs, err := spiderpoolip.ParseIPRange(*ipPool.Spec.IPVersion, allocatedIPFromRecords)
 		if nil != err {
 			return nil, err
 		}
 		logger.Sugar().Warnf("find previous IP '%s' from IPPool '%s' recorded IP allocations", allocatedIPFromRecords, ipPool.Name)
+		resIP = availableIPs[0]
+		resPrefix = allocatedRecords[allocatedIPFromRecords].Prefix
+	}
+
+	if ipPool.Status.AllocatedIPCount == nil {
+		ipPool.Status.AllocatedIPCount = new(int64)
+	}
+
+	// reference issue: https://github.com/spidernet-io/spiderpool/issues/3771
+	if count := allocatedIPCount(allocatedRecords); count != *ipPool.Status.AllocatedIPCount {
+		logger.Sugar().Errorf("Handling AllocatedIPCount while allocating IP from IPPool %s, but there is a data discrepancy. Expected %d, but got %d.", ipPool.Name, count, *ipPool.Status.AllocatedIPCount)
 	}
-	resIP := availableIPs[0]
 
 	if allocatedRecords == nil {
 		allocatedRecords = spiderpoolv2beta1.PoolIPAllocations{}
@@ -215,6 +316,7 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 	allocatedRecords[resIP.String()] = spiderpoolv2beta1.PoolIPAllocation{
 		NamespacedName: key,
 		PodUID:         string(pod.UID),
+		Prefix:         resPrefix,
 	}
 
 	data, err := convert.MarshalIPPoolAllocatedIPs(allocatedRecords)
@@ -223,23 +325,159 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 	}
 	ipPool.Status.AllocatedIPs = data
 
-	if ipPool.Status.AllocatedIPCount == nil {
-		ipPool.Status.AllocatedIPCount = new(int64)
+	// Adding a newly assigned IP, or the whole prefix in prefix delegation mode
+	*ipPool.Status.AllocatedIPCount = allocatedIPCount(allocatedRecords)
+
+	if int64(len(allocatedRecords)) > int64(*im.config.MaxAllocatedIPs) {
+		return nil, fmt.Errorf("%w, threshold of IP records(<=%d) for IPPool %s exceeded", constant.ErrIPUsedOut, im.config.MaxAllocatedIPs, ipPool.Name)
 	}
 
-	// reference issue: https://github.com/spidernet-io/spiderpool/issues/3771
-	if int64(len(usedIPs)) != *ipPool.Status.AllocatedIPCount {
-		logger.Sugar().Errorf("Handling AllocatedIPCount while allocating IP from IPPool %s, but there is a data discrepancy. Expected %d, but got %d.", ipPool.Name, len(usedIPs), *ipPool.Status.AllocatedIPCount)
+	return resIP, nil
+}
+
+// assembleClaimedIPs returns the IP addresses of the IPPool claimed by the
+// effective SpiderIPClaims for the Pod, and the ones claimed for the others.
+func (im *ipPoolManager) assembleClaimedIPs(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, pod *corev1.Pod, podController types.PodTopController) ([]net.IP, []net.IP, error) {
+	var claimList spiderpoolv2beta1.SpiderIPClaimList
+	if err := im.client.List(ctx, &claimList, client.MatchingFields{constant.SpecIPPoolField: ipPool.Name}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list SpiderIPClaims: %w", err)
 	}
 
-	// Adding a newly assigned IP
-	*ipPool.Status.AllocatedIPCount = int64(len(usedIPs)) + 1
+	now := time.Now()
+	var ownedIPs, claimedIPs []net.IP
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+		if !ipclaimmanager.IsIPClaimEffective(claim, now) {
+			continue
+		}
 
-	if *ipPool.Status.AllocatedIPCount > int64(*im.config.MaxAllocatedIPs) {
-		return nil, fmt.Errorf("%w, threshold of IP records(<=%d) for IPPool %s exceeded", constant.ErrIPUsedOut, im.config.MaxAllocatedIPs, ipPool.Name)
+		ips, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, claim.Spec.IPs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid 'spec.ips' of SpiderIPClaim %s/%s: %w", claim.Namespace, claim.Name, err)
+		}
+
+		if ipclaimmanager.IsIPClaimOwner(claim, pod, podController) {
+			ownedIPs = append(ownedIPs, ips...)
+		} else {
+			claimedIPs = append(claimedIPs, ips...)
+		}
 	}
 
-	return resIP, nil
+	return ownedIPs, claimedIPs, nil
+}
+
+// genIPv6AddressByMode generates the IPv6 address whose interface identifier
+// is derived from the Pod according to 'spec.ipv6AddressMode', it tries the
+// CIDRs of the IPPool in order and returns nil if the addresses are all
+// unavailable, so that the caller could fall back to a random one.
+func (im *ipPoolManager) genIPv6AddressByMode(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, ipRanges []string, unAvailableIPs []net.IP, key string, pod *corev1.Pod, nic string) (net.IP, error) {
+	logger := logutils.FromContext(ctx)
+
+	var mac net.HardwareAddr
+	if *ipPool.Spec.IPv6AddressMode == constant.IPv6AddressModeEUI64 {
+		podMACPrefix, err := im.podMACPrefix(ctx, pod, nic)
+		if err != nil {
+			return nil, err
+		}
+		if podMACPrefix == "" {
+			logger.Sugar().Warnf("No 'spec.podMACPrefix' of SpiderCoordinator for the EUI-64 address of IPPool %s, fall back to random", ipPool.Name)
+			return nil, nil
+		}
+
+		// the MAC address is '<podMACPrefix>:xx:xx:xx:xx', which coordinator
+		// recovers from the EUI-64 address
+		prefix, err := net.ParseMAC(podMACPrefix + ":00:00:00:00")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'podMACPrefix' %s of coordinator: %w", podMACPrefix, err)
+		}
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(key))
+		mac = append(net.HardwareAddr{prefix[0], prefix[1]}, h.Sum(nil)...)
+	}
+
+	for _, cidr := range SubnetCIDRs(ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		var ip net.IP
+		if mac != nil {
+			ip, err = spiderpoolip.GenEUI64IP(ipNet, mac)
+		} else {
+			ip, err = spiderpoolip.GenStableIP(ipNet, key, im.config.IPv6StableSecret)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.ContainsFunc(unAvailableIPs, ip.Equal) && ipRangesContainIP(ipRanges, ip) {
+			return ip, nil
+		}
+		logger.Sugar().Infof("The %s IPv6 address %s of IPPool %s is unavailable", *ipPool.Spec.IPv6AddressMode, ip, ipPool.Name)
+	}
+
+	logger.Sugar().Warnf("No available %s IPv6 address in IPPool %s, fall back to random", *ipPool.Spec.IPv6AddressMode, ipPool.Name)
+	return nil, nil
+}
+
+// checkSubnetNamespaceIPQuota checks the namespace quota of the Subnet which
+// the IPPool belongs to. The IPPools of the Subnet are updated apart, so the
+// concurrent allocations from different IPPools may exceed the quota slightly.
+func (im *ipPoolManager) checkSubnetNamespaceIPQuota(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, namespace, namespacedName string) error {
+	subnetName, ok := ipPool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]
+	if !ok {
+		return nil
+	}
+
+	var subnet spiderpoolv2beta1.SpiderSubnet
+	if err := im.client.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if subnet.Spec.NamespaceQuota == nil {
+		return nil
+	}
+
+	var poolList spiderpoolv2beta1.SpiderIPPoolList
+	if err := im.client.List(ctx, &poolList, client.MatchingLabels{constant.LabelIPPoolOwnerSpiderSubnet: subnetName}); err != nil {
+		return fmt.Errorf("failed to list IPPools of Subnet %s: %w", subnetName, err)
+	}
+
+	return CheckSubnetNamespaceIPQuota(&subnet, poolList.Items, ipPool, namespace, namespacedName)
+}
+
+// podMACPrefix returns the 'podMACPrefix' which coordinator sets on the NIC
+// of the Pod, the one of the SpiderMultusConfig of the NIC overrides the one
+// of the default SpiderCoordinator.
+func (im *ipPoolManager) podMACPrefix(ctx context.Context, pod *corev1.Pod, nic string) (string, error) {
+	logger := logutils.FromContext(ctx)
+
+	netInfo, err := iaasutils.GetMultusNetworkForNIC(pod, nic, im.config.AgentNamespace, im.config.MultusClusterNetwork)
+	if err != nil {
+		logger.Sugar().Debugf("No Multus network of NIC %s: %v", nic, err)
+	} else {
+		var smc spiderpoolv2beta1.SpiderMultusConfig
+		err := im.client.Get(ctx, apitypes.NamespacedName{Namespace: netInfo.Namespace, Name: netInfo.Name}, &smc)
+		if client.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed to get SpiderMultusConfig %s/%s: %w", netInfo.Namespace, netInfo.Name, err)
+		}
+		if err == nil && smc.Spec.CoordinatorConfig != nil && smc.Spec.CoordinatorConfig.PodMACPrefix != nil && *smc.Spec.CoordinatorConfig.PodMACPrefix != "" {
+			return *smc.Spec.CoordinatorConfig.PodMACPrefix, nil
+		}
+	}
+
+	var coord spiderpoolv2beta1.SpiderCoordinator
+	if err := im.client.Get(ctx, apitypes.NamespacedName{Name: im.config.DefaultCoordinatorName}, &coord); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get SpiderCoordinator %s: %w", im.config.DefaultCoordinatorName, err)
+	}
+	if coord.Spec.PodMACPrefix == nil {
+		return "", nil
+	}
+
+	return *coord.Spec.PodMACPrefix, nil
 }
 
 func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUIDs []types.IPAndUID) error {
@@ -268,8 +506,8 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUI
 		}
 
 		// reference issue: https://github.com/spidernet-io/spiderpool/issues/3771
-		if int64(len(allocatedRecords)) != *ipPool.Status.AllocatedIPCount {
-			logger.Sugar().Errorf("Handling AllocatedIPCount while releasing IP from IPPool %s, but there is a data discrepancy. Expected %d, but got %d.", ipPool.Name, len(allocatedRecords), *ipPool.Status.AllocatedIPCount)
+		if count := allocatedIPCount(allocatedRecords); count != *ipPool.Status.AllocatedIPCount {
+			logger.Sugar().Errorf("Handling AllocatedIPCount while releasing IP from IPPool %s, but there is a data discrepancy. Expected %d, but got %d.", ipPool.Name, count, *ipPool.Status.AllocatedIPCount)
 		}
 
 		release := false
@@ -277,7 +515,7 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUI
 			if record, ok := allocatedRecords[iu.IP]; ok {
 				if record.PodUID == iu.UID {
 					delete(allocatedRecords, iu.IP)
-					*ipPool.Status.AllocatedIPCount = int64(len(allocatedRecords))
+					*ipPool.Status.AllocatedIPCount = allocatedIPCount(allocatedRecords)
 					release = true
 				}
 			}
@@ -71,6 +71,10 @@ var _ = BeforeSuite(func() {
 			}
 			return []string{}
 		}).
+		WithIndex(&spiderpoolv2beta1.SpiderIPClaim{}, constant.SpecIPPoolField, func(raw client.Object) []string {
+			ipClaim := raw.(*spiderpoolv2beta1.SpiderIPClaim)
+			return []string{ipClaim.Spec.IPPool}
+		}).
 		WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
 		Build()
 	_, err = metric.InitMetric(context.TODO(), constant.SpiderpoolAgent, false, false)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/golang/mock/gomock"
@@ -26,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
@@ -409,6 +411,279 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 				Expect(res.Vlan).To(Equal(vlan))
 			})
 
+			It("allocate prefix with prefix delegation mode", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv6)).
+					Return(nil, nil).
+					Times(1)
+
+				ipVersion := constant.IPv6
+				ipPoolT.Spec.IPVersion = ptr.To(ipVersion)
+				ipPoolT.Spec.Subnet = "abcd:1234::/48"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::-abcd:1234:0:ffff:ffff:ffff:ffff:ffff")
+				ipPoolT.Spec.ExcludeIPs = append(ipPoolT.Spec.ExcludeIPs, "abcd:1234::1")
+				ipPoolT.Spec.PrefixLength = ptr.To(int32(120))
+
+				records := spiderpoolv2beta1.PoolIPAllocations{
+					"abcd:1234::101": spiderpoolv2beta1.PoolIPAllocation{
+						NamespacedName: "default/other",
+						PodUID:         string(uuid.NewUUID()),
+						Prefix:         "abcd:1234::100/120",
+					},
+				}
+				allocatedIPs, err := json.Marshal(records)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Status = spiderpoolv2beta1.IPPoolStatus{
+					AllocatedIPs:     ptr.To(string(allocatedIPs)),
+					AllocatedIPCount: ptr.To(int64(256)),
+				}
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Version).To(Equal(ipVersion))
+				// the Subnet-Router anycast address of the prefix is skipped
+				Expect(*res.Address).To(Equal("abcd:1234::201/48"))
+				Expect(res.Prefix).To(Equal("abcd:1234::200/120"))
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolName}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+
+				newRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newRecords).To(HaveKeyWithValue("abcd:1234::201", spiderpoolv2beta1.PoolIPAllocation{
+					NamespacedName: podT.Namespace + "/" + podT.Name,
+					PodUID:         string(podT.UID),
+					Prefix:         "abcd:1234::200/120",
+				}))
+				Expect(ipPool.Status.AllocatedIPCount).To(Equal(ptr.To(int64(512))))
+			})
+
+			It("allocate EUI-64 IPv6 address", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv6)).
+					Return(nil, nil).
+					Times(1)
+
+				coord := &spiderpoolv2beta1.SpiderCoordinator{
+					ObjectMeta: metav1.ObjectMeta{Name: "default"},
+					Spec:       spiderpoolv2beta1.CoordinatorSpec{PodMACPrefix: ptr.To("0a:00")},
+				}
+				err := fakeClient.Create(ctx, coord)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(func() {
+					Expect(fakeClient.Delete(ctx, coord)).To(Succeed())
+				})
+
+				ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+				ipPoolT.Spec.Subnet = "abcd:1234::/64"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::-abcd:1234::ffff:ffff:ffff:ffff")
+				ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeEUI64)
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+
+				ip, _, err := net.ParseCIDR(*res.Address)
+				Expect(err).NotTo(HaveOccurred())
+				mac := spiderpoolip.ParseEUI64MAC(ip)
+				Expect(mac).NotTo(BeNil())
+				Expect(mac.String()).To(HavePrefix("0a:00:"))
+			})
+
+			It("allocate EUI-64 IPv6 address with the podMACPrefix of the SpiderMultusConfig", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv6)).
+					Return(nil, nil).
+					Times(1)
+
+				coord := &spiderpoolv2beta1.SpiderCoordinator{
+					ObjectMeta: metav1.ObjectMeta{Name: "default"},
+					Spec:       spiderpoolv2beta1.CoordinatorSpec{PodMACPrefix: ptr.To("0a:00")},
+				}
+				smc := &spiderpoolv2beta1.SpiderMultusConfig{
+					ObjectMeta: metav1.ObjectMeta{Namespace: podT.Namespace, Name: "macvlan"},
+					Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+						CoordinatorConfig: &spiderpoolv2beta1.CoordinatorSpec{PodMACPrefix: ptr.To("0e:01")},
+					},
+				}
+				for _, obj := range []client.Object{coord, smc} {
+					Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+					DeferCleanup(fakeClient.Delete, ctx, obj)
+				}
+
+				podT.Annotations = map[string]string{constant.MultusDefaultNetAnnot: podT.Namespace + "/macvlan"}
+				ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+				ipPoolT.Spec.Subnet = "abcd:1234::/64"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::-abcd:1234::ffff:ffff:ffff:ffff")
+				ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeEUI64)
+
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+
+				ip, _, err := net.ParseCIDR(*res.Address)
+				Expect(err).NotTo(HaveOccurred())
+				mac := spiderpoolip.ParseEUI64MAC(ip)
+				Expect(mac).NotTo(BeNil())
+				Expect(mac.String()).To(HavePrefix("0e:01:"))
+			})
+
+			It("falls back to random IPv6 address without the default SpiderCoordinator", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv6)).
+					Return(nil, nil).
+					Times(1)
+
+				coord := &spiderpoolv2beta1.SpiderCoordinator{
+					ObjectMeta: metav1.ObjectMeta{Name: "other"},
+					Spec:       spiderpoolv2beta1.CoordinatorSpec{PodMACPrefix: ptr.To("0a:00")},
+				}
+				err := fakeClient.Create(ctx, coord)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(func() {
+					Expect(fakeClient.Delete(ctx, coord)).To(Succeed())
+				})
+
+				ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+				ipPoolT.Spec.Subnet = "abcd:1234::/64"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::10")
+				ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeEUI64)
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Address).To(Equal("abcd:1234::10/64"))
+			})
+
+			It("falls back to random IPv6 address when the stable one is used", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv6)).
+					Return(nil, nil).
+					Times(1)
+
+				ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+				ipPoolT.Spec.Subnet = "abcd:1234::/64"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::10")
+				ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeStable)
+
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Address).To(Equal("abcd:1234::10/64"))
+			})
+
+			Context("with SpiderIPClaims", func() {
+				newIPClaim := func(name, ownerName string, ips []string) *spiderpoolv2beta1.SpiderIPClaim {
+					return &spiderpoolv2beta1.SpiderIPClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              name,
+							Namespace:         podT.Namespace,
+							CreationTimestamp: metav1.Now(),
+						},
+						Spec: spiderpoolv2beta1.IPClaimSpec{
+							IPPool: ipPoolName,
+							IPs:    ips,
+							Owner: spiderpoolv2beta1.IPClaimOwner{
+								Kind: constant.KindPod,
+								Name: ownerName,
+							},
+						},
+					}
+				}
+
+				createIPClaim := func(claim *spiderpoolv2beta1.SpiderIPClaim) {
+					err := fakeClient.Create(ctx, claim)
+					Expect(err).NotTo(HaveOccurred())
+					DeferCleanup(func() {
+						err := fakeClient.Delete(context.TODO(), claim)
+						Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+					})
+				}
+
+				BeforeEach(func() {
+					mockRIPManager.EXPECT().
+						AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+						Return(nil, nil).
+						Times(1)
+
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.40-172.18.40.42")
+
+					err := fakeClient.Create(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					err = tracker.Add(ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("allocates the IP address claimed for the Pod", func() {
+					createIPClaim(newIPClaim("owned", podT.Name, []string{"172.18.40.42"}))
+
+					res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(*res.Address).To(Equal("172.18.40.42/24"))
+				})
+
+				It("allocates the IP address claimed for the controller of the Pod", func() {
+					claim := newIPClaim("owned-by-controller", "sts", []string{"172.18.40.41"})
+					claim.Spec.Owner.Kind = constant.KindStatefulSet
+					createIPClaim(claim)
+
+					podController := spiderpooltypes.PodTopController{
+						AppNamespacedName: spiderpooltypes.AppNamespacedName{
+							Kind:      constant.KindStatefulSet,
+							Namespace: podT.Namespace,
+							Name:      "sts",
+						},
+					}
+					res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(*res.Address).To(Equal("172.18.40.41/24"))
+				})
+
+				It("skips the IP addresses claimed for the others", func() {
+					createIPClaim(newIPClaim("others", "other-pod", []string{"172.18.40.40-172.18.40.41"}))
+
+					res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(*res.Address).To(Equal("172.18.40.42/24"))
+				})
+
+				It("ignores the expired SpiderIPClaims", func() {
+					claim := newIPClaim("expired", "other-pod", []string{"172.18.40.40"})
+					claim.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+					claim.Spec.TTL = &metav1.Duration{Duration: time.Minute}
+					createIPClaim(claim)
+
+					res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, podT, spiderpooltypes.PodTopController{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(*res.Address).To(Equal("172.18.40.40/24"))
+				})
+			})
+
 			It("allocate IP address from the previous records", func() {
 				mockRIPManager.EXPECT().
 					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
@@ -462,6 +737,137 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 			})
 		})
 
+		Describe("AllocateIP with namespace quota", Label("namespace_quota"), func() {
+			var podT *corev1.Pod
+
+			BeforeEach(func() {
+				podT = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod",
+						Namespace: "default",
+						UID:       uuid.NewUUID(),
+					},
+				}
+
+				records := spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.40": spiderpoolv2beta1.PoolIPAllocation{
+						NamespacedName: "default/other",
+						PodUID:         string(uuid.NewUUID()),
+					},
+				}
+				allocatedIPs, err := json.Marshal(records)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+				ipPoolT.Spec.Subnet = "172.18.40.0/24"
+				ipPoolT.Spec.IPs = []string{"172.18.40.40-172.18.40.41"}
+				ipPoolT.Status = spiderpoolv2beta1.IPPoolStatus{
+					AllocatedIPs:     ptr.To(string(allocatedIPs)),
+					TotalIPCount:     ptr.To(int64(2)),
+					AllocatedIPCount: ptr.To(int64(1)),
+				}
+			})
+
+			It("refuses to allocate IP address if the namespace used up the quota", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					Return(nil, nil).
+					Times(1)
+
+				ipPoolT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+					Default: ptr.To(int64(10)),
+					Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+						{Namespace: "default", MaxIPs: 1},
+					},
+				}
+
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "eth0", podT, spiderpooltypes.PodTopController{})
+				Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+				Expect(res).To(BeNil())
+			})
+
+			It("allocates IP address within the default quota", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					Return(nil, nil).
+					Times(1)
+
+				ipPoolT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+					Default: ptr.To(int64(2)),
+					Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+						{Namespace: "kube-system", MaxIPs: 0},
+					},
+				}
+
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "eth0", podT, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Address).To(Equal("172.18.40.41/24"))
+			})
+
+			It("refuses to allocate IP address if the namespace used up the quota of Subnet", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					Return(nil, nil).
+					Times(1)
+
+				subnet := &spiderpoolv2beta1.SpiderSubnet{
+					ObjectMeta: metav1.ObjectMeta{Name: "quota-subnet"},
+					Spec: spiderpoolv2beta1.SubnetSpec{
+						IPVersion: ptr.To(constant.IPv4),
+						Subnet:    "172.18.40.0/24",
+						NamespaceQuota: &spiderpoolv2beta1.IPPoolNamespaceQuota{
+							Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+								{Namespace: "default", MaxIPs: 2},
+							},
+						},
+					},
+				}
+				records, err := json.Marshal(spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.50": spiderpoolv2beta1.PoolIPAllocation{
+						NamespacedName: "default/another",
+						PodUID:         string(uuid.NewUUID()),
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				otherPool := &spiderpoolv2beta1.SpiderIPPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "quota-other-pool",
+						Labels: map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: subnet.Name},
+					},
+					Spec: spiderpoolv2beta1.IPPoolSpec{
+						IPVersion: ptr.To(constant.IPv4),
+						Subnet:    "172.18.40.0/24",
+						IPs:       []string{"172.18.40.50"},
+					},
+					Status: spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: ptr.To(string(records))},
+				}
+				for _, obj := range []client.Object{subnet, otherPool} {
+					Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+					DeferCleanup(fakeClient.Delete, ctx, obj)
+				}
+
+				ipPoolT.Labels = map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: subnet.Name}
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "eth0", podT, spiderpooltypes.PodTopController{})
+				Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+				Expect(res).To(BeNil())
+			})
+		})
+
 		Describe("ReleaseIP", func() {
 			var ip string
 			var uid string
@@ -153,4 +153,12 @@ func InheritSubnetProperties(subnet *spiderpoolv2beta1.SpiderSubnet, ipPool *spi
 		copy(routes, subnet.Spec.Routes)
 		ipPool.Spec.Routes = routes
 	}
+
+	if len(subnet.Spec.SecondarySubnets) != 0 && ipPool.Spec.SecondarySubnets == nil {
+		secondarySubnets := make([]spiderpoolv2beta1.SecondarySubnet, 0, len(subnet.Spec.SecondarySubnets))
+		for _, s := range subnet.Spec.SecondarySubnets {
+			secondarySubnets = append(secondarySubnets, *s.DeepCopy())
+		}
+		ipPool.Spec.SecondarySubnets = secondarySubnets
+	}
 }
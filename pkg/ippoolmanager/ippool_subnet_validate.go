@@ -15,6 +15,7 @@ import (
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
@@ -81,6 +82,10 @@ func (iw *IPPoolWebhook) validateSubnetTotalIPsContainsIPPoolTotalIPs(ctx contex
 		)
 	}
 
+	if err := validateIPPoolTotalIPsWithinSubnetTenants(ipPool, poolTotalIPs, &subnet); err != nil {
+		return err
+	}
+
 	if IsAutoCreatedIPPool(ipPool) {
 		return validateNewAutoPoolTotalIPsWithinSubnet(ipPool, &subnet)
 	}
@@ -88,6 +93,51 @@ func (iw *IPPoolWebhook) validateSubnetTotalIPsContainsIPPoolTotalIPs(ctx contex
 	return nil
 }
 
+// validateIPPoolTotalIPsWithinSubnetTenants checks the IPPool of a
+// SpiderTenantIPPool only uses the IP addresses granted to its namespace, and
+// the other IPPools never use the IP addresses granted to any namespace.
+func validateIPPoolTotalIPsWithinSubnetTenants(pool *spiderpoolv2beta1.SpiderIPPool, poolTotalIPs []net.IP, subnet *spiderpoolv2beta1.SpiderSubnet) *field.Error {
+	tenantNamespace := pool.Labels[constant.LabelIPPoolOwnerTenantNamespace]
+	for _, tenant := range subnet.Spec.Tenants {
+		grantedIPs, err := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, tenant.IPs)
+		if err != nil {
+			return field.InternalError(subnetField, fmt.Errorf("failed to parse the IPs granted to namespace %s by Subnet %s: %w", tenant.Namespace, subnet.Name, err))
+		}
+
+		if tenant.Namespace == tenantNamespace {
+			outIPs := spiderpoolip.IPsDiffSet(poolTotalIPs, grantedIPs, false)
+			if len(outIPs) > 0 {
+				ranges, _ := spiderpoolip.ConvertIPsToIPRanges(*pool.Spec.IPVersion, outIPs)
+				return field.Forbidden(
+					ipsField,
+					fmt.Sprintf("add some IP ranges %v that are not granted to namespace %s by Subnet %s", ranges, tenantNamespace, subnet.Name),
+				)
+			}
+			continue
+		}
+
+		grantedToOthers := spiderpoolip.IPsIntersectionSet(poolTotalIPs, grantedIPs, false)
+		if len(grantedToOthers) > 0 {
+			ranges, _ := spiderpoolip.ConvertIPsToIPRanges(*pool.Spec.IPVersion, grantedToOthers)
+			return field.Forbidden(
+				ipsField,
+				fmt.Sprintf("add some IP ranges %v that are granted to namespace %s by Subnet %s", ranges, tenant.Namespace, subnet.Name),
+			)
+		}
+	}
+
+	if tenantNamespace != "" {
+		if _, ok, _ := SubnetTenantIPs(subnet, tenantNamespace); !ok {
+			return field.Forbidden(
+				ipsField,
+				fmt.Sprintf("Subnet %s grants no IP addresses to namespace %s", subnet.Name, tenantNamespace),
+			)
+		}
+	}
+
+	return nil
+}
+
 func validateNewAutoPoolTotalIPsWithinSubnet(pool *spiderpoolv2beta1.SpiderIPPool, subnet *spiderpoolv2beta1.SpiderSubnet) *field.Error {
 	var subnetPreAllocateIPs []net.IP
 
@@ -6,6 +6,8 @@ package ippoolmanager
 import (
 	"context"
 	"fmt"
+	"net"
+	"reflect"
 	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,12 +24,17 @@ import (
 )
 
 var (
-	ipVersionField   *field.Path = field.NewPath("spec").Child("ipVersion")
-	subnetField      *field.Path = field.NewPath("spec").Child("subnet")
-	ipsField         *field.Path = field.NewPath("spec").Child("ips")
-	gatewayField     *field.Path = field.NewPath("spec").Child("gateway")
-	routesField      *field.Path = field.NewPath("spec").Child("routes")
-	podAffinityField *field.Path = field.NewPath("spec").Child("podAffinity")
+	ipVersionField        *field.Path = field.NewPath("spec").Child("ipVersion")
+	subnetField           *field.Path = field.NewPath("spec").Child("subnet")
+	ipsField              *field.Path = field.NewPath("spec").Child("ips")
+	excludeIPsField       *field.Path = field.NewPath("spec").Child("excludeIPs")
+	gatewayField          *field.Path = field.NewPath("spec").Child("gateway")
+	routesField           *field.Path = field.NewPath("spec").Child("routes")
+	secondarySubnetsField *field.Path = field.NewPath("spec").Child("secondarySubnets")
+	prefixLengthField     *field.Path = field.NewPath("spec").Child("prefixLength")
+	ipv6AddressModeField  *field.Path = field.NewPath("spec").Child("ipv6AddressMode")
+	podAffinityField      *field.Path = field.NewPath("spec").Child("podAffinity")
+	namespaceQuotaField   *field.Path = field.NewPath("spec").Child("namespaceQuota")
 )
 
 func (iw *IPPoolWebhook) validateCreateIPPool(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool) field.ErrorList {
@@ -102,18 +109,47 @@ func validateIPPoolShouldNotBeChanged(oldIPPool, newIPPool *spiderpoolv2beta1.Sp
 		)
 	}
 
+	if !slices.Equal(
+		SubnetCIDRs(newIPPool.Spec.Subnet, newIPPool.Spec.SecondarySubnets),
+		SubnetCIDRs(oldIPPool.Spec.Subnet, oldIPPool.Spec.SecondarySubnets),
+	) {
+		return field.Forbidden(
+			secondarySubnetsField,
+			"the CIDRs are not changeable",
+		)
+	}
+
+	if !reflect.DeepEqual(newIPPool.Spec.PrefixLength, oldIPPool.Spec.PrefixLength) {
+		return field.Forbidden(
+			prefixLengthField,
+			"is not changeable",
+		)
+	}
+
 	return nil
 }
 
 func (iw *IPPoolWebhook) validateIPPoolSpec(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
+	if err := ValidateSecondarySubnets(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs); err != nil {
+		return err
+	}
 	if err := iw.validateIPPoolAvailableIPs(ctx, ipPool); err != nil {
 		return err
 	}
 	if err := validateIPPoolGateway(ipPool); err != nil {
 		return err
 	}
+	if err := validateIPPoolPrefixLength(ipPool); err != nil {
+		return err
+	}
+	if err := validateIPPoolIPv6AddressMode(ipPool); err != nil {
+		return err
+	}
+	if err := validateIPPoolNamespaceQuota(ipPool); err != nil {
+		return err
+	}
 
-	return validateIPPoolRoutes(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.Routes)
+	return validateIPPoolRoutes(routesField, *ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.Routes)
 }
 
 func validateIPPoolIPInUse(ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
@@ -192,6 +228,9 @@ func (iw *IPPoolWebhook) validateIPPoolCIDR(ctx context.Context, ipPool *spiderp
 	if err := spiderpoolip.IsFormatCIDR(ipPool.Spec.Subnet); err != nil {
 		return field.Invalid(subnetField, ipPool.Spec.Subnet, err.Error())
 	}
+	if err := ValidateSecondarySubnetsCIDR(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets); err != nil {
+		return err
+	}
 
 	var ipPoolList spiderpoolv2beta1.SpiderIPPoolList
 	if err := iw.APIReader.List(ctx, &ipPoolList); err != nil {
@@ -206,20 +245,19 @@ func (iw *IPPoolWebhook) validateIPPoolCIDR(ctx context.Context, ipPool *spiderp
 				return field.InternalError(subnetField, fmt.Errorf("IPPool %s %s", ipPool.Name, metav1.StatusReasonAlreadyExists))
 			}
 
-			if pool.Spec.Subnet == ipPool.Spec.Subnet {
-				continue
-			}
-
-			overlap, err := spiderpoolip.IsCIDROverlap(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, pool.Spec.Subnet)
+			// the IPPools with the same CIDR are allowed
+			cidrs := SubnetCIDRs(ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets)
+			existCIDRs := SubnetCIDRs(pool.Spec.Subnet, pool.Spec.SecondarySubnets)
+			i, j, err := spiderpoolip.FindOverlappedCIDRs(*ipPool.Spec.IPVersion, cidrs, existCIDRs, true)
 			if err != nil {
 				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps: %w", err))
 			}
 
-			if overlap {
+			if i != -1 {
 				return field.Invalid(
-					subnetField,
-					ipPool.Spec.Subnet,
-					fmt.Sprintf("overlap with IPPool %s which 'spec.subnet' is %s", pool.Name, pool.Spec.Subnet),
+					CIDRFieldPath(i),
+					cidrs[i],
+					fmt.Sprintf("overlap with IPPool %s which '%s' is %s", pool.Name, CIDRFieldPath(j), existCIDRs[j]),
 				)
 			}
 		}
@@ -228,33 +266,157 @@ func (iw *IPPoolWebhook) validateIPPoolCIDR(ctx context.Context, ipPool *spiderp
 	return nil
 }
 
+// CIDRFieldPath returns the field path of the CIDR indexed in the sequence
+// returned by SubnetCIDRs.
+func CIDRFieldPath(index int) *field.Path {
+	if index == 0 {
+		return subnetField
+	}
+
+	return secondarySubnetsField.Index(index - 1).Child("subnet")
+}
+
+// ValidateSecondarySubnetsCIDR checks whether the CIDRs of the secondary
+// subnets are valid and don't overlap with each other or 'spec.subnet'.
+func ValidateSecondarySubnetsCIDR(version types.IPVersion, subnet string, secondarySubnets []spiderpoolv2beta1.SecondarySubnet) *field.Error {
+	cidrs := []string{subnet}
+	for i, s := range secondarySubnets {
+		fieldPath := secondarySubnetsField.Index(i).Child("subnet")
+		if err := spiderpoolip.IsCIDR(version, s.Subnet); err != nil {
+			return field.Invalid(fieldPath, s.Subnet, err.Error())
+		}
+		if err := spiderpoolip.IsFormatCIDR(s.Subnet); err != nil {
+			return field.Invalid(fieldPath, s.Subnet, err.Error())
+		}
+
+		_, j, err := spiderpoolip.FindOverlappedCIDRs(version, []string{s.Subnet}, cidrs, false)
+		if err != nil {
+			return field.InternalError(fieldPath, fmt.Errorf("failed to compare whether the secondary subnet overlaps: %w", err))
+		}
+		if j != -1 {
+			return field.Invalid(fieldPath, s.Subnet, fmt.Sprintf("overlap with '%s' %s", CIDRFieldPath(j), cidrs[j]))
+		}
+		cidrs = append(cidrs, s.Subnet)
+	}
+
+	return nil
+}
+
+// ValidateSecondarySubnets checks the gateway and routes of each secondary
+// subnet, and whether 'spec.ips' and 'spec.excludeIPs' pertain to the CIDRs.
+func ValidateSecondarySubnets(version types.IPVersion, subnet string, secondarySubnets []spiderpoolv2beta1.SecondarySubnet, ips, excludeIPs []string) *field.Error {
+	if len(secondarySubnets) == 0 {
+		return nil
+	}
+
+	for i, s := range secondarySubnets {
+		fieldPath := secondarySubnetsField.Index(i)
+		if s.Gateway != nil {
+			if err := ValidateContainsIP(fieldPath.Child("gateway"), version, s.Subnet, *s.Gateway); err != nil {
+				return err
+			}
+			if err := validateGatewayNotInIPs(fieldPath.Child("gateway"), version, *s.Gateway, ips, excludeIPs); err != nil {
+				return err
+			}
+		}
+
+		if err := validateIPPoolRoutes(fieldPath.Child("routes"), version, s.Subnet, s.Routes); err != nil {
+			return err
+		}
+	}
+
+	cidrs := SubnetCIDRs(subnet, secondarySubnets)
+	if _, err := spiderpoolip.SplitIPRangesByCIDR(version, cidrs, ips); err != nil {
+		return field.Invalid(ipsField, ips, err.Error())
+	}
+	if _, err := spiderpoolip.SplitIPRangesByCIDR(version, cidrs, excludeIPs); err != nil {
+		return field.Invalid(excludeIPsField, excludeIPs, err.Error())
+	}
+
+	return nil
+}
+
+// newSubnetCIDRs builds the IP ranges of each CIDR, indexed by CIDR.
+func newSubnetCIDRs(version types.IPVersion, subnet string, secondarySubnets []spiderpoolv2beta1.SecondarySubnet, ips, excludeIPs []string) (map[string]*spiderpoolip.CIDR, error) {
+	if len(secondarySubnets) == 0 {
+		c, err := spiderpoolip.NewCIDR(subnet, ips, excludeIPs)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]*spiderpoolip.CIDR{subnet: c}, nil
+	}
+
+	cidrs := SubnetCIDRs(subnet, secondarySubnets)
+	ipsByCIDR, err := spiderpoolip.SplitIPRangesByCIDR(version, cidrs, ips)
+	if err != nil {
+		return nil, err
+	}
+	excludeIPsByCIDR, err := spiderpoolip.SplitIPRangesByCIDR(version, cidrs, excludeIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*spiderpoolip.CIDR, len(cidrs))
+	for i, cidr := range cidrs {
+		c, err := spiderpoolip.NewCIDR(cidr, ipsByCIDR[i], excludeIPsByCIDR[i])
+		if err != nil {
+			return nil, err
+		}
+		result[cidr] = c
+	}
+
+	return result, nil
+}
+
 func (iw *IPPoolWebhook) validateIPPoolAvailableIPs(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
-	newPool, err := spiderpoolip.NewCIDR(ipPool.Spec.Subnet, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+	newPool, err := newSubnetCIDRs(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
 	if err != nil {
 		return field.Invalid(subnetField, ipPool.Spec.Subnet, err.Error())
 	}
 
-	cidr, err := spiderpoolip.CIDRToLabelValue(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet)
-	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to parse CIDR %s as a valid label value: %w", ipPool.Spec.Subnet, err))
+	// the IPPools sharing a secondary subnet don't own the CIDR label of it,
+	// so we have to list all IPPools once secondary subnets are involved.
+	var listOpts []client.ListOption
+	if len(ipPool.Spec.SecondarySubnets) == 0 {
+		cidr, err := spiderpoolip.CIDRToLabelValue(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet)
+		if err != nil {
+			return field.InternalError(ipsField, fmt.Errorf("failed to parse CIDR %s as a valid label value: %w", ipPool.Spec.Subnet, err))
+		}
+		listOpts = append(listOpts, client.MatchingLabels{constant.LabelIPPoolCIDR: cidr})
 	}
 
 	var ipPoolList spiderpoolv2beta1.SpiderIPPoolList
-	if err := iw.APIReader.List(
-		ctx,
-		&ipPoolList,
-		client.MatchingLabels{constant.LabelIPPoolCIDR: cidr},
-	); err != nil {
+	if err := iw.APIReader.List(ctx, &ipPoolList, listOpts...); err != nil {
 		return field.InternalError(ipsField, fmt.Errorf("failed to list IPPools: %w", err))
 	}
 
 	for _, pool := range ipPoolList.Items {
-		if pool.Name != ipPool.Name {
-			existPool, err := spiderpoolip.NewCIDR(pool.Spec.Subnet, pool.Spec.IPs, pool.Spec.ExcludeIPs)
-			if err != nil {
-				return field.Invalid(subnetField, pool.Spec.Subnet, err.Error())
+		if pool.Name == ipPool.Name {
+			continue
+		}
+
+		shared := false
+		for _, cidr := range SubnetCIDRs(pool.Spec.Subnet, pool.Spec.SecondarySubnets) {
+			if _, ok := newPool[cidr]; ok {
+				shared = true
+				break
 			}
-			if overlapRanges, isOverlap := newPool.IsOverlapIPRanges(existPool.IPRange()); isOverlap {
+		}
+		if !shared {
+			continue
+		}
+
+		existPool, err := newSubnetCIDRs(*ipPool.Spec.IPVersion, pool.Spec.Subnet, pool.Spec.SecondarySubnets, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+		if err != nil {
+			return field.Invalid(subnetField, pool.Spec.Subnet, err.Error())
+		}
+		for cidr, c := range newPool {
+			existCIDR, ok := existPool[cidr]
+			if !ok {
+				continue
+			}
+			if overlapRanges, isOverlap := c.IsOverlapIPRanges(existCIDR.IPRange()); isOverlap {
 				return field.Forbidden(
 					ipsField,
 					fmt.Sprintf("overlap with IPPool %s in IP ranges %v, total IP addresses of an IPPool are jointly determined by 'spec.ips' and 'spec.excludeIPs'", pool.Name, overlapRanges),
@@ -275,28 +437,126 @@ func validateIPPoolGateway(ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error
 		return err
 	}
 
-	for _, r := range ipPool.Spec.ExcludeIPs {
-		contains, _ := spiderpoolip.IPRangeContainsIP(*ipPool.Spec.IPVersion, r, *ipPool.Spec.Gateway)
+	return validateGatewayNotInIPs(gatewayField, *ipPool.Spec.IPVersion, *ipPool.Spec.Gateway, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+}
+
+func validateGatewayNotInIPs(gatewayPath *field.Path, version types.IPVersion, gateway string, ips, excludeIPs []string) *field.Error {
+	for _, r := range excludeIPs {
+		contains, _ := spiderpoolip.IPRangeContainsIP(version, r, gateway)
 		if contains {
 			return nil
 		}
 	}
 
-	for i, r := range ipPool.Spec.IPs {
-		contains, _ := spiderpoolip.IPRangeContainsIP(*ipPool.Spec.IPVersion, r, *ipPool.Spec.Gateway)
+	for i, r := range ips {
+		contains, _ := spiderpoolip.IPRangeContainsIP(version, r, gateway)
 		if contains {
 			return field.Invalid(
 				ipsField.Index(i),
 				r,
-				fmt.Sprintf("conflicts with 'spec.gateway' %s, add the gateway IP address to 'spec.excludeIPs' or remove it from 'spec.ips'", *ipPool.Spec.Gateway),
+				fmt.Sprintf("conflicts with '%s' %s, add the gateway IP address to 'spec.excludeIPs' or remove it from 'spec.ips'", gatewayPath, gateway),
+			)
+		}
+	}
+
+	return nil
+}
+
+func validateIPPoolPrefixLength(ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
+	if ipPool.Spec.PrefixLength == nil {
+		return nil
+	}
+
+	prefixLength := int(*ipPool.Spec.PrefixLength)
+	for i, cidr := range SubnetCIDRs(ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets) {
+		ipNet, err := spiderpoolip.ParseCIDR(*ipPool.Spec.IPVersion, cidr)
+		if err != nil {
+			return field.InternalError(CIDRFieldPath(i), err)
+		}
+
+		ones, bits := ipNet.Mask.Size()
+		if prefixLength <= ones || prefixLength > bits {
+			return field.Invalid(
+				prefixLengthField,
+				prefixLength,
+				fmt.Sprintf("must be greater than the mask length of '%s' %s and no more than %d", CIDRFieldPath(i), cidr, bits),
+			)
+		}
+	}
+
+	return nil
+}
+
+func validateIPPoolIPv6AddressMode(ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
+	mode := ipPool.Spec.IPv6AddressMode
+	if mode == nil || *mode == constant.IPv6AddressModeRandom {
+		return nil
+	}
+
+	if *ipPool.Spec.IPVersion != constant.IPv6 {
+		return field.Forbidden(
+			ipv6AddressModeField,
+			fmt.Sprintf("'%s' only works with IPv6 IPPool", *mode),
+		)
+	}
+
+	if ipPool.Spec.PrefixLength != nil {
+		return field.Forbidden(
+			ipv6AddressModeField,
+			fmt.Sprintf("'%s' doesn't work with '%s'", *mode, prefixLengthField),
+		)
+	}
+
+	for i, cidr := range SubnetCIDRs(ipPool.Spec.Subnet, ipPool.Spec.SecondarySubnets) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return field.InternalError(CIDRFieldPath(i), err)
+		}
+
+		if ones, _ := ipNet.Mask.Size(); ones > 64 {
+			return field.Invalid(
+				CIDRFieldPath(i),
+				cidr,
+				fmt.Sprintf("the mask length must be no more than 64 with '%s' %s", ipv6AddressModeField, *mode),
+			)
+		}
+	}
+
+	return nil
+}
+
+func validateIPPoolNamespaceQuota(ipPool *spiderpoolv2beta1.SpiderIPPool) *field.Error {
+	return ValidateNamespaceQuota(namespaceQuotaField, ipPool.Spec.NamespaceQuota)
+}
+
+// ValidateNamespaceQuota checks the namespaces of the quota are specified
+// only once, it serves both SpiderIPPool and SpiderSubnet.
+func ValidateNamespaceQuota(fieldPath *field.Path, quota *spiderpoolv2beta1.IPPoolNamespaceQuota) *field.Error {
+	if quota == nil {
+		return nil
+	}
+
+	namespaces := make(map[string]bool, len(quota.Namespaces))
+	for i, q := range quota.Namespaces {
+		if q.Namespace == "" {
+			return field.Required(
+				fieldPath.Child("namespaces").Index(i).Child("namespace"),
+				"",
+			)
+		}
+		if namespaces[q.Namespace] {
+			return field.Duplicate(
+				fieldPath.Child("namespaces").Index(i).Child("namespace"),
+				q.Namespace,
 			)
 		}
+		namespaces[q.Namespace] = true
 	}
 
 	return nil
 }
 
-func validateIPPoolRoutes(version types.IPVersion, subnet string, routes []spiderpoolv2beta1.Route) *field.Error {
+func validateIPPoolRoutes(fieldPath *field.Path, version types.IPVersion, subnet string, routes []spiderpoolv2beta1.Route) *field.Error {
 	if len(routes) == 0 {
 		return nil
 	}
@@ -306,7 +566,7 @@ func validateIPPoolRoutes(version types.IPVersion, subnet string, routes []spide
 		if version == constant.IPv4 && r.Dst == "0.0.0.0/0" ||
 			version == constant.IPv6 && r.Dst == "::/0" {
 			return field.Invalid(
-				routesField.Index(i).Child("dst"),
+				fieldPath.Index(i).Child("dst"),
 				r.Dst,
 				"please specify 'spec.gateway' to configure the default route",
 			)
@@ -314,7 +574,7 @@ func validateIPPoolRoutes(version types.IPVersion, subnet string, routes []spide
 
 		if _, ok := dstSet[r.Dst]; ok {
 			return field.Invalid(
-				routesField.Index(i).Child("dst"),
+				fieldPath.Index(i).Child("dst"),
 				r.Dst,
 				"duplicate route with the same dst",
 			)
@@ -323,13 +583,13 @@ func validateIPPoolRoutes(version types.IPVersion, subnet string, routes []spide
 
 		if err := spiderpoolip.IsCIDR(version, r.Dst); err != nil {
 			return field.Invalid(
-				routesField.Index(i).Child("dst"),
+				fieldPath.Index(i).Child("dst"),
 				r.Dst,
 				err.Error(),
 			)
 		}
 
-		if err := ValidateContainsIP(routesField.Index(i).Child("gw"), version, subnet, r.Gw); err != nil {
+		if err := ValidateContainsIP(fieldPath.Index(i).Child("gw"), version, subnet, r.Gw); err != nil {
 			return err
 		}
 	}
@@ -1114,6 +1114,208 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				})
 			})
 
+			When("Validating 'spec.ipv6AddressMode'", func() {
+				It("sets 'eui64' for IPv4 IPPool", func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeEUI64)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("sets 'stable' for the subnet longer than /64", func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/120"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::10")
+					ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeStable)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("creates IPPool with 'eui64'", func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/64"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::-abcd:1234::ffff:ffff:ffff:ffff")
+					ipPoolT.Spec.IPv6AddressMode = ptr.To(constant.IPv6AddressModeEUI64)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.namespaceQuota'", func() {
+				BeforeEach(func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+				})
+
+				It("inputs duplicate namespaces", func() {
+					ipPoolT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+						Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+							{Namespace: "ns1", MaxIPs: 1},
+							{Namespace: "ns1", MaxIPs: 2},
+						},
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs an empty namespace", func() {
+					ipPoolT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+						Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+							{MaxIPs: 1},
+						},
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("creates IPPool with namespace quota", func() {
+					ipPoolT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+						Default: ptr.To(int64(1)),
+						Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+							{Namespace: "ns1", MaxIPs: 0},
+						},
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.prefixLength'", func() {
+				BeforeEach(func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/48"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::-abcd:1234::ffff:ffff:ffff:ffff")
+				})
+
+				It("inputs the prefix length no more than the mask length of 'spec.subnet'", func() {
+					ipPoolT.Spec.PrefixLength = ptr.To(int32(48))
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs the prefix length greater than 128", func() {
+					ipPoolT.Spec.PrefixLength = ptr.To(int32(129))
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("creates IPPool in prefix delegation mode", func() {
+					ipPoolT.Spec.PrefixLength = ptr.To(int32(80))
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.secondarySubnets'", func() {
+				BeforeEach(func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(
+						ipPoolT.Spec.IPs,
+						[]string{
+							"172.18.40.10",
+							"172.18.41.10",
+						}...,
+					)
+				})
+
+				It("overlaps with 'spec.subnet'", func() {
+					ipPoolT.Spec.SecondarySubnets = append(
+						ipPoolT.Spec.SecondarySubnets,
+						spiderpoolv2beta1.SecondarySubnet{Subnet: "172.18.40.0/25"},
+					)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs 'spec.ips' that do not pertains to any CIDR", func() {
+					ipPoolT.Spec.SecondarySubnets = append(
+						ipPoolT.Spec.SecondarySubnets,
+						spiderpoolv2beta1.SecondarySubnet{Subnet: "172.18.42.0/24"},
+					)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs gateway that do not pertains to the secondary subnet", func() {
+					ipPoolT.Spec.SecondarySubnets = append(
+						ipPoolT.Spec.SecondarySubnets,
+						spiderpoolv2beta1.SecondarySubnet{
+							Subnet:  "172.18.41.0/24",
+							Gateway: ptr.To("172.18.40.1"),
+						},
+					)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("overlaps with existing IPPool in the secondary subnet", func() {
+					existIPPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					existIPPoolT.Spec.Subnet = "172.18.41.0/24"
+					existIPPoolT.Spec.IPs = append(existIPPoolT.Spec.IPs, "172.18.41.10")
+
+					err := tracker.Add(existIPPoolT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.SecondarySubnets = append(
+						ipPoolT.Spec.SecondarySubnets,
+						spiderpoolv2beta1.SecondarySubnet{Subnet: "172.18.41.0/24"},
+					)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("creates IPPool with valid secondary subnets", func() {
+					ipPoolT.Spec.Gateway = ptr.To("172.18.40.1")
+					ipPoolT.Spec.SecondarySubnets = append(
+						ipPoolT.Spec.SecondarySubnets,
+						spiderpoolv2beta1.SecondarySubnet{
+							Subnet:  "172.18.41.0/24",
+							Gateway: ptr.To("172.18.41.1"),
+							Routes: []spiderpoolv2beta1.Route{
+								{
+									Dst: "192.168.41.0/24",
+									Gw:  "172.18.41.40",
+								},
+							},
+						},
+					)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
 			When("Validating the total IP addresses contained in the controller Subnet", func() {
 				BeforeEach(func() {
 					ipPoolWebhook.EnableSpiderSubnet = true
@@ -1223,6 +1425,42 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 					Expect(apierrors.IsInvalid(err)).To(BeTrue())
 					Expect(warns).To(BeNil())
 				})
+
+				It("uses the IP addresses granted to a namespace", func() {
+					subnetT.SetUID(uuid.NewUUID())
+					subnetT.Spec.IPVersion = ptr.To(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = []string{"172.18.40.1-172.18.40.20"}
+					subnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+						{Namespace: "tenant-a", IPs: []string{"172.18.40.10-172.18.40.20"}},
+					}
+
+					err := tracker.Add(subnetT)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = controllerutil.SetControllerReference(subnetT, ipPoolT, scheme)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = []string{"172.18.40.5-172.18.40.10"}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("granted to namespace tenant-a"))
+					Expect(warns).To(BeNil())
+
+					ipPoolT.Labels = map[string]string{constant.LabelIPPoolOwnerTenantNamespace: "tenant-a"}
+					warns, err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("not granted to namespace tenant-a"))
+					Expect(warns).To(BeNil())
+
+					ipPoolT.Spec.IPs = []string{"172.18.40.10-172.18.40.15"}
+					warns, err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
 			})
 
 			When("Validating 'spec.podAffinity'", func() {
@@ -1513,6 +1751,41 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				})
 			})
 
+			When("Validating 'spec.secondarySubnets'", func() {
+				It("changes the CIDRs of 'spec.secondarySubnets'", func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.SecondarySubnets = append(
+						ipPoolT.Spec.SecondarySubnets,
+						spiderpoolv2beta1.SecondarySubnet{Subnet: "172.18.41.0/24"},
+					)
+
+					newIPPoolT := ipPoolT.DeepCopy()
+					newIPPoolT.Spec.SecondarySubnets[0].Subnet = "172.18.42.0/24"
+
+					warns, err := ipPoolWebhook.ValidateUpdate(ctx, ipPoolT, newIPPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.prefixLength'", func() {
+				It("changes 'spec.prefixLength'", func() {
+					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.0-172.18.40.255")
+					ipPoolT.Spec.PrefixLength = ptr.To(int32(30))
+
+					newIPPoolT := ipPoolT.DeepCopy()
+					newIPPoolT.Spec.PrefixLength = ptr.To(int32(29))
+
+					warns, err := ipPoolWebhook.ValidateUpdate(ctx, ipPoolT, newIPPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
 			When("Validating 'spec.default'", func() {
 				It("set default IPv4 IPPool", func() {
 					ipPoolT.Spec.IPVersion = ptr.To(constant.IPv4)
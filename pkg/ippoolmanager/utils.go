@@ -4,6 +4,9 @@
 package ippoolmanager
 
 import (
+	"fmt"
+	"math"
+	"net"
 	"sort"
 	"strings"
 
@@ -11,8 +14,10 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
 )
 
 func IsAutoCreatedIPPool(pool *spiderpoolv2beta1.SpiderIPPool) bool {
@@ -22,6 +27,128 @@ func IsAutoCreatedIPPool(pool *spiderpoolv2beta1.SpiderIPPool) bool {
 	return ok
 }
 
+// AutoPoolOwnerSubnet returns the SpiderSubnet which controls the auto-created
+// IPPool, it's empty for the other IPPools, even though they are labeled with
+// the SpiderSubnet whose CIDR is the same.
+func AutoPoolOwnerSubnet(pool *spiderpoolv2beta1.SpiderIPPool) string {
+	if !IsAutoCreatedIPPool(pool) {
+		return ""
+	}
+
+	owner := metav1.GetControllerOf(pool)
+	if owner == nil || owner.Kind != constant.KindSpiderSubnet {
+		return ""
+	}
+
+	return owner.Name
+}
+
+// SubnetCIDRs returns 'spec.subnet' followed by the CIDRs of 'spec.secondarySubnets'.
+func SubnetCIDRs(subnet string, secondarySubnets []spiderpoolv2beta1.SecondarySubnet) []string {
+	cidrs := []string{subnet}
+	for _, s := range secondarySubnets {
+		cidrs = append(cidrs, s.Subnet)
+	}
+
+	return cidrs
+}
+
+// SubnetTenantIPs returns the IP addresses of the Subnet granted to the
+// namespace, and whether the namespace is a tenant of the Subnet.
+func SubnetTenantIPs(subnet *spiderpoolv2beta1.SpiderSubnet, namespace string) ([]net.IP, bool, error) {
+	for _, tenant := range subnet.Spec.Tenants {
+		if tenant.Namespace != namespace {
+			continue
+		}
+
+		ips, err := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, tenant.IPs)
+		if err != nil {
+			return nil, true, err
+		}
+
+		return ips, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// GenSubnetIPCounts counts the total and allocated IP addresses of each CIDR.
+func GenSubnetIPCounts(cidrs []string, totalIPs, allocatedIPs []net.IP) []spiderpoolv2beta1.SubnetIPCount {
+	counts := make([]spiderpoolv2beta1.SubnetIPCount, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+
+		count := spiderpoolv2beta1.SubnetIPCount{Subnet: cidr}
+		for _, ip := range totalIPs {
+			if ipNet.Contains(ip) {
+				count.TotalIPCount++
+			}
+		}
+		for _, ip := range allocatedIPs {
+			if ipNet.Contains(ip) {
+				count.AllocatedIPCount++
+			}
+		}
+		counts = append(counts, count)
+	}
+
+	return counts
+}
+
+// allocatedIPCount counts the IP addresses of the allocation records, where a
+// delegated prefix counts all IP addresses of it.
+func allocatedIPCount(records spiderpoolv2beta1.PoolIPAllocations) int64 {
+	var count int64
+	for _, record := range records {
+		size := int64(1)
+		if record.Prefix != "" {
+			if _, ipNet, err := net.ParseCIDR(record.Prefix); err == nil {
+				ones, bits := ipNet.Mask.Size()
+				size = math.MaxInt64
+				if bits-ones < 63 {
+					size = 1 << (bits - ones)
+				}
+			}
+		}
+
+		if count > math.MaxInt64-size {
+			return math.MaxInt64
+		}
+		count += size
+	}
+
+	return count
+}
+
+// SortIPsByCIDRs sorts the IP addresses by the sequence of the CIDRs they
+// pertain to, the original order is kept within the same CIDR.
+func SortIPsByCIDRs(ips []net.IP, cidrs []string) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+
+	index := func(ip net.IP) int {
+		for i, ipNet := range ipNets {
+			if ipNet.Contains(ip) {
+				return i
+			}
+		}
+		return len(ipNets)
+	}
+
+	sort.SliceStable(ips, func(i, j int) bool {
+		return index(ips[i]) < index(ips[j])
+	})
+}
+
 func NewAutoPoolPodAffinity(podTopController types.PodTopController) *metav1.LabelSelector {
 	var group, version string
 
@@ -137,6 +264,109 @@ func findAllocatedIPFromRecords(allocatedRecords spiderpoolv2beta1.PoolIPAllocat
 	return "", false
 }
 
+// NamespaceIPQuota returns the maximum number of IP addresses allocated from
+// the IPPool to the Pods of the namespace, and whether there is a limit.
+func NamespaceIPQuota(ipPool *spiderpoolv2beta1.SpiderIPPool, namespace string) (int64, bool) {
+	return namespaceIPQuota(ipPool.Spec.NamespaceQuota, namespace)
+}
+
+func namespaceIPQuota(quota *spiderpoolv2beta1.IPPoolNamespaceQuota, namespace string) (int64, bool) {
+	if quota == nil {
+		return 0, false
+	}
+
+	for _, q := range quota.Namespaces {
+		if q.Namespace == namespace {
+			return q.MaxIPs, true
+		}
+	}
+	if quota.Default != nil {
+		return *quota.Default, true
+	}
+
+	return 0, false
+}
+
+// CheckNamespaceIPQuota returns an error wrapping constant.ErrIPQuotaExceeded
+// if the Pods of the namespace have used up the quota of the IPPool. The IP
+// allocations recorded for namespacedName are not counted, they are reused
+// by the Pod with the same name, such as the Pod of StatefulSet.
+func CheckNamespaceIPQuota(ipPool *spiderpoolv2beta1.SpiderIPPool, namespace, namespacedName string) error {
+	if _, ok := NamespaceIPQuota(ipPool, namespace); !ok {
+		return nil
+	}
+
+	allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+	if err != nil {
+		return err
+	}
+
+	return checkNamespaceIPQuota(ipPool, allocatedRecords, namespace, namespacedName)
+}
+
+func checkNamespaceIPQuota(ipPool *spiderpoolv2beta1.SpiderIPPool, allocatedRecords spiderpoolv2beta1.PoolIPAllocations, namespace, namespacedName string) error {
+	maxIPs, ok := NamespaceIPQuota(ipPool, namespace)
+	if !ok {
+		return nil
+	}
+
+	count := countNamespaceIPs(allocatedRecords, namespace, namespacedName)
+	if count >= maxIPs {
+		return fmt.Errorf("%w, namespace %s has used %d of %d IP addresses allowed in IPPool %s", constant.ErrIPQuotaExceeded, namespace, count, maxIPs, ipPool.Name)
+	}
+
+	return nil
+}
+
+// CheckSubnetNamespaceIPQuota returns an error wrapping
+// constant.ErrIPQuotaExceeded if the Pods of the namespace have used up the
+// quota of the Subnet, whose IP addresses are counted across ipPools, all the
+// IPPools of the Subnet. ipPool, the one to allocate from, takes precedence
+// over the one with the same name in ipPools as it's fresher.
+func CheckSubnetNamespaceIPQuota(subnet *spiderpoolv2beta1.SpiderSubnet, ipPools []spiderpoolv2beta1.SpiderIPPool, ipPool *spiderpoolv2beta1.SpiderIPPool, namespace, namespacedName string) error {
+	maxIPs, ok := namespaceIPQuota(subnet.Spec.NamespaceQuota, namespace)
+	if !ok {
+		return nil
+	}
+
+	var count int64
+	pools := []*spiderpoolv2beta1.SpiderIPPool{ipPool}
+	for i := range ipPools {
+		if ipPools[i].Name != ipPool.Name {
+			pools = append(pools, &ipPools[i])
+		}
+	}
+	for _, pool := range pools {
+		allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			return err
+		}
+		count += countNamespaceIPs(allocatedRecords, namespace, namespacedName)
+	}
+
+	if count >= maxIPs {
+		return fmt.Errorf("%w, namespace %s has used %d of %d IP addresses allowed in Subnet %s", constant.ErrIPQuotaExceeded, namespace, count, maxIPs, subnet.Name)
+	}
+
+	return nil
+}
+
+// countNamespaceIPs counts the IP addresses allocated to the Pods of the
+// namespace except namespacedName.
+func countNamespaceIPs(allocatedRecords spiderpoolv2beta1.PoolIPAllocations, namespace, namespacedName string) int64 {
+	var count int64
+	for _, record := range allocatedRecords {
+		if record.NamespacedName == namespacedName {
+			continue
+		}
+		if strings.HasPrefix(record.NamespacedName, namespace+"/") {
+			count++
+		}
+	}
+
+	return count
+}
+
 // HasWildcardInStr checks whether the wildcard '*', '?', '[]' exists in the given string variable
 func HasWildcardInStr(str string) bool {
 	switch {
@@ -159,3 +389,17 @@ func HasWildcardInSlice(arr []string) bool {
 	}
 	return false
 }
+
+// ipRangesContainIP reports whether the IP address pertains to any of the
+// IP ranges.
+func ipRangesContainIP(ipRanges []string, ip net.IP) bool {
+	for _, r := range ipRanges {
+		arr := strings.Split(r, "-")
+		start, end := net.ParseIP(arr[0]), net.ParseIP(arr[len(arr)-1])
+		if spiderpoolip.Cmp(ip, start) >= 0 && spiderpoolip.Cmp(ip, end) <= 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@
 package ippoolmanager
 
 import (
+	"net"
 	"sort"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,10 +13,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types2 "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
 )
 
 var _ = Describe("IPPoolManager-utils", Label("ippool_manager_utils"), func() {
@@ -38,6 +41,39 @@ var _ = Describe("IPPoolManager-utils", Label("ippool_manager_utils"), func() {
 		})
 	})
 
+	Context("AutoPoolOwnerSubnet", Labels{"unittest", "AutoPoolOwnerSubnet"}, func() {
+		var pool *spiderpoolv2beta1.SpiderIPPool
+
+		BeforeEach(func() {
+			pool = &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: "label-subnet"},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: spiderpoolv2beta1.SchemeGroupVersion.String(),
+						Kind:       constant.KindSpiderSubnet,
+						Name:       "owner-subnet",
+						Controller: ptr.To(true),
+					}},
+				},
+			}
+		})
+
+		It("returns the controller SpiderSubnet of auto-created IPPool", func() {
+			pool.Labels[constant.LabelIPPoolOwnerApplicationName] = "test-name"
+			Expect(AutoPoolOwnerSubnet(pool)).To(Equal("owner-subnet"))
+		})
+
+		It("returns empty for the IPPool created manually", func() {
+			Expect(AutoPoolOwnerSubnet(pool)).To(BeEmpty())
+		})
+
+		It("returns empty for the auto-created IPPool without controller SpiderSubnet", func() {
+			pool.Labels[constant.LabelIPPoolOwnerApplicationName] = "test-name"
+			pool.OwnerReferences = nil
+			Expect(AutoPoolOwnerSubnet(pool)).To(BeEmpty())
+		})
+	})
+
 	Context("Test Auto IPPool PodAffinity", Labels{"unittest", "AutoPool-PodAffinity"}, func() {
 		It("match auto-created IPPool affinity", func() {
 			podTopController := types.PodTopController{
@@ -329,6 +365,107 @@ var _ = Describe("IPPoolManager-utils", Label("ippool_manager_utils"), func() {
 		})
 	})
 
+	Context("Test namespace IP quota", Labels{"unittest", "NamespaceIPQuota"}, func() {
+		var ipPool *spiderpoolv2beta1.SpiderIPPool
+
+		BeforeEach(func() {
+			records := spiderpoolv2beta1.PoolIPAllocations{
+				"172.18.40.40": spiderpoolv2beta1.PoolIPAllocation{NamespacedName: "ns1/pod1"},
+				"172.18.40.41": spiderpoolv2beta1.PoolIPAllocation{NamespacedName: "ns1/pod2"},
+				"172.18.40.42": spiderpoolv2beta1.PoolIPAllocation{NamespacedName: "ns10/pod1"},
+			}
+			data, err := convert.MarshalIPPoolAllocatedIPs(records)
+			Expect(err).NotTo(HaveOccurred())
+
+			ipPool = &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec: spiderpoolv2beta1.IPPoolSpec{
+					NamespaceQuota: &spiderpoolv2beta1.IPPoolNamespaceQuota{
+						Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+							{Namespace: "ns1", MaxIPs: 2},
+						},
+					},
+				},
+				Status: spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: data},
+			}
+		})
+
+		It("gets the quota of namespaces", func() {
+			maxIPs, ok := NamespaceIPQuota(ipPool, "ns1")
+			Expect(ok).To(BeTrue())
+			Expect(maxIPs).To(Equal(int64(2)))
+
+			_, ok = NamespaceIPQuota(ipPool, "ns2")
+			Expect(ok).To(BeFalse())
+
+			ipPool.Spec.NamespaceQuota.Default = ptr.To(int64(5))
+			maxIPs, ok = NamespaceIPQuota(ipPool, "ns2")
+			Expect(ok).To(BeTrue())
+			Expect(maxIPs).To(Equal(int64(5)))
+
+			ipPool.Spec.NamespaceQuota = nil
+			_, ok = NamespaceIPQuota(ipPool, "ns1")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("checks the quota of namespaces", func() {
+			err := CheckNamespaceIPQuota(ipPool, "ns1", "ns1/pod3")
+			Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+
+			err = CheckNamespaceIPQuota(ipPool, "ns1", "ns1/pod2")
+			Expect(err).NotTo(HaveOccurred())
+
+			ipPool.Spec.NamespaceQuota.Default = ptr.To(int64(1))
+			err = CheckNamespaceIPQuota(ipPool, "ns10", "ns10/pod2")
+			Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+
+			err = CheckNamespaceIPQuota(ipPool, "ns2", "ns2/pod1")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails to check the quota with broken records", func() {
+			ipPool.Status.AllocatedIPs = ptr.To("broken")
+			err := CheckNamespaceIPQuota(ipPool, "ns1", "ns1/pod3")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("checks the quota of namespaces across the IPPools of Subnet", func() {
+			subnet := &spiderpoolv2beta1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+				Spec: spiderpoolv2beta1.SubnetSpec{
+					NamespaceQuota: &spiderpoolv2beta1.IPPoolNamespaceQuota{
+						Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+							{Namespace: "ns1", MaxIPs: 3},
+						},
+					},
+				},
+			}
+			data, err := convert.MarshalIPPoolAllocatedIPs(spiderpoolv2beta1.PoolIPAllocations{
+				"172.18.40.50": spiderpoolv2beta1.PoolIPAllocation{NamespacedName: "ns1/pod4"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			otherPool := spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-pool"},
+				Status:     spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: data},
+			}
+			// the stale copy of the IPPool to allocate from is ignored
+			stalePool := spiderpoolv2beta1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			ipPools := []spiderpoolv2beta1.SpiderIPPool{stalePool, otherPool}
+
+			err = CheckSubnetNamespaceIPQuota(subnet, ipPools, ipPool, "ns1", "ns1/pod5")
+			Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+
+			err = CheckSubnetNamespaceIPQuota(subnet, ipPools, ipPool, "ns1", "ns1/pod4")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = CheckSubnetNamespaceIPQuota(subnet, ipPools[:1], ipPool, "ns1", "ns1/pod5")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = CheckSubnetNamespaceIPQuota(subnet, ipPools, ipPool, "ns10", "ns10/pod2")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Context("Test Wildcard", func() {
 		It("For single string variable", func() {
 			hasWildcardInStr := HasWildcardInStr("pool")
@@ -352,4 +489,25 @@ var _ = Describe("IPPoolManager-utils", Label("ippool_manager_utils"), func() {
 			Expect(hasWildcardInSlice).To(BeTrue())
 		})
 	})
+
+	Context("Test secondary subnets", Labels{"unittest", "SecondarySubnets"}, func() {
+		cidrs := []string{"172.18.41.0/24", "172.18.40.0/24"}
+
+		It("sorts IPs by the sequence of CIDRs", func() {
+			ips := []net.IP{net.ParseIP("172.18.40.1"), net.ParseIP("172.18.41.2"), net.ParseIP("172.18.40.2"), net.ParseIP("172.18.41.1")}
+			SortIPsByCIDRs(ips, cidrs)
+			Expect(ips).To(Equal([]net.IP{net.ParseIP("172.18.41.2"), net.ParseIP("172.18.41.1"), net.ParseIP("172.18.40.1"), net.ParseIP("172.18.40.2")}))
+		})
+
+		It("counts IPs of each CIDR", func() {
+			totalIPs := []net.IP{net.ParseIP("172.18.40.1"), net.ParseIP("172.18.40.2"), net.ParseIP("172.18.41.1")}
+			allocatedIPs := []net.IP{net.ParseIP("172.18.40.2")}
+
+			counts := GenSubnetIPCounts(cidrs, totalIPs, allocatedIPs)
+			Expect(counts).To(Equal([]spiderpoolv2beta1.SubnetIPCount{
+				{Subnet: "172.18.41.0/24", TotalIPCount: 1, AllocatedIPCount: 0},
+				{Subnet: "172.18.40.0/24", TotalIPCount: 2, AllocatedIPCount: 1},
+			}))
+		})
+	})
 })
@@ -17,12 +17,28 @@ type CoordinatorSpec struct {
 	// +kubebuilder:default=auto
 	Mode *string `json:"mode,omitempty"`
 
+	// Datapath specifies how the coordinator forwards the traffic between the
+	// pod and the host, the configurable values include route (default) and
+	// ebpf. route installs the hijack routes in the pod and the routes of the
+	// pod IPs on the host, ebpf redirects the traffic by the TC programs
+	// attached to the pod's NIC and the host instead. veth0 and the policy
+	// rules are still installed with ebpf.
+	// +kubebuilder:validation:Enum=route;ebpf
+	// +kubebuilder:validation:Optional
+	Datapath *string `json:"datapath,omitempty"`
+
+	// DatapathFallback falls back to the route datapath if the ebpf datapath
+	// fails to set up, which is recorded as an Event of the Pod. The Pod fails
+	// to set up by default.
+	// +kubebuilder:validation:Optional
+	DatapathFallback *bool `json:"datapathFallback,omitempty"`
+
 	// CoordinatorSpec is used by SpiderCoordinator and SpiderMultusConfig
 	// in spidermultusconfig CRD , podCIDRType should not be required, which
 	// could be merged from SpiderCoordinator CR
 	// but in SpiderCoordinator CRD, podCIDRType should be required
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=auto;cluster;calico;cilium;none
+	// +kubebuilder:validation:Enum=auto;cluster;calico;cilium;flannel;antrea;ovn-kubernetes;kube-ovn;weave;none
 	PodCIDRType *string `json:"podCIDRType,omitempty"`
 
 	// HijackCIDR configure static routing tables in the pod that target these
@@ -96,6 +112,20 @@ type CoordinatorStatus struct {
 
 	// +kubebuilder:validation:Optional
 	ServiceCIDR []string `json:"serviceCIDR"`
+
+	// NodePodCIDRs is the pod CIDRs allocated to each node, which are fetched
+	// from the node spec.podCIDRs, the Calico IPAM blocks or the CiliumNodes.
+	// +kubebuilder:validation:Optional
+	NodePodCIDRs []NodePodCIDR `json:"nodePodCIDRs,omitempty"`
+}
+
+// NodePodCIDR is the pod CIDRs allocated to a node.
+type NodePodCIDR struct {
+	// +kubebuilder:validation:Required
+	NodeName string `json:"nodeName"`
+
+	// +kubebuilder:validation:Optional
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
 }
 
 // +kubebuilder:resource:categories={spiderpool},path="spidercoordinators",scope="Cluster",shortName={scc},singular="spidercoordinator"
@@ -46,6 +46,22 @@ type IPAllocationDetail struct {
 	// +kubebuilder:validation:Optional
 	IPv6Pool *string `json:"ipv6Pool,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// IPv4Subnet is the SpiderSubnet that the auto-created IPv4 IPPool belongs to.
+	IPv4Subnet *string `json:"ipv4Subnet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IPv6Subnet is the SpiderSubnet that the auto-created IPv6 IPPool belongs to.
+	IPv6Subnet *string `json:"ipv6Subnet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IPv4Prefix is the IPv4 prefix delegated to the interface.
+	IPv4Prefix *string `json:"ipv4Prefix,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IPv6Prefix is the IPv6 prefix delegated to the interface.
+	IPv6Prefix *string `json:"ipv6Prefix,omitempty"`
+
 	// +kubebuilder:default=0
 	// +kubebuilder:validation:Maximum=4094
 	// +kubebuilder:validation:Minimum=0
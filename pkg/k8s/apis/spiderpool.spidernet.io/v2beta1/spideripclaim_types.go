@@ -0,0 +1,72 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v2beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPClaimSpec defines the desired state of SpiderIPClaim.
+type IPClaimSpec struct {
+	// IPPool is the name of the SpiderIPPool which the IP addresses are claimed from.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	IPPool string `json:"ippool"`
+
+	// IPs are the IP addresses or IP ranges held for the owner.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	IPs []string `json:"ips"`
+
+	// Owner is the Pod, controller or VM in the namespace of the SpiderIPClaim
+	// which the IP addresses are held for, it does not need to exist yet.
+	// +kubebuilder:validation:Required
+	Owner IPClaimOwner `json:"owner"`
+
+	// TTL is how long the IP addresses are held after the SpiderIPClaim is
+	// created, the expired SpiderIPClaim is deleted. Never expire if it's empty.
+	// +kubebuilder:validation:Optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// IPClaimOwner is the owner of the IP addresses of SpiderIPClaim.
+type IPClaimOwner struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Pod;Deployment;StatefulSet;DaemonSet;ReplicaSet;Job;CronJob;VirtualMachine
+	Kind string `json:"kind"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spideripclaims",scope="Namespaced",shortName={sic},singular="spideripclaim"
+// +kubebuilder:printcolumn:JSONPath=".spec.ippool",description="ippool",name="IPPOOL",type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.owner.kind",description="ownerKind",name="OWNER-KIND",type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.owner.name",description="ownerName",name="OWNER",type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.ttl",description="ttl",name="TTL",type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+
+// SpiderIPClaim is the Schema for the spideripclaims API.
+type SpiderIPClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IPClaimSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderIPClaimList contains a list of SpiderIPClaim.
+type SpiderIPClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderIPClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderIPClaim{}, &SpiderIPClaimList{})
+}
@@ -28,6 +28,33 @@ type IPPoolSpec struct {
 	// +kubebuilder:validation:Optional
 	Routes []Route `json:"routes,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	SecondarySubnets []SecondarySubnet `json:"secondarySubnets,omitempty"`
+
+	// PrefixLength turns the IPPool into prefix delegation mode, every allocation
+	// takes an aligned prefix of this length from 'spec.ips' and the Pod uses the
+	// first IP address of it, or the second one for IPv6 since the first one is
+	// the Subnet-Router anycast address.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	// +kubebuilder:validation:Optional
+	PrefixLength *int32 `json:"prefixLength,omitempty"`
+
+	// IPv6AddressMode decides how the IPv6 addresses are picked. 'eui64' derives
+	// them from the Pod MAC address controlled by 'spec.podMACPrefix' of the
+	// SpiderCoordinator, 'stable' derives them from a stable hash of the Pod,
+	// both fall back to 'random' on conflict.
+	// +kubebuilder:validation:Enum=random;eui64;stable
+	// +kubebuilder:validation:Optional
+	IPv6AddressMode *string `json:"ipv6AddressMode,omitempty"`
+
+	// Weight is the relative chance to pick the IPPool among the candidates
+	// of the same priority with the "weighted" selection policy. The IPPool
+	// with weight 0 is only tried after the others. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Weight *int32 `json:"weight,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	PodAffinity *metav1.LabelSelector `json:"podAffinity,omitempty"`
 
@@ -37,6 +64,11 @@ type IPPoolSpec struct {
 	// +kubebuilder:validation:Optional
 	NamespaceName []string `json:"namespaceName,omitempty"`
 
+	// NamespaceQuota caps the IP addresses allocated from the IPPool to the
+	// Pods of a namespace.
+	// +kubebuilder:validation:Optional
+	NamespaceQuota *IPPoolNamespaceQuota `json:"namespaceQuota,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	NodeAffinity *metav1.LabelSelector `json:"nodeAffinity,omitempty"`
 
@@ -55,6 +87,27 @@ type IPPoolSpec struct {
 	Disable *bool `json:"disable,omitempty"`
 }
 
+type IPPoolNamespaceQuota struct {
+	// Default is the maximum number of IP addresses allocated to the Pods of
+	// a namespace not listed in 'namespaces'. No limit if it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Default *int64 `json:"default,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Namespaces []NamespaceIPQuota `json:"namespaces,omitempty"`
+}
+
+type NamespaceIPQuota struct {
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Required
+	MaxIPs int64 `json:"maxIPs"`
+}
+
 type Route struct {
 	// +kubebuilder:validation:Required
 	Dst string `json:"dst"`
@@ -63,6 +116,33 @@ type Route struct {
 	Gw string `json:"gw"`
 }
 
+// SecondarySubnet describes an additional CIDR in the same L2 segment as 'spec.subnet',
+// the IP addresses of 'spec.ips' could pertain to it and take its own gateway and routes.
+type SecondarySubnet struct {
+	// +kubebuilder:validation:Required
+	Subnet string `json:"subnet"`
+
+	// +kubebuilder:validation:Optional
+	Gateway *string `json:"gateway,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// SubnetIPCount records the IP counts of one CIDR.
+type SubnetIPCount struct {
+	// +kubebuilder:validation:Required
+	Subnet string `json:"subnet"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Required
+	TotalIPCount int64 `json:"totalIPCount"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Required
+	AllocatedIPCount int64 `json:"allocatedIPCount"`
+}
+
 // IPPoolStatus defines the observed state of SpiderIPPool.
 type IPPoolStatus struct {
 	// +kubebuilder:validation:Optional
@@ -75,6 +155,11 @@ type IPPoolStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+
+	// SubnetIPCounts records the IP counts of 'spec.subnet' and every secondary subnet,
+	// it's only set when the IPPool has secondary subnets.
+	// +kubebuilder:validation:Optional
+	SubnetIPCounts []SubnetIPCount `json:"subnetIPCounts,omitempty"`
 }
 
 // PoolIPAllocations is a map of IP allocation details indexed by IP address.
@@ -83,6 +168,7 @@ type PoolIPAllocations map[string]PoolIPAllocation
 type PoolIPAllocation struct {
 	NamespacedName string `json:"pod"`
 	PodUID         string `json:"podUid"`
+	Prefix         string `json:"prefix,omitempty"`
 }
 
 // +kubebuilder:resource:categories={spiderpool},path="spiderippools",scope="Cluster",shortName={sp},singular="spiderippool"
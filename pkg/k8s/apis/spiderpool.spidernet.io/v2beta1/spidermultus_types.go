@@ -29,7 +29,7 @@ type SpiderMultusConfigList struct {
 // MultusCNIConfigSpec defines the desired state of SpiderMultusConfig.
 type MultusCNIConfigSpec struct {
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=macvlan;ipvlan;vlan;sriov;ovs;ib-sriov;ipoib;custom
+	// +kubebuilder:validation:Enum=macvlan;ipvlan;vlan;sriov;ovs;ib-sriov;ipoib;bridge;host-device;ovn-k8s;custom
 	// +kubebuilder:default=custom
 	CniType *string `json:"cniType,omitempty"`
 
@@ -54,6 +54,15 @@ type MultusCNIConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	IpoibConfig *SpiderIpoibCniConfig `json:"ipoib,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	BridgeConfig *SpiderBridgeCniConfig `json:"bridge,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	HostDeviceConfig *SpiderHostDeviceCniConfig `json:"hostDevice,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	OvnK8sConfig *SpiderOvnK8sCniConfig `json:"ovnK8s,omitempty"`
+
 	// if CniType was set to custom, we'll mutate this field to be false
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
@@ -66,6 +75,11 @@ type MultusCNIConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	CoordinatorConfig *CoordinatorSpec `json:"coordinator,omitempty"`
 
+	// Bandwidth shapes and marks the traffic of the macvlan, ipvlan, ovs, bridge
+	// and host-device interfaces, which is done by coordinator in the pod.
+	// +kubebuilder:validation:Optional
+	Bandwidth *BandwidthConfig `json:"bandwidth,omitempty"`
+
 	// ChainCNIJsonData is used to configure the configuration of chain CNI.
 	// format in json.
 	// +kubebuilder:validation:Optional
@@ -280,6 +294,102 @@ type SpiderOvsCniConfig struct {
 	SpiderpoolConfigPools *SpiderpoolPools `json:"ippools,omitempty"`
 }
 
+type SpiderBridgeCniConfig struct {
+	// +kubebuilder:validation:Required
+	// name of the linux bridge on the host, it's created if it doesn't exist.
+	BrName string `json:"bridge"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4094
+	// the VLAN ID of the pod's port, the VLAN filtering of the bridge is enabled
+	// if it's not 0 or the vlanTrunk is set.
+	VlanID *int32 `json:"vlanID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// the VLAN IDs allowed on the pod's port, which is a trunk port, it can't
+	// be set together with a non-zero vlanID.
+	VlanTrunk []*Trunk `json:"vlanTrunk,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// set hairpin mode for the pod's port on the bridge.
+	HairpinMode *bool `json:"hairpinMode,omitempty"`
+
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// explicitly set MTU to the specified value. Defaults('0' or no value provided) to the value chosen by the kernel.
+	MTU *int32 `json:"mtu,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	SpiderpoolConfigPools *SpiderpoolPools `json:"ippools,omitempty"`
+}
+
+// SpiderHostDeviceCniConfig moves a device of the host into the pod, the device
+// is identified by exactly one of the device, hwaddr, kernelpath and pciBusID.
+type SpiderHostDeviceCniConfig struct {
+	// +kubebuilder:validation:Optional
+	// name of the host interface.
+	Device string `json:"device,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MAC address of the host interface.
+	HWAddr string `json:"hwaddr,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// kernel device path of the host interface, e.g. /sys/devices/pci0000:00/0000:00:1f.6
+	KernelPath string `json:"kernelpath,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PCI address of the device in valid sysfs format, e.g. 0000:00:1f.6
+	PCIBusID string `json:"pciBusID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	SpiderpoolConfigPools *SpiderpoolPools `json:"ippools,omitempty"`
+}
+
+// SpiderOvnK8sCniConfig is the secondary network of OVN-Kubernetes. The IPs of
+// the network are allocated by OVN-Kubernetes from the subnets, it doesn't use
+// the IPAM plugin, so the network has no IP if the subnets are not set.
+type SpiderOvnK8sCniConfig struct {
+	// +kubebuilder:validation:Optional
+	// name of the OVN network, the NetworkAttachmentDefinitions with the same
+	// networkName attach to the same network. Defaults to the name of the
+	// NetworkAttachmentDefinition.
+	NetworkName string `json:"networkName,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=layer2;localnet
+	// layer2 is a switch spanning all nodes, localnet connects the switch to
+	// the physical network of the nodes.
+	Topology string `json:"topology"`
+
+	// +kubebuilder:validation:Optional
+	// the subnets in CIDR format, OVN-Kubernetes allocates the IPs from them.
+	Subnets []string `json:"subnets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// the CIDRs in the subnets which are not allocated.
+	ExcludeSubnets []string `json:"excludeSubnets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4094
+	// the VLAN ID of the physical network, only for localnet.
+	VlanID *int32 `json:"vlanID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// the name of the physical network in the bridge mappings of OVS, only for
+	// localnet. Defaults to the networkName.
+	PhysicalNetworkName string `json:"physicalNetworkName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// explicitly set MTU to the specified value. Defaults('0' or no value provided) to the value chosen by OVN-Kubernetes.
+	MTU *int32 `json:"mtu,omitempty"`
+}
+
 type Trunk struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Minimum=0
@@ -308,6 +418,43 @@ type BondConfig struct {
 	Options *string `json:"options,omitempty"`
 }
 
+// BandwidthConfig is the rates and bursts of the traffic of the interface, like
+// the bandwidth CNI plugin. It can be overridden by the "bandwidth" of the pod
+// in the Multus network annotation.
+type BandwidthConfig struct {
+	// the rate in bits per second of the traffic received by the interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	IngressRate *int64 `json:"ingressRate,omitempty"`
+
+	// the burst in bits of the traffic received by the interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	IngressBurst *int64 `json:"ingressBurst,omitempty"`
+
+	// the rate in bits per second of the traffic sent by the interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	EgressRate *int64 `json:"egressRate,omitempty"`
+
+	// the burst in bits of the traffic sent by the interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	EgressBurst *int64 `json:"egressBurst,omitempty"`
+
+	// the DSCP marked to the IPv4 and IPv6 traffic sent by the interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=63
+	DSCP *int32 `json:"dscp,omitempty"`
+
+	// the skb priority set to the traffic sent by the interface, in the form
+	// "major:minor" of the tc class ID in hex, like "1:10"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{1,4}:[0-9a-fA-F]{1,4}$`
+	Priority *string `json:"priority,omitempty"`
+}
+
 // SpiderpoolPools could specify the IPAM spiderpool CNI configuration default IPv4&IPv6 pools.
 type SpiderpoolPools struct {
 	// enable IPAM to check if the IPPools of the pod if matched the master subnet
@@ -27,6 +27,30 @@ type SubnetSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Routes []Route `json:"routes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	SecondarySubnets []SecondarySubnet `json:"secondarySubnets,omitempty"`
+
+	// Tenants grant slices of the Subnet to namespaces, the tenants of a
+	// namespace create their SpiderTenantIPPools from its slice.
+	// +kubebuilder:validation:Optional
+	Tenants []SubnetTenant `json:"tenants,omitempty"`
+
+	// NamespaceQuota caps the IP addresses allocated from all the IPPools of
+	// the Subnet to the Pods of a namespace.
+	// +kubebuilder:validation:Optional
+	NamespaceQuota *IPPoolNamespaceQuota `json:"namespaceQuota,omitempty"`
+}
+
+// SubnetTenant is a slice of the Subnet granted to a namespace.
+type SubnetTenant struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	IPs []string `json:"ips"`
 }
 
 // SubnetStatus defines the observed state of SpiderSubnet.
@@ -41,6 +65,11 @@ type SubnetStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+
+	// SubnetIPCounts records the IP counts of 'spec.subnet' and every secondary subnet,
+	// it's only set when the Subnet has secondary subnets.
+	// +kubebuilder:validation:Optional
+	SubnetIPCounts []SubnetIPCount `json:"subnetIPCounts,omitempty"`
 }
 
 // PoolIPPreAllocations is a map of pool IP pre-allocation details indexed by pool name.
@@ -0,0 +1,71 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v2beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantIPPoolSpec defines the desired state of SpiderTenantIPPool.
+type TenantIPPoolSpec struct {
+	// Subnet is the name of the SpiderSubnet which grants IP addresses to
+	// the namespace of the SpiderTenantIPPool.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Subnet string `json:"subnet"`
+
+	// IPs are the IP addresses or IP ranges of the pool, they must be in the
+	// slice of the Subnet granted to the namespace.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	IPs []string `json:"ips"`
+}
+
+// TenantIPPoolStatus defines the observed state of SpiderTenantIPPool.
+type TenantIPPoolStatus struct {
+	// IPPool is the name of the SpiderIPPool created for the SpiderTenantIPPool,
+	// Pods in the namespace use it as the other SpiderIPPools.
+	// +kubebuilder:validation:Optional
+	IPPool string `json:"ippool,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	TotalIPCount *int64 `json:"totalIPCount,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spidertenantippools",scope="Namespaced",shortName={stp},singular="spidertenantippool"
+// +kubebuilder:printcolumn:JSONPath=".spec.subnet",description="subnet",name="SUBNET",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.ippool",description="ippool",name="IPPOOL",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.allocatedIPCount",description="allocatedIPCount",name="ALLOCATED-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.totalIPCount",description="totalIPCount",name="TOTAL-IP-COUNT",type=integer
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +genclient
+
+// SpiderTenantIPPool is the Schema for the spidertenantippools API.
+type SpiderTenantIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantIPPoolSpec   `json:"spec,omitempty"`
+	Status TenantIPPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderTenantIPPoolList contains a list of SpiderTenantIPPool.
+type SpiderTenantIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderTenantIPPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderTenantIPPool{}, &SpiderTenantIPPoolList{})
+}
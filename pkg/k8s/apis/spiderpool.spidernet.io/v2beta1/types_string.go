@@ -40,6 +40,9 @@ func (in *IPPoolSpec) String() string {
 		`ExcludeIPs:` + fmt.Sprintf("%v", in.ExcludeIPs) + `,`,
 		`Gateway:` + stringutil.ValueToStringGenerated(in.Gateway) + `,`,
 		`Routes:` + fmt.Sprintf("%+v", in.Routes) + `,`,
+		`SecondarySubnets:` + fmt.Sprintf("%+v", in.SecondarySubnets) + `,`,
+		`PrefixLength:` + stringutil.ValueToStringGenerated(in.PrefixLength) + `,`,
+		`IPv6AddressMode:` + stringutil.ValueToStringGenerated(in.IPv6AddressMode) + `,`,
 		`PodAffinity:` + fmt.Sprintf("%v", in.PodAffinity.String()) + `,`,
 		`NamespaceAffinity:` + fmt.Sprintf("%v", in.NamespaceAffinity.String()) + `,`,
 		`NamespaceName:` + fmt.Sprintf("%v", in.NamespaceName) + `,`,
@@ -64,6 +67,7 @@ func (in *IPPoolStatus) String() string {
 		`AllocatedIPs:` + stringutil.ValueToStringGenerated(in.AllocatedIPs) + `,`,
 		`TotalIPCount:` + stringutil.ValueToStringGenerated(in.TotalIPCount) + `,`,
 		`AllocatedIPCount:` + stringutil.ValueToStringGenerated(in.AllocatedIPCount) + `,`,
+		`SubnetIPCounts:` + fmt.Sprintf("%+v", in.SubnetIPCounts) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -135,6 +139,10 @@ func (in *IPAllocationDetail) String() string {
 		`IPv6:` + stringutil.ValueToStringGenerated(in.IPv6) + `,`,
 		`IPv4Pool:` + stringutil.ValueToStringGenerated(in.IPv4Pool) + `,`,
 		`IPv6Pool:` + stringutil.ValueToStringGenerated(in.IPv6Pool) + `,`,
+		`IPv4Subnet:` + stringutil.ValueToStringGenerated(in.IPv4Subnet) + `,`,
+		`IPv6Subnet:` + stringutil.ValueToStringGenerated(in.IPv6Subnet) + `,`,
+		`IPv4Prefix:` + stringutil.ValueToStringGenerated(in.IPv4Prefix) + `,`,
+		`IPv6Prefix:` + stringutil.ValueToStringGenerated(in.IPv6Prefix) + `,`,
 		`Vlan:` + stringutil.ValueToStringGenerated(in.Vlan) + `,`,
 		`IPv4Gateway:` + stringutil.ValueToStringGenerated(in.IPv4Gateway) + `,`,
 		`IPv6Gateway:` + stringutil.ValueToStringGenerated(in.IPv6Gateway) + `,`,
@@ -205,6 +213,7 @@ func (in *SubnetSpec) String() string {
 		`ExcludeIPs:` + fmt.Sprintf("%v", in.ExcludeIPs) + `,`,
 		`Gateway:` + stringutil.ValueToStringGenerated(in.Gateway) + `,`,
 		`Routes:` + fmt.Sprintf("%+v", in.Routes) + `,`,
+		`SecondarySubnets:` + fmt.Sprintf("%+v", in.SecondarySubnets) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -221,6 +230,7 @@ func (in *SubnetStatus) String() string {
 		`ControlledIPPools:` + stringutil.ValueToStringGenerated(in.ControlledIPPools) + `,`,
 		`TotalIPCount:` + stringutil.ValueToStringGenerated(in.TotalIPCount) + `,`,
 		`AllocatedIPCount:` + stringutil.ValueToStringGenerated(in.AllocatedIPCount) + `,`,
+		`SubnetIPCounts:` + fmt.Sprintf("%+v", in.SubnetIPCounts) + `,`,
 		`}`,
 	}, "")
 	return s
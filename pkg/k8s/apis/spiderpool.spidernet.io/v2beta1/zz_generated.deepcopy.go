@@ -13,6 +13,51 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthConfig) DeepCopyInto(out *BandwidthConfig) {
+	*out = *in
+	if in.IngressRate != nil {
+		in, out := &in.IngressRate, &out.IngressRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IngressBurst != nil {
+		in, out := &in.IngressBurst, &out.IngressBurst
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressRate != nil {
+		in, out := &in.EgressRate, &out.EgressRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressBurst != nil {
+		in, out := &in.EgressBurst, &out.EgressBurst
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthConfig.
+func (in *BandwidthConfig) DeepCopy() *BandwidthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BondConfig) DeepCopyInto(out *BondConfig) {
 	*out = *in
@@ -41,6 +86,16 @@ func (in *CoordinatorSpec) DeepCopyInto(out *CoordinatorSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Datapath != nil {
+		in, out := &in.Datapath, &out.Datapath
+		*out = new(string)
+		**out = **in
+	}
+	if in.DatapathFallback != nil {
+		in, out := &in.DatapathFallback, &out.DatapathFallback
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PodCIDRType != nil {
 		in, out := &in.PodCIDRType, &out.PodCIDRType
 		*out = new(string)
@@ -121,6 +176,13 @@ func (in *CoordinatorStatus) DeepCopyInto(out *CoordinatorStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodePodCIDRs != nil {
+		in, out := &in.NodePodCIDRs, &out.NodePodCIDRs
+		*out = make([]NodePodCIDR, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorStatus.
@@ -156,6 +218,26 @@ func (in *IPAllocationDetail) DeepCopyInto(out *IPAllocationDetail) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IPv4Subnet != nil {
+		in, out := &in.IPv4Subnet, &out.IPv4Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6Subnet != nil {
+		in, out := &in.IPv6Subnet, &out.IPv6Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv4Prefix != nil {
+		in, out := &in.IPv4Prefix, &out.IPv4Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6Prefix != nil {
+		in, out := &in.IPv6Prefix, &out.IPv6Prefix
+		*out = new(string)
+		**out = **in
+	}
 	if in.Vlan != nil {
 		in, out := &in.Vlan, &out.Vlan
 		*out = new(int64)
@@ -198,6 +280,72 @@ func (in *IPAllocationDetail) DeepCopy() *IPAllocationDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPClaimOwner) DeepCopyInto(out *IPClaimOwner) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPClaimOwner.
+func (in *IPClaimOwner) DeepCopy() *IPClaimOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(IPClaimOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPClaimSpec) DeepCopyInto(out *IPClaimSpec) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Owner = in.Owner
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPClaimSpec.
+func (in *IPClaimSpec) DeepCopy() *IPClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolNamespaceQuota) DeepCopyInto(out *IPPoolNamespaceQuota) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceIPQuota, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolNamespaceQuota.
+func (in *IPPoolNamespaceQuota) DeepCopy() *IPPoolNamespaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolNamespaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 	*out = *in
@@ -226,6 +374,28 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = make([]Route, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecondarySubnets != nil {
+		in, out := &in.SecondarySubnets, &out.SecondarySubnets
+		*out = make([]SecondarySubnet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrefixLength != nil {
+		in, out := &in.PrefixLength, &out.PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPv6AddressMode != nil {
+		in, out := &in.IPv6AddressMode, &out.IPv6AddressMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 	if in.PodAffinity != nil {
 		in, out := &in.PodAffinity, &out.PodAffinity
 		*out = new(v1.LabelSelector)
@@ -241,6 +411,11 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespaceQuota != nil {
+		in, out := &in.NamespaceQuota, &out.NamespaceQuota
+		*out = new(IPPoolNamespaceQuota)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeAffinity != nil {
 		in, out := &in.NodeAffinity, &out.NodeAffinity
 		*out = new(v1.LabelSelector)
@@ -296,6 +471,11 @@ func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.SubnetIPCounts != nil {
+		in, out := &in.SubnetIPCounts, &out.SubnetIPCounts
+		*out = make([]SubnetIPCount, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
@@ -351,6 +531,21 @@ func (in *MultusCNIConfigSpec) DeepCopyInto(out *MultusCNIConfigSpec) {
 		*out = new(SpiderIpoibCniConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BridgeConfig != nil {
+		in, out := &in.BridgeConfig, &out.BridgeConfig
+		*out = new(SpiderBridgeCniConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostDeviceConfig != nil {
+		in, out := &in.HostDeviceConfig, &out.HostDeviceConfig
+		*out = new(SpiderHostDeviceCniConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OvnK8sConfig != nil {
+		in, out := &in.OvnK8sConfig, &out.OvnK8sConfig
+		*out = new(SpiderOvnK8sCniConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnableCoordinator != nil {
 		in, out := &in.EnableCoordinator, &out.EnableCoordinator
 		*out = new(bool)
@@ -366,6 +561,11 @@ func (in *MultusCNIConfigSpec) DeepCopyInto(out *MultusCNIConfigSpec) {
 		*out = new(CoordinatorSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(BandwidthConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ChainCNIJsonData != nil {
 		in, out := &in.ChainCNIJsonData, &out.ChainCNIJsonData
 		*out = make([]string, len(*in))
@@ -388,6 +588,41 @@ func (in *MultusCNIConfigSpec) DeepCopy() *MultusCNIConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceIPQuota) DeepCopyInto(out *NamespaceIPQuota) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceIPQuota.
+func (in *NamespaceIPQuota) DeepCopy() *NamespaceIPQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceIPQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePodCIDR) DeepCopyInto(out *NodePodCIDR) {
+	*out = *in
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePodCIDR.
+func (in *NodePodCIDR) DeepCopy() *NodePodCIDR {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePodCIDR)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIPAllocation) DeepCopyInto(out *PodIPAllocation) {
 	*out = *in
@@ -532,6 +767,77 @@ func (in *Route) DeepCopy() *Route {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondarySubnet) DeepCopyInto(out *SecondarySubnet) {
+	*out = *in
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(string)
+		**out = **in
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondarySubnet.
+func (in *SecondarySubnet) DeepCopy() *SecondarySubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondarySubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderBridgeCniConfig) DeepCopyInto(out *SpiderBridgeCniConfig) {
+	*out = *in
+	if in.VlanID != nil {
+		in, out := &in.VlanID, &out.VlanID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VlanTrunk != nil {
+		in, out := &in.VlanTrunk, &out.VlanTrunk
+		*out = make([]*Trunk, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Trunk)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.HairpinMode != nil {
+		in, out := &in.HairpinMode, &out.HairpinMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SpiderpoolConfigPools != nil {
+		in, out := &in.SpiderpoolConfigPools, &out.SpiderpoolConfigPools
+		*out = new(SpiderpoolPools)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderBridgeCniConfig.
+func (in *SpiderBridgeCniConfig) DeepCopy() *SpiderBridgeCniConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderBridgeCniConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpiderCoordinator) DeepCopyInto(out *SpiderCoordinator) {
 	*out = *in
@@ -649,6 +955,26 @@ func (in *SpiderEndpointList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderHostDeviceCniConfig) DeepCopyInto(out *SpiderHostDeviceCniConfig) {
+	*out = *in
+	if in.SpiderpoolConfigPools != nil {
+		in, out := &in.SpiderpoolConfigPools, &out.SpiderpoolConfigPools
+		*out = new(SpiderpoolPools)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderHostDeviceCniConfig.
+func (in *SpiderHostDeviceCniConfig) DeepCopy() *SpiderHostDeviceCniConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderHostDeviceCniConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpiderIBSriovCniConfig) DeepCopyInto(out *SpiderIBSriovCniConfig) {
 	*out = *in
@@ -694,6 +1020,64 @@ func (in *SpiderIBSriovCniConfig) DeepCopy() *SpiderIBSriovCniConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderIPClaim) DeepCopyInto(out *SpiderIPClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPClaim.
+func (in *SpiderIPClaim) DeepCopy() *SpiderIPClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderIPClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderIPClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderIPClaimList) DeepCopyInto(out *SpiderIPClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderIPClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPClaimList.
+func (in *SpiderIPClaimList) DeepCopy() *SpiderIPClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderIPClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderIPClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpiderIPPool) DeepCopyInto(out *SpiderIPPool) {
 	*out = *in
@@ -921,6 +1305,41 @@ func (in *SpiderMultusConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderOvnK8sCniConfig) DeepCopyInto(out *SpiderOvnK8sCniConfig) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeSubnets != nil {
+		in, out := &in.ExcludeSubnets, &out.ExcludeSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VlanID != nil {
+		in, out := &in.VlanID, &out.VlanID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderOvnK8sCniConfig.
+func (in *SpiderOvnK8sCniConfig) DeepCopy() *SpiderOvnK8sCniConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderOvnK8sCniConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpiderOvsCniConfig) DeepCopyInto(out *SpiderOvsCniConfig) {
 	*out = *in
@@ -1124,6 +1543,65 @@ func (in *SpiderSubnetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderTenantIPPool) DeepCopyInto(out *SpiderTenantIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderTenantIPPool.
+func (in *SpiderTenantIPPool) DeepCopy() *SpiderTenantIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderTenantIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderTenantIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderTenantIPPoolList) DeepCopyInto(out *SpiderTenantIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderTenantIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderTenantIPPoolList.
+func (in *SpiderTenantIPPoolList) DeepCopy() *SpiderTenantIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderTenantIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderTenantIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpiderVlanCniConfig) DeepCopyInto(out *SpiderVlanCniConfig) {
 	*out = *in
@@ -1204,6 +1682,21 @@ func (in *SpiderpoolPools) DeepCopy() *SpiderpoolPools {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetIPCount) DeepCopyInto(out *SubnetIPCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetIPCount.
+func (in *SubnetIPCount) DeepCopy() *SubnetIPCount {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetIPCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
@@ -1232,6 +1725,25 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = make([]Route, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecondarySubnets != nil {
+		in, out := &in.SecondarySubnets, &out.SecondarySubnets
+		*out = make([]SecondarySubnet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]SubnetTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceQuota != nil {
+		in, out := &in.NamespaceQuota, &out.NamespaceQuota
+		*out = new(IPPoolNamespaceQuota)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -1262,6 +1774,11 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.SubnetIPCounts != nil {
+		in, out := &in.SubnetIPCounts, &out.SubnetIPCounts
+		*out = make([]SubnetIPCount, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
@@ -1274,6 +1791,71 @@ func (in *SubnetStatus) DeepCopy() *SubnetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetTenant) DeepCopyInto(out *SubnetTenant) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetTenant.
+func (in *SubnetTenant) DeepCopy() *SubnetTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantIPPoolSpec) DeepCopyInto(out *TenantIPPoolSpec) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantIPPoolSpec.
+func (in *TenantIPPoolSpec) DeepCopy() *TenantIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantIPPoolStatus) DeepCopyInto(out *TenantIPPoolStatus) {
+	*out = *in
+	if in.TotalIPCount != nil {
+		in, out := &in.TotalIPCount, &out.TotalIPCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllocatedIPCount != nil {
+		in, out := &in.AllocatedIPCount, &out.AllocatedIPCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantIPPoolStatus.
+func (in *TenantIPPoolStatus) DeepCopy() *TenantIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Trunk) DeepCopyInto(out *Trunk) {
 	*out = *in
@@ -31,6 +31,10 @@ func (c *FakeSpiderpoolV2beta1) SpiderSubnets() v2beta1.SpiderSubnetInterface {
 	return &FakeSpiderSubnets{c}
 }
 
+func (c *FakeSpiderpoolV2beta1) SpiderTenantIPPools(namespace string) v2beta1.SpiderTenantIPPoolInterface {
+	return &FakeSpiderTenantIPPools{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSpiderpoolV2beta1) RESTClient() rest.Interface {
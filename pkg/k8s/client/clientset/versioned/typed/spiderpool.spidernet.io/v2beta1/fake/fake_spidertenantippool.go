@@ -0,0 +1,128 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSpiderTenantIPPools implements SpiderTenantIPPoolInterface
+type FakeSpiderTenantIPPools struct {
+	Fake *FakeSpiderpoolV2beta1
+	ns   string
+}
+
+var spidertenantippoolsResource = v2beta1.SchemeGroupVersion.WithResource("spidertenantippools")
+
+var spidertenantippoolsKind = v2beta1.SchemeGroupVersion.WithKind("SpiderTenantIPPool")
+
+// Get takes name of the spiderTenantIPPool, and returns the corresponding spiderTenantIPPool object, and an error if there is any.
+func (c *FakeSpiderTenantIPPools) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(spidertenantippoolsResource, c.ns, name), &v2beta1.SpiderTenantIPPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.SpiderTenantIPPool), err
+}
+
+// List takes label and field selectors, and returns the list of SpiderTenantIPPools that match those selectors.
+func (c *FakeSpiderTenantIPPools) List(ctx context.Context, opts v1.ListOptions) (result *v2beta1.SpiderTenantIPPoolList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(spidertenantippoolsResource, spidertenantippoolsKind, c.ns, opts), &v2beta1.SpiderTenantIPPoolList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2beta1.SpiderTenantIPPoolList{ListMeta: obj.(*v2beta1.SpiderTenantIPPoolList).ListMeta}
+	for _, item := range obj.(*v2beta1.SpiderTenantIPPoolList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested spiderTenantIPPools.
+func (c *FakeSpiderTenantIPPools) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(spidertenantippoolsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a spiderTenantIPPool and creates it.  Returns the server's representation of the spiderTenantIPPool, and an error, if there is any.
+func (c *FakeSpiderTenantIPPools) Create(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.CreateOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(spidertenantippoolsResource, c.ns, spiderTenantIPPool), &v2beta1.SpiderTenantIPPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.SpiderTenantIPPool), err
+}
+
+// Update takes the representation of a spiderTenantIPPool and updates it. Returns the server's representation of the spiderTenantIPPool, and an error, if there is any.
+func (c *FakeSpiderTenantIPPools) Update(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.UpdateOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(spidertenantippoolsResource, c.ns, spiderTenantIPPool), &v2beta1.SpiderTenantIPPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.SpiderTenantIPPool), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSpiderTenantIPPools) UpdateStatus(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.UpdateOptions) (*v2beta1.SpiderTenantIPPool, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(spidertenantippoolsResource, "status", c.ns, spiderTenantIPPool), &v2beta1.SpiderTenantIPPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.SpiderTenantIPPool), err
+}
+
+// Delete takes name of the spiderTenantIPPool and deletes it. Returns an error if one occurs.
+func (c *FakeSpiderTenantIPPools) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(spidertenantippoolsResource, c.ns, name, opts), &v2beta1.SpiderTenantIPPool{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSpiderTenantIPPools) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(spidertenantippoolsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2beta1.SpiderTenantIPPoolList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched spiderTenantIPPool.
+func (c *FakeSpiderTenantIPPools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2beta1.SpiderTenantIPPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(spidertenantippoolsResource, c.ns, name, pt, data, subresources...), &v2beta1.SpiderTenantIPPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.SpiderTenantIPPool), err
+}
@@ -12,3 +12,5 @@ type SpiderIPPoolExpansion interface{}
 type SpiderMultusConfigExpansion interface{}
 
 type SpiderSubnetExpansion interface{}
+
+type SpiderTenantIPPoolExpansion interface{}
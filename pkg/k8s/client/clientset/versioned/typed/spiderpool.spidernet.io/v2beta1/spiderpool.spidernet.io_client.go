@@ -19,6 +19,7 @@ type SpiderpoolV2beta1Interface interface {
 	SpiderIPPoolsGetter
 	SpiderMultusConfigsGetter
 	SpiderSubnetsGetter
+	SpiderTenantIPPoolsGetter
 }
 
 // SpiderpoolV2beta1Client is used to interact with features provided by the spiderpool.spidernet.io group.
@@ -42,6 +43,10 @@ func (c *SpiderpoolV2beta1Client) SpiderSubnets() SpiderSubnetInterface {
 	return newSpiderSubnets(c)
 }
 
+func (c *SpiderpoolV2beta1Client) SpiderTenantIPPools(namespace string) SpiderTenantIPPoolInterface {
+	return newSpiderTenantIPPools(c, namespace)
+}
+
 // NewForConfig creates a new SpiderpoolV2beta1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -0,0 +1,182 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2beta1
+
+import (
+	"context"
+	"time"
+
+	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	scheme "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// SpiderTenantIPPoolsGetter has a method to return a SpiderTenantIPPoolInterface.
+// A group's client should implement this interface.
+type SpiderTenantIPPoolsGetter interface {
+	SpiderTenantIPPools(namespace string) SpiderTenantIPPoolInterface
+}
+
+// SpiderTenantIPPoolInterface has methods to work with SpiderTenantIPPool resources.
+type SpiderTenantIPPoolInterface interface {
+	Create(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.CreateOptions) (*v2beta1.SpiderTenantIPPool, error)
+	Update(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.UpdateOptions) (*v2beta1.SpiderTenantIPPool, error)
+	UpdateStatus(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.UpdateOptions) (*v2beta1.SpiderTenantIPPool, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2beta1.SpiderTenantIPPool, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2beta1.SpiderTenantIPPoolList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2beta1.SpiderTenantIPPool, err error)
+	SpiderTenantIPPoolExpansion
+}
+
+// spiderTenantIPPools implements SpiderTenantIPPoolInterface
+type spiderTenantIPPools struct {
+	client rest.Interface
+	ns     string
+}
+
+// newSpiderTenantIPPools returns a SpiderTenantIPPools
+func newSpiderTenantIPPools(c *SpiderpoolV2beta1Client, namespace string) *spiderTenantIPPools {
+	return &spiderTenantIPPools{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the spiderTenantIPPool, and returns the corresponding spiderTenantIPPool object, and an error if there is any.
+func (c *spiderTenantIPPools) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	result = &v2beta1.SpiderTenantIPPool{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of SpiderTenantIPPools that match those selectors.
+func (c *spiderTenantIPPools) List(ctx context.Context, opts v1.ListOptions) (result *v2beta1.SpiderTenantIPPoolList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2beta1.SpiderTenantIPPoolList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested spiderTenantIPPools.
+func (c *spiderTenantIPPools) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a spiderTenantIPPool and creates it.  Returns the server's representation of the spiderTenantIPPool, and an error, if there is any.
+func (c *spiderTenantIPPools) Create(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.CreateOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	result = &v2beta1.SpiderTenantIPPool{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(spiderTenantIPPool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a spiderTenantIPPool and updates it. Returns the server's representation of the spiderTenantIPPool, and an error, if there is any.
+func (c *spiderTenantIPPools) Update(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.UpdateOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	result = &v2beta1.SpiderTenantIPPool{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		Name(spiderTenantIPPool.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(spiderTenantIPPool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *spiderTenantIPPools) UpdateStatus(ctx context.Context, spiderTenantIPPool *v2beta1.SpiderTenantIPPool, opts v1.UpdateOptions) (result *v2beta1.SpiderTenantIPPool, err error) {
+	result = &v2beta1.SpiderTenantIPPool{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		Name(spiderTenantIPPool.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(spiderTenantIPPool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the spiderTenantIPPool and deletes it. Returns an error if one occurs.
+func (c *spiderTenantIPPools) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *spiderTenantIPPools) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched spiderTenantIPPool.
+func (c *spiderTenantIPPools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2beta1.SpiderTenantIPPool, err error) {
+	result = &v2beta1.SpiderTenantIPPool{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("spidertenantippools").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
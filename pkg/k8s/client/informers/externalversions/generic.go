@@ -48,6 +48,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Spiderpool().V2beta1().SpiderMultusConfigs().Informer()}, nil
 	case v2beta1.SchemeGroupVersion.WithResource("spidersubnets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Spiderpool().V2beta1().SpiderSubnets().Informer()}, nil
+	case v2beta1.SchemeGroupVersion.WithResource("spidertenantippools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Spiderpool().V2beta1().SpiderTenantIPPools().Informer()}, nil
 
 	}
 
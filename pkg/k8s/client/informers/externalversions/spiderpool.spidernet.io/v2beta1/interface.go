@@ -19,6 +19,8 @@ type Interface interface {
 	SpiderMultusConfigs() SpiderMultusConfigInformer
 	// SpiderSubnets returns a SpiderSubnetInformer.
 	SpiderSubnets() SpiderSubnetInformer
+	// SpiderTenantIPPools returns a SpiderTenantIPPoolInformer.
+	SpiderTenantIPPools() SpiderTenantIPPoolInformer
 }
 
 type version struct {
@@ -51,3 +53,8 @@ func (v *version) SpiderMultusConfigs() SpiderMultusConfigInformer {
 func (v *version) SpiderSubnets() SpiderSubnetInformer {
 	return &spiderSubnetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// SpiderTenantIPPools returns a SpiderTenantIPPoolInformer.
+func (v *version) SpiderTenantIPPools() SpiderTenantIPPoolInformer {
+	return &spiderTenantIPPoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
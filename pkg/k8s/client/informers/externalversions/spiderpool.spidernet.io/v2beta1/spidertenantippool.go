@@ -0,0 +1,77 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v2beta1
+
+import (
+	"context"
+	time "time"
+
+	spiderpoolspidernetiov2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	versioned "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
+	internalinterfaces "github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions/internalinterfaces"
+	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/client/listers/spiderpool.spidernet.io/v2beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// SpiderTenantIPPoolInformer provides access to a shared informer and lister for
+// SpiderTenantIPPools.
+type SpiderTenantIPPoolInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v2beta1.SpiderTenantIPPoolLister
+}
+
+type spiderTenantIPPoolInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewSpiderTenantIPPoolInformer constructs a new informer for SpiderTenantIPPool type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewSpiderTenantIPPoolInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredSpiderTenantIPPoolInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredSpiderTenantIPPoolInformer constructs a new informer for SpiderTenantIPPool type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredSpiderTenantIPPoolInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SpiderpoolV2beta1().SpiderTenantIPPools(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SpiderpoolV2beta1().SpiderTenantIPPools(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&spiderpoolspidernetiov2beta1.SpiderTenantIPPool{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *spiderTenantIPPoolInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredSpiderTenantIPPoolInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *spiderTenantIPPoolInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&spiderpoolspidernetiov2beta1.SpiderTenantIPPool{}, f.defaultInformer)
+}
+
+func (f *spiderTenantIPPoolInformer) Lister() v2beta1.SpiderTenantIPPoolLister {
+	return v2beta1.NewSpiderTenantIPPoolLister(f.Informer().GetIndexer())
+}
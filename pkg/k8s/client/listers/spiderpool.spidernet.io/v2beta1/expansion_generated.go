@@ -24,3 +24,11 @@ type SpiderMultusConfigNamespaceListerExpansion interface{}
 // SpiderSubnetListerExpansion allows custom methods to be added to
 // SpiderSubnetLister.
 type SpiderSubnetListerExpansion interface{}
+
+// SpiderTenantIPPoolListerExpansion allows custom methods to be added to
+// SpiderTenantIPPoolLister.
+type SpiderTenantIPPoolListerExpansion interface{}
+
+// SpiderTenantIPPoolNamespaceListerExpansion allows custom methods to be added to
+// SpiderTenantIPPoolNamespaceLister.
+type SpiderTenantIPPoolNamespaceListerExpansion interface{}
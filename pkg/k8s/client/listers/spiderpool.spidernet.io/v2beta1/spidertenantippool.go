@@ -0,0 +1,86 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v2beta1
+
+import (
+	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SpiderTenantIPPoolLister helps list SpiderTenantIPPools.
+// All objects returned here must be treated as read-only.
+type SpiderTenantIPPoolLister interface {
+	// List lists all SpiderTenantIPPools in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2beta1.SpiderTenantIPPool, err error)
+	// SpiderTenantIPPools returns an object that can list and get SpiderTenantIPPools.
+	SpiderTenantIPPools(namespace string) SpiderTenantIPPoolNamespaceLister
+	SpiderTenantIPPoolListerExpansion
+}
+
+// spiderTenantIPPoolLister implements the SpiderTenantIPPoolLister interface.
+type spiderTenantIPPoolLister struct {
+	indexer cache.Indexer
+}
+
+// NewSpiderTenantIPPoolLister returns a new SpiderTenantIPPoolLister.
+func NewSpiderTenantIPPoolLister(indexer cache.Indexer) SpiderTenantIPPoolLister {
+	return &spiderTenantIPPoolLister{indexer: indexer}
+}
+
+// List lists all SpiderTenantIPPools in the indexer.
+func (s *spiderTenantIPPoolLister) List(selector labels.Selector) (ret []*v2beta1.SpiderTenantIPPool, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2beta1.SpiderTenantIPPool))
+	})
+	return ret, err
+}
+
+// SpiderTenantIPPools returns an object that can list and get SpiderTenantIPPools.
+func (s *spiderTenantIPPoolLister) SpiderTenantIPPools(namespace string) SpiderTenantIPPoolNamespaceLister {
+	return spiderTenantIPPoolNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// SpiderTenantIPPoolNamespaceLister helps list and get SpiderTenantIPPools.
+// All objects returned here must be treated as read-only.
+type SpiderTenantIPPoolNamespaceLister interface {
+	// List lists all SpiderTenantIPPools in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2beta1.SpiderTenantIPPool, err error)
+	// Get retrieves the SpiderTenantIPPool from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v2beta1.SpiderTenantIPPool, error)
+	SpiderTenantIPPoolNamespaceListerExpansion
+}
+
+// spiderTenantIPPoolNamespaceLister implements the SpiderTenantIPPoolNamespaceLister
+// interface.
+type spiderTenantIPPoolNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all SpiderTenantIPPools in the indexer for a given namespace.
+func (s spiderTenantIPPoolNamespaceLister) List(selector labels.Selector) (ret []*v2beta1.SpiderTenantIPPool, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2beta1.SpiderTenantIPPool))
+	})
+	return ret, err
+}
+
+// Get retrieves the SpiderTenantIPPool from the indexer for a given namespace and name.
+func (s spiderTenantIPPoolNamespaceLister) Get(name string) (*v2beta1.SpiderTenantIPPool, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v2beta1.Resource("spidertenantippool"), name)
+	}
+	return obj.(*v2beta1.SpiderTenantIPPool), nil
+}
@@ -65,6 +65,24 @@ const (
 	ipGCCCountsName       = metricPrefix + "ipGCCCountsName"
 	ipGCFailureCountsName = metricPrefix + "ipGCFailureCountsName"
 
+	// spiderpool controller IaaS reconciliation metrics name
+	iaasPendingReleaseCountsName     = metricPrefix + "iaasPendingReleaseCountsName"
+	iaasOrphanedAssignmentCountsName = metricPrefix + "iaasOrphanedAssignmentCountsName"
+
+	// spiderpool agent IaaS parent NIC MAC cache metrics name
+	iaasParentNicMacCacheEntriesName = metricPrefix + "iaasParentNicMacCacheEntriesName"
+
+	// spiderpool agent IaaS circuit breaker metrics name
+	iaasCircuitBreakerStateName          = metricPrefix + "iaasCircuitBreakerStateName"
+	iaasCircuitBreakerRejectedCountsName = metricPrefix + "iaasCircuitBreakerRejectedCountsName"
+
+	// spiderpool agent coordinator orphan sweeper metrics name
+	coordinatorOrphanCleanedCountsName      = metricPrefix + "coordinatorOrphanCleanedCountsName"
+	coordinatorOrphanCleanFailureCountsName = metricPrefix + "coordinatorOrphanCleanFailureCountsName"
+
+	// spiderpool agent coordinator datapath metrics name
+	coordinatorDatapathFallbackCountsName = metricPrefix + "coordinatorDatapathFallbackCountsName"
+
 	// spiderpool IPPool and Subnet metrics and these include some debug level metrics
 	totalIPPoolCountsName                = metricPrefix + "totalIPPoolCountsName"
 	ippoolTotalIPCountsName              = metricPrefix + debugPrefix + "ippoolTotalIPCountsName"
@@ -74,6 +92,7 @@ const (
 	subnetTotalIPCountsName              = metricPrefix + debugPrefix + "subnetTotalIPCountsName"
 	subnetAvailableIPCountsName          = metricPrefix + debugPrefix + "subnetAvailableIPCountsName"
 	autoPoolWaitedForAvailableCountsName = metricPrefix + debugPrefix + "autoPoolWaitedForAvailableCountsName"
+	subnetAutoPoolIdleIPCountsName       = metricPrefix + debugPrefix + "subnetAutoPoolIdleIPCountsName"
 )
 
 var (
@@ -117,6 +136,24 @@ var (
 	IPGCTotalCounts   api.Int64Counter
 	IPGCFailureCounts api.Int64Counter
 
+	// IaaS reconciliation metrics in spiderpool-controller
+	IaaSPendingReleaseCounts     = new(asyncInt64Gauge)
+	IaaSOrphanedAssignmentCounts = new(asyncInt64Gauge)
+
+	// IaaS parent NIC MAC cache metrics in spiderpool-agent
+	IaaSParentNicMacCacheEntries = new(asyncInt64Gauge)
+
+	// IaaS circuit breaker metrics in spiderpool-agent
+	IaaSCircuitBreakerState          = new(asyncInt64Gauge)
+	IaaSCircuitBreakerRejectedCounts api.Int64Counter
+
+	// coordinator orphan sweeper metrics in spiderpool-agent
+	CoordinatorOrphanCleanedCounts      api.Int64Counter
+	CoordinatorOrphanCleanFailureCounts api.Int64Counter
+
+	// coordinator datapath metrics in spiderpool-agent
+	CoordinatorDatapathFallbackCounts api.Int64Counter
+
 	// IPPool&Subnet metrics in spiderpool-controller
 	TotalIPPoolCounts       = new(asyncInt64Gauge)
 	IPPoolTotalIPCounts     api.Int64Counter
@@ -126,6 +163,9 @@ var (
 	SubnetTotalIPCounts     api.Int64Counter
 	SubnetAvailableIPCounts api.Int64Counter
 
+	// SubnetAutoPoolIdleIPCounts records the IPs held by auto-created IPPools but not allocated to any Pod
+	SubnetAutoPoolIdleIPCounts = new(asyncInt64Gauge)
+
 	// SpiderSubnet feature performance monitoring metric in spiderpool-agent
 	AutoPoolWaitedForAvailableCounts api.Int64Counter
 )
@@ -244,6 +284,16 @@ func InitSpiderpoolAgentMetrics(ctx context.Context, cache podownercache.CacheIn
 		return err
 	}
 
+	err = initSpiderpoolAgentIaaSMetrics()
+	if nil != err {
+		return err
+	}
+
+	err = initSpiderpoolAgentCoordinatorMetrics(ctx)
+	if nil != err {
+		return err
+	}
+
 	autoPoolWaitedForAvailableCounts, err := newMetricInt64Counter(autoPoolWaitedForAvailableCountsName, "ipam waited for auto-created IPPool available counts", false)
 	if nil != err {
 		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %w", autoPoolWaitedForAvailableCountsName, err)
@@ -265,6 +315,11 @@ func InitSpiderpoolControllerMetrics(ctx context.Context) error {
 		return err
 	}
 
+	err = initSpiderpoolControllerIaaSMetrics()
+	if nil != err {
+		return err
+	}
+
 	return nil
 }
 
@@ -555,5 +610,72 @@ func initSpiderpoolControllerCRMetrics(ctx context.Context) error {
 		return err
 	}
 
+	err = SubnetAutoPoolIdleIPCounts.initGauge(subnetAutoPoolIdleIPCountsName, "spider subnet corresponding auto-created ippools idle IP counts", true)
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+// initSpiderpoolAgentIaaSMetrics will init spiderpool-agent IaaS provider metrics
+func initSpiderpoolAgentIaaSMetrics() error {
+	err := IaaSParentNicMacCacheEntries.initGauge(iaasParentNicMacCacheEntriesName, "spiderpool agent IaaS parent NIC MAC cache entries", false)
+	if nil != err {
+		return err
+	}
+
+	err = IaaSCircuitBreakerState.initGauge(iaasCircuitBreakerStateName, "spiderpool agent IaaS circuit breaker state, 0 closed, 1 half-open and 2 open", false)
+	if nil != err {
+		return err
+	}
+
+	rejectedCounts, err := newMetricInt64Counter(iaasCircuitBreakerRejectedCountsName, "spiderpool agent IaaS provider calls rejected by the open circuit breaker counts", false)
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %w", iaasCircuitBreakerRejectedCountsName, err)
+	}
+	IaaSCircuitBreakerRejectedCounts = rejectedCounts
+
+	return nil
+}
+
+// initSpiderpoolAgentCoordinatorMetrics will init spiderpool-agent coordinator orphan sweeper metrics
+func initSpiderpoolAgentCoordinatorMetrics(ctx context.Context) error {
+	cleanedCounts, err := newMetricInt64Counter(coordinatorOrphanCleanedCountsName, "spiderpool agent coordinator orphan host veths, routes and rules cleaned counts", false)
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %w", coordinatorOrphanCleanedCountsName, err)
+	}
+	CoordinatorOrphanCleanedCounts = cleanedCounts
+	CoordinatorOrphanCleanedCounts.Add(ctx, 0)
+
+	cleanFailureCounts, err := newMetricInt64Counter(coordinatorOrphanCleanFailureCountsName, "spiderpool agent coordinator orphan host veths, routes and rules clean failure counts", false)
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %w", coordinatorOrphanCleanFailureCountsName, err)
+	}
+	CoordinatorOrphanCleanFailureCounts = cleanFailureCounts
+	CoordinatorOrphanCleanFailureCounts.Add(ctx, 0)
+
+	fallbackCounts, err := newMetricInt64Counter(coordinatorDatapathFallbackCountsName, "spiderpool agent coordinator falling back to the route datapath counts", false)
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %w", coordinatorDatapathFallbackCountsName, err)
+	}
+	CoordinatorDatapathFallbackCounts = fallbackCounts
+	CoordinatorDatapathFallbackCounts.Add(ctx, 0)
+
+	return nil
+}
+
+// initSpiderpoolControllerIaaSMetrics will init spiderpool-controller IaaS reconciliation metrics
+func initSpiderpoolControllerIaaSMetrics() error {
+	err := IaaSPendingReleaseCounts.initGauge(iaasPendingReleaseCountsName, "spiderpool controller IaaS IP releases pending to retry counts", false)
+	if nil != err {
+		return err
+	}
+
+	err = IaaSOrphanedAssignmentCounts.initGauge(iaasOrphanedAssignmentCountsName, "spiderpool controller IaaS IP assignments not tracked by spiderpool counts", false)
+	if nil != err {
+		return err
+	}
+
 	return nil
 }
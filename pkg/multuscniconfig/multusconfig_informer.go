@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -357,7 +358,7 @@ func generateNetAttachDef(netAttachName string, multusConf *spiderpoolv2beta1.Sp
 	// with Kubernetes OpenAPI validation, multusConfSpec.EnableCoordinator must not be nil
 	hasCoordinator := *multusConfSpec.EnableCoordinator
 	if hasCoordinator {
-		coordinatorCNIConf := generateCoordinatorCNIConf(multusConfSpec.CoordinatorConfig)
+		coordinatorCNIConf := generateCoordinatorCNIConf(multusConfSpec.CoordinatorConfig, multusConfSpec.Bandwidth)
 		// head insertion later
 		plugins = append(plugins, coordinatorCNIConf)
 	}
@@ -491,6 +492,32 @@ func generateNetAttachDef(netAttachName string, multusConf *spiderpoolv2beta1.Sp
 			anno[constant.ResourceNameAnnot] = fmt.Sprintf("%s/%s", constant.ResourceNameOvsCniValue, multusConfSpec.OvsConfig.BrName)
 		}
 
+	case constant.BridgeCNI:
+		bridgeConf := generateBridgeCNIConf(disableIPAM, multusConfSpec)
+		plugins = append([]interface{}{bridgeConf}, plugins...)
+		confStr, err = marshalCniConfig2String(cniConfigName, cniVersion, plugins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal bridge cniConfig to String: %w", err)
+		}
+
+	case constant.HostDeviceCNI:
+		hostDeviceConf := generateHostDeviceCNIConf(disableIPAM, multusConfSpec)
+		plugins = append([]interface{}{hostDeviceConf}, plugins...)
+		confStr, err = marshalCniConfig2String(cniConfigName, cniVersion, plugins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal host-device cniConfig to String: %w", err)
+		}
+
+	case constant.OvnK8sCNI:
+		// OVN-Kubernetes doesn't support the chained plugins, which are forbidden by webhook
+		ovnK8sConf := generateOvnK8sCNIConf(cniConfigName, cniVersion, multusConf.Namespace, netAttachName, multusConfSpec.OvnK8sConfig)
+		var ovnK8sConfBytes []byte
+		ovnK8sConfBytes, err = json.Marshal(ovnK8sConf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal ovn-k8s cniConfig to String: %w", err)
+		}
+		confStr = string(ovnK8sConfBytes)
+
 	case constant.CustomCNI:
 		if multusConfSpec.CustomCNIConfig != nil && len(*multusConfSpec.CustomCNIConfig) > 0 {
 			if !json.Valid([]byte(*multusConfSpec.CustomCNIConfig)) {
@@ -763,6 +790,93 @@ func generateOvsCNIConf(disableIPAM bool, multusConfSpec *spiderpoolv2beta1.Mult
 	return netConf
 }
 
+func generateBridgeCNIConf(disableIPAM bool, multusConfSpec *spiderpoolv2beta1.MultusCNIConfigSpec) interface{} {
+	netConf := BridgeNetConf{
+		Type:   constant.BridgeCNI,
+		BrName: multusConfSpec.BridgeConfig.BrName,
+	}
+
+	if multusConfSpec.BridgeConfig.VlanID != nil {
+		netConf.Vlan = *multusConfSpec.BridgeConfig.VlanID
+	}
+
+	if len(multusConfSpec.BridgeConfig.VlanTrunk) > 0 {
+		netConf.VlanTrunk = multusConfSpec.BridgeConfig.VlanTrunk
+	}
+
+	if multusConfSpec.BridgeConfig.HairpinMode != nil {
+		netConf.HairpinMode = *multusConfSpec.BridgeConfig.HairpinMode
+	}
+
+	if multusConfSpec.BridgeConfig.MTU != nil && *multusConfSpec.BridgeConfig.MTU > 0 {
+		netConf.MTU = multusConfSpec.BridgeConfig.MTU
+	}
+
+	if !disableIPAM {
+		netConf.IPAM = &spiderpoolcmd.IPAMConfig{
+			Type: constant.Spiderpool,
+		}
+		if multusConfSpec.BridgeConfig.SpiderpoolConfigPools != nil {
+			netConf.IPAM.DefaultIPv4IPPool = multusConfSpec.BridgeConfig.SpiderpoolConfigPools.IPv4IPPool
+			netConf.IPAM.DefaultIPv6IPPool = multusConfSpec.BridgeConfig.SpiderpoolConfigPools.IPv6IPPool
+		}
+	}
+
+	return netConf
+}
+
+func generateHostDeviceCNIConf(disableIPAM bool, multusConfSpec *spiderpoolv2beta1.MultusCNIConfigSpec) interface{} {
+	netConf := HostDeviceNetConf{
+		Type:       constant.HostDeviceCNI,
+		Device:     multusConfSpec.HostDeviceConfig.Device,
+		HWAddr:     multusConfSpec.HostDeviceConfig.HWAddr,
+		KernelPath: multusConfSpec.HostDeviceConfig.KernelPath,
+		PCIBusID:   multusConfSpec.HostDeviceConfig.PCIBusID,
+	}
+
+	if !disableIPAM {
+		netConf.IPAM = &spiderpoolcmd.IPAMConfig{
+			Type: constant.Spiderpool,
+		}
+		if multusConfSpec.HostDeviceConfig.SpiderpoolConfigPools != nil {
+			netConf.IPAM.DefaultIPv4IPPool = multusConfSpec.HostDeviceConfig.SpiderpoolConfigPools.IPv4IPPool
+			netConf.IPAM.DefaultIPv6IPPool = multusConfSpec.HostDeviceConfig.SpiderpoolConfigPools.IPv6IPPool
+		}
+	}
+
+	return netConf
+}
+
+func generateOvnK8sCNIConf(cniConfigName, cniVersion, namespace, netAttachName string, ovnK8sConfig *spiderpoolv2beta1.SpiderOvnK8sCniConfig) OvnK8sNetConf {
+	netConf := OvnK8sNetConf{
+		CNIVersion: cniVersion,
+		Name:       cniConfigName,
+		Type:       constant.OvnK8sCNIOverlay,
+		Topology:   ovnK8sConfig.Topology,
+		// OVN-Kubernetes requires it to be the same as the NetworkAttachmentDefinition
+		NetAttachDefName: fmt.Sprintf("%s/%s", namespace, netAttachName),
+		Subnets:          strings.Join(ovnK8sConfig.Subnets, ","),
+		ExcludeSubnets:   strings.Join(ovnK8sConfig.ExcludeSubnets, ","),
+	}
+
+	if ovnK8sConfig.NetworkName != "" {
+		netConf.Name = ovnK8sConfig.NetworkName
+	}
+
+	if ovnK8sConfig.Topology == constant.OvnK8sTopologyLocalnet {
+		if ovnK8sConfig.VlanID != nil {
+			netConf.VlanID = *ovnK8sConfig.VlanID
+		}
+		netConf.PhysicalNetworkName = ovnK8sConfig.PhysicalNetworkName
+	}
+
+	if ovnK8sConfig.MTU != nil {
+		netConf.MTU = *ovnK8sConfig.MTU
+	}
+
+	return netConf
+}
+
 func generateIfacer(master []string, vlanID int32, bond *spiderpoolv2beta1.BondConfig) interface{} {
 	netConf := IfacerNetConf{
 		Type:       constant.Ifacer,
@@ -777,7 +891,7 @@ func generateIfacer(master []string, vlanID int32, bond *spiderpoolv2beta1.BondC
 	return netConf
 }
 
-func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSpec) interface{} {
+func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSpec, bandwidth *spiderpoolv2beta1.BandwidthConfig) interface{} {
 	coordinatorNetConf := CoordinatorConfig{
 		Type: constant.Coordinator,
 	}
@@ -787,6 +901,12 @@ func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSp
 		if coordinatorSpec.Mode != nil {
 			coordinatorNetConf.Mode = coordinatorcmd.Mode(*coordinatorSpec.Mode)
 		}
+		if coordinatorSpec.Datapath != nil {
+			coordinatorNetConf.Datapath = *coordinatorSpec.Datapath
+		}
+		if coordinatorSpec.DatapathFallback != nil {
+			coordinatorNetConf.DatapathFallback = coordinatorSpec.DatapathFallback
+		}
 		if len(coordinatorSpec.HijackCIDR) != 0 {
 			coordinatorNetConf.HijackCIDR = coordinatorSpec.HijackCIDR
 		}
@@ -819,9 +939,38 @@ func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSp
 		}
 	}
 
+	if bandwidth != nil {
+		setCoordinatorBandwidth(&coordinatorNetConf, bandwidth)
+	}
+
 	return coordinatorNetConf
 }
 
+// setCoordinatorBandwidth sets the bandwidth of the coordinator CNI config, whose
+// rates and bursts can be overridden by the "bandwidth" of the pod in the Multus
+// network annotation with the bandwidth capability.
+func setCoordinatorBandwidth(coordinatorNetConf *CoordinatorConfig, bandwidth *spiderpoolv2beta1.BandwidthConfig) {
+	bw := &coordinatorcmd.BandwidthConfig{DSCP: bandwidth.DSCP}
+	if bandwidth.IngressRate != nil {
+		bw.IngressRate = uint64(*bandwidth.IngressRate)
+	}
+	if bandwidth.IngressBurst != nil {
+		bw.IngressBurst = uint64(*bandwidth.IngressBurst)
+	}
+	if bandwidth.EgressRate != nil {
+		bw.EgressRate = uint64(*bandwidth.EgressRate)
+	}
+	if bandwidth.EgressBurst != nil {
+		bw.EgressBurst = uint64(*bandwidth.EgressBurst)
+	}
+	if bandwidth.Priority != nil {
+		bw.Priority = *bandwidth.Priority
+	}
+
+	coordinatorNetConf.Bandwidth = bw
+	coordinatorNetConf.Capabilities = map[string]bool{"bandwidth": true}
+}
+
 func marshalCniConfig2String(netAttachName, cniVersion string, plugins interface{}) (string, error) {
 	rawList := map[string]interface{}{
 		"name":       netAttachName,
@@ -36,6 +36,10 @@ func mutateSpiderMultusConfig(ctx context.Context, smc *spiderpoolv2beta1.Spider
 		setIpoibDefaultConfig(smc.Spec.IpoibConfig)
 	case constant.OvsCNI:
 		setOvsDefaultConfig(smc.Spec.OvsConfig)
+	case constant.BridgeCNI:
+		setBridgeDefaultConfig(smc.Spec.BridgeConfig)
+	case constant.HostDeviceCNI:
+		setHostDeviceDefaultConfig(smc.Spec.HostDeviceConfig)
 	case constant.CustomCNI:
 		if smc.Spec.CustomCNIConfig == nil {
 			smc.Spec.CustomCNIConfig = ptr.To("")
@@ -46,6 +50,12 @@ func mutateSpiderMultusConfig(ctx context.Context, smc *spiderpoolv2beta1.Spider
 	if *smc.Spec.CniType == constant.CustomCNI {
 		smc.Spec.CoordinatorConfig = nil
 		smc.Spec.EnableCoordinator = ptr.To(false)
+	} else if *smc.Spec.CniType == constant.OvnK8sCNI {
+		// OVN-Kubernetes allocates the IPs and sets up the routes of its networks by itself,
+		// and doesn't support the chained plugins
+		smc.Spec.CoordinatorConfig = nil
+		smc.Spec.EnableCoordinator = ptr.To(false)
+		smc.Spec.DisableIPAM = ptr.To(true)
 	} else {
 		smc.Spec.CoordinatorConfig = setCoordinatorDefaultConfig(smc.Spec.CoordinatorConfig)
 	}
@@ -253,6 +263,44 @@ func setIpoibDefaultConfig(config *spiderpoolv2beta1.SpiderIpoibCniConfig) {
 	}
 }
 
+func setBridgeDefaultConfig(bridgeConfig *spiderpoolv2beta1.SpiderBridgeCniConfig) {
+	if bridgeConfig == nil {
+		return
+	}
+
+	if bridgeConfig.VlanID == nil {
+		bridgeConfig.VlanID = ptr.To(int32(0))
+	}
+
+	if bridgeConfig.HairpinMode == nil {
+		bridgeConfig.HairpinMode = ptr.To(false)
+	}
+
+	if bridgeConfig.MTU == nil {
+		bridgeConfig.MTU = ptr.To(int32(0))
+	}
+
+	if bridgeConfig.SpiderpoolConfigPools == nil {
+		bridgeConfig.SpiderpoolConfigPools = &spiderpoolv2beta1.SpiderpoolPools{
+			IPv4IPPool: []string{},
+			IPv6IPPool: []string{},
+		}
+	}
+}
+
+func setHostDeviceDefaultConfig(hostDeviceConfig *spiderpoolv2beta1.SpiderHostDeviceCniConfig) {
+	if hostDeviceConfig == nil {
+		return
+	}
+
+	if hostDeviceConfig.SpiderpoolConfigPools == nil {
+		hostDeviceConfig.SpiderpoolConfigPools = &spiderpoolv2beta1.SpiderpoolPools{
+			IPv4IPPool: []string{},
+			IPv6IPPool: []string{},
+		}
+	}
+}
+
 func setOvsDefaultConfig(ovsConfig *spiderpoolv2beta1.SpiderOvsCniConfig) {
 	if ovsConfig == nil {
 		return
@@ -14,6 +14,7 @@ import (
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -83,3 +84,366 @@ var _ = Describe("SpiderMultusConfig vlan mode", Label("spidermultusconfig", "un
 		Expect(decoded).NotTo(HaveKey("vlanId"))
 	})
 })
+
+var _ = Describe("SpiderMultusConfig bandwidth", Label("spidermultusconfig", "unittest"), func() {
+	newMacvlanSMC := func(bandwidth *spiderpoolv2beta1.BandwidthConfig) *spiderpoolv2beta1.SpiderMultusConfig {
+		return &spiderpoolv2beta1.SpiderMultusConfig{
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType: ptr.To(constant.MacvlanCNI),
+				MacvlanConfig: &spiderpoolv2beta1.SpiderMacvlanCniConfig{
+					Master: []string{"eth0"},
+					VlanID: ptr.To(int32(0)),
+				},
+				EnableCoordinator: ptr.To(true),
+				Bandwidth:         bandwidth,
+				ChainCNIJsonData:  []string{},
+			},
+		}
+	}
+
+	It("accepts the bandwidth of macvlan", func() {
+		smc := newMacvlanSMC(&spiderpoolv2beta1.BandwidthConfig{
+			IngressRate:  ptr.To(int64(100_000_000)),
+			IngressBurst: ptr.To(int64(1_000_000)),
+			DSCP:         ptr.To(int32(46)),
+			Priority:     ptr.To("1:10"),
+		})
+
+		Expect(validateCNIConfig(smc)).To(BeNil())
+	})
+
+	It("forbids the bandwidth without coordinator", func() {
+		smc := newMacvlanSMC(&spiderpoolv2beta1.BandwidthConfig{DSCP: ptr.To(int32(46))})
+		smc.Spec.EnableCoordinator = ptr.To(false)
+
+		err := validateCNIConfig(smc)
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("please enable coordinator"))
+	})
+
+	It("forbids the bandwidth of sriov", func() {
+		smc := newMacvlanSMC(&spiderpoolv2beta1.BandwidthConfig{DSCP: ptr.To(int32(46))})
+		smc.Spec.CniType = ptr.To(constant.SriovCNI)
+		smc.Spec.MacvlanConfig = nil
+		smc.Spec.SriovConfig = &spiderpoolv2beta1.SpiderSRIOVCniConfig{ResourceName: ptr.To("sriov")}
+
+		err := validateCNIConfig(smc)
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("does not support bandwidth"))
+	})
+
+	It("requires both the rate and burst", func() {
+		smc := newMacvlanSMC(&spiderpoolv2beta1.BandwidthConfig{EgressRate: ptr.To(int64(100_000_000))})
+
+		err := validateCNIConfig(smc)
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("must be both set or unset"))
+	})
+
+	It("rejects the dscp out of range", func() {
+		smc := newMacvlanSMC(&spiderpoolv2beta1.BandwidthConfig{DSCP: ptr.To(int32(64))})
+
+		err := validateCNIConfig(smc)
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.Field).To(Equal("spec.bandwidth.dscp"))
+	})
+
+	It("rejects the priority not in the form major:minor", func() {
+		smc := newMacvlanSMC(&spiderpoolv2beta1.BandwidthConfig{Priority: ptr.To("10")})
+
+		err := validateCNIConfig(smc)
+
+		Expect(err).NotTo(BeNil())
+		Expect(err.Field).To(Equal("spec.bandwidth.priority"))
+	})
+
+	It("generates the bandwidth of coordinator with the bandwidth capability", func() {
+		conf := generateCoordinatorCNIConf(nil, &spiderpoolv2beta1.BandwidthConfig{
+			EgressRate:  ptr.To(int64(100_000_000)),
+			EgressBurst: ptr.To(int64(1_000_000)),
+			DSCP:        ptr.To(int32(46)),
+			Priority:    ptr.To("1:10"),
+		})
+		data, err := json.Marshal(conf)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).To(HaveKeyWithValue("type", constant.Coordinator))
+		Expect(decoded).To(HaveKeyWithValue("capabilities", map[string]interface{}{"bandwidth": true}))
+		Expect(decoded).To(HaveKey("bandwidth"))
+		bw := decoded["bandwidth"].(map[string]interface{})
+		Expect(bw).To(HaveKeyWithValue("egressRate", float64(100_000_000)))
+		Expect(bw).To(HaveKeyWithValue("egressBurst", float64(1_000_000)))
+		Expect(bw).To(HaveKeyWithValue("dscp", float64(46)))
+		Expect(bw).To(HaveKeyWithValue("priority", "1:10"))
+		Expect(bw).NotTo(HaveKey("ingressRate"))
+	})
+})
+
+var _ = Describe("SpiderMultusConfig coordinator datapath", Label("spidermultusconfig", "unittest"), func() {
+	It("generates the datapath and the opt-in fallback of coordinator", func() {
+		conf := generateCoordinatorCNIConf(&spiderpoolv2beta1.CoordinatorSpec{
+			Datapath:         ptr.To("ebpf"),
+			DatapathFallback: ptr.To(true),
+		}, nil)
+		data, err := json.Marshal(conf)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).To(HaveKeyWithValue("datapath", "ebpf"))
+		Expect(decoded).To(HaveKeyWithValue("datapathFallback", true))
+	})
+
+	It("leaves the fallback to SpiderCoordinator if it's not set", func() {
+		conf := generateCoordinatorCNIConf(&spiderpoolv2beta1.CoordinatorSpec{Datapath: ptr.To("ebpf")}, nil)
+		data, err := json.Marshal(conf)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).NotTo(HaveKey("datapathFallback"))
+	})
+})
+
+var _ = Describe("SpiderMultusConfig coordinator hostRuleTable", Label("spidermultusconfig", "unittest"), func() {
+	newMacvlanSMC := func(hostRuleTable int) *spiderpoolv2beta1.SpiderMultusConfig {
+		return &spiderpoolv2beta1.SpiderMultusConfig{
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType: ptr.To(constant.MacvlanCNI),
+				MacvlanConfig: &spiderpoolv2beta1.SpiderMacvlanCniConfig{
+					Master: []string{"eth0"},
+					VlanID: ptr.To(int32(0)),
+				},
+				EnableCoordinator: ptr.To(true),
+				CoordinatorConfig: &spiderpoolv2beta1.CoordinatorSpec{HostRuleTable: ptr.To(hostRuleTable)},
+				ChainCNIJsonData:  []string{},
+			},
+		}
+	}
+
+	It("accepts the custom host rule table", func() {
+		Expect(validateCNIConfig(newMacvlanSMC(600))).To(BeNil())
+	})
+
+	DescribeTable("rejects the unspecified and reserved tables",
+		func(hostRuleTable int) {
+			err := validateCNIConfig(newMacvlanSMC(hostRuleTable))
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Field).To(Equal("spec.coordinator.hostRuleTable"))
+		},
+		Entry("unspecified", 0),
+		Entry("negative", -1),
+		Entry("default", 253),
+		Entry("main", 254),
+		Entry("local", 255),
+	)
+})
+
+var _ = Describe("SpiderMultusConfig bridge and host-device", Label("spidermultusconfig", "unittest"), func() {
+	It("generates the bridge CNI config with spiderpool IPAM and coordinator", func() {
+		smc := &spiderpoolv2beta1.SpiderMultusConfig{
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType: ptr.To(constant.BridgeCNI),
+				BridgeConfig: &spiderpoolv2beta1.SpiderBridgeCniConfig{
+					BrName:      "br1",
+					VlanTrunk:   []*spiderpoolv2beta1.Trunk{{MinID: ptr.To(uint(100)), MaxID: ptr.To(uint(200))}},
+					HairpinMode: ptr.To(true),
+					SpiderpoolConfigPools: &spiderpoolv2beta1.SpiderpoolPools{
+						IPv4IPPool: []string{"pool-v4"},
+					},
+				},
+				EnableCoordinator: ptr.To(true),
+			},
+		}
+		mutateSpiderMultusConfig(logutils.IntoContext(context.Background(), zap.NewNop()), smc)
+		Expect(validateCNIConfig(smc)).To(BeNil())
+
+		nad, err := generateNetAttachDef("bridge-demo", smc)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal([]byte(nad.Spec.Config), &decoded)).To(Succeed())
+		plugins := decoded["plugins"].([]interface{})
+		Expect(plugins).To(HaveLen(2))
+		bridge := plugins[0].(map[string]interface{})
+		Expect(bridge).To(HaveKeyWithValue("type", constant.BridgeCNI))
+		Expect(bridge).To(HaveKeyWithValue("bridge", "br1"))
+		Expect(bridge).To(HaveKeyWithValue("hairpinMode", true))
+		Expect(bridge).NotTo(HaveKey("vlan"))
+		Expect(bridge).NotTo(HaveKey("mtu"))
+		Expect(bridge["vlanTrunk"]).To(HaveLen(1))
+		Expect(bridge["ipam"]).To(HaveKeyWithValue("type", constant.Spiderpool))
+		Expect(plugins[1]).To(HaveKeyWithValue("type", constant.Coordinator))
+	})
+
+	It("rejects the bridge without name, with an incorrect trunk or with both vlanID and vlanTrunk", func() {
+		smc := &spiderpoolv2beta1.SpiderMultusConfig{
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType:      ptr.To(constant.BridgeCNI),
+				BridgeConfig: &spiderpoolv2beta1.SpiderBridgeCniConfig{},
+			},
+		}
+		err := validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Field).To(Equal("spec.bridge.bridge"))
+
+		smc.Spec.BridgeConfig.BrName = "br1"
+		smc.Spec.BridgeConfig.VlanTrunk = []*spiderpoolv2beta1.Trunk{{MinID: ptr.To(uint(200)), MaxID: ptr.To(uint(100))}}
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("minID is greater than maxID"))
+
+		smc.Spec.BridgeConfig.VlanID = ptr.To(int32(100))
+		smc.Spec.BridgeConfig.VlanTrunk = []*spiderpoolv2beta1.Trunk{{MinID: ptr.To(uint(100)), MaxID: ptr.To(uint(200))}}
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Field).To(Equal("spec.bridge.vlanTrunk"))
+	})
+
+	It("generates the host-device CNI config", func() {
+		smc := &spiderpoolv2beta1.SpiderMultusConfig{
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType:           ptr.To(constant.HostDeviceCNI),
+				HostDeviceConfig:  &spiderpoolv2beta1.SpiderHostDeviceCniConfig{PCIBusID: "0000:3b:00.1"},
+				EnableCoordinator: ptr.To(false),
+			},
+		}
+		mutateSpiderMultusConfig(logutils.IntoContext(context.Background(), zap.NewNop()), smc)
+		Expect(validateCNIConfig(smc)).To(BeNil())
+
+		nad, err := generateNetAttachDef("hostdevice-demo", smc)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal([]byte(nad.Spec.Config), &decoded)).To(Succeed())
+		plugins := decoded["plugins"].([]interface{})
+		Expect(plugins).To(HaveLen(1))
+		hostDevice := plugins[0].(map[string]interface{})
+		Expect(hostDevice).To(HaveKeyWithValue("type", constant.HostDeviceCNI))
+		Expect(hostDevice).To(HaveKeyWithValue("pciBusID", "0000:3b:00.1"))
+		Expect(hostDevice).NotTo(HaveKey("device"))
+		Expect(hostDevice["ipam"]).To(HaveKeyWithValue("type", constant.Spiderpool))
+	})
+
+	It("requires exactly one identifier of the host device", func() {
+		smc := &spiderpoolv2beta1.SpiderMultusConfig{
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType:          ptr.To(constant.HostDeviceCNI),
+				HostDeviceConfig: &spiderpoolv2beta1.SpiderHostDeviceCniConfig{Device: "eth1", HWAddr: "00:11:22:33:44:55"},
+			},
+		}
+		err := validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("exactly one of device, hwaddr, kernelpath and pciBusID"))
+
+		smc.Spec.HostDeviceConfig = &spiderpoolv2beta1.SpiderHostDeviceCniConfig{PCIBusID: "3b:00.1"}
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("invalid pciBusID"))
+
+		smc.Spec.HostDeviceConfig = &spiderpoolv2beta1.SpiderHostDeviceCniConfig{Device: "eth1"}
+		smc.Spec.MacvlanConfig = &spiderpoolv2beta1.SpiderMacvlanCniConfig{Master: []string{"eth0"}}
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("please remove other CNI configs"))
+	})
+})
+
+var _ = Describe("SpiderMultusConfig ovn-k8s", Label("spidermultusconfig", "unittest"), func() {
+	newOvnK8sSMC := func(config *spiderpoolv2beta1.SpiderOvnK8sCniConfig) *spiderpoolv2beta1.SpiderMultusConfig {
+		return &spiderpoolv2beta1.SpiderMultusConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "localnet-demo", Namespace: "vm"},
+			Spec: spiderpoolv2beta1.MultusCNIConfigSpec{
+				CniType:           ptr.To(constant.OvnK8sCNI),
+				OvnK8sConfig:      config,
+				EnableCoordinator: ptr.To(true),
+			},
+		}
+	}
+
+	It("generates the localnet network without coordinator", func() {
+		smc := newOvnK8sSMC(&spiderpoolv2beta1.SpiderOvnK8sCniConfig{
+			NetworkName:    "physnet",
+			Topology:       constant.OvnK8sTopologyLocalnet,
+			Subnets:        []string{"192.168.100.0/24", "fd00:100::/64"},
+			ExcludeSubnets: []string{"192.168.100.0/29"},
+			VlanID:         ptr.To(int32(100)),
+			MTU:            ptr.To(int32(1400)),
+		})
+		mutateSpiderMultusConfig(logutils.IntoContext(context.Background(), zap.NewNop()), smc)
+		Expect(*smc.Spec.EnableCoordinator).To(BeFalse())
+		Expect(validateCNIConfig(smc)).To(BeNil())
+
+		nad, err := generateNetAttachDef("localnet-demo", smc)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal([]byte(nad.Spec.Config), &decoded)).To(Succeed())
+		Expect(decoded).NotTo(HaveKey("plugins"))
+		Expect(decoded).NotTo(HaveKey("ipam"))
+		Expect(decoded).To(HaveKeyWithValue("name", "physnet"))
+		Expect(decoded).To(HaveKeyWithValue("type", constant.OvnK8sCNIOverlay))
+		Expect(decoded).To(HaveKeyWithValue("topology", constant.OvnK8sTopologyLocalnet))
+		Expect(decoded).To(HaveKeyWithValue("netAttachDefName", "vm/localnet-demo"))
+		Expect(decoded).To(HaveKeyWithValue("subnets", "192.168.100.0/24,fd00:100::/64"))
+		Expect(decoded).To(HaveKeyWithValue("excludeSubnets", "192.168.100.0/29"))
+		Expect(decoded).To(HaveKeyWithValue("vlanID", float64(100)))
+		Expect(decoded).To(HaveKeyWithValue("mtu", float64(1400)))
+	})
+
+	It("names the layer2 network after the NetworkAttachmentDefinition by default", func() {
+		smc := newOvnK8sSMC(&spiderpoolv2beta1.SpiderOvnK8sCniConfig{Topology: constant.OvnK8sTopologyLayer2})
+		mutateSpiderMultusConfig(logutils.IntoContext(context.Background(), zap.NewNop()), smc)
+		Expect(validateCNIConfig(smc)).To(BeNil())
+
+		nad, err := generateNetAttachDef("layer2-demo", smc)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal([]byte(nad.Spec.Config), &decoded)).To(Succeed())
+		Expect(decoded).To(HaveKeyWithValue("name", "layer2-demo"))
+		Expect(decoded).NotTo(HaveKey("subnets"))
+		Expect(decoded).NotTo(HaveKey("vlanID"))
+	})
+
+	It("rejects the invalid networks", func() {
+		smc := newOvnK8sSMC(&spiderpoolv2beta1.SpiderOvnK8sCniConfig{
+			Topology: constant.OvnK8sTopologyLayer2,
+			VlanID:   ptr.To(int32(100)),
+		})
+		err := validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Field).To(Equal("spec.ovnK8s.vlanID"))
+
+		smc = newOvnK8sSMC(&spiderpoolv2beta1.SpiderOvnK8sCniConfig{
+			Topology:       constant.OvnK8sTopologyLocalnet,
+			ExcludeSubnets: []string{"192.168.100.0/29"},
+		})
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("excludeSubnets requires subnets"))
+
+		smc.Spec.OvnK8sConfig.Subnets = []string{"192.168.200.0/24"}
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("isn't contained in any of the subnets"))
+
+		smc = newOvnK8sSMC(&spiderpoolv2beta1.SpiderOvnK8sCniConfig{Topology: "layer3"})
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Field).To(Equal("spec.ovnK8s.topology"))
+
+		smc = newOvnK8sSMC(&spiderpoolv2beta1.SpiderOvnK8sCniConfig{Topology: constant.OvnK8sTopologyLayer2})
+		smc.Spec.ChainCNIJsonData = []string{`{"type":"tuning"}`}
+		err = validateCNIConfig(smc)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("does not support chained CNI"))
+	})
+})
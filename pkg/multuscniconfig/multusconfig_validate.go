@@ -7,6 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net"
+	"regexp"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ktypes "k8s.io/apimachinery/pkg/types"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/containernetworking/cni/libcni"
 	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	coordinatorcmd "github.com/spidernet-io/spiderpool/cmd/coordinator/cmd"
 	"github.com/spidernet-io/spiderpool/cmd/spiderpool/cmd"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/coordinatormanager"
@@ -32,11 +36,18 @@ var (
 	ibsriovConfigField   = field.NewPath("spec").Child("ibsriovConfig")
 	ipoibConfigField     = field.NewPath("spec").Child("ipoibConfig")
 	ovsConfigField       = field.NewPath("spec").Child("ovsConfig")
+	bridgeConfigField    = field.NewPath("spec").Child("bridge")
+	hostDeviceField      = field.NewPath("spec").Child("hostDevice")
+	ovnK8sConfigField    = field.NewPath("spec").Child("ovnK8s")
 	customCniConfigField = field.NewPath("spec").Child("customCniTypeConfig")
 	chainCniConfigField  = field.NewPath("spec").Child("chainCNIJsonData")
+	bandwidthField       = field.NewPath("spec").Child("bandwidth")
+	coordinatorField     = field.NewPath("spec").Child("coordinator")
 	annotationField      = field.NewPath("metadata").Child("annotations")
 )
 
+var pciAddressRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+
 func (mcw *MultusConfigWebhook) validate(ctx context.Context, oldMultusConfig, multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *field.Error {
 	if oldMultusConfig != nil {
 		err := validateCustomAnnoNameShouldNotBeChangeable(oldMultusConfig, multusConfig)
@@ -80,6 +91,15 @@ func checkExistedConfig(spec *spiderpoolv2beta1.MultusCNIConfigSpec, exclude str
 	if exclude != constant.IPoIBCNI && spec.IpoibConfig != nil {
 		return true
 	}
+	if exclude != constant.BridgeCNI && spec.BridgeConfig != nil {
+		return true
+	}
+	if exclude != constant.HostDeviceCNI && spec.HostDeviceConfig != nil {
+		return true
+	}
+	if exclude != constant.OvnK8sCNI && spec.OvnK8sConfig != nil {
+		return true
+	}
 	if exclude != constant.CustomCNI && spec.CustomCNIConfig != nil {
 		return true
 	}
@@ -312,32 +332,91 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 			return field.Invalid(ovsConfigField, *multusConfig.Spec.OvsConfig.VlanTag, err.Error())
 		}
 
-		for idx, trunk := range multusConfig.Spec.OvsConfig.Trunk {
-			if trunk.MinID != nil {
-				if *trunk.MinID > 4094 {
-					return field.Invalid(ovsConfigField, multusConfig.Spec.OvsConfig.Trunk[idx], "incorrect trunk minID parameter")
-				}
-			}
-			if trunk.MaxID != nil {
-				if *trunk.MaxID > 4094 {
-					return field.Invalid(ovsConfigField, multusConfig.Spec.OvsConfig.Trunk[idx], "incorrect trunk maxID parameter")
-				}
-				if *trunk.MaxID < *trunk.MinID {
-					return field.Invalid(ovsConfigField, multusConfig.Spec.OvsConfig.Trunk[idx], "minID is greater than maxID in trunk parameter")
-				}
-			}
-
-			if trunk.ID != nil {
-				if *trunk.ID > 4096 {
-					return field.Invalid(ovsConfigField, multusConfig.Spec.OvsConfig.Trunk[idx], "incorrect trunk id parameter")
-				}
-			}
+		if err := validateTrunks(ovsConfigField, multusConfig.Spec.OvsConfig.Trunk); err != nil {
+			return err
 		}
 
 		if checkExistedConfig(&multusConfig.Spec, constant.OvsCNI) {
 			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s only supports %s, please remove other CNI configs", *multusConfig.Spec.CniType, sriovConfigField.String()))
 		}
 
+	case constant.BridgeCNI:
+		if injectRdmaResource || injectNetworkResource {
+			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s does not support RDMA resource or network resource injected", *multusConfig.Spec.CniType))
+		}
+		if multusConfig.Spec.BridgeConfig == nil {
+			return field.Required(bridgeConfigField, fmt.Sprintf("no %s specified", bridgeConfigField.String()))
+		}
+
+		if multusConfig.Spec.BridgeConfig.BrName == "" {
+			return field.Required(bridgeConfigField.Child("bridge"), "the name of the bridge can't be empty")
+		}
+
+		if err := validateVlanID(multusConfig.Spec.BridgeConfig.VlanID); err != nil {
+			return field.Invalid(bridgeConfigField, *multusConfig.Spec.BridgeConfig.VlanID, err.Error())
+		}
+
+		if err := validateTrunks(bridgeConfigField, multusConfig.Spec.BridgeConfig.VlanTrunk); err != nil {
+			return err
+		}
+
+		// the bridge CNI refuses to set both of them on the port
+		if multusConfig.Spec.BridgeConfig.VlanID != nil && *multusConfig.Spec.BridgeConfig.VlanID != 0 && len(multusConfig.Spec.BridgeConfig.VlanTrunk) != 0 {
+			return field.Forbidden(bridgeConfigField.Child("vlanTrunk"), "vlanTrunk can't be set together with a non-zero vlanID")
+		}
+
+		if multusConfig.Spec.BridgeConfig.MTU != nil && *multusConfig.Spec.BridgeConfig.MTU < 0 {
+			return field.Invalid(bridgeConfigField, *multusConfig.Spec.BridgeConfig.MTU, "MTU must be greater than or equal to 0")
+		}
+
+		if multusConfig.Spec.BridgeConfig.SpiderpoolConfigPools != nil && multusConfig.Spec.BridgeConfig.SpiderpoolConfigPools.MatchMasterSubnet != nil && *multusConfig.Spec.BridgeConfig.SpiderpoolConfigPools.MatchMasterSubnet {
+			return field.Invalid(bridgeConfigField, *multusConfig.Spec.BridgeConfig, "MatchMasterSubnet feature is not supported for bridge")
+		}
+
+		if checkExistedConfig(&multusConfig.Spec, constant.BridgeCNI) {
+			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s only supports %s, please remove other CNI configs", *multusConfig.Spec.CniType, bridgeConfigField.String()))
+		}
+
+	case constant.HostDeviceCNI:
+		if injectRdmaResource || injectNetworkResource {
+			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s does not support RDMA resource or network resource injected", *multusConfig.Spec.CniType))
+		}
+		if multusConfig.Spec.HostDeviceConfig == nil {
+			return field.Required(hostDeviceField, fmt.Sprintf("no %s specified", hostDeviceField.String()))
+		}
+
+		if err := validateHostDevice(multusConfig.Spec.HostDeviceConfig); err != nil {
+			return field.Invalid(hostDeviceField, *multusConfig.Spec.HostDeviceConfig, err.Error())
+		}
+
+		if multusConfig.Spec.HostDeviceConfig.SpiderpoolConfigPools != nil && multusConfig.Spec.HostDeviceConfig.SpiderpoolConfigPools.MatchMasterSubnet != nil && *multusConfig.Spec.HostDeviceConfig.SpiderpoolConfigPools.MatchMasterSubnet {
+			return field.Invalid(hostDeviceField, *multusConfig.Spec.HostDeviceConfig, "MatchMasterSubnet feature is not supported for host-device")
+		}
+
+		if checkExistedConfig(&multusConfig.Spec, constant.HostDeviceCNI) {
+			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s only supports %s, please remove other CNI configs", *multusConfig.Spec.CniType, hostDeviceField.String()))
+		}
+
+	case constant.OvnK8sCNI:
+		if injectRdmaResource || injectNetworkResource {
+			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s does not support RDMA resource or network resource injected", *multusConfig.Spec.CniType))
+		}
+		if multusConfig.Spec.OvnK8sConfig == nil {
+			return field.Required(ovnK8sConfigField, fmt.Sprintf("no %s specified", ovnK8sConfigField.String()))
+		}
+
+		if err := validateOvnK8sConfig(multusConfig.Spec.OvnK8sConfig); err != nil {
+			return err
+		}
+
+		if len(multusConfig.Spec.ChainCNIJsonData) != 0 {
+			return field.Forbidden(chainCniConfigField, fmt.Sprintf("the cniType %s does not support chained CNI", *multusConfig.Spec.CniType))
+		}
+
+		if checkExistedConfig(&multusConfig.Spec, constant.OvnK8sCNI) {
+			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s only supports %s, please remove other CNI configs", *multusConfig.Spec.CniType, ovnK8sConfigField.String()))
+		}
+
 	case constant.CustomCNI:
 		if injectRdmaResource || injectNetworkResource {
 			return field.Forbidden(cniTypeField, fmt.Sprintf("the cniType %s does not support RDMA resource or network resource injected", *multusConfig.Spec.CniType))
@@ -355,7 +434,19 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 	}
 
 	if multusConfig.Spec.CoordinatorConfig != nil {
-		err := coordinatormanager.ValidateCoordinatorSpec(multusConfig.Spec.CoordinatorConfig.DeepCopy(), false)
+		err := coordinatormanager.ValidateCoordinatorHostRuleTable(coordinatorField.Child("hostRuleTable"), multusConfig.Spec.CoordinatorConfig.HostRuleTable)
+		if nil != err {
+			return err
+		}
+
+		err = coordinatormanager.ValidateCoordinatorSpec(multusConfig.Spec.CoordinatorConfig.DeepCopy(), false)
+		if nil != err {
+			return err
+		}
+	}
+
+	if multusConfig.Spec.Bandwidth != nil {
+		err := validateBandwidth(multusConfig)
 		if nil != err {
 			return err
 		}
@@ -372,6 +463,163 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 	return nil
 }
 
+// validateBandwidth validates the bandwidth, which is set up by coordinator in
+// the netns of the pod, so the coordinator must be enabled.
+func validateBandwidth(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *field.Error {
+	bandwidth := multusConfig.Spec.Bandwidth
+
+	supportedCNITypes := []string{constant.MacvlanCNI, constant.IPVlanCNI, constant.OvsCNI, constant.BridgeCNI, constant.HostDeviceCNI}
+	if !slices.Contains(supportedCNITypes, *multusConfig.Spec.CniType) {
+		return field.Forbidden(bandwidthField, fmt.Sprintf("the cniType %s does not support bandwidth, only %v are supported",
+			*multusConfig.Spec.CniType, supportedCNITypes))
+	}
+
+	if multusConfig.Spec.EnableCoordinator == nil || !*multusConfig.Spec.EnableCoordinator {
+		return field.Forbidden(bandwidthField, "the bandwidth is set up by coordinator, please enable coordinator")
+	}
+
+	if err := validateRateAndBurst(bandwidth.IngressRate, bandwidth.IngressBurst); err != nil {
+		return field.Invalid(bandwidthField, *bandwidth, fmt.Sprintf("invalid ingress bandwidth: %v", err))
+	}
+
+	if err := validateRateAndBurst(bandwidth.EgressRate, bandwidth.EgressBurst); err != nil {
+		return field.Invalid(bandwidthField, *bandwidth, fmt.Sprintf("invalid egress bandwidth: %v", err))
+	}
+
+	if bandwidth.DSCP != nil && (*bandwidth.DSCP < 0 || *bandwidth.DSCP > 63) {
+		return field.Invalid(bandwidthField.Child("dscp"), *bandwidth.DSCP, "dscp must be in range [0,63]")
+	}
+
+	if bandwidth.Priority != nil && !coordinatorcmd.PriorityRegex.MatchString(*bandwidth.Priority) {
+		return field.Invalid(bandwidthField.Child("priority"), *bandwidth.Priority, "priority must be in the form major:minor in hex, like 1:10")
+	}
+
+	return nil
+}
+
+func validateRateAndBurst(rate, burst *int64) error {
+	r, b := ptr.Deref(rate, 0), ptr.Deref(burst, 0)
+	if r < 0 || b < 0 {
+		return fmt.Errorf("rate %d and burst %d must be greater than or equal to 0", r, b)
+	}
+	if (r == 0) != (b == 0) {
+		return fmt.Errorf("rate %d and burst %d must be both set or unset", r, b)
+	}
+	// the buffer of the tbf qdisc is a uint32 in bytes
+	if b/8 >= math.MaxUint32 {
+		return fmt.Errorf("burst %d is too large", b)
+	}
+	return nil
+}
+
+func validateTrunks(fieldPath *field.Path, trunks []*spiderpoolv2beta1.Trunk) *field.Error {
+	for idx, trunk := range trunks {
+		if trunk.MinID != nil {
+			if *trunk.MinID > 4094 {
+				return field.Invalid(fieldPath, trunks[idx], "incorrect trunk minID parameter")
+			}
+		}
+		if trunk.MaxID != nil {
+			if *trunk.MaxID > 4094 {
+				return field.Invalid(fieldPath, trunks[idx], "incorrect trunk maxID parameter")
+			}
+			if trunk.MinID != nil && *trunk.MaxID < *trunk.MinID {
+				return field.Invalid(fieldPath, trunks[idx], "minID is greater than maxID in trunk parameter")
+			}
+		}
+
+		if trunk.ID != nil {
+			if *trunk.ID > 4096 {
+				return field.Invalid(fieldPath, trunks[idx], "incorrect trunk id parameter")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateHostDevice makes sure the device is identified by exactly one of
+// the device, hwaddr, kernelpath and pciBusID, like the host-device plugin.
+func validateHostDevice(config *spiderpoolv2beta1.SpiderHostDeviceCniConfig) error {
+	count := 0
+	for _, v := range []string{config.Device, config.HWAddr, config.KernelPath, config.PCIBusID} {
+		if v != "" {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("exactly one of device, hwaddr, kernelpath and pciBusID must be specified")
+	}
+
+	if config.HWAddr != "" {
+		if _, err := net.ParseMAC(config.HWAddr); err != nil {
+			return fmt.Errorf("invalid hwaddr %s: %w", config.HWAddr, err)
+		}
+	}
+
+	if config.PCIBusID != "" && !pciAddressRegex.MatchString(config.PCIBusID) {
+		return fmt.Errorf("invalid pciBusID %s, it must be in the format of 0000:00:1f.6", config.PCIBusID)
+	}
+
+	return nil
+}
+
+func validateOvnK8sConfig(config *spiderpoolv2beta1.SpiderOvnK8sCniConfig) *field.Error {
+	switch config.Topology {
+	case constant.OvnK8sTopologyLayer2:
+		if config.VlanID != nil && *config.VlanID != 0 {
+			return field.Forbidden(ovnK8sConfigField.Child("vlanID"), "vlanID is only supported by the localnet topology")
+		}
+		if config.PhysicalNetworkName != "" {
+			return field.Forbidden(ovnK8sConfigField.Child("physicalNetworkName"), "physicalNetworkName is only supported by the localnet topology")
+		}
+	case constant.OvnK8sTopologyLocalnet:
+		if err := validateVlanID(config.VlanID); err != nil {
+			return field.Invalid(ovnK8sConfigField.Child("vlanID"), *config.VlanID, err.Error())
+		}
+	default:
+		return field.NotSupported(ovnK8sConfigField.Child("topology"), config.Topology, []string{constant.OvnK8sTopologyLayer2, constant.OvnK8sTopologyLocalnet})
+	}
+
+	if config.MTU != nil && *config.MTU < 0 {
+		return field.Invalid(ovnK8sConfigField.Child("mtu"), *config.MTU, "MTU must be greater than or equal to 0")
+	}
+
+	subnets := make([]*net.IPNet, 0, len(config.Subnets))
+	for _, subnet := range config.Subnets {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return field.Invalid(ovnK8sConfigField.Child("subnets"), subnet, err.Error())
+		}
+		subnets = append(subnets, ipNet)
+	}
+
+	if len(config.ExcludeSubnets) != 0 && len(subnets) == 0 {
+		return field.Forbidden(ovnK8sConfigField.Child("excludeSubnets"), "excludeSubnets requires subnets")
+	}
+	for _, excludeSubnet := range config.ExcludeSubnets {
+		_, ipNet, err := net.ParseCIDR(excludeSubnet)
+		if err != nil {
+			return field.Invalid(ovnK8sConfigField.Child("excludeSubnets"), excludeSubnet, err.Error())
+		}
+
+		contained := false
+		for _, subnet := range subnets {
+			excludeOnes, _ := ipNet.Mask.Size()
+			subnetOnes, _ := subnet.Mask.Size()
+			if subnet.Contains(ipNet.IP) && excludeOnes >= subnetOnes {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return field.Invalid(ovnK8sConfigField.Child("excludeSubnets"), excludeSubnet, "it isn't contained in any of the subnets")
+		}
+	}
+
+	return nil
+}
+
 func validateVlanCNIConfig(master []string, bond *spiderpoolv2beta1.BondConfig) error {
 	if len(master) == 0 {
 		return fmt.Errorf("master can't be empty")
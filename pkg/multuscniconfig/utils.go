@@ -98,6 +98,40 @@ type OvsNetConf struct {
 	Trunk    []*v2beta1.Trunk          `json:"trunk,omitempty"`
 }
 
+type BridgeNetConf struct {
+	Type        string                    `json:"type"`
+	BrName      string                    `json:"bridge"`
+	Vlan        int32                     `json:"vlan,omitempty"`
+	VlanTrunk   []*v2beta1.Trunk          `json:"vlanTrunk,omitempty"`
+	HairpinMode bool                      `json:"hairpinMode,omitempty"`
+	MTU         *int32                    `json:"mtu,omitempty"`
+	IPAM        *spiderpoolcmd.IPAMConfig `json:"ipam,omitempty"`
+}
+
+type HostDeviceNetConf struct {
+	Type       string                    `json:"type"`
+	Device     string                    `json:"device,omitempty"`
+	HWAddr     string                    `json:"hwaddr,omitempty"`
+	KernelPath string                    `json:"kernelpath,omitempty"`
+	PCIBusID   string                    `json:"pciBusID,omitempty"`
+	IPAM       *spiderpoolcmd.IPAMConfig `json:"ipam,omitempty"`
+}
+
+// OvnK8sNetConf is a single CNI config rather than a list, the name of which is
+// the name of the OVN network.
+type OvnK8sNetConf struct {
+	CNIVersion          string `json:"cniVersion"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Topology            string `json:"topology"`
+	NetAttachDefName    string `json:"netAttachDefName"`
+	Subnets             string `json:"subnets,omitempty"`
+	ExcludeSubnets      string `json:"excludeSubnets,omitempty"`
+	VlanID              int32  `json:"vlanID,omitempty"`
+	PhysicalNetworkName string `json:"physicalNetworkName,omitempty"`
+	MTU                 int32  `json:"mtu,omitempty"`
+}
+
 type IfacerNetConf struct {
 	VlanID     int                 `json:"vlanID,omitempty"`
 	Type       string              `json:"type"`
@@ -119,6 +153,8 @@ type CoordinatorConfig struct {
 	TunePodRoutes      *bool               `json:"tunePodRoutes,omitempty"`
 	MacPrefix          string              `json:"podMACPrefix,omitempty"`
 	Mode               coordinatorcmd.Mode `json:"mode,omitempty"`
+	Datapath           string              `json:"datapath,omitempty"`
+	DatapathFallback   *bool               `json:"datapathFallback,omitempty"`
 	Type               string              `json:"type"`
 	PodDefaultRouteNIC string              `json:"podDefaultRouteNic,omitempty"`
 	PodRPFilter        *int                `json:"podRPFilter,omitempty" `
@@ -126,6 +162,9 @@ type CoordinatorConfig struct {
 	ServiceCIDR        []string            `json:"serviceCIDR,omitempty"`
 	HijackCIDR         []string            `json:"hijackCIDR,omitempty"`
 	PolicyRoutes       []v2beta1.Route     `json:"policyRoutes,omitempty"`
+
+	Bandwidth    *coordinatorcmd.BandwidthConfig `json:"bandwidth,omitempty"`
+	Capabilities map[string]bool                 `json:"capabilities,omitempty"`
 }
 
 func ParsePodNetworkAnnotation(podNetworks, defaultNamespace string) ([]*netv1.NetworkSelectionElement, error) {
@@ -0,0 +1,7 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ebpf loads the TC programs of the coordinator datapath by
+// github.com/cilium/ebpf. The programs are built from the instructions in
+// place, so that no compiler toolchain is required to build or run spiderpool.
+package ebpf
@@ -0,0 +1,16 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ebpf
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEbpf(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ebpf Suite", Label("ebpf", "unittest"))
+}
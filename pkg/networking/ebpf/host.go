@@ -0,0 +1,223 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ebpf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/rlimit"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// HostLinkName is the veth device on the host, the traffic to the pod
+	// subnets is routed to it and forwarded by the host program.
+	HostLinkName = "spider-host"
+	// HostPeerLinkName is the peer of HostLinkName, nothing is sent to it
+	// unless the host program passes the traffic.
+	HostPeerLinkName = "spider-net"
+	// HostFilterName is the name of the TC filter of the host program
+	HostFilterName = "spiderpool-host"
+	// DefaultPinPath is the directory in the BPF filesystem where the maps of
+	// the host program are pinned.
+	DefaultPinPath = "/sys/fs/bpf/spiderpool"
+
+	hostMapV4Name     = "host_redirect_v4"
+	hostMapV6Name     = "host_redirect_v6"
+	hostMapMaxEntries = 65536
+
+	// pinRetries is the retries to load the maps once they're pinned by
+	// another coordinator at the same time.
+	pinRetries = 3
+)
+
+// HostEndpoint is an entry of the host maps, the traffic to Prefix is
+// redirected to the host veth of the pod whose index is Ifindex.
+type HostEndpoint struct {
+	Prefix  *net.IPNet
+	Ifindex int
+}
+
+// HostRedirect is the host side of the ebpf datapath. Instead of a route for
+// each pod IP, the traffic to the pod subnets is routed to HostLinkName, whose
+// TC program looks up the dst address in the maps pinned on the host: the pod
+// IPs and the prefixes delegated to the pods are redirected to the host veths
+// of the pods, the other addresses of the subnets are forwarded to the NICs
+// which the main routing table of the host routes them to.
+type HostRedirect struct {
+	v4Map, v6Map *ebpf.Map
+}
+
+// OpenHostRedirect loads the host maps pinned in pinPath, they're created
+// and pinned if they haven't been.
+func OpenHostRedirect(pinPath string) (*HostRedirect, error) {
+	// the maps and programs are charged against the locked memory before
+	// kernel 5.11, it's fine to fail on the new kernels.
+	_ = rlimit.RemoveMemlock()
+
+	if err := os.MkdirAll(pinPath, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the pin path %s: %w", pinPath, err)
+	}
+
+	open := func(name string, addrLen int) (m *ebpf.Map, err error) {
+		for i := 0; i < pinRetries; i++ {
+			m, err = newLPMTrie(name, addrLen, hostMapMaxEntries, ebpf.PinByName, pinPath)
+			if !errors.Is(err, os.ErrExist) {
+				break
+			}
+		}
+		return m, err
+	}
+
+	v4Map, err := open(hostMapV4Name, net.IPv4len)
+	if err != nil {
+		return nil, err
+	}
+
+	v6Map, err := open(hostMapV6Name, net.IPv6len)
+	if err != nil {
+		v4Map.Close()
+		return nil, err
+	}
+
+	return &HostRedirect{v4Map: v4Map, v6Map: v6Map}, nil
+}
+
+// LoadHostRedirect loads the host maps pinned in pinPath, the error wraps
+// os.ErrNotExist if they haven't been pinned.
+func LoadHostRedirect(pinPath string) (*HostRedirect, error) {
+	v4Map, err := ebpf.LoadPinnedMap(filepath.Join(pinPath, hostMapV4Name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the host map %s: %w", hostMapV4Name, err)
+	}
+
+	v6Map, err := ebpf.LoadPinnedMap(filepath.Join(pinPath, hostMapV6Name), nil)
+	if err != nil {
+		v4Map.Close()
+		return nil, fmt.Errorf("failed to load the host map %s: %w", hostMapV6Name, err)
+	}
+
+	return &HostRedirect{v4Map: v4Map, v6Map: v6Map}, nil
+}
+
+// Close releases the maps, the pinned ones stay on the host.
+func (h *HostRedirect) Close() error {
+	return errors.Join(h.v4Map.Close(), h.v6Map.Close())
+}
+
+// Attach creates HostLinkName if it doesn't exist, and attaches the host
+// program to its egress, the program attached by the previous call is
+// replaced. HostLinkName is a veth without ARP, so the traffic routed to it
+// is sent without resolving the neighbors.
+func (h *HostRedirect) Attach() (netlink.Link, error) {
+	err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: HostLinkName}, PeerName: HostPeerLinkName})
+	if err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("failed to add %s: %w", HostLinkName, err)
+	}
+
+	link, err := netlink.LinkByName(HostLinkName)
+	if err != nil {
+		return nil, err
+	}
+	if err := netlink.LinkSetARPOff(link); err != nil {
+		return nil, fmt.Errorf("failed to set arp off for %s: %w", HostLinkName, err)
+	}
+
+	peer, err := netlink.LinkByName(HostPeerLinkName)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range []netlink.Link{peer, link} {
+		if err := netlink.LinkSetUp(l); err != nil {
+			return nil, fmt.Errorf("failed to set %s up: %w", l.Attrs().Name, err)
+		}
+	}
+
+	if err := attachProgram(link, netlink.HANDLE_MIN_EGRESS, HostFilterName, redirectProgram(h.v4Map, h.v6Map)); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// AddEndpoint redirects the traffic to the prefix to the NIC of ifindex, after
+// rewriting its hw addresses.
+func (h *HostRedirect) AddEndpoint(prefix *net.IPNet, ifindex int, dstHwAddr, srcHwAddr net.HardwareAddr) error {
+	value, err := newRedirectValue(ifindex, 0, dstHwAddr, srcHwAddr)
+	if err != nil {
+		return err
+	}
+
+	return h.put(prefix, value)
+}
+
+// AddSubnet forwards the traffic to the subnet, which matches no endpoint,
+// to the NIC of ifindex by its neighbors.
+func (h *HostRedirect) AddSubnet(subnet *net.IPNet, ifindex int) error {
+	value, err := newRedirectValue(ifindex, redirectFlagNeigh, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return h.put(subnet, value)
+}
+
+// Delete deletes the endpoint or subnet of the prefix, it's not an error if
+// it doesn't exist.
+func (h *HostRedirect) Delete(prefix *net.IPNet) error {
+	err := h.mapOf(prefix).Delete(lpmKey(prefix))
+	if err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+		return fmt.Errorf("failed to delete %s from the host map: %w", prefix, err)
+	}
+
+	return nil
+}
+
+// Endpoints returns the endpoints in the host maps, the subnets are skipped.
+func (h *HostRedirect) Endpoints() ([]HostEndpoint, error) {
+	var endpoints []HostEndpoint
+	for _, m := range []*ebpf.Map{h.v4Map, h.v6Map} {
+		var key []byte
+		var value redirectValue
+		iter := m.Iterate()
+		for iter.Next(&key, &value) {
+			if value.Flags&redirectFlagNeigh != 0 {
+				continue
+			}
+
+			ones := int(binary.NativeEndian.Uint32(key))
+			ip := net.IP(append([]byte(nil), key[4:]...))
+			endpoints = append(endpoints, HostEndpoint{
+				Prefix:  &net.IPNet{IP: ip, Mask: net.CIDRMask(ones, len(ip)*8)},
+				Ifindex: int(value.Ifindex),
+			})
+		}
+		if err := iter.Err(); err != nil {
+			return nil, fmt.Errorf("failed to iterate the host map: %w", err)
+		}
+	}
+
+	return endpoints, nil
+}
+
+func (h *HostRedirect) put(prefix *net.IPNet, value *redirectValue) error {
+	if err := h.mapOf(prefix).Put(lpmKey(prefix), value); err != nil {
+		return fmt.Errorf("failed to add %s to the host map: %w", prefix, err)
+	}
+
+	return nil
+}
+
+func (h *HostRedirect) mapOf(prefix *net.IPNet) *ebpf.Map {
+	if prefix.IP.To4() != nil {
+		return h.v4Map
+	}
+	return h.v6Map
+}
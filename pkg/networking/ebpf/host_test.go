@@ -0,0 +1,255 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ebpf
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// The harness emulates the node of the underlay mode: uplink is the NIC of the
+// host to the underlay subnet, where ext0 is another host. vethpod is the host
+// veth of the pod whose peer is veth0, the pod IP is on lo of the pod instead
+// of its underlay NIC. The traffic to the subnet is routed to HostLinkName by
+// the host rule table, instead of a route for the pod IP.
+var _ = Describe("HostRedirect — real netns", Label("ebpf_host_test"), func() {
+	const (
+		port          = 8080
+		hostRuleTable = 500
+	)
+
+	var hostNetns, podNetns, extNetns ns.NetNS
+	var uplink, vethpod, veth0 netlink.Link
+	var pinPath string
+
+	BeforeEach(func() {
+		if os.Geteuid() != 0 {
+			Skip("the real netns harness requires root")
+		}
+
+		pinPath = GinkgoT().TempDir()
+		if err := unix.Mount("bpffs", pinPath, "bpf", 0, ""); err != nil {
+			Skip(fmt.Sprintf("failed to mount the BPF filesystem: %v", err))
+		}
+		DeferCleanup(func() {
+			_ = unix.Unmount(pinPath, 0)
+		})
+
+		var err error
+		for _, netns := range []*ns.NetNS{&hostNetns, &podNetns, &extNetns} {
+			*netns, err = testutils.NewNS()
+			Expect(err).NotTo(HaveOccurred())
+		}
+		DeferCleanup(func() {
+			for _, netns := range []ns.NetNS{hostNetns, podNetns, extNetns} {
+				_ = netns.Close()
+				_ = testutils.UnmountNS(netns)
+			}
+		})
+
+		err = hostNetns.Do(func(_ ns.NetNS) error {
+			for _, veth := range []*netlink.Veth{
+				{LinkAttrs: netlink.LinkAttrs{Name: "uplink"}, PeerName: "ext0"},
+				{LinkAttrs: netlink.LinkAttrs{Name: "vethpod"}, PeerName: "veth0"},
+			} {
+				if err := netlink.LinkAdd(veth); err != nil {
+					return err
+				}
+			}
+
+			for peer, netns := range map[string]ns.NetNS{"ext0": extNetns, "veth0": podNetns} {
+				link, err := netlink.LinkByName(peer)
+				if err != nil {
+					return err
+				}
+				if err := netlink.LinkSetNsFd(link, int(netns.Fd())); err != nil {
+					return err
+				}
+			}
+
+			var err error
+			if uplink, err = setUp("uplink", "10.6.0.1/24", "fd00:6::1/64"); err != nil {
+				return err
+			}
+			if vethpod, err = setUp("vethpod"); err != nil {
+				return err
+			}
+
+			for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+				rule := netlink.NewRule()
+				rule.Family = family
+				rule.Table = hostRuleTable
+				rule.Priority = 32765
+				if err := netlink.RuleAdd(rule); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = extNetns.Do(func(_ ns.NetNS) error {
+			if _, err := setUp("lo"); err != nil {
+				return err
+			}
+			_, err := setUp("ext0", "10.6.0.9/24", "fd00:6::9/64")
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = podNetns.Do(func(_ ns.NetNS) error {
+			if _, err := setUp("lo", "10.6.0.2/32", "fd00:6::2/128"); err != nil {
+				return err
+			}
+
+			var err error
+			veth0, err = setUp("veth0")
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	// open opens the host maps, attaches the host program and routes the
+	// subnets to HostLinkName.
+	open := func() *HostRedirect {
+		var h *HostRedirect
+		err := hostNetns.Do(func(_ ns.NetNS) error {
+			var err error
+			if h, err = OpenHostRedirect(pinPath); err != nil {
+				return err
+			}
+
+			link, err := h.Attach()
+			if err != nil {
+				return err
+			}
+
+			for _, subnet := range []string{"10.6.0.0/24", "fd00:6::/64"} {
+				_, ipNet, err := net.ParseCIDR(subnet)
+				if err != nil {
+					return err
+				}
+				if err := h.AddSubnet(ipNet, uplink.Attrs().Index); err != nil {
+					return err
+				}
+
+				route := &netlink.Route{LinkIndex: link.Attrs().Index, Dst: ipNet, Table: hostRuleTable, Scope: netlink.SCOPE_LINK}
+				if err := netlink.RouteReplace(route); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(h.Close)
+
+		return h
+	}
+
+	addEndpoints := func(h *HostRedirect) {
+		for _, ip := range []string{"10.6.0.2/32", "fd00:6::2/128"} {
+			_, ipNet, err := net.ParseCIDR(ip)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h.AddEndpoint(ipNet, vethpod.Attrs().Index, veth0.Attrs().HardwareAddr, vethpod.Attrs().HardwareAddr)).To(Succeed())
+		}
+	}
+
+	// received sends an UDP packet from the host netns to dst, and reports
+	// whether it's received in the netns. The packet queued for the neighbor
+	// resolution may be dropped, so the positive cases are retried.
+	received := func(netns ns.NetNS, dst string) bool {
+		var conn *net.UDPConn
+		err := netns.Do(func(_ ns.NetNS) error {
+			var err error
+			conn, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP(dst), Port: port})
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		err = hostNetns.Do(func(_ ns.NetNS) error {
+			c, err := net.Dial("udp", net.JoinHostPort(dst, fmt.Sprint(port)))
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.Write([]byte("spiderpool"))
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conn.SetReadDeadline(time.Now().Add(time.Second))).To(Succeed())
+		buf := make([]byte, 64)
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return false
+		}
+
+		return string(buf[:n]) == "spiderpool"
+	}
+
+	DescribeTable("redirects the traffic to the pod IPs to the host veths",
+		func(podIP, extIP string) {
+			h := open()
+			Expect(received(podNetns, podIP)).To(BeFalse(), "traffic is expected to be forwarded to the uplink without the endpoint")
+			Eventually(received).WithArguments(extNetns, extIP).WithTimeout(5 * time.Second).Should(BeTrue())
+
+			addEndpoints(h)
+			Eventually(received).WithArguments(podNetns, podIP).WithTimeout(5 * time.Second).Should(BeTrue())
+			Eventually(received).WithArguments(extNetns, extIP).WithTimeout(5 * time.Second).Should(BeTrue())
+
+			// the maps are shared by the coordinators on the node
+			again := open()
+			Eventually(received).WithArguments(podNetns, podIP).WithTimeout(5 * time.Second).Should(BeTrue())
+
+			ip := net.ParseIP(podIP)
+			ipNet := &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+			if ip.To4() != nil {
+				ipNet = &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}
+			}
+			Expect(again.Delete(ipNet)).To(Succeed())
+			Expect(again.Delete(ipNet)).To(Succeed())
+			Expect(received(podNetns, podIP)).To(BeFalse())
+		},
+		Entry("IPv4", "10.6.0.2", "10.6.0.9"),
+		Entry("IPv6", "fd00:6::2", "fd00:6::9"),
+	)
+
+	It("lists the endpoints of the host maps", func() {
+		h := open()
+		addEndpoints(h)
+
+		endpoints, err := h.Endpoints()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoints).To(HaveLen(2))
+
+		prefixes := []string{}
+		for _, endpoint := range endpoints {
+			Expect(endpoint.Ifindex).To(Equal(vethpod.Attrs().Index))
+			prefixes = append(prefixes, endpoint.Prefix.String())
+		}
+		Expect(prefixes).To(ConsistOf("10.6.0.2/32", "fd00:6::2/128"))
+
+		loaded, err := LoadHostRedirect(pinPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer loaded.Close()
+		endpoints, err = loaded.Endpoints()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoints).To(HaveLen(2))
+	})
+
+	It("fails to load the host maps which haven't been pinned", func() {
+		_, err := LoadHostRedirect(pinPath)
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+})
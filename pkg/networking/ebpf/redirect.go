@@ -0,0 +1,332 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ebpf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/rlimit"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// RedirectFilterName is the name of the TC filter of the redirect program
+	RedirectFilterName = "spiderpool-redirect"
+
+	redirectFilterPriority = 1
+	redirectFilterHandle   = 1
+
+	// the offsets of the ethertype and dst address in the ethernet frame
+	ethTypeOffset = 12
+	ipv4DstOffset = 14 + 16
+	ipv6DstOffset = 14 + 24
+
+	// the offsets of the LPM key and the ethertype in the stack of the program
+	stackKey     = -24
+	stackAddr    = -20
+	stackEthType = -4
+
+	// the offsets of the fields of redirectValue
+	valueIfindexOffset = 0
+	valueFlagsOffset   = 4
+	valueHwAddrOffset  = 8
+
+	tcActOK = 0
+)
+
+// redirectFlagNeigh redirects the packet by bpf_redirect_neigh, whose hw
+// addresses are resolved by the neighbor subsystem of the target NIC
+// instead of being rewritten from the value.
+const redirectFlagNeigh uint32 = 1 << 0
+
+// redirectValue is the value of the LPM maps of the redirect programs.
+type redirectValue struct {
+	Ifindex uint32
+	Flags   uint32
+	// DstHwAddr and SrcHwAddr are rewritten to the ethernet header of the
+	// redirected packet if redirectFlagNeigh isn't set.
+	DstHwAddr [6]byte
+	SrcHwAddr [6]byte
+}
+
+// Redirect describes the egress traffic of a NIC to be redirected to another
+// NIC in the same netns.
+type Redirect struct {
+	// CIDRs are the destinations of the traffic to redirect
+	CIDRs []*net.IPNet
+	// TargetIfindex is the index of the NIC which the traffic is redirected to
+	TargetIfindex int
+	// DstHwAddr and SrcHwAddr are rewritten to the ethernet header of the
+	// redirected traffic, so that the peer of the target NIC accepts it.
+	DstHwAddr, SrcHwAddr net.HardwareAddr
+}
+
+// AttachRedirect attaches a TC program to the egress of the link, which
+// redirects the IPv4 and IPv6 traffic to r.CIDRs to the egress of the target
+// NIC. It replaces the program attached by the previous call. The program and
+// its maps are released by the kernel together with the link.
+func AttachRedirect(link netlink.Link, r *Redirect) error {
+	value, err := newRedirectValue(r.TargetIfindex, 0, r.DstHwAddr, r.SrcHwAddr)
+	if err != nil {
+		return err
+	}
+
+	// the maps and programs are charged against the locked memory before
+	// kernel 5.11, it's fine to fail on the new kernels.
+	_ = rlimit.RemoveMemlock()
+
+	v4Map, err := newLPMTrie("", net.IPv4len, max(len(r.CIDRs), 1), ebpf.PinNone, "")
+	if err != nil {
+		return err
+	}
+	defer v4Map.Close()
+
+	v6Map, err := newLPMTrie("", net.IPv6len, max(len(r.CIDRs), 1), ebpf.PinNone, "")
+	if err != nil {
+		return err
+	}
+	defer v6Map.Close()
+
+	for _, cidr := range r.CIDRs {
+		m := v6Map
+		if cidr.IP.To4() != nil {
+			m = v4Map
+		}
+		if err := m.Put(lpmKey(cidr), value); err != nil {
+			return fmt.Errorf("failed to add %s to the map: %w", cidr, err)
+		}
+	}
+
+	return attachProgram(link, netlink.HANDLE_MIN_EGRESS, RedirectFilterName, redirectProgram(v4Map, v6Map))
+}
+
+// DetachRedirect detaches the TC program attached by AttachRedirect from the
+// egress of the link, it's not an error if none is attached.
+func DetachRedirect(link netlink.Link) error {
+	return detachProgram(link, netlink.HANDLE_MIN_EGRESS, RedirectFilterName)
+}
+
+// attachProgram loads the TC classifier and attaches it to the parent of the
+// link with the name, the program attached with the same name is replaced.
+func attachProgram(link netlink.Link, parent uint32, name string, insns asm.Instructions) error {
+	// the log of the verifier is returned within the error if it's rejected
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Type:         ebpf.SchedCLS,
+		Instructions: insns,
+		License:      "Apache-2.0",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load the TC program: %w", err)
+	}
+	defer prog.Close()
+
+	if err := ensureClsact(link); err != nil {
+		return err
+	}
+
+	filter := &netlink.BpfFilter{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    parent,
+			Handle:    redirectFilterHandle,
+			Priority:  redirectFilterPriority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Fd:           prog.FD(),
+		Name:         name,
+		DirectAction: true,
+	}
+	if err := netlink.FilterReplace(filter); err != nil {
+		return fmt.Errorf("failed to attach the TC program to %s: %w", link.Attrs().Name, err)
+	}
+
+	return nil
+}
+
+func detachProgram(link netlink.Link, parent uint32, name string) error {
+	filters, err := netlink.FilterList(link, parent)
+	if err != nil {
+		// no clsact qdisc
+		if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOENT) {
+			return nil
+		}
+		return fmt.Errorf("failed to list the TC filters of %s: %w", link.Attrs().Name, err)
+	}
+
+	for _, f := range filters {
+		bpfFilter, ok := f.(*netlink.BpfFilter)
+		if !ok || bpfFilter.Name != name {
+			continue
+		}
+
+		if err := netlink.FilterDel(f); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to detach the TC program from %s: %w", link.Attrs().Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureClsact adds the clsact qdisc to the link which the TC programs are
+// attached to.
+func ensureClsact(link netlink.Link) error {
+	qdisc := &netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}
+
+	if err := netlink.QdiscAdd(qdisc); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("failed to add clsact qdisc to %s: %w", link.Attrs().Name, err)
+	}
+
+	return nil
+}
+
+// newLPMTrie creates the longest prefix match map of redirectValue, whose key
+// is the prefix length followed by the address in network byte order. The map
+// is pinned by the name in the pinPath, or loaded if it has been pinned.
+func newLPMTrie(name string, addrLen, maxEntries int, pinning ebpf.PinType, pinPath string) (*ebpf.Map, error) {
+	m, err := ebpf.NewMapWithOptions(&ebpf.MapSpec{
+		Name:       name,
+		Type:       ebpf.LPMTrie,
+		KeySize:    uint32(4 + addrLen),
+		ValueSize:  uint32(binary.Size(redirectValue{})),
+		MaxEntries: uint32(maxEntries),
+		Flags:      unix.BPF_F_NO_PREALLOC,
+		Pinning:    pinning,
+	}, ebpf.MapOptions{PinPath: pinPath})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LPM trie map: %w", err)
+	}
+
+	return m, nil
+}
+
+func newRedirectValue(ifindex int, flags uint32, dstHwAddr, srcHwAddr net.HardwareAddr) (*redirectValue, error) {
+	value := &redirectValue{Ifindex: uint32(ifindex), Flags: flags}
+	if flags&redirectFlagNeigh != 0 {
+		return value, nil
+	}
+
+	if len(dstHwAddr) != 6 || len(srcHwAddr) != 6 {
+		return nil, fmt.Errorf("invalid hardware address %q or %q", dstHwAddr, srcHwAddr)
+	}
+	copy(value.DstHwAddr[:], dstHwAddr)
+	copy(value.SrcHwAddr[:], srcHwAddr)
+
+	return value, nil
+}
+
+// lpmKey returns the key of the LPM maps for the CIDR.
+func lpmKey(cidr *net.IPNet) []byte {
+	ones, _ := cidr.Mask.Size()
+	ip := cidr.IP.To4()
+	if ip == nil {
+		ip = cidr.IP.To16()
+	}
+
+	key := binary.NativeEndian.AppendUint32(nil, uint32(ones))
+	return append(key, ip.Mask(net.CIDRMask(ones, len(ip)*8))...)
+}
+
+// redirectProgram returns the TC classifier which looks up the dst address of
+// the IPv4 or IPv6 packet in the maps, and redirects the matched one to the
+// NIC of the value, the others are passed.
+func redirectProgram(v4Map, v6Map *ebpf.Map) asm.Instructions {
+	// loadBytes copies n bytes of the packet at offset to the stack
+	loadBytes := func(offset, stack, n int32) asm.Instructions {
+		return asm.Instructions{
+			asm.Mov.Reg(asm.R1, asm.R6),
+			asm.Mov.Imm(asm.R2, offset),
+			asm.Mov.Reg(asm.R3, asm.RFP),
+			asm.Add.Imm(asm.R3, stack),
+			asm.Mov.Imm(asm.R4, n),
+			asm.FnSkbLoadBytes.Call(),
+			asm.JNE.Imm(asm.R0, 0, "pass"),
+		}
+	}
+
+	// lookup looks up the key in the stack from the map
+	lookup := func(m *ebpf.Map) asm.Instructions {
+		return asm.Instructions{
+			asm.LoadMapPtr(asm.R1, m.FD()),
+			asm.Mov.Reg(asm.R2, asm.RFP),
+			asm.Add.Imm(asm.R2, stackKey),
+			asm.FnMapLookupElem.Call(),
+			asm.Ja.Label("found"),
+		}
+	}
+
+	// r6 = skb
+	insns := asm.Instructions{asm.Mov.Reg(asm.R6, asm.R1)}
+	insns = append(insns, loadBytes(ethTypeOffset, stackEthType, 2)...)
+	insns = append(insns,
+		asm.LoadMem(asm.R1, asm.RFP, stackEthType, asm.Half),
+		// the ethertype is in network byte order
+		asm.JEq.Imm(asm.R1, int32(nativeUint16([]byte{0x08, 0x00})), "ipv4"),
+		asm.JEq.Imm(asm.R1, int32(nativeUint16([]byte{0x86, 0xdd})), "ipv6"),
+		asm.Ja.Label("pass"),
+
+		asm.StoreImm(asm.RFP, stackKey, 32, asm.Word).WithSymbol("ipv4"),
+	)
+	insns = append(insns, loadBytes(ipv4DstOffset, stackAddr, net.IPv4len)...)
+	insns = append(insns, lookup(v4Map)...)
+
+	insns = append(insns,
+		asm.StoreImm(asm.RFP, stackKey, 128, asm.Word).WithSymbol("ipv6"),
+	)
+	insns = append(insns, loadBytes(ipv6DstOffset, stackAddr, net.IPv6len)...)
+	insns = append(insns, lookup(v6Map)...)
+
+	insns = append(insns,
+		asm.JEq.Imm(asm.R0, 0, "pass").WithSymbol("found"),
+		// r7 = value.ifindex
+		asm.LoadMem(asm.R7, asm.R0, valueIfindexOffset, asm.Word),
+		asm.LoadMem(asm.R8, asm.R0, valueFlagsOffset, asm.Word),
+		asm.JSet.Imm(asm.R8, int32(redirectFlagNeigh), "neigh"),
+
+		// rewrite the dst and src hw address
+		asm.Mov.Reg(asm.R3, asm.R0),
+		asm.Add.Imm(asm.R3, valueHwAddrOffset),
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.Mov.Imm(asm.R2, 0),
+		asm.Mov.Imm(asm.R4, 2*6),
+		asm.Mov.Imm(asm.R5, 0),
+		asm.FnSkbStoreBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, "pass"),
+		// return bpf_redirect(value.ifindex, 0)
+		asm.Mov.Reg(asm.R1, asm.R7),
+		asm.Mov.Imm(asm.R2, 0),
+		asm.FnRedirect.Call(),
+		asm.Return(),
+
+		// return bpf_redirect_neigh(value.ifindex, NULL, 0, 0)
+		asm.Mov.Reg(asm.R1, asm.R7).WithSymbol("neigh"),
+		asm.Mov.Imm(asm.R2, 0),
+		asm.Mov.Imm(asm.R3, 0),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnRedirectNeigh.Call(),
+		asm.Return(),
+
+		asm.Mov.Imm(asm.R0, tcActOK).WithSymbol("pass"),
+		asm.Return(),
+	)
+
+	return insns
+}
+
+func nativeUint16(b []byte) uint16 {
+	return binary.NativeEndian.Uint16(b)
+}
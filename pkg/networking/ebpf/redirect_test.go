@@ -0,0 +1,238 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ebpf
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// The harness emulates the pod of the underlay mode: net1 is the underlay NIC
+// whose gateway is gw0, veth0 is the veth to the host netns whose peer is
+// host0. The traffic to the service CIDR is routed to net1, and is expected to
+// be received in the host netns once it's redirected to veth0.
+var _ = Describe("Redirect — real netns", Label("ebpf_redirect_test"), func() {
+	const port = 8080
+
+	var podNetns, hostNetns ns.NetNS
+	var net1, veth0, host0 netlink.Link
+
+	BeforeEach(func() {
+		if os.Geteuid() != 0 {
+			Skip("the real netns harness requires root")
+		}
+
+		var err error
+		podNetns, err = testutils.NewNS()
+		Expect(err).NotTo(HaveOccurred())
+		hostNetns, err = testutils.NewNS()
+		Expect(err).NotTo(HaveOccurred())
+
+		DeferCleanup(func() {
+			_ = podNetns.Close()
+			_ = testutils.UnmountNS(podNetns)
+			_ = hostNetns.Close()
+			_ = testutils.UnmountNS(hostNetns)
+		})
+
+		err = podNetns.Do(func(_ ns.NetNS) error {
+			for _, veth := range []*netlink.Veth{
+				{LinkAttrs: netlink.LinkAttrs{Name: "net1"}, PeerName: "gw0"},
+				{LinkAttrs: netlink.LinkAttrs{Name: "veth0"}, PeerName: "host0"},
+			} {
+				if err := netlink.LinkAdd(veth); err != nil {
+					return err
+				}
+			}
+
+			gw0, err := setUp("gw0")
+			if err != nil {
+				return err
+			}
+			if net1, err = setUp("net1", "10.6.0.2/24", "fd00:6::2/64"); err != nil {
+				return err
+			}
+			if veth0, err = setUp("veth0"); err != nil {
+				return err
+			}
+
+			for _, gw := range []string{"10.6.0.1", "fd00:6::1"} {
+				if err := netlink.NeighAdd(&netlink.Neigh{
+					LinkIndex:    net1.Attrs().Index,
+					State:        netlink.NUD_PERMANENT,
+					IP:           net.ParseIP(gw),
+					HardwareAddr: gw0.Attrs().HardwareAddr,
+				}); err != nil {
+					return err
+				}
+			}
+
+			if err := addRoute(net1, "10.233.0.0/18", "10.6.0.1"); err != nil {
+				return err
+			}
+			if err := addRoute(net1, "fd00:233::/112", "fd00:6::1"); err != nil {
+				return err
+			}
+
+			host0, err = netlink.LinkByName("host0")
+			if err != nil {
+				return err
+			}
+			return netlink.LinkSetNsFd(host0, int(hostNetns.Fd()))
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = hostNetns.Do(func(_ ns.NetNS) error {
+			var err error
+			if host0, err = setUp("host0", "10.233.0.10/32", "fd00:233::10/128"); err != nil {
+				return err
+			}
+			if err := addRoute(host0, "10.6.0.0/24", ""); err != nil {
+				return err
+			}
+			return addRoute(host0, "fd00:6::/64", "")
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	attach := func(cidrs ...string) error {
+		r := &Redirect{
+			TargetIfindex: veth0.Attrs().Index,
+			DstHwAddr:     host0.Attrs().HardwareAddr,
+			SrcHwAddr:     veth0.Attrs().HardwareAddr,
+		}
+		for _, cidr := range cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return err
+			}
+			r.CIDRs = append(r.CIDRs, ipNet)
+		}
+
+		return podNetns.Do(func(_ ns.NetNS) error {
+			return AttachRedirect(net1, r)
+		})
+	}
+
+	// received sends an UDP packet from the pod netns to dst, and reports
+	// whether it's received in the host netns.
+	received := func(dst string) bool {
+		var conn *net.UDPConn
+		err := hostNetns.Do(func(_ ns.NetNS) error {
+			var err error
+			conn, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP(dst), Port: port})
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		err = podNetns.Do(func(_ ns.NetNS) error {
+			c, err := net.Dial("udp", net.JoinHostPort(dst, fmt.Sprint(port)))
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.Write([]byte("spiderpool"))
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conn.SetReadDeadline(time.Now().Add(time.Second))).To(Succeed())
+		buf := make([]byte, 64)
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return false
+		}
+
+		return string(buf[:n]) == "spiderpool"
+	}
+
+	DescribeTable("redirects the traffic to the CIDRs to the host",
+		func(dst string) {
+			Expect(received(dst)).To(BeFalse(), "traffic is expected to be routed to the underlay without the program")
+
+			Expect(attach("10.233.0.0/18", "fd00:233::/112")).To(Succeed())
+			Expect(received(dst)).To(BeTrue())
+
+			// attaching again replaces the program
+			Expect(attach("10.233.0.0/18", "fd00:233::/112")).To(Succeed())
+			Expect(received(dst)).To(BeTrue())
+
+			Expect(podNetns.Do(func(_ ns.NetNS) error {
+				return DetachRedirect(net1)
+			})).To(Succeed())
+			Expect(received(dst)).To(BeFalse())
+		},
+		Entry("IPv4", "10.233.0.10"),
+		Entry("IPv6", "fd00:233::10"),
+	)
+
+	It("passes the traffic to the other destinations", func() {
+		Expect(attach("10.233.1.0/24", "fd00:233::100/120")).To(Succeed())
+		Expect(received("10.233.0.10")).To(BeFalse())
+		Expect(received("fd00:233::10")).To(BeFalse())
+	})
+
+	It("detaches nothing if no program is attached", func() {
+		Expect(podNetns.Do(func(_ ns.NetNS) error {
+			return DetachRedirect(net1)
+		})).To(Succeed())
+	})
+
+	It("rejects the invalid hardware address", func() {
+		err := podNetns.Do(func(_ ns.NetNS) error {
+			return AttachRedirect(net1, &Redirect{TargetIfindex: veth0.Attrs().Index})
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func setUp(name string, addrs ...string) (netlink.Link, error) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range addrs {
+		addr, err := netlink.ParseAddr(a)
+		if err != nil {
+			return nil, err
+		}
+		addr.Flags |= unix.IFA_F_NODAD
+		if err := netlink.AddrAdd(link, addr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := netlink.LinkSetUp(link); err != nil {
+		return nil, err
+	}
+
+	return netlink.LinkByName(name)
+}
+
+func addRoute(link netlink.Link, dst, gw string) error {
+	_, ipNet, err := net.ParseCIDR(dst)
+	if err != nil {
+		return err
+	}
+
+	route := &netlink.Route{LinkIndex: link.Attrs().Index, Dst: ipNet}
+	if gw != "" {
+		route.Gw = net.ParseIP(gw)
+	} else {
+		route.Scope = netlink.SCOPE_LINK
+	}
+
+	return netlink.RouteAdd(route)
+}
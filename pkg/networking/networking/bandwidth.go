@@ -0,0 +1,153 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package networking
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// the latency of the tbf qdisc, the same as the bandwidth plugin
+	tbfLatencyInMillis = 25
+
+	// ingressRedirectPriority is the priority of the filter in the ingress of
+	// the clsact qdisc, which redirects the traffic to the ifb device
+	ingressRedirectPriority = 1
+)
+
+// SetEgressRateLimit replaces the root qdisc of the link with a tbf qdisc,
+// which shapes the traffic sent by the link. Equivalent to:
+// `tc qdisc replace dev <link> root tbf rate <rate>bit burst <burst>bit latency 25ms`
+func SetEgressRateLimit(link netlink.Link, rateInBits, burstInBits uint64) error {
+	if rateInBits == 0 || burstInBits == 0 {
+		return fmt.Errorf("invalid egress rate %d or burst %d", rateInBits, burstInBits)
+	}
+
+	rateInBytes := rateInBits / 8
+	burstInBytes := burstInBits / 8
+	bufferInTicks := tbfBuffer(rateInBytes, burstInBytes)
+
+	qdisc := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rateInBytes,
+		Limit:  tbfLimit(rateInBytes, burstInBytes),
+		Buffer: bufferInTicks,
+	}
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to replace the tbf qdisc of %s: %w", link.Attrs().Name, err)
+	}
+
+	return nil
+}
+
+// SetIngressRateLimit shapes the traffic received by the link like the
+// bandwidth plugin does, the traffic is redirected to an ifb device in the
+// same netns, whose egress is shaped by the tbf qdisc.
+func SetIngressRateLimit(link netlink.Link, rateInBits, burstInBits uint64) error {
+	if rateInBits == 0 || burstInBits == 0 {
+		return fmt.Errorf("invalid ingress rate %d or burst %d", rateInBits, burstInBits)
+	}
+
+	ifb, err := ensureIfb(IfbName(link.Attrs().Name), link.Attrs().MTU)
+	if err != nil {
+		return err
+	}
+
+	if err := SetEgressRateLimit(ifb, rateInBits, burstInBits); err != nil {
+		return err
+	}
+
+	qdisc := &netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}
+	if err := netlink.QdiscAdd(qdisc); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("failed to add clsact qdisc to %s: %w", link.Attrs().Name, err)
+	}
+
+	filters, err := netlink.FilterList(link, netlink.HANDLE_MIN_INGRESS)
+	if err != nil {
+		return fmt.Errorf("failed to list the ingress filters of %s: %w", link.Attrs().Name, err)
+	}
+	for _, f := range filters {
+		if u32, ok := f.(*netlink.U32); ok && u32.Priority == ingressRedirectPriority {
+			for _, action := range u32.Actions {
+				if mirred, ok := action.(*netlink.MirredAction); ok && mirred.Ifindex == ifb.Attrs().Index {
+					return nil
+				}
+			}
+		}
+	}
+
+	// equivalent: tc filter add dev <link> ingress protocol all prio 1 u32 match u32 0 0 action mirred egress redirect dev <ifb>
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_INGRESS,
+			Priority:  ingressRedirectPriority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		ClassId: netlink.MakeHandle(1, 1),
+		Actions: []netlink.Action{netlink.NewMirredAction(ifb.Attrs().Index)},
+	}
+	if err := netlink.FilterAdd(filter); err != nil {
+		return fmt.Errorf("failed to redirect the ingress traffic of %s to %s: %w", link.Attrs().Name, ifb.Attrs().Name, err)
+	}
+
+	return nil
+}
+
+// IfbName returns the name of the ifb device which shapes the ingress traffic
+// of the link.
+func IfbName(linkName string) string {
+	name := "ifb-" + linkName
+	return name[:min(len(name), unix.IFNAMSIZ-1)]
+}
+
+func ensureIfb(name string, mtu int) (netlink.Link, error) {
+	ifb, err := netlink.LinkByName(name)
+	if err != nil {
+		var linkNotFoundErr netlink.LinkNotFoundError
+		if !errors.As(err, &linkNotFoundErr) {
+			return nil, fmt.Errorf("failed to get ifb device %s: %w", name, err)
+		}
+
+		if err := netlink.LinkAdd(&netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: name, MTU: mtu, TxQLen: 1000}}); err != nil {
+			return nil, fmt.Errorf("failed to add ifb device %s: %w", name, err)
+		}
+
+		if ifb, err = netlink.LinkByName(name); err != nil {
+			return nil, fmt.Errorf("failed to get ifb device %s: %w", name, err)
+		}
+	}
+
+	if err := netlink.LinkSetUp(ifb); err != nil {
+		return nil, fmt.Errorf("failed to set ifb device %s up: %w", name, err)
+	}
+
+	return ifb, nil
+}
+
+// tbfBuffer returns the time in ticks to send the burst at the rate
+func tbfBuffer(rateInBytes, burstInBytes uint64) uint32 {
+	return uint32(float64(burstInBytes) * float64(netlink.TIME_UNITS_PER_SEC) / float64(rateInBytes) * netlink.TickInUsec())
+}
+
+// tbfLimit returns the bytes queued by the tbf qdisc within the latency
+func tbfLimit(rateInBytes, burstInBytes uint64) uint32 {
+	return uint32(float64(rateInBytes)*tbfLatencyInMillis/1000 + float64(burstInBytes))
+}
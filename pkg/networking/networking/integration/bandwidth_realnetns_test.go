@@ -0,0 +1,122 @@
+// Copyright 2026 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package integration_test
+
+import (
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+)
+
+var _ = Describe("Bandwidth — real netns", Label("networking_bandwidth_realnetns_test"), func() {
+	var testNetns ns.NetNS
+	var link netlink.Link
+
+	BeforeEach(func() {
+		var err error
+		testNetns, err = testutils.NewNS()
+		Expect(err).NotTo(HaveOccurred())
+
+		DeferCleanup(func() {
+			_ = testNetns.Close()
+			_ = testutils.UnmountNS(testNetns)
+		})
+
+		err = testNetns.Do(func(_ ns.NetNS) error {
+			veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "net1"}, PeerName: "peer1"}
+			if err := netlink.LinkAdd(veth); err != nil {
+				return err
+			}
+			link, err = netlink.LinkByName("net1")
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("shapes the egress traffic by the tbf qdisc", func() {
+		err := testNetns.Do(func(_ ns.NetNS) error {
+			if err := networking.SetEgressRateLimit(link, 100_000_000, 1_000_000); err != nil {
+				return err
+			}
+			// replace the previous one
+			return networking.SetEgressRateLimit(link, 200_000_000, 2_000_000)
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var qdiscs []netlink.Qdisc
+		err = testNetns.Do(func(_ ns.NetNS) error {
+			var err error
+			qdiscs, err = netlink.QdiscList(link)
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var tbf *netlink.Tbf
+		for _, q := range qdiscs {
+			if t, ok := q.(*netlink.Tbf); ok {
+				tbf = t
+			}
+		}
+		Expect(tbf).NotTo(BeNil())
+		Expect(tbf.Rate).To(Equal(uint64(200_000_000 / 8)))
+	})
+
+	It("shapes the ingress traffic by the ifb device", func() {
+		err := testNetns.Do(func(_ ns.NetNS) error {
+			if err := networking.SetIngressRateLimit(link, 100_000_000, 1_000_000); err != nil {
+				return err
+			}
+			return networking.SetIngressRateLimit(link, 200_000_000, 2_000_000)
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var ifb netlink.Link
+		var filters []netlink.Filter
+		var qdiscs []netlink.Qdisc
+		err = testNetns.Do(func(_ ns.NetNS) error {
+			var err error
+			if ifb, err = netlink.LinkByName(networking.IfbName("net1")); err != nil {
+				return err
+			}
+			if filters, err = netlink.FilterList(link, netlink.HANDLE_MIN_INGRESS); err != nil {
+				return err
+			}
+			qdiscs, err = netlink.QdiscList(ifb)
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// the redirect filter isn't added twice
+		Expect(filters).To(HaveLen(1))
+		u32, ok := filters[0].(*netlink.U32)
+		Expect(ok).To(BeTrue())
+		Expect(u32.Actions).To(HaveLen(1))
+		mirred, ok := u32.Actions[0].(*netlink.MirredAction)
+		Expect(ok).To(BeTrue())
+		Expect(mirred.Ifindex).To(Equal(ifb.Attrs().Index))
+
+		var tbf *netlink.Tbf
+		for _, q := range qdiscs {
+			if t, ok := q.(*netlink.Tbf); ok {
+				tbf = t
+			}
+		}
+		Expect(tbf).NotTo(BeNil())
+		Expect(tbf.Rate).To(Equal(uint64(200_000_000 / 8)))
+	})
+
+	It("truncates the name of the ifb device", func() {
+		Expect(networking.IfbName("net1")).To(Equal("ifb-net1"))
+		Expect(networking.IfbName("0123456789abcde")).To(Equal("ifb-0123456789a"))
+	})
+
+	It("rejects the rate without burst", func() {
+		Expect(networking.SetEgressRateLimit(link, 100_000_000, 0)).NotTo(Succeed())
+		Expect(networking.SetIngressRateLimit(link, 0, 1_000_000)).NotTo(Succeed())
+	})
+})
@@ -12,10 +12,13 @@ import (
 	"net/netip"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/vishvananda/netlink"
 	"go.uber.org/zap"
+
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 )
 
 // OverwriteHwAddress override the hardware address of the specified interface.
@@ -41,6 +44,14 @@ func OverwriteHwAddress(logger *zap.Logger, netns ns.NetNS, macPrefix, iface str
 
 	// newmac = xx:xx + xx:xx:xx:xx
 	hwAddr := macPrefix + ":" + suffix
+
+	// keep consistent with the EUI-64 IPv6 address allocated by IPAM
+	for _, ip := range ips {
+		if mac := spiderpoolip.ParseEUI64MAC(ip.IP); mac != nil && strings.HasPrefix(mac.String(), strings.ToLower(macPrefix)+":") {
+			hwAddr = mac.String()
+			break
+		}
+	}
 	err = netns.Do(func(netNS ns.NetNS) error {
 		link, err := netlink.LinkByName(iface)
 		if err != nil {
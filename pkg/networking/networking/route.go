@@ -15,6 +15,11 @@ import (
 
 var defaultRulePriority = 1000
 
+// RouteProtocolCoordinator is the protocol of the routes added by coordinator
+// to the host rule table, so that they're told apart from the routes added by
+// the other components, like `ip route show proto 83` does.
+const RouteProtocolCoordinator netlink.RouteProtocol = 83
+
 // GetRoutesByName return all routes is belonged to specify interface
 // filter by family also
 func GetRoutesByName(iface string, ipfamily int) (routes []netlink.Route, err error) {
@@ -102,6 +107,12 @@ func DelFromRuleTable(src *net.IPNet, ruleTable int) error {
 
 // AddRoute add static route to specify rule table
 func AddRoute(logger *zap.Logger, ruleTable, ipFamily int, scope netlink.Scope, iface string, src, dst *net.IPNet, v4Gw, v6Gw net.IP) error {
+	return AddRouteWithProtocol(logger, ruleTable, ipFamily, scope, iface, src, dst, v4Gw, v6Gw, 0)
+}
+
+// AddRouteWithProtocol is like AddRoute, the route is tagged with the protocol
+// if it's not 0.
+func AddRouteWithProtocol(logger *zap.Logger, ruleTable, ipFamily int, scope netlink.Scope, iface string, src, dst *net.IPNet, v4Gw, v6Gw net.IP, protocol netlink.RouteProtocol) error {
 	link, err := netlink.LinkByName(iface)
 	if err != nil {
 		logger.Error(err.Error())
@@ -113,6 +124,7 @@ func AddRoute(logger *zap.Logger, ruleTable, ipFamily int, scope netlink.Scope,
 		Scope:     scope,
 		Dst:       dst,
 		Table:     ruleTable,
+		Protocol:  protocol,
 	}
 
 	if src != nil {
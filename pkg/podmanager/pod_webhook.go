@@ -110,6 +110,11 @@ func (pw *PWebhook) Default(ctx context.Context, obj runtime.Object) error {
 		}
 	}
 
+	if err = podIPQuotaMutatingWebhook(ctx, pw.spiderClient, pod); err != nil {
+		mutateLogger.Sugar().Errorf("Failed to check IP quota for pod %s/%s: %v", pod.Namespace, pod.GenerateName, err)
+		return err
+	}
+
 	if err = podMasterNICResourceMutatingWebhook(ctx, pw.spiderClient, pod, pw.eniConfig); err != nil {
 		mutateLogger.Sugar().Errorf("Failed to inject master NIC resources for pod %s/%s: %v", pod.Namespace, pod.GenerateName, err)
 		return err
@@ -393,6 +393,37 @@ var _ = Describe("Pod Webhook Internal", Label("podwebhook", "unittest"), func()
 			Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveLen(3))
 		})
 
+		It("should inject the resource of the device moved into the Pod by host-device", func() {
+			ctx := context.Background()
+			hostDeviceType := constant.HostDeviceCNI
+			spiderClient := spiderpoolfake.NewSimpleClientset(&v2beta1.SpiderMultusConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "hostdevice-net", Namespace: "tenant-a"},
+				Spec: v2beta1.MultusCNIConfigSpec{
+					CniType:          &hostDeviceType,
+					HostDeviceConfig: &v2beta1.SpiderHostDeviceCniConfig{Device: "eth3"},
+				},
+			})
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod-a",
+					Namespace: "tenant-a",
+					Annotations: map[string]string{
+						constant.MultusNetworkAttachmentAnnot: "tenant-a/hostdevice-net",
+					},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			}
+
+			err := podMasterNICResourceMutatingWebhook(ctx, spiderClient, pod, PodENIResourceInjectConfig{
+				MasterNICEnabled:      true,
+				InjectPodENIResources: true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveLen(1))
+			Expect(pod.Spec.Containers[0].Resources.Limits["spidernet.io/eth3-nic"]).To(Equal(resource.MustParse("1")))
+		})
+
 		It("should preserve a master NIC resource already declared by the workload", func() {
 			ctx := context.Background()
 			cniType := constant.MacvlanCNI
@@ -737,4 +768,96 @@ var _ = Describe("Pod Webhook Internal", Label("podwebhook", "unittest"), func()
 			})
 		})
 	})
+
+	Describe("podIPQuotaMutatingWebhook", Label("podwebhook_ip_quota_test"), func() {
+		var ctx context.Context
+		var spiderClient *spiderpoolfake.Clientset
+		var pod *corev1.Pod
+
+		newIPPool := func(name string, maxIPs int64) *v2beta1.SpiderIPPool {
+			return &v2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: v2beta1.IPPoolSpec{
+					NamespaceQuota: &v2beta1.IPPoolNamespaceQuota{
+						Namespaces: []v2beta1.NamespaceIPQuota{
+							{Namespace: "tenant-a", MaxIPs: maxIPs},
+						},
+					},
+				},
+				Status: v2beta1.IPPoolStatus{
+					AllocatedIPs: ptr.To(`{"172.18.40.40":{"pod":"tenant-a/other","podUid":"uid"}}`),
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			spiderClient = spiderpoolfake.NewSimpleClientset(
+				newIPPool("full-pool", 1),
+				newIPPool("spare-pool", 2),
+			)
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod-a",
+					Namespace:   "tenant-a",
+					Annotations: map[string]string{},
+				},
+			}
+		})
+
+		It("rejects the Pod if all the IPPools of a NIC run out of the quota", func() {
+			pod.Annotations[constant.AnnoPodIPPools] = `[{"interface":"eth0","ipv4":["spare-pool"]},{"interface":"net1","ipv4":["full-pool"]}]`
+			err := podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+		})
+
+		It("admits the Pod if any IPPool of a NIC has the quota left", func() {
+			pod.Annotations[constant.AnnoPodIPPool] = `{"ipv4":["full-pool","spare-pool"]}`
+			err := podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("admits the Pod reusing its IP allocation", func() {
+			pod.Name = "other"
+			pod.Annotations[constant.AnnoPodIPPool] = `{"ipv4":["full-pool"]}`
+			err := podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("leaves the wildcard and non-existent IPPools to IPAM", func() {
+			pod.Annotations[constant.AnnoPodIPPool] = `{"ipv4":["full-pool","pool*"],"ipv6":["non-existent"]}`
+			err := podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the Pod if the namespace runs out of the quota of Subnet", func() {
+			subnetPool := newIPPool("subnet-pool", 2)
+			subnetPool.Labels = map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: "subnet"}
+			otherPool := newIPPool("other-subnet-pool", 2)
+			otherPool.Labels = subnetPool.Labels
+			otherPool.Status.AllocatedIPs = ptr.To(`{"172.18.40.41":{"pod":"tenant-a/another","podUid":"uid"}}`)
+			subnet := &v2beta1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+				Spec: v2beta1.SubnetSpec{
+					NamespaceQuota: &v2beta1.IPPoolNamespaceQuota{Default: ptr.To(int64(2))},
+				},
+			}
+			spiderClient = spiderpoolfake.NewSimpleClientset(subnetPool, otherPool, subnet)
+
+			pod.Annotations[constant.AnnoPodIPPool] = `{"ipv4":["subnet-pool"]}`
+			err := podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).To(MatchError(constant.ErrIPQuotaExceeded))
+
+			subnet.Spec.NamespaceQuota.Default = ptr.To(int64(3))
+			spiderClient = spiderpoolfake.NewSimpleClientset(subnetPool, otherPool, subnet)
+			err = podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails to parse the annotation", func() {
+			pod.Annotations[constant.AnnoPodIPPool] = "invalid"
+			err := podIPQuotaMutatingWebhook(ctx, spiderClient, pod)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
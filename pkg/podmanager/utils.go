@@ -5,6 +5,7 @@ package podmanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -17,10 +18,14 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	k8s_resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	kubevirtv1 "kubevirt.io/api/core/v1"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/multuscniconfig"
+	"github.com/spidernet-io/spiderpool/pkg/types"
 )
 
 func IsPodAlive(pod *corev1.Pod) bool {
@@ -162,6 +167,105 @@ func podENIResourceMutatingWebhook(ctx context.Context, spiderClient crdclientse
 	return nil
 }
 
+// podIPQuotaMutatingWebhook rejects the Pod early if the Pods of its
+// namespace have used up the IP quota of all the IPPools specified by the
+// annotation "ipam.spidernet.io/ippools" or "ipam.spidernet.io/ippool" for a
+// NIC, which IPAM would refuse to allocate IP addresses from. The IPPools with
+// wildcard names or failing to get are left to IPAM.
+func podIPQuotaMutatingWebhook(ctx context.Context, spiderClient crdclientset.Interface, pod *corev1.Pod) error {
+	candidates, err := podIPPoolCandidates(pod)
+	if err != nil {
+		return err
+	}
+
+	for _, pools := range candidates {
+		if len(pools) == 0 {
+			continue
+		}
+
+		var errs []error
+		for _, name := range pools {
+			if ippoolmanager.HasWildcardInStr(name) {
+				errs = nil
+				break
+			}
+
+			ipPool, err := spiderClient.SpiderpoolV2beta1().SpiderIPPools().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				errs = nil
+				break
+			}
+
+			err = ippoolmanager.CheckNamespaceIPQuota(ipPool, pod.Namespace, pod.Namespace+"/"+pod.Name)
+			if err == nil {
+				err = checkSubnetNamespaceIPQuota(ctx, spiderClient, ipPool, pod)
+			}
+			if err == nil {
+				errs = nil
+				break
+			}
+			errs = append(errs, err)
+		}
+
+		if len(errs) != 0 {
+			return fmt.Errorf("no IP quota left in IPPools %v for namespace %s: %w", pools, pod.Namespace, utilerrors.NewAggregate(errs))
+		}
+	}
+
+	return nil
+}
+
+// checkSubnetNamespaceIPQuota checks the namespace quota of the Subnet which
+// the IPPool belongs to, the Subnet or IPPools failing to get are left to
+// IPAM as well.
+func checkSubnetNamespaceIPQuota(ctx context.Context, spiderClient crdclientset.Interface, ipPool *v2beta1.SpiderIPPool, pod *corev1.Pod) error {
+	subnetName, ok := ipPool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]
+	if !ok {
+		return nil
+	}
+
+	subnet, err := spiderClient.SpiderpoolV2beta1().SpiderSubnets().Get(ctx, subnetName, metav1.GetOptions{})
+	if err != nil || subnet.Spec.NamespaceQuota == nil {
+		return nil
+	}
+
+	poolList, err := spiderClient.SpiderpoolV2beta1().SpiderIPPools().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{constant.LabelIPPoolOwnerSpiderSubnet: subnetName}).String(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	return ippoolmanager.CheckSubnetNamespaceIPQuota(subnet, poolList.Items, ipPool, pod.Namespace, pod.Namespace+"/"+pod.Name)
+}
+
+// podIPPoolCandidates returns the candidate IPPools of every NIC and IP
+// version specified by the annotations of the Pod.
+func podIPPoolCandidates(pod *corev1.Pod) ([][]string, error) {
+	var candidates [][]string
+	if v, ok := pod.Annotations[constant.AnnoPodIPPools]; ok {
+		var annoPodIPPools types.AnnoPodIPPoolsValue
+		if err := json.Unmarshal([]byte(v), &annoPodIPPools); err != nil {
+			return nil, fmt.Errorf("failed to parse annotation %s: %w", constant.AnnoPodIPPools, err)
+		}
+		for _, item := range annoPodIPPools {
+			candidates = append(candidates, item.IPv4Pools, item.IPv6Pools)
+		}
+
+		return candidates, nil
+	}
+
+	if v, ok := pod.Annotations[constant.AnnoPodIPPool]; ok {
+		var annoPodIPPool types.AnnoPodIPPoolValue
+		if err := json.Unmarshal([]byte(v), &annoPodIPPool); err != nil {
+			return nil, fmt.Errorf("failed to parse annotation %s: %w", constant.AnnoPodIPPool, err)
+		}
+		candidates = append(candidates, annoPodIPPool.IPv4Pools, annoPodIPPool.IPv6Pools)
+	}
+
+	return candidates, nil
+}
+
 func podMasterNICResourceMutatingWebhook(ctx context.Context, spiderClient crdclientset.Interface, pod *corev1.Pod, cfg PodENIResourceInjectConfig) error {
 	// Master NIC resource injection is independent of provider mode per
 	// FR-033 / SC-013: master NIC scheduling must work both with and without
@@ -220,6 +324,11 @@ func getSpiderMultusConfigMasters(mc *v2beta1.SpiderMultusConfig) []string {
 		if mc.Spec.IpoibConfig != nil && mc.Spec.IpoibConfig.Master != "" {
 			return []string{mc.Spec.IpoibConfig.Master}
 		}
+	case constant.HostDeviceCNI:
+		// the device named by hwaddr, kernelpath or pciBusID has no known name
+		if mc.Spec.HostDeviceConfig != nil && mc.Spec.HostDeviceConfig.Device != "" {
+			return []string{mc.Spec.HostDeviceConfig.Device}
+		}
 	}
 
 	return nil
@@ -6,6 +6,7 @@ package subnetmanager
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"sync"
 	"time"
@@ -516,8 +517,16 @@ func (sc *SubnetController) syncControlledIPPoolIPs(ctx context.Context, subnet
 		return err
 	}
 
+	var autoPoolIdleIPCount int64
 	for _, ipPool := range ipPools {
-		if !ippoolmanager.IsAutoCreatedIPPool(ipPool) {
+		if ippoolmanager.IsAutoCreatedIPPool(ipPool) {
+			if ipPool.Status.TotalIPCount != nil {
+				autoPoolIdleIPCount += *ipPool.Status.TotalIPCount
+				if ipPool.Status.AllocatedIPCount != nil {
+					autoPoolIdleIPCount -= *ipPool.Status.AllocatedIPCount
+				}
+			}
+		} else {
 			poolTotalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
 			if err != nil {
 				logger.Sugar().Errorf("Invalid total IP ranges of IPPool %s, remove the pre-allocation from Subnet", ipPool.Name)
@@ -535,6 +544,9 @@ func (sc *SubnetController) syncControlledIPPoolIPs(ctx context.Context, subnet
 		}
 	}
 
+	// record the metric of how many IPs are pre-allocated to the auto-created IPPools but not used by any Pod.
+	metric.SubnetAutoPoolIdleIPCounts.Record(autoPoolIdleIPCount, attribute.String(constant.KindSpiderSubnet, subnet.Name))
+
 	sync := false
 	if !reflect.DeepEqual(newPreAllocations, preAllocations) {
 		data, err := convert.MarshalSubnetAllocatedIPPools(newPreAllocations)
@@ -554,6 +566,23 @@ func (sc *SubnetController) syncControlledIPPoolIPs(ctx context.Context, subnet
 		sync = true
 	}
 
+	var subnetIPCounts []spiderpoolv2beta1.SubnetIPCount
+	if len(subnet.Spec.SecondarySubnets) != 0 {
+		var allocatedIPs []net.IP
+		for _, preAllocation := range newPreAllocations {
+			ips, err := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, preAllocation.IPs)
+			if err != nil {
+				return err
+			}
+			allocatedIPs = append(allocatedIPs, ips...)
+		}
+		subnetIPCounts = ippoolmanager.GenSubnetIPCounts(ippoolmanager.SubnetCIDRs(subnet.Spec.Subnet, subnet.Spec.SecondarySubnets), subnetTotalIPs, allocatedIPs)
+	}
+	if !reflect.DeepEqual(subnetIPCounts, subnet.Status.SubnetIPCounts) {
+		subnet.Status.SubnetIPCounts = subnetIPCounts
+		sync = true
+	}
+
 	if sync {
 		return sc.Client.Status().Update(ctx, subnet)
 	}
@@ -139,17 +139,22 @@ func (sm *subnetManager) ReconcileAutoIPPool(ctx context.Context, pool *spiderpo
 		labels[constant.LabelIPPoolReclaimIPPool] = applicationinformers.IsReclaimAutoPoolLabelValue(autoPoolProperty.IsReclaimIPPool)
 		pool.SetLabels(labels)
 	} else {
+		poolName := applicationinformers.AutoPoolName(podController.Name, autoPoolProperty.IPVersion, autoPoolProperty.IfName, podController.UID)
+		if autoPoolProperty.IsFallback {
+			poolName = applicationinformers.FallbackAutoPoolName(podController.Name, autoPoolProperty.IPVersion, autoPoolProperty.IfName, podController.UID, subnet.Name)
+		}
 		pool = &spiderpoolv2beta1.SpiderIPPool{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: applicationinformers.AutoPoolName(podController.Name, autoPoolProperty.IPVersion, autoPoolProperty.IfName, podController.UID),
+				Name: poolName,
 			},
 			Spec: spiderpoolv2beta1.IPPoolSpec{
 				IPVersion: ptr.To(autoPoolProperty.IPVersion),
 				Subnet:    subnet.Spec.Subnet,
 				Gateway:   subnet.Spec.Gateway,
 				// Vlan:        subnet.Spec.Vlan,
-				Routes:      subnet.Spec.Routes,
-				PodAffinity: ippoolmanager.NewAutoPoolPodAffinity(podController),
+				Routes:           subnet.Spec.Routes,
+				SecondarySubnets: subnet.Spec.SecondarySubnets,
+				PodAffinity:      ippoolmanager.NewAutoPoolPodAffinity(podController),
 			},
 		}
 
@@ -304,9 +309,10 @@ func (sm *subnetManager) preAllocateIPsFromSubnet(ctx context.Context, subnet *s
 				return nil, fmt.Errorf("%w: failed to parse IPPool %s Status AllocatedIPs: %w", constant.ErrWrongInput, pool.Name, err)
 			}
 
-			// shrink: free IP number >= return IP Num
-			// when it needs to scale down IP, enough IP is released to make sure it scale down successfully
-			if len(subnetPoolIPs)-len(poolIPAllocations) >= len(subnetPoolIPs)-desiredIPNum {
+			// shrink: only the unallocated IPs could be returned to the SpiderSubnet.
+			// If some of the IPs to be discarded are still occupied by Pods, we just return the free ones
+			// and leave the rest to the next reconciliation.
+			if len(subnetPoolIPs) > len(poolIPAllocations) {
 				// exist auto pool allocated IPs
 				poolAllocatedIPs, err := func() ([]net.IP, error) {
 					var ips []string
@@ -321,7 +327,16 @@ func (sm *subnetManager) preAllocateIPsFromSubnet(ctx context.Context, subnet *s
 
 				// free IPs
 				freeIPs := spiderpoolip.IPsDiffSet(subnetPoolIPs, poolAllocatedIPs, true)
-				discardedIPs := freeIPs[:len(subnetPoolIPs)-desiredIPNum]
+				discardedIPNum := len(subnetPoolIPs) - desiredIPNum
+				if len(freeIPs) < discardedIPNum {
+					log.Sugar().Infof("IPPool %s only has %d unallocated IPs, partially shrink it and return the others to SpiderSubnet %s later",
+						pool.Name, len(freeIPs), subnet.Name)
+					discardedIPNum = len(freeIPs)
+				}
+				if discardedIPNum == 0 {
+					return nil, fmt.Errorf("failed to scale down IPPool %s IPs: %w", pool.Name, constant.ErrFreeIPsNotEnough)
+				}
+				discardedIPs := freeIPs[:discardedIPNum]
 				newIPs := spiderpoolip.IPsDiffSet(subnetPoolIPs, discardedIPs, false)
 				poolIPRange, err := spiderpoolip.ConvertIPsToIPRanges(ipVersion, newIPs)
 				if nil != err {
@@ -370,9 +385,14 @@ func (sm *subnetManager) preAllocateIPsFromSubnet(ctx context.Context, subnet *s
 		freeIPs = spiderpoolip.IPsDiffSet(freeIPs, reservedIPs, true)
 	}
 
+	// pre-allocate IPs from 'spec.subnet' and then the secondary subnets in order
+	if len(subnet.Spec.SecondarySubnets) != 0 {
+		ippoolmanager.SortIPsByCIDRs(freeIPs, ippoolmanager.SubnetCIDRs(subnet.Spec.Subnet, subnet.Spec.SecondarySubnets))
+	}
+
 	// check the filtered subnet free IP number is enough or not
 	if len(freeIPs) < ipNum {
-		return nil, fmt.Errorf("insufficient subnet FreeIPs, required '%d' but only left '%d'", ipNum, len(freeIPs))
+		return nil, fmt.Errorf("insufficient subnet FreeIPs, required '%d' but only left '%d': %w", ipNum, len(freeIPs), constant.ErrFreeIPsNotEnough)
 	}
 
 	allocateIPs := make([]net.IP, 0, ipNum)
@@ -408,7 +428,14 @@ func (sm *subnetManager) preAllocateIPsFromSubnet(ctx context.Context, subnet *s
 }
 
 func subnetStatusCount(subnet *spiderpoolv2beta1.SpiderSubnet) (totalCount, allocatedCount int64) {
-	s, _ := spiderpoolip.NewCIDR(subnet.Spec.Subnet, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+	var totalIPCount int64
+	if len(subnet.Spec.SecondarySubnets) == 0 {
+		s, _ := spiderpoolip.NewCIDR(subnet.Spec.Subnet, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+		totalIPCount = int64(s.TotalIPInt())
+	} else {
+		totalIPs, _ := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+		totalIPCount = int64(len(totalIPs))
+	}
 
 	if subnet.Status.ControlledIPPools == nil {
 		return 0, 0
@@ -425,5 +452,5 @@ func subnetStatusCount(subnet *spiderpoolv2beta1.SpiderSubnet) (totalCount, allo
 		tmpIPs, _ := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, poolAllocation.IPs)
 		allocatedIPCount += int64(len(tmpIPs))
 	}
-	return int64(s.TotalIPInt()), allocatedIPCount
+	return totalIPCount, allocatedIPCount
 }
@@ -18,6 +18,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
@@ -260,6 +261,144 @@ var _ = Describe("SubnetManager", Label("subnet_manager_test"), func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(*autoPool.Spec.IPVersion).Should(BeEquivalentTo(constant.IPv4))
 			})
+
+			It("partially shrink an auto IPPool whose IPs are still allocated", func() {
+				podController := types.PodTopController{
+					AppNamespacedName: types.AppNamespacedName{
+						APIVersion: appsv1.SchemeGroupVersion.String(),
+						Kind:       constant.KindDeployment,
+						Namespace:  "default",
+						Name:       "deployment2",
+					},
+					UID: "d-e-f",
+					APP: nil,
+				}
+
+				poolName := fmt.Sprintf("auto4-deployment2-eth0-%v", count)
+				subnet := subnetT.DeepCopy()
+				subnet.Spec = spiderpoolv2beta1.SubnetSpec{
+					IPVersion: ptr.To(int64(4)),
+					Subnet:    "172.16.0.0/16",
+					IPs:       []string{"172.16.42.1-172.16.42.200"},
+				}
+				subnet.Status.ControlledIPPools = ptr.To(fmt.Sprintf(`{"%s":{"ips":["172.16.42.1-172.16.42.4"],"application":"apps_v1:Deployment:default:deployment2"}}`, poolName))
+				err := fakeClient.Create(ctx, subnet)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(subnet)
+				Expect(err).NotTo(HaveOccurred())
+
+				pool := &spiderpoolv2beta1.SpiderIPPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: poolName,
+						Labels: map[string]string{
+							constant.LabelIPPoolOwnerApplicationUID: string(podController.UID),
+							constant.LabelIPPoolReclaimIPPool:       constant.True,
+						},
+					},
+					Spec: spiderpoolv2beta1.IPPoolSpec{
+						IPVersion: ptr.To(int64(4)),
+						Subnet:    "172.16.0.0/16",
+						IPs:       []string{"172.16.42.1-172.16.42.4"},
+					},
+					Status: spiderpoolv2beta1.IPPoolStatus{
+						AllocatedIPs: ptr.To(`{"172.16.42.1":{"pod":"default/pod1","podUid":"uid1"},"172.16.42.3":{"pod":"default/pod2","podUid":"uid2"}}`),
+					},
+				}
+				err = fakeClient.Create(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				defer func() {
+					Expect(fakeClient.Delete(ctx, pool)).NotTo(HaveOccurred())
+				}()
+
+				autoPoolProperty := types.AutoPoolProperty{
+					DesiredIPNumber:     1,
+					IPVersion:           constant.IPv4,
+					IsReclaimIPPool:     true,
+					IfName:              "eth0",
+					AnnoPoolIPNumberVal: "1",
+				}
+
+				autoPool, err := subnetManager.ReconcileAutoIPPool(ctx, pool, subnet.Name, podController, autoPoolProperty)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(autoPool.Spec.IPs).To(Equal([]string{"172.16.42.1", "172.16.42.3"}))
+			})
+
+			It("reconcile an auto IPPool with an exhausted SpiderSubnet", func() {
+				subnet := subnetT.DeepCopy()
+				subnet.Spec = spiderpoolv2beta1.SubnetSpec{
+					IPVersion: ptr.To(int64(4)),
+					Subnet:    "172.16.0.0/16",
+					IPs:       []string{"172.16.43.1-172.16.43.2"},
+				}
+				err := fakeClient.Create(ctx, subnet)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(subnet)
+				Expect(err).NotTo(HaveOccurred())
+
+				patches := gomonkey.ApplyMethodReturn(mockRIPManager, "AssembleReservedIPs", nil, nil)
+				defer patches.Reset()
+
+				podController := types.PodTopController{
+					AppNamespacedName: types.AppNamespacedName{
+						APIVersion: appsv1.SchemeGroupVersion.String(),
+						Kind:       constant.KindDeployment,
+						Namespace:  "default",
+						Name:       "deployment3",
+					},
+					UID: "g-h-i",
+					APP: nil,
+				}
+				autoPoolProperty := types.AutoPoolProperty{
+					DesiredIPNumber:     3,
+					IPVersion:           constant.IPv4,
+					IsReclaimIPPool:     true,
+					IfName:              "eth0",
+					AnnoPoolIPNumberVal: "3",
+				}
+
+				_, err = subnetManager.ReconcileAutoIPPool(ctx, nil, subnet.Name, podController, autoPoolProperty)
+				Expect(err).To(MatchError(constant.ErrFreeIPsNotEnough))
+			})
+
+			It("reconcile an auto IPPool in the fallback SpiderSubnet", func() {
+				subnet := subnetT.DeepCopy()
+				subnet.Spec = spiderpoolv2beta1.SubnetSpec{
+					IPVersion: ptr.To(int64(4)),
+					Subnet:    "172.16.0.0/16",
+					IPs:       []string{"172.16.44.1-172.16.44.200"},
+				}
+				err := fakeClient.Create(ctx, subnet)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(subnet)
+				Expect(err).NotTo(HaveOccurred())
+
+				patches := gomonkey.ApplyMethodReturn(mockRIPManager, "AssembleReservedIPs", nil, nil)
+				defer patches.Reset()
+
+				podController := types.PodTopController{
+					AppNamespacedName: types.AppNamespacedName{
+						APIVersion: appsv1.SchemeGroupVersion.String(),
+						Kind:       constant.KindDeployment,
+						Namespace:  "default",
+						Name:       "deployment4",
+					},
+					UID: "j-k-l",
+					APP: nil,
+				}
+				autoPoolProperty := types.AutoPoolProperty{
+					DesiredIPNumber:     2,
+					IPVersion:           constant.IPv4,
+					IsReclaimIPPool:     true,
+					IfName:              "eth0",
+					AnnoPoolIPNumberVal: "2",
+					IsFallback:          true,
+				}
+
+				autoPool, err := subnetManager.ReconcileAutoIPPool(ctx, nil, subnet.Name, podController, autoPoolProperty)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(autoPool.Name).To(Equal(applicationinformers.FallbackAutoPoolName(podController.Name, constant.IPv4, "eth0", podController.UID, subnet.Name)))
+				Expect(autoPool.Labels).To(HaveKeyWithValue(constant.LabelIPPoolOwnerSpiderSubnet, subnet.Name))
+			})
 		})
 	})
 })
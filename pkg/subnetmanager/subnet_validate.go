@@ -6,10 +6,14 @@ package subnetmanager
 import (
 	"context"
 	"fmt"
+	"net"
+	"reflect"
+	"slices"
 	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
@@ -26,6 +30,9 @@ var (
 	excludeIPsField        *field.Path = field.NewPath("spec").Child("excludeIPs")
 	gatewayField           *field.Path = field.NewPath("spec").Child("gateway")
 	routesField            *field.Path = field.NewPath("spec").Child("routes")
+	secondarySubnetsField  *field.Path = field.NewPath("spec").Child("secondarySubnets")
+	tenantsField           *field.Path = field.NewPath("spec").Child("tenants")
+	namespaceQuotaField    *field.Path = field.NewPath("spec").Child("namespaceQuota")
 	controlledIPPoolsField *field.Path = field.NewPath("status").Child("controlledIPPools")
 )
 
@@ -42,6 +49,12 @@ func (sw *SubnetWebhook) validateCreateSubnet(ctx context.Context, subnet *spide
 	if err := sw.validateSubnetSpec(ctx, subnet); err != nil {
 		errs = append(errs, err)
 	}
+	if err := validateSubnetTenants(subnet); err != nil {
+		errs = append(errs, err)
+	}
+	if err := ippoolmanager.ValidateNamespaceQuota(namespaceQuotaField, subnet.Spec.NamespaceQuota); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -63,10 +76,23 @@ func (sw *SubnetWebhook) validateUpdateSubnet(ctx context.Context, oldSubnet, ne
 		return field.ErrorList{err}
 	}
 
+	if err := validateSubnetTenants(newSubnet); err != nil {
+		return field.ErrorList{err}
+	}
+
+	if err := ippoolmanager.ValidateNamespaceQuota(namespaceQuotaField, newSubnet.Spec.NamespaceQuota); err != nil {
+		return field.ErrorList{err}
+	}
+
 	var errs field.ErrorList
 	if err := validateSubnetIPInUse(newSubnet); err != nil {
 		errs = append(errs, err)
 	}
+	if !reflect.DeepEqual(oldSubnet.Spec.Tenants, newSubnet.Spec.Tenants) {
+		if err := sw.validateSubnetTenantsInUse(ctx, newSubnet); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -91,15 +117,31 @@ func validateSubnetShouldNotBeChanged(oldSubnet, newSubnet *spiderpoolv2beta1.Sp
 		)
 	}
 
+	if !slices.Equal(
+		ippoolmanager.SubnetCIDRs(newSubnet.Spec.Subnet, newSubnet.Spec.SecondarySubnets),
+		ippoolmanager.SubnetCIDRs(oldSubnet.Spec.Subnet, oldSubnet.Spec.SecondarySubnets),
+	) {
+		return field.Forbidden(
+			secondarySubnetsField,
+			"the CIDRs are not changeable",
+		)
+	}
+
 	return nil
 }
 
 func (sw *SubnetWebhook) validateSubnetSpec(ctx context.Context, subnet *spiderpoolv2beta1.SpiderSubnet) *field.Error {
-	if err := validateSubnetIPs(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.IPs); err != nil {
-		return err
-	}
-	if err := validateSubnetExcludeIPs(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.ExcludeIPs); err != nil {
-		return err
+	if len(subnet.Spec.SecondarySubnets) == 0 {
+		if err := validateSubnetIPs(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.IPs); err != nil {
+			return err
+		}
+		if err := validateSubnetExcludeIPs(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.ExcludeIPs); err != nil {
+			return err
+		}
+	} else {
+		if err := ippoolmanager.ValidateSecondarySubnets(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.SecondarySubnets, subnet.Spec.IPs, subnet.Spec.ExcludeIPs); err != nil {
+			return err
+		}
 	}
 	if err := validateSubnetGateway(subnet); err != nil {
 		return err
@@ -189,6 +231,9 @@ func (sw *SubnetWebhook) validateSubnetCIDR(ctx context.Context, subnet *spiderp
 	if err := spiderpoolip.IsFormatCIDR(subnet.Spec.Subnet); err != nil {
 		return field.Invalid(subnetField, subnet.Spec.Subnet, err.Error())
 	}
+	if err := ippoolmanager.ValidateSecondarySubnetsCIDR(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.SecondarySubnets); err != nil {
+		return err
+	}
 
 	subnetList := spiderpoolv2beta1.SpiderSubnetList{}
 	if err := sw.APIReader.List(ctx, &subnetList); err != nil {
@@ -203,16 +248,18 @@ func (sw *SubnetWebhook) validateSubnetCIDR(ctx context.Context, subnet *spiderp
 				return field.InternalError(subnetField, fmt.Errorf("subnet %s %s", subnet.Name, metav1.StatusReasonAlreadyExists))
 			}
 
-			overlap, err := spiderpoolip.IsCIDROverlap(*subnet.Spec.IPVersion, subnet.Spec.Subnet, s.Spec.Subnet)
+			cidrs := ippoolmanager.SubnetCIDRs(subnet.Spec.Subnet, subnet.Spec.SecondarySubnets)
+			existCIDRs := ippoolmanager.SubnetCIDRs(s.Spec.Subnet, s.Spec.SecondarySubnets)
+			i, j, err := spiderpoolip.FindOverlappedCIDRs(*subnet.Spec.IPVersion, cidrs, existCIDRs, false)
 			if err != nil {
 				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps: %w", err))
 			}
 
-			if overlap {
+			if i != -1 {
 				return field.Invalid(
-					subnetField,
-					subnet.Spec.Subnet,
-					fmt.Sprintf("overlap with Subnet %s which 'spec.subnet' is %s", s.Name, s.Spec.Subnet),
+					ippoolmanager.CIDRFieldPath(i),
+					cidrs[i],
+					fmt.Sprintf("overlap with Subnet %s which '%s' is %s", s.Name, ippoolmanager.CIDRFieldPath(j), existCIDRs[j]),
 				)
 			}
 		}
@@ -235,16 +282,26 @@ func (sw *SubnetWebhook) validateOrphanIPPool(ctx context.Context, subnet *spide
 			continue
 		}
 
-		// validate the Spec.Subnet whether overlaps or not
-		if tmpPool.Spec.Subnet != subnet.Spec.Subnet {
-			isCIDROverlap, err := spiderpoolip.IsCIDROverlap(*subnet.Spec.IPVersion, tmpPool.Spec.Subnet, subnet.Spec.Subnet)
-			if nil != err {
-				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps with SpiderIPPool '%s', error: %w", tmpPool.Name, err))
-			}
-			if isCIDROverlap {
-				return field.Invalid(subnetField, subnet.Spec.Subnet, fmt.Sprintf("overlap with SpiderIPPool '%s' resource 'spec.subnet' %s", tmpPool.Name, tmpPool.Spec.Subnet))
+		// validate the Spec.Subnet whether overlaps or not, an IPPool with the same 'spec.subnet'
+		// could share the secondary subnets of the Subnet
+		cidrs := ippoolmanager.SubnetCIDRs(subnet.Spec.Subnet, subnet.Spec.SecondarySubnets)
+		poolCIDRs := ippoolmanager.SubnetCIDRs(tmpPool.Spec.Subnet, tmpPool.Spec.SecondarySubnets)
+		sameSubnet := tmpPool.Spec.Subnet == subnet.Spec.Subnet
+		if sameSubnet {
+			poolCIDRs = poolCIDRs[1:]
+		}
+		i, j, err := spiderpoolip.FindOverlappedCIDRs(*subnet.Spec.IPVersion, cidrs, poolCIDRs, sameSubnet)
+		if nil != err {
+			return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps with SpiderIPPool '%s', error: %w", tmpPool.Name, err))
+		}
+		if i != -1 {
+			if !sameSubnet && j == 0 {
+				return field.Invalid(ippoolmanager.CIDRFieldPath(i), cidrs[i], fmt.Sprintf("overlap with SpiderIPPool '%s' resource 'spec.subnet' %s", tmpPool.Name, tmpPool.Spec.Subnet))
 			}
-		} else {
+			return field.Invalid(ippoolmanager.CIDRFieldPath(i), cidrs[i], fmt.Sprintf("overlap with SpiderIPPool '%s' resource 'spec.secondarySubnets' %s", tmpPool.Name, poolCIDRs[j]))
+		}
+
+		if sameSubnet {
 			// validate the Spec.IPs whether contains or not
 			poolIPs, err := spiderpoolip.AssembleTotalIPs(*tmpPool.Spec.IPVersion, tmpPool.Spec.IPs, tmpPool.Spec.ExcludeIPs)
 			if nil != err {
@@ -353,3 +410,115 @@ func validateSubnetRoutes(version types.IPVersion, subnet string, routes []spide
 
 	return nil
 }
+
+// validateSubnetTenants checks the IP addresses granted to each namespace are
+// the total IP addresses of the Subnet, and not granted to other namespaces.
+func validateSubnetTenants(subnet *spiderpoolv2beta1.SpiderSubnet) *field.Error {
+	if len(subnet.Spec.Tenants) == 0 {
+		return nil
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+	if err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %w", subnet.Name, err))
+	}
+
+	grants := make(map[string][]net.IP, len(subnet.Spec.Tenants))
+	for i, tenant := range subnet.Spec.Tenants {
+		if _, ok := grants[tenant.Namespace]; ok {
+			return field.Duplicate(tenantsField.Index(i).Child("namespace"), tenant.Namespace)
+		}
+
+		var grantedIPs []net.IP
+		for j, r := range tenant.IPs {
+			if err := spiderpoolip.IsIPRange(*subnet.Spec.IPVersion, r); err != nil {
+				return field.Invalid(tenantsField.Index(i).Child("ips").Index(j), r, err.Error())
+			}
+			ips, _ := spiderpoolip.ParseIPRange(*subnet.Spec.IPVersion, r)
+			grantedIPs = append(grantedIPs, ips...)
+		}
+
+		outIPs := spiderpoolip.IPsDiffSet(grantedIPs, totalIPs, false)
+		if len(outIPs) > 0 {
+			ranges, _ := spiderpoolip.ConvertIPsToIPRanges(*subnet.Spec.IPVersion, outIPs)
+			return field.Invalid(
+				tenantsField.Index(i).Child("ips"),
+				tenant.IPs,
+				fmt.Sprintf("IP ranges %v are not in the total IP addresses of the Subnet, which are jointly determined by 'spec.ips' and 'spec.excludeIPs'", ranges),
+			)
+		}
+
+		for namespace, ips := range grants {
+			overlappedIPs := spiderpoolip.IPsIntersectionSet(grantedIPs, ips, false)
+			if len(overlappedIPs) > 0 {
+				ranges, _ := spiderpoolip.ConvertIPsToIPRanges(*subnet.Spec.IPVersion, overlappedIPs)
+				return field.Forbidden(
+					tenantsField.Index(i).Child("ips"),
+					fmt.Sprintf("IP ranges %v are already granted to namespace %s", ranges, namespace),
+				)
+			}
+		}
+		grants[tenant.Namespace] = grantedIPs
+	}
+
+	return nil
+}
+
+// validateSubnetTenantsInUse checks the IPPools of SpiderTenantIPPools are
+// still in the IP addresses granted to their namespaces, and the IP addresses
+// granted to namespaces are not used by the other IPPools.
+func (sw *SubnetWebhook) validateSubnetTenantsInUse(ctx context.Context, subnet *spiderpoolv2beta1.SpiderSubnet) *field.Error {
+	var ipPoolList spiderpoolv2beta1.SpiderIPPoolList
+	if err := sw.APIReader.List(ctx, &ipPoolList, client.MatchingLabels{constant.LabelIPPoolOwnerSpiderSubnet: subnet.Name}); err != nil {
+		return field.InternalError(tenantsField, fmt.Errorf("failed to list the IPPools of Subnet %s: %w", subnet.Name, err))
+	}
+
+	for _, pool := range ipPoolList.Items {
+		poolTotalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+		if err != nil {
+			return field.InternalError(tenantsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %w", pool.Name, err))
+		}
+
+		tenantNamespace, isTenantPool := pool.Labels[constant.LabelIPPoolOwnerTenantNamespace]
+		if isTenantPool {
+			grantedIPs, ok, err := ippoolmanager.SubnetTenantIPs(subnet, tenantNamespace)
+			if err != nil {
+				return field.InternalError(tenantsField, err)
+			}
+			if !ok {
+				return field.Forbidden(
+					tenantsField,
+					fmt.Sprintf("remove the namespace %s whose IP addresses are being used by IPPool %s", tenantNamespace, pool.Name),
+				)
+			}
+
+			outIPs := spiderpoolip.IPsDiffSet(poolTotalIPs, grantedIPs, false)
+			if len(outIPs) > 0 {
+				ranges, _ := spiderpoolip.ConvertIPsToIPRanges(*subnet.Spec.IPVersion, outIPs)
+				return field.Forbidden(
+					tenantsField,
+					fmt.Sprintf("remove some IP ranges %v granted to namespace %s that are being used by IPPool %s", ranges, tenantNamespace, pool.Name),
+				)
+			}
+			continue
+		}
+
+		for _, tenant := range subnet.Spec.Tenants {
+			grantedIPs, _, err := ippoolmanager.SubnetTenantIPs(subnet, tenant.Namespace)
+			if err != nil {
+				return field.InternalError(tenantsField, err)
+			}
+
+			usedIPs := spiderpoolip.IPsIntersectionSet(poolTotalIPs, grantedIPs, false)
+			if len(usedIPs) > 0 {
+				ranges, _ := spiderpoolip.ConvertIPsToIPRanges(*subnet.Spec.IPVersion, usedIPs)
+				return field.Forbidden(
+					tenantsField,
+					fmt.Sprintf("grant some IP ranges %v to namespace %s that are being used by IPPool %s", ranges, tenant.Namespace, pool.Name),
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -1553,6 +1553,169 @@ var _ = Describe("SubnetWebhook", Label("subnet_webhook_test"), func() {
 			})
 		})
 
+		Describe("Validate 'spec.tenants'", func() {
+			BeforeEach(func() {
+				subnetT.Spec.IPVersion = ptr.To(constant.IPv4)
+				subnetT.Spec.Subnet = "172.18.40.0/24"
+				subnetT.Spec.IPs = []string{"172.18.40.10-172.18.40.100"}
+				subnetT.Spec.ExcludeIPs = []string{"172.18.40.50"}
+			})
+
+			addIPPool := func(name, tenantNamespace string, ips []string) {
+				ipPool := &spiderpoolv2beta1.SpiderIPPool{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       constant.KindSpiderIPPool,
+						APIVersion: fmt.Sprintf("%s/%s", constant.SpiderpoolAPIGroup, constant.SpiderpoolAPIVersion),
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   name,
+						Labels: map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: subnetT.Name},
+					},
+					Spec: spiderpoolv2beta1.IPPoolSpec{
+						IPVersion: ptr.To(constant.IPv4),
+						Subnet:    subnetT.Spec.Subnet,
+						IPs:       ips,
+					},
+				}
+				if tenantNamespace != "" {
+					ipPool.Labels[constant.LabelIPPoolOwnerTenantNamespace] = tenantNamespace
+				}
+				DeferCleanup(func() {
+					err := tracker.Delete(
+						schema.GroupVersionResource{
+							Group:    constant.SpiderpoolAPIGroup,
+							Version:  constant.SpiderpoolAPIVersion,
+							Resource: "spiderippools",
+						}, ipPool.Namespace, ipPool.Name,
+					)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				err := tracker.Add(ipPool)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			It("grants the IP addresses to namespaces", func() {
+				subnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.10-172.18.40.20"}},
+					{Namespace: "tenant-b", IPs: []string{"172.18.40.21-172.18.40.30"}},
+				}
+
+				warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warns).To(BeNil())
+			})
+
+			It("grants the IP addresses to the same namespace twice", func() {
+				subnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.10"}},
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.11"}},
+				}
+
+				_, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.tenants[1].namespace"))
+			})
+
+			It("grants the IP addresses out of the Subnet", func() {
+				subnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.45-172.18.40.55"}},
+				}
+
+				_, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("172.18.40.50"))
+			})
+
+			It("grants the same IP addresses to two namespaces", func() {
+				subnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.10-172.18.40.20"}},
+					{Namespace: "tenant-b", IPs: []string{"172.18.40.20-172.18.40.30"}},
+				}
+
+				_, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("already granted to namespace tenant-a"))
+			})
+
+			It("shrinks the grant used by the IPPool of the tenant", func() {
+				subnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.10-172.18.40.20"}},
+				}
+				addIPPool("tenant-a.pool", "tenant-a", []string{"172.18.40.15-172.18.40.20"})
+
+				newSubnetT := subnetT.DeepCopy()
+				newSubnetT.Spec.Tenants[0].IPs = []string{"172.18.40.10-172.18.40.16"}
+
+				_, err := subnetWebhook.ValidateUpdate(ctx, subnetT, newSubnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("being used by IPPool tenant-a.pool"))
+
+				newSubnetT.Spec.Tenants = nil
+				_, err = subnetWebhook.ValidateUpdate(ctx, subnetT, newSubnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("remove the namespace tenant-a"))
+
+				newSubnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.15-172.18.40.30"}},
+				}
+				_, err = subnetWebhook.ValidateUpdate(ctx, subnetT, newSubnetT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("grants the IP addresses used by the other IPPool", func() {
+				addIPPool("cluster-pool", "", []string{"172.18.40.30"})
+
+				newSubnetT := subnetT.DeepCopy()
+				newSubnetT.Spec.Tenants = []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.21-172.18.40.30"}},
+				}
+
+				_, err := subnetWebhook.ValidateUpdate(ctx, subnetT, newSubnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("being used by IPPool cluster-pool"))
+			})
+		})
+
+		Describe("Validate 'spec.namespaceQuota'", func() {
+			BeforeEach(func() {
+				subnetT.Spec.IPVersion = ptr.To(constant.IPv4)
+				subnetT.Spec.Subnet = "172.18.40.0/24"
+				subnetT.Spec.IPs = []string{"172.18.40.10-172.18.40.100"}
+			})
+
+			It("caps the IP addresses of namespaces", func() {
+				subnetT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+					Default: ptr.To(int64(10)),
+					Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+						{Namespace: "tenant-a", MaxIPs: 20},
+					},
+				}
+
+				warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warns).To(BeNil())
+			})
+
+			It("caps the IP addresses of the same namespace twice", func() {
+				newSubnetT := subnetT.DeepCopy()
+				newSubnetT.Spec.NamespaceQuota = &spiderpoolv2beta1.IPPoolNamespaceQuota{
+					Namespaces: []spiderpoolv2beta1.NamespaceIPQuota{
+						{Namespace: "tenant-a", MaxIPs: 20},
+						{Namespace: "tenant-a", MaxIPs: 10},
+					},
+				}
+
+				_, err := subnetWebhook.ValidateCreate(ctx, newSubnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.namespaceQuota.namespaces[1].namespace"))
+
+				_, err = subnetWebhook.ValidateUpdate(ctx, subnetT, newSubnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.namespaceQuota.namespaces[1].namespace"))
+			})
+		})
+
 		Describe("ValidateDelete", func() {
 			It("passes", func() {
 				warns, err := subnetWebhook.ValidateDelete(ctx, subnetT)
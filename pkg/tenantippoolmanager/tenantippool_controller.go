@@ -0,0 +1,432 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	crdclientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
+	"github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions"
+	informers "github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var controllerLogger *zap.Logger
+
+type TenantIPPoolControllerConfig struct {
+	Workers             int
+	MaxWorkqueueLength  int
+	WorkQueueMaxRetries int
+	LeaderRetryElectGap time.Duration
+	ResyncPeriod        time.Duration
+}
+
+// TenantIPPoolController creates a SpiderIPPool for every SpiderTenantIPPool
+// and keeps it in sync, so that tenants own their IPPools with namespaced
+// RBAC while IPAM only works with the SpiderIPPools. The SpiderIPPool is
+// deleted before the finalizer of the SpiderTenantIPPool is removed.
+type TenantIPPoolController struct {
+	config TenantIPPoolControllerConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+
+	tenantPoolSynced    cache.InformerSynced
+	poolSynced          cache.InformerSynced
+	tenantPoolWorkqueue workqueue.RateLimitingInterface
+}
+
+func NewTenantIPPoolController(config TenantIPPoolControllerConfig, client client.Client, leader election.SpiderLeaseElector) (*TenantIPPoolController, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("spiderpool controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.MaxWorkqueueLength <= 0 {
+		config.MaxWorkqueueLength = 10000
+	}
+	if config.LeaderRetryElectGap <= 0 {
+		config.LeaderRetryElectGap = time.Second
+	}
+
+	controllerLogger = logutils.Logger.Named("TenantIPPool-Controller")
+
+	return &TenantIPPoolController{
+		config: config,
+		client: client,
+		leader: leader,
+	}, nil
+}
+
+// SetupInformer runs the informers of SpiderTenantIPPool and SpiderIPPool on
+// the leader, and syncs the TenantIPPools in the workqueue until the context
+// is done.
+func (tc *TenantIPPoolController) SetupInformer(ctx context.Context, client crdclientset.Interface) error {
+	controllerLogger.Info("try to register SpiderTenantIPPool informer")
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if !tc.leader.IsElected() {
+				time.Sleep(tc.config.LeaderRetryElectGap)
+				continue
+			}
+
+			innerCtx, innerCancel := context.WithCancel(ctx)
+			go func() {
+				for {
+					select {
+					case <-innerCtx.Done():
+						return
+					default:
+					}
+
+					if !tc.leader.IsElected() {
+						controllerLogger.Warn("Leader lost, stop SpiderTenantIPPool informer")
+						innerCancel()
+						return
+					}
+					time.Sleep(tc.config.LeaderRetryElectGap)
+				}
+			}()
+
+			controllerLogger.Info("create SpiderTenantIPPool informer")
+			factory := externalversions.NewSharedInformerFactory(client, tc.config.ResyncPeriod)
+			err := tc.addEventHandlers(factory.Spiderpool().V2beta1().SpiderTenantIPPools(), factory.Spiderpool().V2beta1().SpiderIPPools())
+			if nil != err {
+				controllerLogger.Error(err.Error())
+				innerCancel()
+				time.Sleep(tc.config.LeaderRetryElectGap)
+				continue
+			}
+			factory.Start(innerCtx.Done())
+
+			if err := tc.Run(innerCtx.Done()); nil != err {
+				controllerLogger.Sugar().Errorf("failed to run SpiderTenantIPPool controller, error: %v", err)
+			}
+			innerCancel()
+			controllerLogger.Error("SpiderTenantIPPool informer broken")
+		}
+	}()
+
+	return nil
+}
+
+func (tc *TenantIPPoolController) addEventHandlers(tenantPoolInformer informers.SpiderTenantIPPoolInformer, poolInformer informers.SpiderIPPoolInformer) error {
+	tc.tenantPoolSynced = tenantPoolInformer.Informer().HasSynced
+	tc.poolSynced = poolInformer.Informer().HasSynced
+
+	tc.tenantPoolWorkqueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SpiderTenantIPPools")
+
+	_, err := tenantPoolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: tc.enqueueTenantIPPool,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			tc.enqueueTenantIPPool(newObj)
+		},
+	})
+	if nil != err {
+		return err
+	}
+
+	// the IPPool is recreated once it's deleted, and its IP counts are
+	// mirrored to the TenantIPPool
+	_, err = poolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			tc.enqueueOwnerTenantIPPool(newObj)
+		},
+		DeleteFunc: tc.enqueueOwnerTenantIPPool,
+	})
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+func (tc *TenantIPPoolController) enqueueTenantIPPool(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if nil != err {
+		controllerLogger.Sugar().Errorf("failed to get the key of SpiderTenantIPPool: %v", err)
+		return
+	}
+
+	if tc.tenantPoolWorkqueue.Len() >= tc.config.MaxWorkqueueLength {
+		controllerLogger.Sugar().Errorf("The SpiderTenantIPPool workqueue is out of capacity, discard enqueue SpiderTenantIPPool '%s'", key)
+		return
+	}
+	tc.tenantPoolWorkqueue.Add(key)
+	controllerLogger.Sugar().Debugf("added '%s' to SpiderTenantIPPool workqueue", key)
+}
+
+func (tc *TenantIPPoolController) enqueueOwnerTenantIPPool(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ipPool, ok := obj.(*spiderpoolv2beta1.SpiderIPPool)
+	if !ok {
+		return
+	}
+
+	namespace, ok := ipPool.Labels[constant.LabelIPPoolOwnerTenantNamespace]
+	if !ok {
+		return
+	}
+	tc.enqueueTenantIPPool(cache.ExplicitKey(namespace + "/" + ipPool.Labels[constant.LabelIPPoolOwnerTenantName]))
+}
+
+// Run waits for the informer caches to sync and starts the workers, it
+// blocks until stopCh is closed, at which point it shuts down the workqueue.
+func (tc *TenantIPPoolController) Run(stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer tc.tenantPoolWorkqueue.ShutDown()
+
+	controllerLogger.Debug("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh, tc.tenantPoolSynced, tc.poolSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < tc.config.Workers; i++ {
+		controllerLogger.Sugar().Debugf("Starting SpiderTenantIPPool processing worker '%d'", i)
+		go wait.Until(tc.runWorker, 1*time.Second, stopCh)
+	}
+
+	<-stopCh
+	controllerLogger.Error("Shutting down SpiderTenantIPPool controller workers")
+	return nil
+}
+
+func (tc *TenantIPPoolController) runWorker() {
+	for tc.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem syncs a single TenantIPPool of the workqueue, the
+// failed one is requeued with the rate limit until it's out of retries.
+func (tc *TenantIPPoolController) processNextWorkItem() bool {
+	obj, shutdown := tc.tenantPoolWorkqueue.Get()
+	if shutdown {
+		controllerLogger.Error("SpiderTenantIPPool workqueue is already shutdown!")
+		return false
+	}
+	defer tc.tenantPoolWorkqueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		tc.tenantPoolWorkqueue.Forget(obj)
+		controllerLogger.Sugar().Errorf("expected string in workQueue but got %+v", obj)
+		return true
+	}
+
+	err := tc.SyncTenantIPPool(context.TODO(), key)
+	if nil == err {
+		tc.tenantPoolWorkqueue.Forget(obj)
+		return true
+	}
+
+	if apierrors.IsConflict(err) {
+		tc.tenantPoolWorkqueue.AddRateLimited(key)
+		controllerLogger.Sugar().Warnf("encountered SpiderTenantIPPool update conflict '%v', retrying...", err)
+		return true
+	}
+
+	if tc.tenantPoolWorkqueue.NumRequeues(obj) < tc.config.WorkQueueMaxRetries {
+		controllerLogger.Sugar().Errorf("failed to sync SpiderTenantIPPool '%s': %v, requeue it", key, err)
+		tc.tenantPoolWorkqueue.AddRateLimited(key)
+		return true
+	}
+
+	tc.tenantPoolWorkqueue.Forget(obj)
+	controllerLogger.Sugar().Errorf("failed to sync SpiderTenantIPPool '%s': %v, out of work queue max retries, discarding it", key, err)
+	return true
+}
+
+// SyncTenantIPPool syncs the TenantIPPool of the '<namespace>/<name>' key,
+// it does nothing if the TenantIPPool no longer exists.
+func (tc *TenantIPPoolController) SyncTenantIPPool(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if nil != err {
+		return fmt.Errorf("%w: invalid key '%s': %w", constant.ErrWrongInput, key, err)
+	}
+
+	var tenantPool spiderpoolv2beta1.SpiderTenantIPPool
+	if err := tc.client.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, &tenantPool); err != nil {
+		if apierrors.IsNotFound(err) {
+			controllerLogger.Sugar().Debugf("SpiderTenantIPPool '%s' in work queue no longer exists", key)
+			return nil
+		}
+		return fmt.Errorf("failed to get TenantIPPool: %w", err)
+	}
+
+	return tc.syncTenantIPPool(ctx, &tenantPool)
+}
+
+func (tc *TenantIPPoolController) syncTenantIPPool(ctx context.Context, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) error {
+	if tenantPool.DeletionTimestamp != nil {
+		return tc.removeFinalizer(ctx, tenantPool)
+	}
+
+	if !controllerutil.ContainsFinalizer(tenantPool, constant.SpiderFinalizer) {
+		controllerutil.AddFinalizer(tenantPool, constant.SpiderFinalizer)
+		if err := tc.client.Update(ctx, tenantPool); err != nil {
+			return fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	ipPool, err := tc.syncIPPool(ctx, tenantPool)
+	if err != nil {
+		event.EventRecorder.Eventf(tenantPool, corev1.EventTypeWarning, constant.EventReasonTenantIPPoolSyncFailed,
+			"Failed to sync IPPool %s: %v", IPPoolName(tenantPool), err)
+		return err
+	}
+
+	return tc.syncStatus(ctx, tenantPool, ipPool)
+}
+
+// syncIPPool creates or updates the IPPool of the TenantIPPool. The webhook of
+// SpiderIPPool makes sure its IP addresses are granted to the namespace.
+func (tc *TenantIPPoolController) syncIPPool(ctx context.Context, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	var subnet spiderpoolv2beta1.SpiderSubnet
+	if err := tc.client.Get(ctx, apitypes.NamespacedName{Name: tenantPool.Spec.Subnet}, &subnet); err != nil {
+		return nil, fmt.Errorf("failed to get Subnet %s: %w", tenantPool.Spec.Subnet, err)
+	}
+
+	var ipPool spiderpoolv2beta1.SpiderIPPool
+	err := tc.client.Get(ctx, apitypes.NamespacedName{Name: IPPoolName(tenantPool)}, &ipPool)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get IPPool: %w", err)
+		}
+
+		ipPool = spiderpoolv2beta1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: IPPoolName(tenantPool),
+				Labels: map[string]string{
+					constant.LabelIPPoolOwnerSpiderSubnet:    subnet.Name,
+					constant.LabelIPPoolOwnerTenantNamespace: tenantPool.Namespace,
+					constant.LabelIPPoolOwnerTenantName:      tenantPool.Name,
+				},
+			},
+			Spec: spiderpoolv2beta1.IPPoolSpec{
+				IPVersion:     subnet.Spec.IPVersion,
+				Subnet:        subnet.Spec.Subnet,
+				IPs:           tenantPool.Spec.IPs,
+				NamespaceName: []string{tenantPool.Namespace},
+			},
+		}
+		if err := ctrl.SetControllerReference(&subnet, &ipPool, tc.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		ippoolmanager.InheritSubnetProperties(&subnet, &ipPool)
+
+		if err := tc.client.Create(ctx, &ipPool); err != nil {
+			return nil, fmt.Errorf("failed to create IPPool: %w", err)
+		}
+		controllerLogger.Sugar().Infof("created IPPool %s for TenantIPPool %s/%s", ipPool.Name, tenantPool.Namespace, tenantPool.Name)
+
+		return &ipPool, nil
+	}
+
+	if !isOwnedByTenantIPPool(&ipPool, tenantPool) {
+		return nil, fmt.Errorf("IPPool %s already exists and is not created for the TenantIPPool", ipPool.Name)
+	}
+
+	if slices.Equal(ipPool.Spec.IPs, tenantPool.Spec.IPs) &&
+		slices.Equal(ipPool.Spec.NamespaceName, []string{tenantPool.Namespace}) {
+		return &ipPool, nil
+	}
+
+	ipPool.Spec.IPs = tenantPool.Spec.IPs
+	ipPool.Spec.NamespaceName = []string{tenantPool.Namespace}
+	if err := tc.client.Update(ctx, &ipPool); err != nil {
+		return nil, fmt.Errorf("failed to update IPPool: %w", err)
+	}
+	controllerLogger.Sugar().Infof("updated IPs of IPPool %s to %v", ipPool.Name, ipPool.Spec.IPs)
+
+	return &ipPool, nil
+}
+
+func (tc *TenantIPPoolController) syncStatus(ctx context.Context, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool, ipPool *spiderpoolv2beta1.SpiderIPPool) error {
+	status := spiderpoolv2beta1.TenantIPPoolStatus{
+		IPPool:           ipPool.Name,
+		TotalIPCount:     ipPool.Status.TotalIPCount,
+		AllocatedIPCount: ipPool.Status.AllocatedIPCount,
+	}
+	if reflect.DeepEqual(status, tenantPool.Status) {
+		return nil
+	}
+
+	tenantPool.Status = status
+	if err := tc.client.Status().Update(ctx, tenantPool); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// removeFinalizer deletes the IPPool of the terminating TenantIPPool, and
+// removes the finalizer once the IPPool is gone, which waits for the Pods
+// using its IP addresses to be deleted.
+func (tc *TenantIPPoolController) removeFinalizer(ctx context.Context, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) error {
+	if !controllerutil.ContainsFinalizer(tenantPool, constant.SpiderFinalizer) {
+		return nil
+	}
+
+	var ipPool spiderpoolv2beta1.SpiderIPPool
+	err := tc.client.Get(ctx, apitypes.NamespacedName{Name: IPPoolName(tenantPool)}, &ipPool)
+	if err == nil && isOwnedByTenantIPPool(&ipPool, tenantPool) {
+		if ipPool.DeletionTimestamp == nil {
+			if err := tc.client.Delete(ctx, &ipPool); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete IPPool %s: %w", ipPool.Name, err)
+			}
+			controllerLogger.Sugar().Infof("deleted IPPool %s of terminating TenantIPPool %s/%s", ipPool.Name, tenantPool.Namespace, tenantPool.Name)
+		}
+
+		return nil
+	}
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get IPPool: %w", err)
+	}
+
+	controllerutil.RemoveFinalizer(tenantPool, constant.SpiderFinalizer)
+	if err := tc.client.Update(ctx, tenantPool); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return nil
+}
+
+func isOwnedByTenantIPPool(ipPool *spiderpoolv2beta1.SpiderIPPool, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) bool {
+	return ipPool.Labels[constant.LabelIPPoolOwnerTenantNamespace] == tenantPool.Namespace &&
+		ipPool.Labels[constant.LabelIPPoolOwnerTenantName] == tenantPool.Name
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	spiderpoolfake "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned/fake"
+	"github.com/spidernet-io/spiderpool/pkg/tenantippoolmanager"
+)
+
+var _ = Describe("TenantIPPoolController", Label("tenantippool_controller_test"), func() {
+	Describe("New TenantIPPoolController", func() {
+		It("inputs nil client", func() {
+			controller, err := tenantippoolmanager.NewTenantIPPoolController(tenantippoolmanager.TenantIPPoolControllerConfig{}, nil, mockLeaderElector)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(controller).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			controller, err := tenantippoolmanager.NewTenantIPPoolController(tenantippoolmanager.TenantIPPoolControllerConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(controller).To(BeNil())
+		})
+	})
+
+	Describe("SyncTenantIPPool", func() {
+		var ctx context.Context
+		var controller *tenantippoolmanager.TenantIPPoolController
+		var subnetT *spiderpoolv2beta1.SpiderSubnet
+		var tenantPoolT *spiderpoolv2beta1.SpiderTenantIPPool
+
+		getIPPool := func() (*spiderpoolv2beta1.SpiderIPPool, error) {
+			var ipPool spiderpoolv2beta1.SpiderIPPool
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Name: tenantippoolmanager.IPPoolName(tenantPoolT)}, &ipPool)
+			return &ipPool, err
+		}
+
+		getTenantIPPool := func() (*spiderpoolv2beta1.SpiderTenantIPPool, error) {
+			var tenantPool spiderpoolv2beta1.SpiderTenantIPPool
+			err := fakeClient.Get(ctx, client.ObjectKeyFromObject(tenantPoolT), &tenantPool)
+			return &tenantPool, err
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+
+			var err error
+			controller, err = tenantippoolmanager.NewTenantIPPoolController(tenantippoolmanager.TenantIPPoolControllerConfig{}, fakeClient, mockLeaderElector)
+			Expect(err).NotTo(HaveOccurred())
+
+			subnetT = &spiderpoolv2beta1.SpiderSubnet{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: spiderpoolv2beta1.GroupVersion.String(),
+					Kind:       constant.KindSpiderSubnet,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "controller-subnet",
+				},
+				Spec: spiderpoolv2beta1.SubnetSpec{
+					IPVersion: ptr.To(constant.IPv4),
+					Subnet:    "172.18.41.0/24",
+					IPs:       []string{"172.18.41.10-172.18.41.100"},
+					Gateway:   ptr.To("172.18.41.1"),
+					Tenants: []spiderpoolv2beta1.SubnetTenant{
+						{Namespace: "tenant-a", IPs: []string{"172.18.41.10-172.18.41.20"}},
+					},
+				},
+			}
+			err = fakeClient.Create(ctx, subnetT)
+			Expect(err).NotTo(HaveOccurred())
+
+			tenantPoolT = &spiderpoolv2beta1.SpiderTenantIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pool",
+					Namespace: "tenant-a",
+				},
+				Spec: spiderpoolv2beta1.TenantIPPoolSpec{
+					Subnet: subnetT.Name,
+					IPs:    []string{"172.18.41.10-172.18.41.12"},
+				},
+			}
+			err = fakeClient.Create(ctx, tenantPoolT)
+			Expect(err).NotTo(HaveOccurred())
+
+			DeferCleanup(func() {
+				ctx := context.TODO()
+				if tenantPool, err := getTenantIPPool(); err == nil {
+					tenantPool.Finalizers = nil
+					Expect(fakeClient.Update(ctx, tenantPool)).To(Succeed())
+					Expect(client.IgnoreNotFound(fakeClient.Delete(ctx, tenantPool))).To(Succeed())
+				}
+				if ipPool, err := getIPPool(); err == nil {
+					Expect(client.IgnoreNotFound(fakeClient.Delete(ctx, ipPool))).To(Succeed())
+				}
+				Expect(client.IgnoreNotFound(fakeClient.Delete(ctx, subnetT))).To(Succeed())
+			})
+		})
+
+		It("creates the IPPool for the TenantIPPool", func() {
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+
+			ipPool, err := getIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ipPool.Name).To(Equal("tenant-a.pool"))
+			Expect(ipPool.Spec.Subnet).To(Equal(subnetT.Spec.Subnet))
+			Expect(ipPool.Spec.IPs).To(Equal(tenantPoolT.Spec.IPs))
+			Expect(ipPool.Spec.NamespaceName).To(Equal([]string{"tenant-a"}))
+			Expect(ipPool.Spec.Gateway).To(Equal(subnetT.Spec.Gateway))
+			Expect(ipPool.Labels).To(HaveKeyWithValue(constant.LabelIPPoolOwnerSpiderSubnet, subnetT.Name))
+			Expect(ipPool.Labels).To(HaveKeyWithValue(constant.LabelIPPoolOwnerTenantNamespace, "tenant-a"))
+			Expect(metav1.IsControlledBy(ipPool, subnetT)).To(BeTrue())
+
+			tenantPool, err := getTenantIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tenantPool.Finalizers).To(ContainElement(constant.SpiderFinalizer))
+			Expect(tenantPool.Status.IPPool).To(Equal(ipPool.Name))
+		})
+
+		It("updates the IPs of the IPPool", func() {
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+
+			tenantPool, err := getTenantIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			tenantPool.Spec.IPs = []string{"172.18.41.10-172.18.41.15"}
+			Expect(fakeClient.Update(ctx, tenantPool)).To(Succeed())
+
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+			ipPool, err := getIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ipPool.Spec.IPs).To(Equal(tenantPool.Spec.IPs))
+		})
+
+		It("mirrors the IP counts of the IPPool", func() {
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+
+			ipPool, err := getIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			ipPool.Status.TotalIPCount = ptr.To(int64(3))
+			ipPool.Status.AllocatedIPCount = ptr.To(int64(1))
+			Expect(fakeClient.Status().Update(ctx, ipPool)).To(Succeed())
+
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+			tenantPool, err := getTenantIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tenantPool.Status.TotalIPCount).To(Equal(ptr.To(int64(3))))
+			Expect(tenantPool.Status.AllocatedIPCount).To(Equal(ptr.To(int64(1))))
+		})
+
+		It("skips the TenantIPPool which no longer exists", func() {
+			Expect(controller.SyncTenantIPPool(ctx, "tenant-a/deleted")).To(Succeed())
+		})
+
+		It("inputs invalid key", func() {
+			Expect(controller.SyncTenantIPPool(ctx, "tenant-a/pool/invalid")).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("failed to sync the TenantIPPool whose IPPool name is taken", func() {
+			ipPool := &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tenantippoolmanager.IPPoolName(tenantPoolT),
+				},
+				Spec: spiderpoolv2beta1.IPPoolSpec{
+					Subnet: subnetT.Spec.Subnet,
+				},
+			}
+			Expect(fakeClient.Create(ctx, ipPool)).To(Succeed())
+
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).NotTo(Succeed())
+		})
+
+		It("deletes the IPPool before removing the finalizer", func() {
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+
+			tenantPool, err := getTenantIPPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Delete(ctx, tenantPool)).To(Succeed())
+
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+			_, err = getIPPool()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			_, err = getTenantIPPool()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(controller.SyncTenantIPPool(ctx, client.ObjectKeyFromObject(tenantPoolT).String())).To(Succeed())
+			_, err = getTenantIPPool()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("SetupInformer", func() {
+		It("syncs the TenantIPPool once it's added", func() {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			mockLeaderElector.EXPECT().
+				IsElected().
+				Return(true).
+				AnyTimes()
+
+			controller, err := tenantippoolmanager.NewTenantIPPoolController(tenantippoolmanager.TenantIPPoolControllerConfig{}, fakeClient, mockLeaderElector)
+			Expect(err).NotTo(HaveOccurred())
+
+			subnet := &spiderpoolv2beta1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "informer-subnet"},
+				Spec: spiderpoolv2beta1.SubnetSpec{
+					IPVersion: ptr.To(constant.IPv4),
+					Subnet:    "172.18.42.0/24",
+					IPs:       []string{"172.18.42.10-172.18.42.100"},
+				},
+			}
+			tenantPool := &spiderpoolv2beta1.SpiderTenantIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "informer-pool", Namespace: "tenant-b"},
+				Spec: spiderpoolv2beta1.TenantIPPoolSpec{
+					Subnet: subnet.Name,
+					IPs:    []string{"172.18.42.10-172.18.42.12"},
+				},
+			}
+			Expect(fakeClient.Create(ctx, subnet)).To(Succeed())
+			Expect(fakeClient.Create(ctx, tenantPool)).To(Succeed())
+			DeferCleanup(func() {
+				ctx := context.TODO()
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				if err := fakeClient.Get(ctx, apitypes.NamespacedName{Name: tenantippoolmanager.IPPoolName(tenantPool)}, &ipPool); err == nil {
+					Expect(fakeClient.Delete(ctx, &ipPool)).To(Succeed())
+				}
+				if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(tenantPool), tenantPool); err == nil {
+					tenantPool.Finalizers = nil
+					Expect(fakeClient.Update(ctx, tenantPool)).To(Succeed())
+					Expect(client.IgnoreNotFound(fakeClient.Delete(ctx, tenantPool))).To(Succeed())
+				}
+				Expect(fakeClient.Delete(ctx, subnet)).To(Succeed())
+			})
+
+			fakeClientSet := spiderpoolfake.NewSimpleClientset(tenantPool.DeepCopy())
+			Expect(controller.SetupInformer(ctx, fakeClientSet)).To(Succeed())
+
+			Eventually(func() error {
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				return fakeClient.Get(ctx, apitypes.NamespacedName{Name: tenantippoolmanager.IPPoolName(tenantPool)}, &ipPool)
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+		})
+	})
+})
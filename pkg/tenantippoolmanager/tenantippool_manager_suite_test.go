@@ -0,0 +1,54 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	electionmock "github.com/spidernet-io/spiderpool/pkg/election/mock"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/tenantippoolmanager"
+)
+
+var (
+	mockCtrl          *gomock.Controller
+	mockLeaderElector *electionmock.MockSpiderLeaseElector
+
+	scheme              *runtime.Scheme
+	fakeClient          client.Client
+	tenantIPPoolWebhook *tenantippoolmanager.TenantIPPoolWebhook
+)
+
+func TestTenantIPPoolManager(t *testing.T) {
+	mockCtrl = gomock.NewController(t)
+	defer mockCtrl.Finish()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TenantIPPoolManager Suite", Label("tenantippoolmanager", "unittest"))
+}
+
+var _ = BeforeSuite(func() {
+	scheme = runtime.NewScheme()
+	err := spiderpoolv2beta1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&spiderpoolv2beta1.SpiderTenantIPPool{}, &spiderpoolv2beta1.SpiderIPPool{}).
+		Build()
+
+	mockLeaderElector = electionmock.NewMockSpiderLeaseElector(mockCtrl)
+
+	tenantippoolmanager.WebhookLogger = logutils.Logger.Named("TenantIPPool-Webhook")
+	tenantIPPoolWebhook = &tenantippoolmanager.TenantIPPoolWebhook{
+		APIReader: fakeClient,
+	}
+})
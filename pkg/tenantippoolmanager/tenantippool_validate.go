@@ -0,0 +1,160 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var (
+	nameField   *field.Path = field.NewPath("metadata").Child("name")
+	subnetField *field.Path = field.NewPath("spec").Child("subnet")
+	ipsField    *field.Path = field.NewPath("spec").Child("ips")
+)
+
+func (tw *TenantIPPoolWebhook) validateCreateTenantIPPool(ctx context.Context, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) field.ErrorList {
+	if err := validateTenantIPPoolName(tenantPool); err != nil {
+		return field.ErrorList{err}
+	}
+
+	var errs field.ErrorList
+	if err := tw.validateTenantIPPoolIPs(ctx, tenantPool); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func (tw *TenantIPPoolWebhook) validateUpdateTenantIPPool(ctx context.Context, oldTenantPool, newTenantPool *spiderpoolv2beta1.SpiderTenantIPPool) field.ErrorList {
+	if newTenantPool.Spec.Subnet != oldTenantPool.Spec.Subnet {
+		return field.ErrorList{field.Forbidden(
+			subnetField,
+			"is not changeable",
+		)}
+	}
+
+	var errs field.ErrorList
+	if err := tw.validateTenantIPPoolIPs(ctx, newTenantPool); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// validateTenantIPPoolName checks the name of the IPPool created for the
+// TenantIPPool is a valid name of SpiderIPPool.
+func validateTenantIPPoolName(tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) *field.Error {
+	if msgs := validation.IsDNS1123Subdomain(IPPoolName(tenantPool)); len(msgs) != 0 {
+		return field.Invalid(
+			nameField,
+			tenantPool.Name,
+			fmt.Sprintf("the name of its IPPool %s is invalid: %v", IPPoolName(tenantPool), msgs),
+		)
+	}
+
+	return nil
+}
+
+// validateTenantIPPoolIPs checks the IP addresses of the TenantIPPool are
+// granted to its namespace by the Subnet, and not used by the other
+// TenantIPPools of the namespace.
+func (tw *TenantIPPoolWebhook) validateTenantIPPoolIPs(ctx context.Context, tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) *field.Error {
+	var subnet spiderpoolv2beta1.SpiderSubnet
+	if err := tw.APIReader.Get(ctx, apitypes.NamespacedName{Name: tenantPool.Spec.Subnet}, &subnet); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(subnetField, tenantPool.Spec.Subnet)
+		}
+
+		return field.InternalError(subnetField, fmt.Errorf("failed to get Subnet %s: %w", tenantPool.Spec.Subnet, err))
+	}
+
+	if subnet.DeletionTimestamp != nil {
+		return field.Forbidden(
+			subnetField,
+			fmt.Sprintf("Subnet %s is terminating", subnet.Name),
+		)
+	}
+
+	if subnet.Spec.IPVersion == nil {
+		return field.InternalError(subnetField, fmt.Errorf("'spec.ipVersion' of Subnet %s is not generated", subnet.Name))
+	}
+
+	grantedIPs, ok, err := ippoolmanager.SubnetTenantIPs(&subnet, tenantPool.Namespace)
+	if err != nil {
+		return field.InternalError(subnetField, err)
+	}
+	if !ok {
+		return field.Forbidden(
+			subnetField,
+			fmt.Sprintf("Subnet %s grants no IP addresses to namespace %s", subnet.Name, tenantPool.Namespace),
+		)
+	}
+
+	version := *subnet.Spec.IPVersion
+	for i, r := range tenantPool.Spec.IPs {
+		if err := spiderpoolip.IsIPRange(version, r); err != nil {
+			return field.Invalid(ipsField.Index(i), r, err.Error())
+		}
+
+		ips, _ := spiderpoolip.ParseIPRange(version, r)
+		outIPs := spiderpoolip.IPsDiffSet(ips, grantedIPs, false)
+		if len(outIPs) > 0 {
+			ranges, _ := spiderpoolip.ConvertIPsToIPRanges(version, outIPs)
+			return field.Invalid(
+				ipsField.Index(i),
+				r,
+				fmt.Sprintf("IP ranges %v are not granted to namespace %s by Subnet %s", ranges, tenantPool.Namespace, subnet.Name),
+			)
+		}
+	}
+
+	var tenantPoolList spiderpoolv2beta1.SpiderTenantIPPoolList
+	if err := tw.APIReader.List(ctx, &tenantPoolList, client.InNamespace(tenantPool.Namespace)); err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to list TenantIPPools: %w", err))
+	}
+
+	for _, p := range tenantPoolList.Items {
+		if p.Name == tenantPool.Name || p.Spec.Subnet != tenantPool.Spec.Subnet {
+			continue
+		}
+
+		ips, err := spiderpoolip.ParseIPRanges(version, p.Spec.IPs)
+		if err != nil {
+			return field.InternalError(ipsField, fmt.Errorf("failed to parse the IPs of TenantIPPool %s: %w", p.Name, err))
+		}
+
+		for i, r := range tenantPool.Spec.IPs {
+			poolIPs, _ := spiderpoolip.ParseIPRange(version, r)
+			usedIPs := spiderpoolip.IPsIntersectionSet(poolIPs, ips, false)
+			if len(usedIPs) > 0 {
+				ranges, _ := spiderpoolip.ConvertIPsToIPRanges(version, usedIPs)
+				return field.Forbidden(
+					ipsField.Index(i),
+					fmt.Sprintf("IP ranges %v are used by TenantIPPool %s", ranges, p.Name),
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var WebhookLogger *zap.Logger
+
+type TenantIPPoolWebhook struct {
+	APIReader client.Reader
+}
+
+func (tw *TenantIPPoolWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookLogger == nil {
+		WebhookLogger = logutils.Logger.Named("TenantIPPool-Webhook")
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&spiderpoolv2beta1.SpiderTenantIPPool{}).
+		WithValidator(tw).
+		Complete()
+}
+
+var _ webhook.CustomValidator = (*TenantIPPoolWebhook)(nil)
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (tw *TenantIPPoolWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	tenantPool := obj.(*spiderpoolv2beta1.SpiderTenantIPPool)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("TenantIPPoolNamespace", tenantPool.Namespace),
+		zap.String("TenantIPPoolName", tenantPool.Name),
+		zap.String("Operation", "CREATE"),
+	)
+	logger.Sugar().Debugf("Request TenantIPPool: %+v", *tenantPool)
+
+	if errs := tw.validateCreateTenantIPPool(logutils.IntoContext(ctx, logger), tenantPool); len(errs) != 0 {
+		logger.Sugar().Errorf("Failed to create TenantIPPool: %v", errs.ToAggregate().Error())
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: constant.SpiderpoolAPIGroup, Kind: constant.KindSpiderTenantIPPool},
+			tenantPool.Name,
+			errs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (tw *TenantIPPoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTenantPool := oldObj.(*spiderpoolv2beta1.SpiderTenantIPPool)
+	newTenantPool := newObj.(*spiderpoolv2beta1.SpiderTenantIPPool)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("TenantIPPoolNamespace", newTenantPool.Namespace),
+		zap.String("TenantIPPoolName", newTenantPool.Name),
+		zap.String("Operation", "UPDATE"),
+	)
+	logger.Sugar().Debugf("Request old TenantIPPool: %+v", *oldTenantPool)
+	logger.Sugar().Debugf("Request new TenantIPPool: %+v", *newTenantPool)
+
+	if newTenantPool.DeletionTimestamp != nil {
+		if oldTenantPool.DeletionTimestamp == nil {
+			return nil, nil
+		}
+
+		// Allow spiderpool-controller to remove the finalizer once the
+		// IPPool of the terminating TenantIPPool is deleted.
+		if newTenantPool.Spec.Subnet == oldTenantPool.Spec.Subnet &&
+			slices.Equal(newTenantPool.Spec.IPs, oldTenantPool.Spec.IPs) {
+			return nil, nil
+		}
+
+		return nil, apierrors.NewForbidden(
+			schema.GroupResource{},
+			"",
+			errors.New("cannot update the spec of a terminating TenantIPPool"),
+		)
+	}
+
+	if errs := tw.validateUpdateTenantIPPool(logutils.IntoContext(ctx, logger), oldTenantPool, newTenantPool); len(errs) != 0 {
+		logger.Sugar().Errorf("Failed to update TenantIPPool: %v", errs.ToAggregate().Error())
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: constant.SpiderpoolAPIGroup, Kind: constant.KindSpiderTenantIPPool},
+			newTenantPool.Name,
+			errs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (tw *TenantIPPoolWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
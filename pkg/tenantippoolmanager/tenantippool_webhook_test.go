@@ -0,0 +1,189 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+var _ = Describe("TenantIPPoolWebhook", Label("tenantippool_webhook_test"), func() {
+	var ctx context.Context
+
+	var count uint64
+	var subnetT *spiderpoolv2beta1.SpiderSubnet
+	var tenantPoolT *spiderpoolv2beta1.SpiderTenantIPPool
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		atomic.AddUint64(&count, 1)
+		subnetT = &spiderpoolv2beta1.SpiderSubnet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("subnet-%v", count),
+			},
+			Spec: spiderpoolv2beta1.SubnetSpec{
+				IPVersion: ptr.To(constant.IPv4),
+				Subnet:    "172.18.40.0/24",
+				IPs:       []string{"172.18.40.10-172.18.40.100"},
+				Tenants: []spiderpoolv2beta1.SubnetTenant{
+					{Namespace: "tenant-a", IPs: []string{"172.18.40.10-172.18.40.20"}},
+					{Namespace: "tenant-b", IPs: []string{"172.18.40.30-172.18.40.40"}},
+				},
+			},
+		}
+		tenantPoolT = &spiderpoolv2beta1.SpiderTenantIPPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("tenantpool-%v", count),
+				Namespace: "tenant-a",
+			},
+			Spec: spiderpoolv2beta1.TenantIPPoolSpec{
+				Subnet: subnetT.Name,
+				IPs:    []string{"172.18.40.10-172.18.40.12"},
+			},
+		}
+
+		DeferCleanup(func() {
+			err := fakeClient.Delete(ctx, subnetT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+	})
+
+	createSubnet := func() {
+		err := fakeClient.Create(ctx, subnetT)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	createTenantIPPool := func(tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) {
+		err := fakeClient.Create(ctx, tenantPool)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			err := fakeClient.Delete(context.TODO(), tenantPool)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+	}
+
+	Describe("ValidateCreate", func() {
+		It("creates the TenantIPPool", func() {
+			createSubnet()
+
+			warns, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+
+		It("failed to create the TenantIPPool of the non-existent Subnet", func() {
+			_, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.subnet"))
+		})
+
+		It("failed to create the TenantIPPool in the namespace without grant", func() {
+			createSubnet()
+			tenantPoolT.Namespace = "tenant-c"
+
+			_, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("grants no IP addresses to namespace tenant-c"))
+		})
+
+		It("failed to create the TenantIPPool with the invalid IP range", func() {
+			createSubnet()
+			tenantPoolT.Spec.IPs = []string{constant.InvalidIPRange}
+
+			_, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("failed to create the TenantIPPool with the IP granted to another namespace", func() {
+			createSubnet()
+			tenantPoolT.Spec.IPs = []string{"172.18.40.20-172.18.40.30"}
+
+			_, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("are not granted to namespace tenant-a"))
+		})
+
+		It("failed to create the TenantIPPool overlapping with the other TenantIPPool", func() {
+			createSubnet()
+			other := tenantPoolT.DeepCopy()
+			other.Name = tenantPoolT.Name + "-other"
+			other.Spec.IPs = []string{"172.18.40.12-172.18.40.13"}
+			createTenantIPPool(other)
+
+			_, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("are used by TenantIPPool " + other.Name))
+		})
+
+		It("failed to create the TenantIPPool with too long name", func() {
+			createSubnet()
+			tenantPoolT.Name = strings.Repeat("a", 253)
+
+			_, err := tenantIPPoolWebhook.ValidateCreate(ctx, tenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("metadata.name"))
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		It("updates the IPs of the TenantIPPool", func() {
+			createSubnet()
+			newTenantPoolT := tenantPoolT.DeepCopy()
+			newTenantPoolT.Spec.IPs = []string{"172.18.40.10-172.18.40.20"}
+
+			_, err := tenantIPPoolWebhook.ValidateUpdate(ctx, tenantPoolT, newTenantPoolT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to change the Subnet of the TenantIPPool", func() {
+			newTenantPoolT := tenantPoolT.DeepCopy()
+			newTenantPoolT.Spec.Subnet = "another"
+
+			_, err := tenantIPPoolWebhook.ValidateUpdate(ctx, tenantPoolT, newTenantPoolT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.subnet"))
+		})
+
+		It("removes the finalizer of the terminating TenantIPPool", func() {
+			now := metav1.Now()
+			tenantPoolT.SetDeletionTimestamp(&now)
+			tenantPoolT.Finalizers = []string{constant.SpiderFinalizer}
+			newTenantPoolT := tenantPoolT.DeepCopy()
+			newTenantPoolT.Finalizers = nil
+
+			_, err := tenantIPPoolWebhook.ValidateUpdate(ctx, tenantPoolT, newTenantPoolT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to update the IPs of the terminating TenantIPPool", func() {
+			now := metav1.Now()
+			tenantPoolT.SetDeletionTimestamp(&now)
+			newTenantPoolT := tenantPoolT.DeepCopy()
+			newTenantPoolT.Spec.IPs = []string{"172.18.40.13"}
+
+			_, err := tenantIPPoolWebhook.ValidateUpdate(ctx, tenantPoolT, newTenantPoolT)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		It("deletes the TenantIPPool", func() {
+			_, err := tenantIPPoolWebhook.ValidateDelete(ctx, tenantPoolT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
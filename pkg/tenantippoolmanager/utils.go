@@ -0,0 +1,15 @@
+// Copyright 2025 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantippoolmanager
+
+import (
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// IPPoolName returns the name of the SpiderIPPool created for the
+// SpiderTenantIPPool. The name of a namespace never contains '.', so the
+// names of the SpiderTenantIPPools in different namespaces never conflict.
+func IPPoolName(tenantPool *spiderpoolv2beta1.SpiderTenantIPPool) string {
+	return tenantPool.Namespace + "." + tenantPool.Name
+}
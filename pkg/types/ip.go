@@ -13,6 +13,8 @@ type AllocationResult struct {
 	IP           *models.IPConfig
 	Routes       []*models.Route
 	CleanGateway bool
+	// Subnet is the SpiderSubnet that the allocated IPPool belongs to, it's empty if the IPPool isn't an auto-created IPPool.
+	Subnet string
 }
 
 type IPAndUID struct {
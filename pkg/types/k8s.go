@@ -29,6 +29,7 @@ type PodTopController struct {
 type AnnoPodIPPoolValue struct {
 	IPv4Pools []string `json:"ipv4,omitempty"`
 	IPv6Pools []string `json:"ipv6,omitempty"`
+	Selection string   `json:"selection,omitempty"`
 }
 type (
 	AnnoPodIPPoolsValue []AnnoIPPoolItem
@@ -37,6 +38,7 @@ type (
 		IPv4Pools    []string `json:"ipv4,omitempty"`
 		IPv6Pools    []string `json:"ipv6,omitempty"`
 		CleanGateway bool     `json:"cleangateway"`
+		Selection    string   `json:"selection,omitempty"`
 	}
 )
 
@@ -103,6 +105,8 @@ type AutoPoolProperty struct {
 	IfName          string
 	// AnnoPoolIPNumberVal serves for AutoPool annotation to explain whether it is IP number flexible or fixed.
 	AnnoPoolIPNumberVal string
+	// IsFallback means the auto-created IPPool belongs to a fallback SpiderSubnet of the interface.
+	IsFallback bool
 }
 type SpiderpoolConfigmapConfig struct {
 	EnableIPv4                                    bool                    `yaml:"enableIPv4"`
@@ -120,6 +124,8 @@ type SpiderpoolConfigmapConfig struct {
 	IpamUnixSocketPath                            string                  `yaml:"ipamUnixSocketPath"`
 	PodResourceInjectConfig                       PodResourceInjectConfig `yaml:"podResourceInject"`
 	IaaSProviderConfig                            IaaSProviderConfig      `yaml:"iaasNetworkProvider,omitempty"`
+	ExternalIPAMConfig                            ExternalIPAMConfig      `yaml:"externalIPAM,omitempty"`
+	PoolSelectionConfig                           PoolSelectionConfig     `yaml:"poolSelection,omitempty"`
 	AgentConfig                                   AgentConfig             `yaml:"agent,omitempty"`
 }
 type PodResourceInjectConfig struct {
@@ -128,8 +134,112 @@ type PodResourceInjectConfig struct {
 	NamespacesInclude []string `yaml:"namespacesInclude"`
 }
 type IaaSProviderConfig struct {
-	ServerURL          string `yaml:"serverUrl,omitempty"`
-	HTTPRequestTimeout string `yaml:"httpRequestTimeout,omitempty"`
+	Driver             string                      `yaml:"driver,omitempty"`
+	ServerURL          string                      `yaml:"serverUrl,omitempty"`
+	HTTPRequestTimeout string                      `yaml:"httpRequestTimeout,omitempty"`
+	ReconcileInterval  string                      `yaml:"reconcileInterval,omitempty"`
+	HTTP               IaaSHTTPDriverConfig        `yaml:"http,omitempty"`
+	GRPC               IaaSGRPCDriverConfig        `yaml:"grpc,omitempty"`
+	Exec               IaaSExecDriverConfig        `yaml:"exec,omitempty"`
+	ParentNicMacCache  IaaSParentNicMacCacheConfig `yaml:"parentNicMacCache,omitempty"`
+	CircuitBreaker     IaaSCircuitBreakerConfig    `yaml:"circuitBreaker,omitempty"`
+}
+
+// IaaSHTTPDriverConfig configures the generic HTTP driver of IaaS provider,
+// the operations without path are not supported by the provider.
+type IaaSHTTPDriverConfig struct {
+	Headers         map[string]string       `yaml:"headers,omitempty"`
+	Allocate        IaaSHTTPOperationConfig `yaml:"allocate,omitempty"`
+	Release         IaaSHTTPOperationConfig `yaml:"release,omitempty"`
+	ListAssignments IaaSHTTPOperationConfig `yaml:"listAssignments,omitempty"`
+}
+
+// IaaSHTTPOperationConfig configures an HTTP call, the path and templates are
+// Go text templates rendered with the JSON fields of the Spiderpool request.
+type IaaSHTTPOperationConfig struct {
+	Method           string `yaml:"method,omitempty"`
+	Path             string `yaml:"path,omitempty"`
+	RequestTemplate  string `yaml:"requestTemplate,omitempty"`
+	ResponseTemplate string `yaml:"responseTemplate,omitempty"`
+}
+
+// IaaSGRPCDriverConfig configures the gRPC driver of IaaS provider.
+type IaaSGRPCDriverConfig struct {
+	Address string `yaml:"address,omitempty"`
+	// Insecure disables TLS to connect the provider.
+	Insecure bool `yaml:"insecure,omitempty"`
+	// CAFile is the CA bundle to verify the provider certificate, the
+	// system roots are used if it's empty.
+	CAFile string `yaml:"caFile,omitempty"`
+	// CertFile and KeyFile are the client certificate for mTLS.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// ServerName overrides the name to verify the provider certificate,
+	// the host of Address is used if it's empty.
+	ServerName string `yaml:"serverName,omitempty"`
+	// InsecureSkipVerify skips the verification of the provider certificate.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+	// Codec is the codec of the messages, "proto" by default or "json".
+	Codec string `yaml:"codec,omitempty"`
+}
+
+type IaaSExecDriverConfig struct {
+	Path string   `yaml:"path,omitempty"`
+	Args []string `yaml:"args,omitempty"`
+}
+
+// IaaSParentNicMacCacheConfig configures the cache of the parent NIC MAC addresses
+// in spiderpool-agent.
+type IaaSParentNicMacCacheConfig struct {
+	// TTL is the lifetime of a cached MAC address, "0s" means never expire.
+	TTL string `yaml:"ttl,omitempty"`
+	// PersistPath is the node-local file to persist the cache across the
+	// restarts of spiderpool-agent, the cache is in memory only if it's empty.
+	PersistPath string `yaml:"persistPath,omitempty"`
+}
+
+// IaaSCircuitBreakerConfig configures the circuit breaker of the IaaS provider
+// calls in spiderpool-agent.
+type IaaSCircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures to open the
+	// circuit, 0 disables the circuit breaker.
+	FailureThreshold int `yaml:"failureThreshold,omitempty"`
+	// OpenDuration is the duration before probing the provider once the
+	// circuit opens, it's doubled by each failed probe up to MaxOpenDuration.
+	OpenDuration    string `yaml:"openDuration,omitempty"`
+	MaxOpenDuration string `yaml:"maxOpenDuration,omitempty"`
+}
+
+// ExternalIPAMConfig configures the synchronization between SpiderIPPools
+// and the external IPAM, which is the source of truth of the underlay ranges.
+type ExternalIPAMConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Driver is the type of the external IPAM, like "netbox".
+	Driver    string `yaml:"driver,omitempty"`
+	ServerURL string `yaml:"serverUrl,omitempty"`
+	// SyncInterval is the interval of the full synchronization.
+	SyncInterval   string `yaml:"syncInterval,omitempty"`
+	RequestTimeout string `yaml:"requestTimeout,omitempty"`
+	// ImportPrefixes creates SpiderIPPools for the prefixes of the external IPAM.
+	ImportPrefixes bool `yaml:"importPrefixes,omitempty"`
+	// MaxImportPrefixSize is the max IPs of the SpiderIPPool imported from a
+	// prefix, the larger prefixes are truncated.
+	MaxImportPrefixSize int64 `yaml:"maxImportPrefixSize,omitempty"`
+	// PrefixFilter is the query parameters to select the prefixes of the
+	// external IPAM, like tag=kubernetes.
+	PrefixFilter map[string]string `yaml:"prefixFilter,omitempty"`
+	// InsecureSkipVerify skips the verification of the server certificate.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// PoolSelectionConfig configures how IPAM picks IPPools from the candidates
+// of a NIC, it could be overridden by the Pod annotations.
+type PoolSelectionConfig struct {
+	// Policy is one of "fillFirst", "leastUtilized", "weighted" and "spread".
+	Policy string `yaml:"policy,omitempty"`
+	// SpreadTopologyKey is the label of Node to spread the Pods across
+	// IPPools with the "spread" policy.
+	SpreadTopologyKey string `yaml:"spreadTopologyKey,omitempty"`
 }
 
 type AgentConfig struct {
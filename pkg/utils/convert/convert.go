@@ -35,11 +35,16 @@ func ConvertIPDetailsToIPConfigsAndAllRoutes(details []spiderpoolv2beta1.IPAlloc
 					routes = append(routes, genDefaultRoute(nic, ipv4Gateway))
 				}
 			}
+			var ipv4Prefix string
+			if d.IPv4Prefix != nil {
+				ipv4Prefix = *d.IPv4Prefix
+			}
 			ips = append(ips, &models.IPConfig{
 				Address:                   d.IPv4,
 				Gateway:                   ipv4Gateway,
 				IPPool:                    *d.IPv4Pool,
 				Nic:                       &nic,
+				Prefix:                    ipv4Prefix,
 				Version:                   &version,
 				Vlan:                      *d.Vlan,
 				EnableGatewayDetection:    enableGatewayDetection,
@@ -57,11 +62,16 @@ func ConvertIPDetailsToIPConfigsAndAllRoutes(details []spiderpoolv2beta1.IPAlloc
 					routes = append(routes, genDefaultRoute(nic, ipv6Gateway))
 				}
 			}
+			var ipv6Prefix string
+			if d.IPv6Prefix != nil {
+				ipv6Prefix = *d.IPv6Prefix
+			}
 			ips = append(ips, &models.IPConfig{
 				Address:                   d.IPv6,
 				Gateway:                   ipv6Gateway,
 				IPPool:                    *d.IPv6Pool,
 				Nic:                       &nic,
+				Prefix:                    ipv6Prefix,
 				Version:                   &version,
 				Vlan:                      *d.Vlan,
 				EnableGatewayDetection:    enableGatewayDetection,
@@ -129,19 +139,31 @@ func ConvertResultsToIPDetails(results []*types.AllocationResult, isMultipleNicW
 
 		address := *r.IP.Address
 		pool := r.IP.IPPool
+		var subnet *string
+		if r.Subnet != "" {
+			subnet = ptr.To(r.Subnet)
+		}
 		vlan := r.IP.Vlan
 		routes := ConvertOAIRoutesToSpecRoutes(r.Routes)
+		var prefix *string
+		if r.IP.Prefix != "" {
+			prefix = ptr.To(r.IP.Prefix)
+		}
 
 		if d, ok := nicToDetail[*r.IP.Nic]; ok {
 			if *r.IP.Version == constant.IPv4 {
 				(*d).IPv4 = &address
 				(*d).IPv4Pool = &pool
+				(*d).IPv4Subnet = subnet
 				(*d).IPv4Gateway = gateway
+				(*d).IPv4Prefix = prefix
 				(*d).Routes = append(d.Routes, routes...)
 			} else {
 				(*d).IPv6 = r.IP.Address
 				(*d).IPv6Pool = &r.IP.IPPool
+				(*d).IPv6Subnet = subnet
 				(*d).IPv6Gateway = gateway
+				(*d).IPv6Prefix = prefix
 				(*d).Routes = append(d.Routes, routes...)
 			}
 			// Set MAC if provided and not already set
@@ -162,8 +184,10 @@ func ConvertResultsToIPDetails(results []*types.AllocationResult, isMultipleNicW
 				NIC:          *r.IP.Nic,
 				IPv4:         &address,
 				IPv4Pool:     &pool,
+				IPv4Subnet:   subnet,
 				Vlan:         &vlan,
 				IPv4Gateway:  gateway,
+				IPv4Prefix:   prefix,
 				CleanGateway: cleanGateway,
 				Routes:       routes,
 				MAC:          mac,
@@ -173,8 +197,10 @@ func ConvertResultsToIPDetails(results []*types.AllocationResult, isMultipleNicW
 				NIC:          *r.IP.Nic,
 				IPv6:         &address,
 				IPv6Pool:     &pool,
+				IPv6Subnet:   subnet,
 				Vlan:         &vlan,
 				IPv6Gateway:  gateway,
+				IPv6Prefix:   prefix,
 				CleanGateway: cleanGateway,
 				Routes:       routes,
 				MAC:          mac,
@@ -277,13 +303,21 @@ func GroupIPAllocationDetails(uid string, details []spiderpoolv2beta1.IPAllocati
 }
 
 func GenIPConfigResult(allocateIP net.IP, nic string, ipPool *spiderpoolv2beta1.SpiderIPPool) *models.IPConfig {
-	ipNet, _ := spiderpoolip.ParseIP(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, true)
+	subnet := IPPoolSubnetOfIP(ipPool, allocateIP)
+	ipNet, _ := spiderpoolip.ParseIP(*ipPool.Spec.IPVersion, subnet.Subnet, true)
 	ipNet.IP = allocateIP
 	address := ipNet.String()
 
 	var gateway string
-	if ipPool.Spec.Gateway != nil {
-		gateway = *ipPool.Spec.Gateway
+	if subnet.Gateway != nil {
+		gateway = *subnet.Gateway
+	}
+
+	// the IP address is the first one of the delegated prefix
+	var prefix string
+	if ipPool.Spec.PrefixLength != nil {
+		mask := net.CIDRMask(int(*ipPool.Spec.PrefixLength), len(ipNet.Mask)*8)
+		prefix = (&net.IPNet{IP: allocateIP.Mask(mask), Mask: mask}).String()
 	}
 
 	return &models.IPConfig{
@@ -291,10 +325,28 @@ func GenIPConfigResult(allocateIP net.IP, nic string, ipPool *spiderpoolv2beta1.
 		Gateway: gateway,
 		IPPool:  ipPool.Name,
 		Nic:     &nic,
+		Prefix:  prefix,
 		Version: ipPool.Spec.IPVersion,
 	}
 }
 
+// IPPoolSubnetOfIP returns the CIDR with its gateway and routes which the IP
+// address pertains to, 'spec.subnet' of the IPPool is returned by default.
+func IPPoolSubnetOfIP(ipPool *spiderpoolv2beta1.SpiderIPPool, ip net.IP) spiderpoolv2beta1.SecondarySubnet {
+	for _, s := range ipPool.Spec.SecondarySubnets {
+		_, ipNet, err := net.ParseCIDR(s.Subnet)
+		if err == nil && ipNet.Contains(ip) {
+			return s
+		}
+	}
+
+	return spiderpoolv2beta1.SecondarySubnet{
+		Subnet:  ipPool.Spec.Subnet,
+		Gateway: ipPool.Spec.Gateway,
+		Routes:  ipPool.Spec.Routes,
+	}
+}
+
 func UnmarshalIPPoolAllocatedIPs(data *string) (spiderpoolv2beta1.PoolIPAllocations, error) {
 	if data == nil {
 		return nil, nil
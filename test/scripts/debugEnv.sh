@@ -174,6 +174,24 @@ elif [ "$TYPE"x == "detail"x ] ; then
     echo "-------- kubectl get spiderreservedips -o json "
     kubectl get spiderreservedips -o json --kubeconfig ${E2E_KUBECONFIG}
 
+    echo ""
+    echo "=============== spiderpool crd spideripclaims ============== "
+    echo "-------- kubectl get spideripclaims -o wide "
+    kubectl get spideripclaims -A -o wide --kubeconfig ${E2E_KUBECONFIG}
+
+    echo ""
+    echo "-------- kubectl get spideripclaims -o json "
+    kubectl get spideripclaims -A -o json --kubeconfig ${E2E_KUBECONFIG}
+
+    echo ""
+    echo "=============== spiderpool crd spidertenantippools ============== "
+    echo "-------- kubectl get spidertenantippools -o wide "
+    kubectl get spidertenantippools -A -o wide --kubeconfig ${E2E_KUBECONFIG}
+
+    echo ""
+    echo "-------- kubectl get spidertenantippools -o json "
+    kubectl get spidertenantippools -A -o json --kubeconfig ${E2E_KUBECONFIG}
+
     echo ""
     echo "=============== spiderpool crd spidersubnet ============== "
     echo "-------- kubectl get spidersubnet -o wide "
@@ -29,6 +29,8 @@ done <<< "$ALL_EP_INFO"
 kubectl delete crd spiderendpoints.spiderpool.spidernet.io
 kubectl delete crd spiderippools.spiderpool.spidernet.io
 kubectl delete crd spiderreservedips.spiderpool.spidernet.io
+kubectl delete crd spideripclaims.spiderpool.spidernet.io
+kubectl delete crd spidertenantippools.spiderpool.spidernet.io
 kubectl delete crd spidersubnets.spiderpool.spidernet.io
 kubectl delete crd spidercoordinators.spiderpool.spidernet.io
 kubectl delete crd spidermultusconfigs.spiderpool.spidernet.io